@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/riada2/config"
+	"github.com/riada2/internal/card"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/credential"
+	"github.com/riada2/internal/geocode"
+	"github.com/riada2/internal/policy"
+	"github.com/riada2/internal/repository"
+	"github.com/riada2/internal/services"
+	"github.com/riada2/internal/ubigeo"
+	"gorm.io/gorm"
+)
+
+// application reúne los repositorios y servicios de la aplicación. La comparten el
+// servidor y los subcomandos, así ambos aplican las mismas reglas y auditoría.
+type application struct {
+	cfg *config.Config
+	db  *gorm.DB
+
+	userRepo         ports.UserRepository
+	personRepo       ports.PersonRepository
+	relationshipRepo ports.RelationshipRepository
+	delegationRepo   ports.DelegationRepository
+	ubigeoCatalog    ports.UbigeoCatalog
+
+	auditService        ports.AuditService
+	userService         ports.UserService
+	settingsService     ports.SettingsService
+	customFieldService  ports.CustomFieldService
+	personService       ports.PersonService
+	addressService      ports.AddressService
+	phoneService        ports.PhoneService
+	historyService      ports.HistoryService
+	relationshipService ports.RelationshipService
+	householdService    ports.HouseholdService
+	groupService        ports.GroupService
+	tagService          ports.TagService
+	eventService        ports.EventService
+	attendanceService   ports.AttendanceService
+	cardService         ports.CardService
+	noteService         ports.NoteService
+	delegationService   ports.DelegationService
+	seedService         ports.SeedService
+}
+
+// newApplication une las piezas (inyección de dependencias) sobre la base de datos db.
+func newApplication(cfg *config.Config, db *gorm.DB) (*application, error) {
+	app := &application{cfg: cfg, db: db}
+
+	// Cada cambio se escribe en una transacción junto con su entrada de auditoría.
+	unitOfWork := repository.NewGormUnitOfWork(db)
+	app.auditService = services.NewAuditService(repository.NewGormAuditRepository(db), unitOfWork)
+
+	app.userRepo = repository.NewGormUserRepository(db)
+	app.userService = services.NewUserService(app.userRepo, unitOfWork, cfg.JWTSecret, cfg.JWTExpiration, app.auditService)
+
+	versionRepo := repository.NewGormVersionRepository(db)
+
+	// La configuración editable (límites de contactos, campos obligatorios) se lee de la base con caché.
+	app.settingsService = services.NewSettingsService(repository.NewGormSettingRepository(db), unitOfWork, app.auditService)
+
+	// Reglas de acceso (dueño, administrador, delegado) a los datos de las personas.
+	app.delegationRepo = repository.NewGormDelegationRepository(db)
+	accessPolicy := policy.New(app.delegationRepo)
+
+	customFieldRepo := repository.NewGormCustomFieldRepository(db)
+	app.customFieldService = services.NewCustomFieldService(customFieldRepo, unitOfWork, app.auditService)
+
+	// El catálogo de ubigeo se carga una sola vez al iniciar.
+	ubigeoCatalog, err := ubigeo.Load(cfg.UbigeoCatalogPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the ubigeo catalog: %w", err)
+	}
+	app.ubigeoCatalog = ubigeoCatalog
+	geocoder, err := geocode.LoadLookupTable(cfg.GeocoderTablePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the geocoding table: %w", err)
+	}
+
+	app.personRepo = repository.NewGormPersonRepository(db)
+	app.personService = services.NewPersonService(app.personRepo, unitOfWork, customFieldRepo, ubigeoCatalog, geocoder, cfg.PhoneDefaultCountryCode, app.settingsService, accessPolicy, app.auditService)
+
+	addressRepo := repository.NewGormAddressRepository(db)
+	app.addressService = services.NewAddressService(addressRepo, app.personRepo, unitOfWork, ubigeoCatalog, geocoder, app.settingsService, accessPolicy, app.auditService)
+
+	phoneRepo := repository.NewGormPhoneRepository(db)
+	app.phoneService = services.NewPhoneService(phoneRepo, app.personRepo, unitOfWork, cfg.PhoneDefaultCountryCode, app.settingsService, accessPolicy, app.auditService)
+
+	app.historyService = services.NewHistoryService(versionRepo, app.personRepo, addressRepo, phoneRepo, unitOfWork, accessPolicy, app.auditService)
+
+	app.relationshipRepo = repository.NewGormRelationshipRepository(db)
+	app.relationshipService = services.NewRelationshipService(app.relationshipRepo, app.personRepo, unitOfWork, accessPolicy, app.auditService)
+
+	householdRepo := repository.NewGormHouseholdRepository(db)
+	app.householdService = services.NewHouseholdService(householdRepo, app.personRepo, unitOfWork, app.auditService)
+
+	groupRepo := repository.NewGormGroupRepository(db)
+	app.groupService = services.NewGroupService(groupRepo, app.personRepo, unitOfWork, app.auditService)
+
+	tagRepo := repository.NewGormTagRepository(db)
+	app.tagService = services.NewTagService(tagRepo, app.personRepo, unitOfWork, app.auditService)
+
+	eventRepo := repository.NewGormEventRepository(db)
+	app.eventService = services.NewEventService(eventRepo, cfg.Location, unitOfWork, app.auditService)
+
+	// Las credenciales con QR se firman con una clave derivada del secreto JWT.
+	credentialSigner := credential.NewJWTSigner(cfg.JWTSecret, cfg.CredentialExpiration)
+	attendanceRepo := repository.NewGormAttendanceRepository(db)
+	app.attendanceService = services.NewAttendanceService(attendanceRepo, eventRepo, app.personRepo, credentialSigner, cfg.Location, accessPolicy, unitOfWork, app.auditService)
+
+	app.cardService = services.NewCardService(app.personRepo, groupRepo, credentialSigner, card.NewPDFRenderer(cfg.OrgName), unitOfWork, app.auditService)
+
+	app.noteService = services.NewNoteService(repository.NewGormNoteRepository(db), app.personRepo, app.userRepo, versionRepo, accessPolicy, unitOfWork, app.auditService)
+
+	app.delegationService = services.NewDelegationService(app.delegationRepo, app.personRepo, app.userRepo, accessPolicy, unitOfWork, app.auditService)
+
+	app.seedService = services.NewSeedService(app.personService, app.userService, app.personRepo, ubigeoCatalog)
+
+	return app, nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/riada2/config"
+	"github.com/riada2/internal/core/domain"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// command es un subcomando del binario. Todos leen la misma configuración que el
+// servidor y, salvo los que no usan la base (noDatabase), trabajan sobre ella.
+type command struct {
+	name       string
+	args       string
+	summary    string
+	noDatabase bool
+	run        func(cfg *config.Config, db *gorm.DB, args []string) error
+}
+
+// logger devuelve el logger de SQL del subcomando. El servidor registra todas las consultas
+// lentas y fallidas; los demás subcomandos solo los errores, sin "record not found", para
+// no mezclar el registro de SQL con su salida.
+func (c command) logger() gormlogger.Interface {
+	if c.name == "serve" {
+		return gormlogger.Default
+	}
+	return gormlogger.New(log.New(os.Stderr, "", log.LstdFlags), gormlogger.Config{
+		SlowThreshold:             time.Second,
+		LogLevel:                  gormlogger.Error,
+		IgnoreRecordNotFoundError: true,
+	})
+}
+
+// commands son los subcomandos en el orden en que se muestran en la ayuda. Sin
+// subcomando se ejecuta serve.
+var commands = []command{
+	{name: "serve", summary: "start the HTTP server (default)", run: runServe},
+	{name: "config", summary: "check the configuration and print it with the secrets hidden", noDatabase: true, run: runConfig},
+	{name: "migrate", args: "up | down [n] | status", summary: "manage the database schema", run: func(_ *config.Config, db *gorm.DB, args []string) error {
+		return runMigrate(db, args)
+	}},
+	{name: "user", args: "list | create | set-password | set-role", summary: "manage user accounts", run: runUser},
+	{name: "export", args: "[-o file]", summary: "write every person as JSON", run: runExport},
+	{name: "import", args: "-as admin [-dry-run] file", summary: "create the persons of a JSON export", run: runImport},
+	{name: "seed", args: "-as admin [-seed n] [-persons n] [-accounts n]", summary: "load reproducible demo persons", run: runSeed},
+}
+
+// runConfig escribe la configuración efectiva. Si llega a ejecutarse, la configuración es
+// válida: los errores se informan al cargarla.
+func runConfig(cfg *config.Config, _ *gorm.DB, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("config takes no arguments, got %q", args)
+	}
+	return cfg.Dump(os.Stdout)
+}
+
+// findCommand busca el subcomando por su nombre.
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// printUsage escribe la ayuda del binario: los subcomandos y los flags de configuración.
+func printUsage(w io.Writer, flags *flag.FlagSet) {
+	fmt.Fprintf(w, "usage: %s [configuration flags] <command> [arguments]\n\ncommands:\n", os.Args[0])
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %s %s\t%s\n", cmd.name, cmd.args, cmd.summary)
+	}
+	tw.Flush()
+	fmt.Fprintln(w, "\nrun \"<command> -h\" for the flags of a command")
+	fmt.Fprintln(w, "\nconfiguration flags, which take precedence over the environment, .env and the -config file:")
+	flags.SetOutput(w)
+	flags.PrintDefaults()
+}
+
+// cliActor es el actor de los subcomandos de cuentas: quien tiene acceso a la línea de
+// comandos y a la configuración administra la base (y puede crear el primer
+// administrador), así que actúa como administrador. En la auditoría queda sin usuario
+// y con "cli" como origen.
+var cliActor = domain.Actor{Role: domain.AdminRole, IP: "cli"}
+
+// adminActor es el actor de los subcomandos que escriben datos de personas, que la
+// política de acceso solo permite a usuarios identificados: el administrador username.
+func adminActor(app *application, username string) (domain.Actor, error) {
+	if username == "" {
+		return domain.Actor{}, errors.New("the -as flag with an admin username is required")
+	}
+	user, err := app.userService.GetUserByUsername(username)
+	if err != nil {
+		return domain.Actor{}, fmt.Errorf("%s: %w", username, err)
+	}
+	if user.Role != domain.AdminRole {
+		return domain.Actor{}, fmt.Errorf("%s is not an admin", username)
+	}
+	return domain.Actor{UserID: user.ID, Role: user.Role, IP: "cli"}, nil
+}
+
+// readPassword lee la contraseña de la primera línea de la entrada estándar, para que
+// no quede en los argumentos ni en el historial de la shell. En una terminal la pide
+// con un mensaje; también acepta una tubería (echo "$PASS" | ... user create ...).
+func readPassword(stdin *os.File) (string, error) {
+	if info, err := stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, "Password: ")
+	}
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("could not read the password: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("the password read from standard input is empty")
+	}
+	return password, nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/riada2/config"
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/handlers"
+	"gorm.io/gorm"
+)
+
+// El formato de export e import es el JSON de las personas en la API: un arreglo de
+// PersonResponse con sus direcciones, teléfonos y campos personalizados. import lo lee
+// como PersonRequest, así que también acepta lo que se enviaría a la API.
+
+// runExport escribe todas las personas en JSON, en el archivo de -o o en la salida estándar.
+func runExport(cfg *config.Config, db *gorm.DB, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	output := flags.String("o", "", "output file (default: standard output)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", flags.Args())
+	}
+	if err := migrateOnStart(db); err != nil {
+		return err
+	}
+	app, err := newApplication(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	persons, err := app.personService.ExportPersons(ports.PersonFilter{})
+	if err != nil {
+		return err
+	}
+	responses := make([]handlers.PersonResponse, 0, len(persons))
+	for i := range persons {
+		responses = append(responses, handlers.NewPersonResponse(&persons[i]))
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(responses); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d persons\n", len(responses))
+	return nil
+}
+
+// runImport crea las personas de un archivo JSON ("-" es la entrada estándar) en nombre del
+// administrador de -as. Cada persona se crea por separado con el servicio de personas, con
+// sus validaciones y auditoría: las que fallan (un documento repetido, un teléfono
+// inválido) se informan y no detienen al resto. Los IDs del archivo se ignoran; las
+// etiquetas y los hogares no se importan.
+func runImport(cfg *config.Config, db *gorm.DB, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	as := flags.String("as", "", "username of the admin recorded as the author")
+	dryRun := flags.Bool("dry-run", false, "only read and check the file format, without writing")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: import -as admin [-dry-run] file")
+	}
+
+	var r io.Reader = os.Stdin
+	if name := flags.Arg(0); name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+	var requests []handlers.PersonRequest
+	if err := json.NewDecoder(r).Decode(&requests); err != nil {
+		return fmt.Errorf("could not read the persons: %w", err)
+	}
+
+	var app *application
+	var actor domain.Actor
+	if !*dryRun {
+		if err := migrateOnStart(db); err != nil {
+			return err
+		}
+		var err error
+		if app, err = newApplication(cfg, db); err != nil {
+			return err
+		}
+		if actor, err = adminActor(app, *as); err != nil {
+			return err
+		}
+	}
+
+	created, failed := 0, 0
+	for i := range requests {
+		person, err := requests[i].ToDomain()
+		if err == nil && !*dryRun {
+			person.ID = 0
+			_, err = app.personService.CreatePerson(person, actor)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "person %d (%s %s): %v\n", i+1, requests[i].Name, requests[i].LastName, err)
+			failed++
+			continue
+		}
+		created++
+	}
+
+	if *dryRun {
+		fmt.Printf("%d persons can be imported, %d are invalid\n", created, failed)
+	} else {
+		fmt.Printf("imported %d persons, %d failed\n", created, failed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d persons were not imported", failed, len(requests))
+	}
+	return nil
+}
@@ -2,22 +2,16 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"os"
 	"strings"
+	_ "time/tzdata" // Incluye la base de zonas horarias para ORG_TIMEZONE
 
-	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/riada2/config"
 	_ "github.com/riada2/docs" // Importa los documentos de Swagger generados
-	"github.com/riada2/internal/core/domain"
-	"github.com/riada2/internal/core/ports"
-	"github.com/riada2/internal/handlers"
 	"github.com/riada2/internal/repository"
-	"github.com/riada2/internal/router"
-	"github.com/riada2/internal/services"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
@@ -41,98 +35,74 @@ import (
 // @in header
 // @name Authorization
 func main() {
-	// Cargar configuración
-	cfg, err := config.LoadConfig("./.env")
-	if err != nil {
-		log.Fatalf("could not load config: %v", err)
+	// Los flags de configuración van antes del subcomando: api -app-port 8080 serve
+	loader := config.NewLoader()
+	flags := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	loader.RegisterFlags(flags)
+	flags.Usage = func() { printUsage(flags.Output(), flags) }
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		os.Exit(2)
 	}
 
-	// Crear la base de datos si no existe
-	err = createDatabaseIfNotExists(cfg)
-	if err != nil {
-		log.Fatalf("database setup failed: %v", err)
+	name, args := "serve", flags.Args()
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
 	}
-
-	// Conectar a la base de datos
-	db, err := gorm.Open(postgres.Open(cfg.DBSource), &gorm.Config{
-		DisableForeignKeyConstraintWhenMigrating: true,
-	})
-	if err != nil {
-		log.Fatalf("could not connect to db: %v", err)
+	if name == "help" {
+		printUsage(os.Stdout, flags)
+		return
+	}
+	cmd, ok := findCommand(name)
+	if !ok {
+		printUsage(os.Stderr, flags)
+		log.Fatalf("unknown command %q", name)
 	}
 
-	// Migrar el esquema
-	err = db.AutoMigrate(&domain.User{}, &domain.Person{}, &domain.Address{}, &domain.Phone{})
+	// Cargar y validar la configuración antes de tocar la base de datos
+	cfg, err := loader.Load()
 	if err != nil {
-		log.Fatalf("could not migrate db: %v", err)
+		log.Fatalf("invalid configuration:\n%v", err)
 	}
 
-	// Crear manualmente las restricciones de clave foránea para evitar dependencias circulares
-	// durante la migración inicial.
-	if !db.Migrator().HasConstraint(&domain.User{}, "Person") {
-		db.Migrator().CreateConstraint(&domain.User{}, "Person")
+	// Conectar a la base de datos (PostgreSQL o SQLite, según DB_DRIVER)
+	var db *gorm.DB
+	if !cmd.noDatabase {
+		if db, err = openDatabase(cfg, cmd.logger()); err != nil {
+			log.Fatalf("could not connect to db: %v", err)
+		}
 	}
-	if !db.Migrator().HasConstraint(&domain.Person{}, "User") {
-		db.Migrator().CreateConstraint(&domain.Person{}, "User")
+
+	if err := cmd.run(cfg, db, args); err != nil {
+		log.Fatalf("%s: %v", name, err)
 	}
+}
 
-	// Inyección de dependencias (unión de piezas)
-	userRepo := repository.NewGormUserRepository(db)
-	userService := services.NewUserService(userRepo, cfg.JWTSecret)
-	userHandler := handlers.NewUserHandler(userService)
-	authHandler := handlers.NewAuthHandler(userService, cfg)
-
-	personRepo := repository.NewGormPersonRepository(db)
-	personService := services.NewPersonService(personRepo)
-	personHandler := handlers.NewPersonHandler(personService)
-
-	addressRepo := repository.NewGormAddressRepository(db)
-	addressService := services.NewAddressService(addressRepo, personRepo)
-	addressHandler := handlers.NewAddressHandler(addressService)
-
-	phoneRepo := repository.NewGormPhoneRepository(db)
-	phoneService := services.NewPhoneService(phoneRepo, personRepo)
-	phoneHandler := handlers.NewPhoneHandler(phoneService)
-
-	createDefaultAdmin(db, userRepo, cfg)
-
-	// Configuración de Fiber
-	app := fiber.New()
-	app.Use(cors.New(cors.Config{
-		// En un entorno de producción, deberías restringir esto a tu dominio de frontend.
-		// Ejemplo: AllowOrigins: "http://localhost:5173, http://mi-frontend.com",
-		AllowOrigins: "*",
-		// AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-		AllowHeaders: "*",
-		AllowMethods: "GET,POST,HEAD,PUT,DELETE,PATCH",
-	}))
-	app.Use(logger.New())
-
-	router.SetupRoutes(app, authHandler, userHandler, personHandler, addressHandler, phoneHandler, cfg)
-
-	log.Fatal(app.Listen(fmt.Sprintf(":%s", cfg.AppPort)))
+// openDatabase abre la base de datos de DB_DRIVER con el logger de SQL sqlLogger. Con
+// PostgreSQL, antes la crea si no existe; SQLite crea el archivo al abrirlo.
+func openDatabase(cfg *config.Config, sqlLogger gormlogger.Interface) (*gorm.DB, error) {
+	gormConfig := &gorm.Config{
+		Logger:                                   sqlLogger,
+		DisableForeignKeyConstraintWhenMigrating: true,
+	}
+	if cfg.DBDriver == "sqlite" {
+		return repository.OpenSQLite(cfg.DBPath, gormConfig)
+	}
+	if err := createDatabaseIfNotExists(cfg); err != nil {
+		return nil, fmt.Errorf("database setup failed: %w", err)
+	}
+	return gorm.Open(postgres.Open(cfg.PostgresDSN(cfg.DBName)), gormConfig)
 }
 
 // createDatabaseIfNotExists se conecta a la base de datos 'postgres' por defecto
 // para verificar si la base de datos de la aplicación existe, y la crea si no.
 func createDatabaseIfNotExists(cfg *config.Config) error {
-	var dbName string
-	// Extraer el nombre de la base de datos del DSN
-	parts := strings.Split(cfg.DBSource, " ")
-	for _, part := range parts {
-		if strings.HasPrefix(part, "dbname=") {
-			dbName = strings.TrimPrefix(part, "dbname=")
-			break
-		}
-	}
-
-	if dbName == "" {
-		return errors.New("dbname not found in DSN")
-	}
+	dbName := cfg.DBName
 
 	// Crear un DSN para la base de datos 'postgres'
-	// Reemplazamos dbname=... por dbname=postgres
-	tempDSN := strings.Replace(cfg.DBSource, "dbname="+dbName, "dbname=postgres", 1)
+	tempDSN := cfg.PostgresDSN("postgres")
 
 	// Conectarse a la base de datos 'postgres'
 	db, err := gorm.Open(postgres.Open(tempDSN), &gorm.Config{
@@ -159,8 +129,9 @@ func createDatabaseIfNotExists(cfg *config.Config) error {
 
 	if !exists {
 		log.Printf("Database '%s' not found, creating...", dbName)
-		// Usar Exec para crear la base de datos.
-		err = db.Exec(fmt.Sprintf("CREATE DATABASE %s", dbName)).Error
+		// Usar Exec para crear la base de datos. El nombre va entre comillas dobles, como
+		// identificador, porque no se puede pasar como parámetro.
+		err = db.Exec(fmt.Sprintf("CREATE DATABASE %s", quoteIdentifier(dbName))).Error
 		if err != nil {
 			return fmt.Errorf("failed to create database '%s': %w", dbName, err)
 		}
@@ -170,32 +141,7 @@ func createDatabaseIfNotExists(cfg *config.Config) error {
 	return nil
 }
 
-func createDefaultAdmin(db *gorm.DB, userRepo ports.UserRepository, cfg *config.Config) {
-	var userCount int64
-	db.Model(&domain.User{}).Count(&userCount)
-
-	if userCount == 0 {
-		log.Println("No users found. Creating default admin user...")
-
-		if cfg.DefaultAdminUser == "" || cfg.DefaultAdminPassword == "" {
-			log.Println("DEFAULT_ADMIN_USER or DEFAULT_ADMIN_PASSWORD not set in .env. Skipping creation.")
-			return
-		}
-
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(cfg.DefaultAdminPassword), bcrypt.DefaultCost)
-		if err != nil {
-			log.Fatalf("Failed to hash default admin password: %v", err)
-		}
-
-		admin := &domain.User{
-			Username:     cfg.DefaultAdminUser,
-			PasswordHash: string(hashedPassword),
-			Role:         domain.AdminRole,
-		}
-
-		if err := userRepo.Save(admin); err != nil {
-			log.Fatalf("Failed to create default admin user: %v", err)
-		}
-		log.Println("Default admin user created successfully.")
-	}
+// quoteIdentifier escribe name como identificador de PostgreSQL entre comillas dobles.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
 }
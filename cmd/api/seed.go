@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/riada2/config"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// runSeed carga personas de demostración en nombre del administrador de -as. Con la
+// misma semilla se generan las mismas personas, así que repetir el comando no duplica
+// nada. Si -accounts es mayor que cero, la contraseña de las cuentas se lee de la
+// entrada estándar.
+func runSeed(cfg *config.Config, db *gorm.DB, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	as := flags.String("as", "", "username of the admin recorded as the author")
+	seed := flags.Int64("seed", 1, "the same seed always generates the same persons")
+	persons := flags.Int("persons", 100, fmt.Sprintf("number of persons, up to %d", ports.MaxSeedPersons))
+	accounts := flags.Int("accounts", 0, "how many of the first persons get a linked user account")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", flags.Args())
+	}
+	if err := migrateOnStart(db); err != nil {
+		return err
+	}
+	app, err := newApplication(cfg, db)
+	if err != nil {
+		return err
+	}
+	actor, err := adminActor(app, *as)
+	if err != nil {
+		return err
+	}
+
+	options := ports.SeedOptions{Seed: *seed, Persons: *persons, Accounts: *accounts}
+	if options.Accounts > 0 {
+		if options.Password, err = readPassword(os.Stdin); err != nil {
+			return err
+		}
+	}
+	report, err := app.seedService.Seed(options, actor)
+	if err != nil {
+		return err
+	}
+	for _, message := range report.Errors {
+		fmt.Fprintln(os.Stderr, message)
+	}
+	fmt.Printf("seed %d: %d persons created, %d already existed, %d accounts created, %d failed\n",
+		report.Seed, report.Created, report.Existing, report.Accounts, report.Failed)
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d persons were not created", report.Failed, report.Persons)
+	}
+	return nil
+}
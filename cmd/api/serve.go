@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/riada2/config"
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/handlers"
+	"github.com/riada2/internal/notify"
+	"github.com/riada2/internal/repository"
+	"github.com/riada2/internal/router"
+	"github.com/riada2/internal/scheduler"
+	"github.com/riada2/internal/services"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// runServe aplica las migraciones pendientes, programa las tareas diarias e inicia el servidor HTTP.
+func runServe(cfg *config.Config, db *gorm.DB, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("serve takes no arguments, got %q", args)
+	}
+
+	log.Println("Configuration:")
+	if err := cfg.Dump(log.Writer()); err != nil {
+		return err
+	}
+
+	// Aplicar las migraciones pendientes del esquema
+	if err := migrateOnStart(db); err != nil {
+		return fmt.Errorf("could not migrate db: %w", err)
+	}
+
+	app, err := newApplication(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	notifier, err := newNotifier(cfg)
+	if err != nil {
+		return fmt.Errorf("could not configure notifications: %w", err)
+	}
+	celebrationService := services.NewCelebrationService(app.personRepo, app.relationshipRepo, notifier, cfg.ReminderRecipients, cfg.Location)
+
+	// Tareas programadas: los recordatorios diarios de cumpleaños y aniversarios.
+	jobScheduler := scheduler.New(repository.NewGormJobStateRepository(db), cfg.Location)
+	err = jobScheduler.Daily("celebration_reminders", cfg.ReminderTime, func(ctx context.Context, scheduledAt time.Time) error {
+		sent, err := celebrationService.SendReminders(ctx, scheduledAt)
+		if err == nil {
+			log.Printf("celebration reminders: %d celebrations notified", sent)
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("could not schedule jobs: %w", err)
+	}
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	jobScheduler.Start(schedulerCtx)
+
+	createDefaultAdmin(db, app.userRepo, cfg)
+
+	// Configuración de Fiber
+	server := fiber.New()
+	server.Use(cors.New(cors.Config{
+		// En un entorno de producción, deberías restringir esto a tu dominio de frontend.
+		// Ejemplo: AllowOrigins: "http://localhost:5173, http://mi-frontend.com",
+		AllowOrigins: "*",
+		// AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders: "*",
+		AllowMethods: "GET,POST,HEAD,PUT,DELETE,PATCH",
+	}))
+	// El ID de petición se expone en la cabecera X-Request-ID y se guarda en la auditoría.
+	server.Use(requestid.New())
+	server.Use(logger.New())
+
+	router.SetupRoutes(server, router.Handlers{
+		Auth:         handlers.NewAuthHandler(app.userService, cfg),
+		User:         handlers.NewUserHandler(app.userService),
+		Person:       handlers.NewPersonHandler(app.personService),
+		Address:      handlers.NewAddressHandler(app.addressService),
+		Phone:        handlers.NewPhoneHandler(app.phoneService),
+		History:      handlers.NewHistoryHandler(app.historyService),
+		Audit:        handlers.NewAuditHandler(app.auditService),
+		Relationship: handlers.NewRelationshipHandler(app.relationshipService),
+		Household:    handlers.NewHouseholdHandler(app.householdService),
+		Group:        handlers.NewGroupHandler(app.groupService),
+		Tag:          handlers.NewTagHandler(app.tagService),
+		CustomField:  handlers.NewCustomFieldHandler(app.customFieldService),
+		Event:        handlers.NewEventHandler(app.eventService),
+		Attendance:   handlers.NewAttendanceHandler(app.attendanceService),
+		Card:         handlers.NewCardHandler(app.cardService),
+		Celebration:  handlers.NewCelebrationHandler(celebrationService, jobScheduler),
+		Note:         handlers.NewNoteHandler(app.noteService),
+		Ubigeo:       handlers.NewUbigeoHandler(app.ubigeoCatalog),
+		Setting:      handlers.NewSettingHandler(app.settingsService),
+		Delegation:   handlers.NewDelegationHandler(app.delegationService),
+		Seed:         handlers.NewSeedHandler(app.seedService),
+	}, cfg)
+
+	return server.Listen(fmt.Sprintf(":%d", cfg.AppPort))
+}
+
+// newNotifier crea el canal de notificaciones configurado en NOTIFICATION_CHANNEL. La
+// configuración ya validó que el canal tenga sus datos.
+func newNotifier(cfg *config.Config) (ports.Notifier, error) {
+	switch cfg.NotificationChannel {
+	case "log":
+		return notify.NewLogNotifier(), nil
+	case "webhook":
+		return notify.NewWebhookNotifier(cfg.WebhookURL), nil
+	case "email":
+		return notify.NewEmailNotifier(notify.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     strconv.Itoa(cfg.SMTPPort),
+			User:     cfg.SMTPUser,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}), nil
+	}
+	return nil, fmt.Errorf("unknown NOTIFICATION_CHANNEL %q, use log, webhook or email", cfg.NotificationChannel)
+}
+
+// createDefaultAdmin crea el administrador de DEFAULT_ADMIN_USER si la base no tiene usuarios.
+// Sin esas variables, el primer administrador se crea con "user create -role admin".
+func createDefaultAdmin(db *gorm.DB, userRepo ports.UserRepository, cfg *config.Config) {
+	var userCount int64
+	db.Model(&domain.User{}).Count(&userCount)
+
+	if userCount == 0 {
+		log.Println("No users found. Creating default admin user...")
+
+		if cfg.DefaultAdminUser == "" || cfg.DefaultAdminPassword == "" {
+			log.Println("DEFAULT_ADMIN_USER or DEFAULT_ADMIN_PASSWORD not set. Skipping creation; use \"user create -role admin\" instead.")
+			return
+		}
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(cfg.DefaultAdminPassword), bcrypt.DefaultCost)
+		if err != nil {
+			log.Fatalf("Failed to hash default admin password: %v", err)
+		}
+
+		admin := &domain.User{
+			Username:     cfg.DefaultAdminUser,
+			PasswordHash: string(hashedPassword),
+			Role:         domain.AdminRole,
+		}
+
+		if err := userRepo.Save(admin); err != nil {
+			log.Fatalf("Failed to create default admin user: %v", err)
+		}
+		log.Println("Default admin user created successfully.")
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/riada2/config"
+	"github.com/riada2/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+const userUsage = `usage:
+  user list
+  user create -username NAME [-role user|admin]    the password is read from standard input
+  user set-password -username NAME                 the password is read from standard input
+  user set-role -username NAME -role user|admin`
+
+// runUser ejecuta los subcomandos de cuentas de usuario. Pasan por el servicio de
+// usuarios, así validan y se auditan igual que desde la API.
+func runUser(cfg *config.Config, db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return errors.New(userUsage)
+	}
+	if err := migrateOnStart(db); err != nil {
+		return err
+	}
+	app, err := newApplication(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("user "+args[0], flag.ContinueOnError)
+	username := flags.String("username", "", "account name")
+	role := flags.String("role", "", "account role: user or admin")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", flags.Args())
+	}
+	if args[0] != "list" && *username == "" {
+		return errors.New("the -username flag is required")
+	}
+
+	switch args[0] {
+	case "list":
+		users, err := app.userService.GetAllUsers()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tUSERNAME\tROLE\tCREATED AT")
+		for _, user := range users {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", user.ID, user.Username, user.Role, user.CreatedAt.Format("2006-01-02 15:04"))
+		}
+		return w.Flush()
+	case "create":
+		if *role == "" {
+			*role = string(domain.UserRole)
+		}
+		if !domain.Role(*role).IsValid() {
+			return errors.New("invalid role, use admin or user")
+		}
+		password, err := readPassword(os.Stdin)
+		if err != nil {
+			return err
+		}
+		user, err := app.userService.CreateUser(*username, password, domain.Role(*role), cliActor)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created user %s (id %d, role %s)\n", user.Username, user.ID, user.Role)
+		return nil
+	case "set-password":
+		user, err := app.userService.GetUserByUsername(*username)
+		if err != nil {
+			return err
+		}
+		password, err := readPassword(os.Stdin)
+		if err != nil {
+			return err
+		}
+		if err := app.userService.SetPassword(user.ID, password, cliActor); err != nil {
+			return err
+		}
+		fmt.Printf("password of %s changed\n", user.Username)
+		return nil
+	case "set-role":
+		if *role == "" {
+			return errors.New("the -role flag is required")
+		}
+		user, err := app.userService.GetUserByUsername(*username)
+		if err != nil {
+			return err
+		}
+		newRole := domain.Role(*role)
+		updated, err := app.userService.UpdateUser(user.ID, nil, &newRole, cliActor)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("role of %s set to %s\n", updated.Username, updated.Role)
+		return nil
+	}
+	return fmt.Errorf("unknown user command %q\n%s", args[0], userUsage)
+}
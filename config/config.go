@@ -1,40 +1,146 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"os"
-
-	"github.com/joho/godotenv"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 )
 
+// minJWTSecretLength es el largo mínimo de JWT_SECRET: 32 caracteres, los 256 bits de HS256.
+const minJWTSecretLength = 32
+
 // Config holds all configuration for the application
 type Config struct {
-	DBSource             string
-	JWTSecret            string
+	// DBDriver es la base de datos: "postgres" o "sqlite". PostgreSQL usa los campos DB*
+	// (ver PostgresDSN); SQLite solo DBPath, la ruta del archivo de la base.
+	DBDriver   string
+	DBHost     string
+	DBPort     int
+	DBUser     string
+	DBPassword string
+	DBName     string
+	DBSSLMode  string
+	DBPath     string
+
+	JWTSecret string
+	// JWTExpiration es la vigencia de los tokens de sesión.
+	JWTExpiration        time.Duration
 	DefaultAdminUser     string
 	DefaultAdminPassword string
-	AppPort              string
+	AppPort              int
 	RecaptchaSecretKey   string
+	OrgName              string // Nombre de la organización, impreso en los carnés
+	// CredentialExpiration es la vigencia de las credenciales con QR de los carnés.
+	CredentialExpiration time.Duration
+
+	// UbigeoCatalogPath es un CSV con el catálogo de ubigeo completo del INEI
+	// (ubigeo,departamento,provincia,distrito). Vacío usa el catálogo incluido, que es
+	// parcial: los distritos que no tiene se aceptan sin verificar.
+	UbigeoCatalogPath string
+	// GeocoderTablePath es un CSV (clave,latitud,longitud) con las coordenadas por ubigeo
+	// o por texto de dirección. Vacío deja las direcciones sin geocodificar.
+	GeocoderTablePath string
+	// PhoneDefaultCountryCode es el código de país (sin "+") que se antepone a los
+	// teléfonos escritos en formato nacional, por ejemplo "51" para Perú.
+	PhoneDefaultCountryCode string
+
+	// Timezone es la zona horaria IANA de la organización; Location es esa zona ya cargada.
+	// Define el día de los eventos y la hora de los recordatorios.
+	Timezone string
+	Location *time.Location
+
+	// ReminderTime es la hora diaria ("HH:MM", en Timezone) del envío de recordatorios
+	// de cumpleaños y aniversarios a ReminderRecipients.
+	ReminderTime       string
+	ReminderRecipients []string
+
+	// NotificationChannel es el canal de las notificaciones: "log" (desarrollo), "webhook" o "email".
+	NotificationChannel string
+	WebhookURL          string
+	SMTPHost            string
+	SMTPPort            int
+	SMTPUser            string
+	SMTPPassword        string
+	SMTPFrom            string
+
+	// SeedEndpointEnabled habilita POST /admin/seed, que carga datos de demostración.
+	// Es para entornos de desarrollo; en producción debe quedar desactivado.
+	SeedEndpointEnabled bool
+
+	// resolved son los valores de texto de cada opción y su origen, para Dump.
+	resolved []resolvedSetting
+}
+
+type resolvedSetting struct {
+	setting *setting
+	value   string
+	source  string
+}
+
+// validate revisa las reglas que dependen de más de una opción. Los valores de cada
+// opción ya se validaron al convertirlos.
+func (c *Config) validate() []error {
+	var errs []error
+	switch {
+	case c.JWTSecret == "":
+		errs = append(errs, errors.New("JWT_SECRET is required"))
+	case len(c.JWTSecret) < minJWTSecretLength:
+		errs = append(errs, fmt.Errorf("JWT_SECRET must be at least %d characters long", minJWTSecretLength))
+	}
+	if c.DBDriver == "postgres" && (c.DBUser == "" || c.DBName == "") {
+		errs = append(errs, errors.New("DB_USER and DB_NAME are required for the postgres driver"))
+	}
+	if (c.DefaultAdminUser == "") != (c.DefaultAdminPassword == "") {
+		errs = append(errs, errors.New("DEFAULT_ADMIN_USER and DEFAULT_ADMIN_PASSWORD must be set together"))
+	}
+	switch c.NotificationChannel {
+	case "webhook":
+		if c.WebhookURL == "" {
+			errs = append(errs, errors.New("NOTIFICATION_WEBHOOK_URL is required for the webhook channel"))
+		}
+	case "email":
+		if c.SMTPHost == "" || c.SMTPFrom == "" {
+			errs = append(errs, errors.New("SMTP_HOST and SMTP_FROM are required for the email channel"))
+		}
+	}
+	return errs
+}
+
+// PostgresDSN devuelve la cadena de conexión a la base dbname del servidor PostgreSQL
+// configurado. Los valores van entre comillas simples, así que pueden tener espacios,
+// comillas o barras.
+func (c *Config) PostgresDSN(dbname string) string {
+	return strings.Join([]string{
+		"host=" + quoteDSN(c.DBHost),
+		"port=" + strconv.Itoa(c.DBPort),
+		"user=" + quoteDSN(c.DBUser),
+		"password=" + quoteDSN(c.DBPassword),
+		"dbname=" + quoteDSN(dbname),
+		"sslmode=" + c.DBSSLMode,
+	}, " ")
+}
+
+var dsnEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+func quoteDSN(value string) string {
+	return "'" + dsnEscaper.Replace(value) + "'"
 }
 
-// LoadConfig loads configuration from .env file
-func LoadConfig(path string) (*Config, error) {
-	// if err := godotenv.Load(path); err != nil {
-	// 	return nil, fmt.Errorf("error loading .env file: %w", err)
-	// }
-
-	_ = godotenv.Load(path)
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
-		os.Getenv("DB_HOST"), os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"), os.Getenv("DB_PORT"), os.Getenv("DB_SSLMODE"))
-
-	return &Config{
-		DBSource:             dsn,
-		JWTSecret:            os.Getenv("JWT_SECRET"),
-		DefaultAdminUser:     os.Getenv("DEFAULT_ADMIN_USER"),
-		DefaultAdminPassword: os.Getenv("DEFAULT_ADMIN_PASSWORD"),
-		AppPort:              os.Getenv("APP_PORT"),
-		RecaptchaSecretKey:   os.Getenv("RECAPTCHA_SECRET_KEY"),
-	}, nil
+// Dump escribe la configuración efectiva en w, una opción por línea con su origen. Los
+// secretos definidos se muestran como "******".
+func (c *Config) Dump(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SETTING\tVALUE\tSOURCE")
+	for _, r := range c.resolved {
+		value := r.value
+		if r.setting.secret && value != "" {
+			value = "******"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.setting.key, value, r.source)
+	}
+	return tw.Flush()
 }
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// load carga la configuración con el entorno env, sin el entorno del proceso, desde el
+// directorio dir (donde se busca .env) y con los flags args.
+func load(t *testing.T, dir string, env map[string]string, args ...string) (*Config, error) {
+	t.Helper()
+	chdir(t, dir)
+	loader := NewLoader()
+	loader.getenv = func(key string) string { return env[key] }
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	loader.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return loader.Load()
+}
+
+// chdir cambia el directorio de trabajo durante la prueba.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(previous) })
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func sqliteEnv() map[string]string {
+	return map[string]string{"DB_DRIVER": "sqlite", "JWT_SECRET": testSecret}
+}
+
+func TestDefaults(t *testing.T) {
+	cfg, err := load(t, t.TempDir(), sqliteEnv())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AppPort != 3001 || cfg.JWTExpiration != 72*time.Hour || cfg.DBPath != "riada.db" ||
+		cfg.Location.String() != "America/Lima" || cfg.SMTPPort != 587 || cfg.SeedEndpointEnabled {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	configFile := writeFile(t, dir, "riada.yaml", `
+db_driver: sqlite
+org_name: From file
+app_port: 4000
+reminder_recipients: [ana@example.com, luis@example.com]
+jwt_expiration: 1h
+`)
+	writeFile(t, dir, ".env", "APP_PORT=5000\nJWT_EXPIRATION=2h\nORG_NAME=\n")
+	env := map[string]string{"CONFIG_FILE": configFile, "JWT_SECRET": testSecret, "JWT_EXPIRATION": "3h"}
+
+	cfg, err := load(t, dir, env, "-jwt-expiration", "4h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Un valor vacío en .env no tapa el del archivo de configuración.
+	if cfg.OrgName != "From file" {
+		t.Errorf("ORG_NAME = %q, want the file value", cfg.OrgName)
+	}
+	if cfg.AppPort != 5000 {
+		t.Errorf("APP_PORT = %d, want the .env value", cfg.AppPort)
+	}
+	if cfg.JWTExpiration != 4*time.Hour {
+		t.Errorf("JWT_EXPIRATION = %v, want the flag value", cfg.JWTExpiration)
+	}
+	if strings.Join(cfg.ReminderRecipients, " ") != "ana@example.com luis@example.com" {
+		t.Errorf("REMINDER_RECIPIENTS = %q", cfg.ReminderRecipients)
+	}
+}
+
+func TestSecretFiles(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := writeFile(t, dir, "jwt", testSecret+"\n")
+
+	cfg, err := load(t, dir, map[string]string{"DB_DRIVER": "sqlite", "JWT_SECRET_FILE": secretFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.JWTSecret != testSecret {
+		t.Errorf("JWT_SECRET = %q, want the file content without the newline", cfg.JWTSecret)
+	}
+
+	// El flag tiene prioridad sobre el entorno, aunque uno sea un archivo.
+	cfg, err = load(t, dir, map[string]string{"DB_DRIVER": "sqlite", "JWT_SECRET": "x"}, "-jwt-secret-file", secretFile)
+	if err != nil || cfg.JWTSecret != testSecret {
+		t.Errorf("JWT_SECRET = %v, %v; want the file of the flag", cfg, err)
+	}
+
+	env := sqliteEnv()
+	env["JWT_SECRET_FILE"] = secretFile
+	if _, err := load(t, dir, env); err == nil || !strings.Contains(err.Error(), "both set") {
+		t.Errorf("JWT_SECRET and JWT_SECRET_FILE together: err = %v", err)
+	}
+}
+
+func TestValidationReportsEveryError(t *testing.T) {
+	env := map[string]string{
+		"APP_PORT":              "0",
+		"JWT_EXPIRATION":        "3 days",
+		"SEED_ENDPOINT_ENABLED": "maybe",
+		"NOTIFICATION_CHANNEL":  "email",
+		"DEFAULT_ADMIN_USER":    "admin",
+	}
+	_, err := load(t, t.TempDir(), env)
+	if err == nil {
+		t.Fatal("invalid configuration was loaded")
+	}
+	for _, want := range []string{"JWT_SECRET is required", "APP_PORT", "JWT_EXPIRATION", "SEED_ENDPOINT_ENABLED",
+		"DB_USER and DB_NAME", "SMTP_HOST", "DEFAULT_ADMIN_PASSWORD"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s:\n%v", want, err)
+		}
+	}
+}
+
+func TestSourceErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := load(t, dir, sqliteEnv(), "-env-file", "missing.env"); err == nil {
+		t.Error("a missing -env-file was ignored")
+	}
+	configFile := writeFile(t, dir, "riada.yaml", "app_prot: 4000\n")
+	if _, err := load(t, dir, sqliteEnv(), "-config", configFile); err == nil || !strings.Contains(err.Error(), "app_prot") {
+		t.Errorf("unknown setting in the file: err = %v", err)
+	}
+}
+
+func TestPostgresDSN(t *testing.T) {
+	cfg := &Config{DBHost: "db.local", DBPort: 5433, DBUser: "riada", DBPassword: `a b'c\d`, DBSSLMode: "disable"}
+	parsed, err := pgconn.ParseConfig(cfg.PostgresDSN("my db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Host != "db.local" || parsed.Port != 5433 || parsed.User != "riada" || parsed.Password != `a b'c\d` || parsed.Database != "my db" {
+		t.Fatalf("parsed DSN = %+v", parsed)
+	}
+}
+
+func TestDumpHidesSecrets(t *testing.T) {
+	env := sqliteEnv()
+	env["SMTP_PASSWORD"] = "smtp-pass"
+	cfg, err := load(t, t.TempDir(), env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := cfg.Dump(&out); err != nil {
+		t.Fatal(err)
+	}
+	dump := out.String()
+	if strings.Contains(dump, testSecret) || strings.Contains(dump, "smtp-pass") {
+		t.Fatalf("dump shows a secret:\n%s", dump)
+	}
+	for _, want := range []string{"JWT_SECRET ****** env", "SMTP_PASSWORD ****** env", "APP_PORT 3001 default", "RECAPTCHA_SECRET_KEY default"} {
+		found := false
+		for _, line := range strings.Split(dump, "\n") {
+			found = found || strings.Join(strings.Fields(line), " ") == want
+		}
+		if !found {
+			t.Errorf("dump does not contain %q:\n%s", want, dump)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting es una opción de configuración. key es su variable de entorno; en el archivo
+// de configuración se escribe en minúsculas (app_port) y como flag con guiones
+// (-app-port). Los secretos también se pueden leer de un archivo con KEY_FILE, y no se
+// muestran en el volcado de la configuración.
+type setting struct {
+	key    string
+	def    string
+	secret bool
+	usage  string
+	set    func(cfg *Config, value string) error
+}
+
+// settings son todas las opciones, en el orden en que se muestran.
+var settings = []setting{
+	{key: "DB_DRIVER", def: "postgres", usage: "database: postgres or sqlite", set: oneOf(func(c *Config) *string { return &c.DBDriver }, "postgres", "sqlite")},
+	{key: "DB_HOST", def: "localhost", usage: "PostgreSQL host", set: text(func(c *Config) *string { return &c.DBHost })},
+	{key: "DB_PORT", def: "5432", usage: "PostgreSQL port", set: port(func(c *Config) *int { return &c.DBPort })},
+	{key: "DB_USER", usage: "PostgreSQL user", set: text(func(c *Config) *string { return &c.DBUser })},
+	{key: "DB_PASSWORD", secret: true, usage: "PostgreSQL password", set: text(func(c *Config) *string { return &c.DBPassword })},
+	{key: "DB_NAME", usage: "PostgreSQL database, created if it does not exist", set: text(func(c *Config) *string { return &c.DBName })},
+	{key: "DB_SSLMODE", def: "prefer", usage: "PostgreSQL sslmode", set: oneOf(func(c *Config) *string { return &c.DBSSLMode }, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")},
+	{key: "DB_PATH", def: "riada.db", usage: "SQLite database file", set: text(func(c *Config) *string { return &c.DBPath })},
+	{key: "JWT_SECRET", secret: true, usage: "key that signs the session tokens, at least 32 characters", set: text(func(c *Config) *string { return &c.JWTSecret })},
+	{key: "JWT_EXPIRATION", def: "72h", usage: "lifetime of the session tokens", set: duration(func(c *Config) *time.Duration { return &c.JWTExpiration })},
+	{key: "APP_PORT", def: "3001", usage: "HTTP server port", set: port(func(c *Config) *int { return &c.AppPort })},
+	{key: "DEFAULT_ADMIN_USER", usage: "initial admin, created only if there are no users", set: text(func(c *Config) *string { return &c.DefaultAdminUser })},
+	{key: "DEFAULT_ADMIN_PASSWORD", secret: true, usage: "password of the initial admin", set: text(func(c *Config) *string { return &c.DefaultAdminPassword })},
+	{key: "RECAPTCHA_SECRET_KEY", secret: true, usage: "reCAPTCHA secret of the registration form", set: text(func(c *Config) *string { return &c.RecaptchaSecretKey })},
+	{key: "ORG_NAME", def: "Riada", usage: "organization name printed on the cards", set: text(func(c *Config) *string { return &c.OrgName })},
+	{key: "CREDENTIAL_EXPIRATION", def: "8760h", usage: "lifetime of the QR credentials printed on the cards", set: duration(func(c *Config) *time.Duration { return &c.CredentialExpiration })},
+	{key: "UBIGEO_CATALOG_PATH", usage: "CSV with the full INEI ubigeo catalog (default: the bundled one)", set: text(func(c *Config) *string { return &c.UbigeoCatalogPath })},
+	{key: "GEOCODER_TABLE_PATH", usage: "CSV with key,latitude,longitude coordinates", set: text(func(c *Config) *string { return &c.GeocoderTablePath })},
+	{key: "PHONE_DEFAULT_COUNTRY_CODE", def: "51", usage: "country code of the phones written without \"+\"", set: countryCode},
+	{key: "ORG_TIMEZONE", def: "America/Lima", usage: "IANA time zone of the organization", set: timezone},
+	{key: "REMINDER_TIME", def: "08:00", usage: "daily time (HH:MM) of the celebration reminders", set: clock(func(c *Config) *string { return &c.ReminderTime })},
+	{key: "REMINDER_RECIPIENTS", usage: "comma separated recipients of the reminders", set: list(func(c *Config) *[]string { return &c.ReminderRecipients })},
+	{key: "NOTIFICATION_CHANNEL", def: "log", usage: "notification channel: log, webhook or email", set: oneOf(func(c *Config) *string { return &c.NotificationChannel }, "log", "webhook", "email")},
+	{key: "NOTIFICATION_WEBHOOK_URL", secret: true, usage: "URL of the webhook channel", set: webURL(func(c *Config) *string { return &c.WebhookURL })},
+	{key: "SMTP_HOST", usage: "SMTP server of the email channel", set: text(func(c *Config) *string { return &c.SMTPHost })},
+	{key: "SMTP_PORT", def: "587", usage: "SMTP port", set: port(func(c *Config) *int { return &c.SMTPPort })},
+	{key: "SMTP_USER", usage: "SMTP user", set: text(func(c *Config) *string { return &c.SMTPUser })},
+	{key: "SMTP_PASSWORD", secret: true, usage: "SMTP password", set: text(func(c *Config) *string { return &c.SMTPPassword })},
+	{key: "SMTP_FROM", usage: "sender address of the emails", set: text(func(c *Config) *string { return &c.SMTPFrom })},
+	{key: "SEED_ENDPOINT_ENABLED", def: "false", usage: "enable POST /admin/seed (development only)", set: boolean(func(c *Config) *bool { return &c.SeedEndpointEnabled })},
+}
+
+// findSetting busca la opción de la variable key.
+func findSetting(key string) (*setting, bool) {
+	for i := range settings {
+		if settings[i].key == key {
+			return &settings[i], true
+		}
+	}
+	return nil, false
+}
+
+// Conversores de los valores de texto de las fuentes a los campos de Config. El error
+// explica el formato esperado; lo completa Load con la clave y el valor.
+
+func text(field func(*Config) *string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}
+}
+
+func oneOf(field func(*Config) *string, values ...string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		for _, allowed := range values {
+			if value == allowed {
+				*field(c) = value
+				return nil
+			}
+		}
+		return fmt.Errorf("use %s", strings.Join(values, ", "))
+	}
+}
+
+func port(field func(*Config) *int) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 65535 {
+			return errors.New("use a port number between 1 and 65535")
+		}
+		*field(c) = n
+		return nil
+	}
+}
+
+func duration(field func(*Config) *time.Duration) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return errors.New("use a positive duration such as 72h or 30m")
+		}
+		*field(c) = d
+		return nil
+	}
+}
+
+func boolean(field func(*Config) *bool) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("use true or false")
+		}
+		*field(c) = b
+		return nil
+	}
+}
+
+func list(field func(*Config) *[]string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		*field(c) = splitList(value)
+		return nil
+	}
+}
+
+func clock(field func(*Config) *string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		if _, err := time.Parse("15:04", value); err != nil {
+			return errors.New("use HH:MM")
+		}
+		*field(c) = value
+		return nil
+	}
+}
+
+func webURL(field func(*Config) *string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("use an http or https URL")
+		}
+		*field(c) = value
+		return nil
+	}
+}
+
+func countryCode(c *Config, value string) error {
+	value = strings.TrimPrefix(value, "+")
+	if !isCountryCode(value) {
+		return errors.New("use 1 to 3 digits")
+	}
+	c.PhoneDefaultCountryCode = value
+	return nil
+}
+
+func timezone(c *Config, value string) error {
+	location, err := time.LoadLocation(value)
+	if err != nil {
+		return err
+	}
+	c.Timezone, c.Location = value, location
+	return nil
+}
+
+// isCountryCode indica si value es un código de país E.164: de 1 a 3 dígitos, sin cero inicial.
+func isCountryCode(value string) bool {
+	if len(value) < 1 || len(value) > 3 || value[0] == '0' {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// splitList separa una lista de valores separados por comas, descartando los vacíos.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,252 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// layer es una fuente de la configuración: sus valores por variable, en texto. Un valor
+// vacío equivale a no definirlo, como en la plantilla env.txt.
+type layer struct {
+	name   string
+	values map[string]string
+}
+
+// lookup devuelve el valor de la opción s en la capa y su origen. Los secretos también
+// se leen del archivo de KEY_FILE; definir KEY y KEY_FILE en la misma capa es un error.
+func (l layer) lookup(s *setting) (value, source string, found bool, err error) {
+	value, hasValue := l.values[s.key]
+	path, hasFile := "", false
+	if s.secret {
+		path, hasFile = l.values[s.key+"_FILE"]
+	}
+	switch {
+	case hasValue && hasFile:
+		return "", "", false, fmt.Errorf("%s and %s_FILE are both set in %s", s.key, s.key, l.name)
+	case hasFile:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", "", false, fmt.Errorf("could not read %s_FILE: %w", s.key, err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), fmt.Sprintf("%s (%s_FILE)", l.name, s.key), true, nil
+	}
+	return value, l.name, hasValue, nil
+}
+
+// flagValue es un flag de texto que recuerda si se pasó en la línea de comandos.
+type flagValue struct {
+	value string
+	set   bool
+}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *flagValue) Set(value string) error {
+	f.value, f.set = value, true
+	return nil
+}
+
+// Loader carga la configuración de sus fuentes. De menor a mayor prioridad: los valores
+// por defecto, el archivo YAML de -config o CONFIG_FILE, el archivo .env, las variables
+// de entorno y los flags. Los flags se registran con RegisterFlags antes de analizar la
+// línea de comandos.
+type Loader struct {
+	configFile flagValue
+	envFile    flagValue
+	flags      map[string]*flagValue
+	// getenv lee las variables de entorno; las pruebas lo reemplazan.
+	getenv func(string) string
+}
+
+// NewLoader crea un cargador que lee las variables de entorno del proceso.
+func NewLoader() *Loader {
+	return &Loader{flags: make(map[string]*flagValue), getenv: os.Getenv}
+}
+
+// RegisterFlags agrega a fs un flag por opción (-app-port para APP_PORT), además de
+// -config y -env-file. Los secretos solo se aceptan como archivo (-jwt-secret-file), para
+// que no queden en la lista de procesos ni en el historial de la shell.
+func (l *Loader) RegisterFlags(fs *flag.FlagSet) {
+	fs.Var(&l.configFile, "config", "YAML configuration file (`CONFIG_FILE`)")
+	fs.Var(&l.envFile, "env-file", "`file` with environment variables (default .env, skipped if missing)")
+	for i := range settings {
+		s := &settings[i]
+		key, usage := s.key, s.usage
+		if s.secret {
+			key, usage = key+"_FILE", "file with the "+usage
+		}
+		// PrintDefaults muestra lo que va entre comillas invertidas como nombre del valor.
+		usage = fmt.Sprintf("%s (`%s`", usage, key)
+		if s.def != "" {
+			usage += ", default " + s.def
+		}
+		value := &flagValue{}
+		l.flags[key] = value
+		fs.Var(value, strings.ToLower(strings.ReplaceAll(key, "_", "-")), usage+")")
+	}
+}
+
+// Load lee todas las fuentes, convierte y valida los valores, y devuelve todos los
+// errores juntos para corregirlos de una vez.
+func (l *Loader) Load() (*Config, error) {
+	dotenv, err := l.dotenvLayer()
+	if err != nil {
+		return nil, err
+	}
+	env := l.envLayer()
+	layers := []layer{dotenv, env, l.flagLayer()}
+
+	configFile := l.configFile.value
+	for _, source := range []layer{env, dotenv} {
+		if configFile == "" {
+			configFile = source.values["CONFIG_FILE"]
+		}
+	}
+	if configFile != "" {
+		file, err := fileLayer(configFile)
+		if err != nil {
+			return nil, err
+		}
+		layers = append([]layer{file}, layers...)
+	}
+
+	cfg := &Config{}
+	var errs []error
+	for i := range settings {
+		s := &settings[i]
+		value, source := s.def, "default"
+		for j := len(layers) - 1; j >= 0; j-- {
+			v, src, found, err := layers[j].lookup(s)
+			if err != nil {
+				errs = append(errs, err)
+				break
+			}
+			if found {
+				value, source = v, src
+				break
+			}
+		}
+		if value != "" {
+			if err := s.set(cfg, value); err != nil {
+				if s.secret {
+					errs = append(errs, fmt.Errorf("invalid %s: %v", s.key, err))
+				} else {
+					errs = append(errs, fmt.Errorf("invalid %s %q: %v", s.key, value, err))
+				}
+				continue
+			}
+		}
+		cfg.resolved = append(cfg.resolved, resolvedSetting{setting: s, value: value, source: source})
+	}
+	errs = append(errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
+}
+
+// dotenvLayer lee el archivo de -env-file, o .env si no se indicó. Sin el flag, que el
+// archivo no exista no es un error: en un contenedor todo llega por el entorno. Las
+// variables no se copian al entorno del proceso.
+func (l *Loader) dotenvLayer() (layer, error) {
+	path := ".env"
+	if l.envFile.set {
+		path = l.envFile.value
+	}
+	values, err := godotenv.Read(path)
+	if err != nil {
+		if !l.envFile.set && errors.Is(err, os.ErrNotExist) {
+			return layer{name: path}, nil
+		}
+		return layer{}, fmt.Errorf("could not read %s: %w", path, err)
+	}
+	return layer{name: path, values: nonEmpty(values)}, nil
+}
+
+func (l *Loader) envLayer() layer {
+	values := make(map[string]string)
+	keys := []string{"CONFIG_FILE"}
+	for _, s := range settings {
+		keys = append(keys, s.key)
+		if s.secret {
+			keys = append(keys, s.key+"_FILE")
+		}
+	}
+	for _, key := range keys {
+		values[key] = l.getenv(key)
+	}
+	return layer{name: "env", values: nonEmpty(values)}
+}
+
+func (l *Loader) flagLayer() layer {
+	values := make(map[string]string)
+	for key, value := range l.flags {
+		if value.set {
+			values[key] = value.value
+		}
+	}
+	return layer{name: "flag", values: nonEmpty(values)}
+}
+
+// fileLayer lee el archivo YAML de configuración: un mapa plano con las opciones en
+// minúsculas (app_port: 3001). Las listas se pueden escribir como secuencias. Una clave
+// desconocida es un error, para no ignorar una opción mal escrita.
+func fileLayer(path string) (layer, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return layer{}, fmt.Errorf("could not read the configuration file: %w", err)
+	}
+	var document map[string]any
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return layer{}, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	var errs []error
+	for name, raw := range document {
+		key := strings.ToUpper(name)
+		s, ok := findSetting(strings.TrimSuffix(key, "_FILE"))
+		if !ok || (key != s.key && !s.secret) {
+			errs = append(errs, fmt.Errorf("unknown setting %q in %s", name, path))
+			continue
+		}
+		switch v := raw.(type) {
+		case nil:
+		case []any:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[key] = strings.Join(items, ",")
+		case map[string]any:
+			errs = append(errs, fmt.Errorf("setting %q in %s must be a value, not a map", name, path))
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	if len(errs) > 0 {
+		return layer{}, errors.Join(errs...)
+	}
+	return layer{name: path, values: nonEmpty(values)}, nil
+}
+
+// nonEmpty descarta los valores vacíos.
+func nonEmpty(values map[string]string) map[string]string {
+	for key, value := range values {
+		if value == "" {
+			delete(values, key)
+		}
+	}
+	return values
+}
@@ -0,0 +1,205 @@
+package card
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Decodificador de fotos JPEG
+	"image/png"
+	"strings"
+	"unicode"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/skip2/go-qrcode"
+)
+
+// Medidas en milímetros. El carné tiene el tamaño estándar ID-1 (ISO/IEC 7810),
+// y la hoja A4 lleva dos columnas de cinco carnés, centradas para que la impresora
+// no recorte los bordes.
+const (
+	cardWidth   = 85.6
+	cardHeight  = 54.0
+	sheetWidth  = 210.0
+	sheetHeight = 297.0
+	sheetCols   = 2
+	sheetRows   = 5
+	sheetGap    = 3.0
+	// Márgenes de la hoja: 17,9 mm a los lados y 7,5 mm arriba y abajo.
+	sheetMarginX = (sheetWidth - sheetCols*cardWidth - (sheetCols-1)*sheetGap) / 2
+	sheetMarginY = (sheetHeight - sheetRows*cardHeight - (sheetRows-1)*sheetGap) / 2
+)
+
+// Límites de la foto del carné. La foto la sube el usuario: se rechaza antes de
+// decodificarla si ocupa o mide demasiado, para que una imagen pequeña que se expande
+// a un mapa de bits enorme no agote la memoria al generar los carnés.
+const (
+	maxPhotoBytes  = 2 << 20
+	maxPhotoPixels = 3000 * 3000
+)
+
+type pdfRenderer struct {
+	title string
+}
+
+// NewPDFRenderer crea un generador de carnés en PDF. El título se imprime en la cabecera de cada carné.
+func NewPDFRenderer(title string) ports.CardRenderer {
+	return &pdfRenderer{title: title}
+}
+
+func (r *pdfRenderer) RenderCard(card domain.IDCard) ([]byte, error) {
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{UnitStr: "mm", Size: gofpdf.SizeType{Wd: cardWidth, Ht: cardHeight}})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+	if err := r.drawCard(pdf, card, 0, 0); err != nil {
+		return nil, err
+	}
+	return output(pdf)
+}
+
+func (r *pdfRenderer) RenderSheet(cards []domain.IDCard) ([]byte, error) {
+	if len(cards) == 0 {
+		return nil, errors.New("no cards to render")
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	perPage := sheetCols * sheetRows
+	for i, card := range cards {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+		slot := i % perPage
+		x := sheetMarginX + float64(slot%sheetCols)*(cardWidth+sheetGap)
+		y := sheetMarginY + float64(slot/sheetCols)*(cardHeight+sheetGap)
+		if err := r.drawCard(pdf, card, x, y); err != nil {
+			return nil, err
+		}
+	}
+	return output(pdf)
+}
+
+// drawCard dibuja un carné con su esquina superior izquierda en (x, y).
+func (r *pdfRenderer) drawCard(pdf *gofpdf.Fpdf, card domain.IDCard, x, y float64) error {
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	// Borde y cabecera
+	pdf.SetDrawColor(120, 120, 120)
+	pdf.SetLineWidth(0.2)
+	pdf.RoundedRect(x, y, cardWidth, cardHeight, 3, "1234", "D")
+	pdf.SetFillColor(31, 78, 121)
+	pdf.RoundedRect(x, y, cardWidth, 9, 3, "12", "F")
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetXY(x, y+1.5)
+	pdf.CellFormat(cardWidth, 6, tr(r.title), "", 0, "C", false, 0, "")
+
+	// Foto, o las iniciales si no hay una foto utilizable
+	photoX, photoY, photoW, photoH := x+4, y+13, 20.0, 25.0
+	if name, ok := registerPhoto(pdf, card); ok {
+		pdf.ImageOptions(name, photoX, photoY, photoW, photoH, false, gofpdf.ImageOptions{}, 0, "")
+	} else {
+		pdf.SetFillColor(225, 229, 235)
+		pdf.Rect(photoX, photoY, photoW, photoH, "F")
+		pdf.SetTextColor(31, 78, 121)
+		pdf.SetFont("Helvetica", "B", 14)
+		pdf.SetXY(photoX, photoY+photoH/2-4)
+		pdf.CellFormat(photoW, 8, tr(initials(card.FullName)), "", 0, "C", false, 0, "")
+	}
+
+	// Datos de la persona
+	textX, textW := x+27, 29.0
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 8)
+	pdf.SetXY(textX, y+13)
+	lines := pdf.SplitLines([]byte(tr(card.FullName)), textW)
+	if len(lines) > 3 {
+		lines = lines[:3]
+	}
+	for _, line := range lines {
+		pdf.SetX(textX)
+		pdf.CellFormat(textW, 3.8, string(line), "", 2, "L", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "", 7)
+	if card.Document != "" {
+		pdf.SetXY(textX, y+30)
+		pdf.CellFormat(textW, 3.5, tr(card.Document), "", 2, "L", false, 0, "")
+	}
+	pdf.SetXY(textX, y+34)
+	pdf.CellFormat(textW, 3.5, fmt.Sprintf("ID %06d", card.PersonID), "", 2, "L", false, 0, "")
+
+	// QR con la credencial firmada
+	qr, err := qrcode.Encode(card.Token, qrcode.Medium, 256)
+	if err != nil {
+		return fmt.Errorf("failed to encode the QR code: %w", err)
+	}
+	qrName := fmt.Sprintf("qr-%d", card.PersonID)
+	pdf.RegisterImageOptionsReader(qrName, gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qr))
+	pdf.ImageOptions(qrName, x+cardWidth-27, y+12, 25, 25, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	pdf.SetTextColor(110, 110, 110)
+	pdf.SetFont("Helvetica", "", 5.5)
+	pdf.SetXY(x, y+cardHeight-7)
+	pdf.CellFormat(cardWidth, 4, tr("Presente este carné para registrar su asistencia"), "", 0, "C", false, 0, "")
+	return pdf.Error()
+}
+
+// registerPhoto registra la foto del carné si es una imagen PNG o JPEG en formato data URI.
+// Las fotos por URL no se descargan. La imagen se vuelve a codificar en PNG para que
+// cualquier variante (por ejemplo, PNG entrelazado) sea aceptada por el PDF. Las fotos
+// que superan maxPhotoBytes o maxPhotoPixels se omiten.
+func registerPhoto(pdf *gofpdf.Fpdf, card domain.IDCard) (string, bool) {
+	header, data, ok := strings.Cut(card.Photo, ",")
+	if !ok || !strings.HasPrefix(header, "data:image/") || !strings.HasSuffix(header, ";base64") {
+		return "", false
+	}
+	if len(data) > base64.StdEncoding.EncodedLen(maxPhotoBytes) {
+		return "", false
+	}
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", false
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil || config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPhotoPixels {
+		return "", false
+	}
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return "", false
+	}
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		return "", false
+	}
+
+	name := fmt.Sprintf("photo-%d", card.PersonID)
+	pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "PNG"}, &encoded)
+	return name, pdf.Ok()
+}
+
+// initials devuelve la inicial del primer y del último nombre.
+func initials(fullName string) string {
+	words := strings.Fields(fullName)
+	if len(words) == 0 {
+		return ""
+	}
+	result := []rune{unicode.ToUpper([]rune(words[0])[0])}
+	if len(words) > 1 {
+		result = append(result, unicode.ToUpper([]rune(words[len(words)-1])[0]))
+	}
+	return string(result)
+}
+
+func output(pdf *gofpdf.Fpdf) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,51 @@
+package card
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/riada2/internal/core/domain"
+)
+
+func dataURI(t *testing.T, width, height int) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestRegisterPhoto(t *testing.T) {
+	tests := []struct {
+		name  string
+		photo string
+		want  bool
+	}{
+		{name: "small photo", photo: dataURI(t, 40, 50), want: true},
+		// Una imagen en blanco de 4000 × 4000 comprime a unos pocos KB.
+		{name: "too many pixels", photo: dataURI(t, 4000, 4000), want: false},
+		{name: "too many bytes", photo: "data:image/png;base64," + strings.Repeat("A", base64.StdEncoding.EncodedLen(maxPhotoBytes)+4), want: false},
+		{name: "not an image", photo: "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("hola")), want: false},
+		{name: "url", photo: "https://example.com/ana.png", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdf := gofpdf.New("P", "mm", "A4", "")
+			if _, ok := registerPhoto(pdf, domain.IDCard{PersonID: 1, Photo: tt.photo}); ok != tt.want {
+				t.Fatalf("registerPhoto = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestSheetFitsA4(t *testing.T) {
+	if sheetMarginX < 5 || sheetMarginY < 5 {
+		t.Fatalf("margins %.1f × %.1f mm are too small to print", sheetMarginX, sheetMarginY)
+	}
+}
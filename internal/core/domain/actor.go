@@ -0,0 +1,17 @@
+package domain
+
+// Actor identifica al usuario autenticado que ejecuta una operación.
+// Los servicios lo reciben para registrar quién hizo cada cambio y para
+// aplicar las reglas de autorización. IP y RequestID provienen de la
+// petición HTTP y se usan en la auditoría.
+type Actor struct {
+	UserID    uint
+	Role      Role
+	IP        string
+	RequestID string
+}
+
+// IsAdmin indica si el actor tiene rol de administrador.
+func (a Actor) IsAdmin() bool {
+	return a.Role == AdminRole
+}
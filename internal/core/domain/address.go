@@ -1,13 +1,75 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Address representa una dirección asociada a una persona.
 // Corresponde a la tabla 'addresses'.
+//
+// Address es la dirección en texto libre. Los campos estructurados son opcionales
+// para no perder las direcciones registradas antes de que existieran; en Perú,
+// Ubigeo identifica el distrito y District, Province y Department guardan sus nombres.
+// Latitude y Longitude (grados WGS 84) se indican a mano o se obtienen al geocodificar.
+// SortOrder define el orden en que se muestran las direcciones de la persona.
 type Address struct {
-	ID        uint
-	PersonID  uint
-	Address   string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID         uint
+	PersonID   uint
+	Address    string
+	Street     string
+	Number     string `gorm:"type:varchar(20)"`
+	Reference  string
+	District   string
+	Province   string
+	Department string
+	Ubigeo     string `gorm:"type:varchar(6);index"`
+	Country    string `gorm:"type:varchar(2);default:PE"`
+	PostalCode string `gorm:"type:varchar(10)"`
+	Latitude   *float64
+	Longitude  *float64
+	SortOrder  int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// FormatLine arma la dirección en una línea a partir de los campos estructurados,
+// por ejemplo "Av. Brasil 1234, Jesús María, Lima, Lima".
+func (a *Address) FormatLine() string {
+	street := strings.TrimSpace(strings.TrimSpace(a.Street) + " " + strings.TrimSpace(a.Number))
+	var parts []string
+	for _, part := range []string{street, a.District, a.Province, a.Department} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if a.Country != "" && a.Country != PeruCountryCode {
+		parts = append(parts, a.Country)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Point devuelve las coordenadas de la dirección, o nil si no está geocodificada.
+func (a *Address) Point() *GeoPoint {
+	if a.Latitude == nil || a.Longitude == nil {
+		return nil
+	}
+	return &GeoPoint{Latitude: *a.Latitude, Longitude: *a.Longitude}
+}
+
+// AddressMigrationReport resume el resultado de estructurar las direcciones en texto libre.
+// Las ambiguas y las no reconocidas quedan solo con su texto.
+type AddressMigrationReport struct {
+	DryRun     bool
+	Scanned    int
+	Structured int
+	Ambiguous  int
+	Unmatched  int
+}
+
+// GeocodeReport resume el resultado de geocodificar las direcciones sin coordenadas.
+type GeocodeReport struct {
+	Scanned  int
+	Geocoded int
+	NotFound int
 }
@@ -0,0 +1,120 @@
+package domain
+
+import "time"
+
+// AuditOutcome indica si la acción auditada terminó correctamente.
+type AuditOutcome string
+
+const (
+	AuditSuccess AuditOutcome = "success"
+	AuditFailure AuditOutcome = "failure"
+)
+
+// Acciones registradas en la auditoría.
+const (
+	AuditUserLogin          = "user.login"
+	AuditUserRegister       = "user.register"
+	AuditUserUpdate         = "user.update"
+	AuditUserRoleChange     = "user.role_change"
+	AuditUserPasswordChange = "user.password_change"
+
+	AuditPersonCreate = "person.create"
+	AuditPersonUpdate = "person.update"
+	AuditPersonDelete = "person.delete"
+
+	AuditAddressCreate  = "address.create"
+	AuditAddressUpdate  = "address.update"
+	AuditAddressDelete  = "address.delete"
+	AuditAddressMigrate = "address.migrate"
+	AuditAddressGeocode = "address.geocode"
+	AuditAddressReorder = "address.reorder"
+
+	AuditPhoneCreate    = "phone.create"
+	AuditPhoneUpdate    = "phone.update"
+	AuditPhoneDelete    = "phone.delete"
+	AuditPhoneNormalize = "phone.normalize"
+	AuditPhoneReorder   = "phone.reorder"
+
+	AuditVersionRevert = "version.revert"
+
+	AuditRelationshipCreate = "relationship.create"
+	AuditRelationshipDelete = "relationship.delete"
+
+	AuditHouseholdCreate        = "household.create"
+	AuditHouseholdUpdate        = "household.update"
+	AuditHouseholdDelete        = "household.delete"
+	AuditHouseholdMemberAdd     = "household.member_add"
+	AuditHouseholdMemberRemove  = "household.member_remove"
+	AuditHouseholdContactAdd    = "household.contact_add"
+	AuditHouseholdContactRemove = "household.contact_remove"
+	AuditHouseholdMigrate       = "household.migrate"
+
+	AuditGroupCreate       = "group.create"
+	AuditGroupUpdate       = "group.update"
+	AuditGroupDelete       = "group.delete"
+	AuditGroupMemberAdd    = "group.member_add"
+	AuditGroupMemberRemove = "group.member_remove"
+
+	AuditPersonTags = "person.tags"
+
+	AuditCustomFieldCreate = "custom_field.create"
+	AuditCustomFieldUpdate = "custom_field.update"
+	AuditCustomFieldDelete = "custom_field.delete"
+
+	AuditEventCreate       = "event.create"
+	AuditEventUpdate       = "event.update"
+	AuditEventDelete       = "event.delete"
+	AuditAttendanceCheckIn = "attendance.check_in"
+	AuditAttendanceDelete  = "attendance.delete"
+
+	AuditCardPrint        = "card.print"
+	AuditCredentialRevoke = "credential.revoke"
+
+	AuditNoteCreate   = "note.create"
+	AuditNoteUpdate   = "note.update"
+	AuditNoteDelete   = "note.delete"
+	AuditNoteComplete = "note.complete"
+
+	AuditSettingsUpdate = "settings.update"
+
+	AuditDelegationGrant   = "delegation.grant"
+	AuditDelegationRequest = "delegation.request"
+	AuditDelegationAccept  = "delegation.accept"
+	AuditDelegationReject  = "delegation.reject"
+	AuditDelegationRevoke  = "delegation.revoke"
+)
+
+// Entidades auditadas que no tienen historial de versiones; el resto de
+// entidades reutiliza los valores de VersionEntity.
+const (
+	AuditUserEntity         = "user"
+	AuditRelationshipEntity = "relationship"
+	AuditHouseholdEntity    = "household"
+	AuditGroupEntity        = "group"
+	AuditCustomFieldEntity  = "custom_field"
+	AuditEventEntity        = "event"
+	AuditAttendanceEntity   = "attendance"
+	AuditNoteEntity         = "note"
+	AuditSettingEntity      = "setting"
+	AuditDelegationEntity   = "delegation"
+)
+
+// AuditEntry es un registro de auditoría de solo inserción.
+// Cada entrada guarda el hash de la anterior (PrevHash) y su propio hash,
+// formando una cadena que permite detectar modificaciones posteriores.
+type AuditEntry struct {
+	ID         uint
+	ActorID    *uint                  `gorm:"index"`
+	ActorRole  Role                   `gorm:"type:varchar(10)"`
+	Action     string                 `gorm:"type:varchar(50);index"`
+	EntityType string                 `gorm:"type:varchar(30);index:idx_audit_entity"`
+	EntityID   *uint                  `gorm:"index:idx_audit_entity"`
+	Changes    map[string]FieldChange `gorm:"serializer:json"`
+	RequestID  string                 `gorm:"type:varchar(64);index"`
+	IP         string                 `gorm:"type:varchar(64)"`
+	Outcome    AuditOutcome           `gorm:"type:varchar(10)"`
+	Error      string
+	PrevHash   string `gorm:"type:varchar(64);uniqueIndex"`
+	Hash       string `gorm:"type:varchar(64);uniqueIndex"`
+	CreatedAt  time.Time
+}
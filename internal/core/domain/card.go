@@ -0,0 +1,11 @@
+package domain
+
+// IDCard son los datos que se imprimen en el carné de una persona. Token es la
+// credencial firmada que se codifica en el QR.
+type IDCard struct {
+	PersonID uint
+	FullName string
+	Document string
+	Photo    string
+	Token    string
+}
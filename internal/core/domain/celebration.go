@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// CelebrationKind es el tipo de fecha que se celebra.
+type CelebrationKind string
+
+const (
+	BirthdayCelebration    CelebrationKind = "birthday"
+	AnniversaryCelebration CelebrationKind = "anniversary"
+)
+
+// IsValid indica si el tipo de celebración es uno de los soportados.
+func (k CelebrationKind) IsValid() bool {
+	return k == BirthdayCelebration || k == AnniversaryCelebration
+}
+
+// Celebration es un cumpleaños o un aniversario de bodas en una fecha concreta.
+// En los aniversarios, Spouse es el cónyuge de Person. Years es el número de años que se cumplen.
+type Celebration struct {
+	Kind   CelebrationKind
+	Date   time.Time
+	Years  int
+	Person Person
+	Spouse *Person
+}
+
+// AnniversaryIn devuelve el aniversario de date en el año indicado, a medianoche en loc.
+// Las fechas del 29 de febrero se celebran el 28 en los años no bisiestos.
+func AnniversaryIn(date time.Time, year int, loc *time.Location) time.Time {
+	day := date.Day()
+	if date.Month() == time.February && day == 29 && !isLeapYear(year) {
+		day = 28
+	}
+	return time.Date(year, date.Month(), day, 0, 0, 0, 0, loc)
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
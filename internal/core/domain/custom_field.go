@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+// CustomFieldType es el tipo de dato de un campo personalizado.
+type CustomFieldType string
+
+const (
+	TextField    CustomFieldType = "text"
+	NumberField  CustomFieldType = "number"
+	DateField    CustomFieldType = "date"
+	EnumField    CustomFieldType = "enum"
+	BooleanField CustomFieldType = "boolean"
+)
+
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case TextField, NumberField, DateField, EnumField, BooleanField:
+		return true
+	}
+	return false
+}
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// IsValidCustomFieldKey indica si la clave es válida: minúsculas, dígitos y guiones
+// bajos, empezando por una letra y con un máximo de 50 caracteres.
+func IsValidCustomFieldKey(key string) bool {
+	return customFieldKeyPattern.MatchString(key)
+}
+
+// CustomFieldDefinition describe un atributo adicional de las personas definido por
+// un administrador (fecha de bautismo, ocupación, contacto de emergencia...).
+//   - Options: valores permitidos de un campo enum.
+//   - Min/Max: rango de un número, o longitud de un texto.
+//   - Pattern: expresión regular que debe cumplir un texto.
+type CustomFieldDefinition struct {
+	ID        uint
+	Key       string `gorm:"type:varchar(50);uniqueIndex"`
+	Label     string
+	Type      CustomFieldType `gorm:"type:varchar(10)"`
+	Required  bool
+	Options   []string `gorm:"serializer:json"`
+	Min       *float64
+	Max       *float64
+	Pattern   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CustomFieldValues son los valores de los campos personalizados de una persona,
+// indexados por la clave del campo. Los valores se guardan como string (text, date
+// en formato "YYYY-MM-DD" y enum), float64 (number) o bool (boolean).
+type CustomFieldValues map[string]any
@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// DelegationStatus es el estado de una delegación.
+type DelegationStatus string
+
+const (
+	// DelegationPending es una solicitud que aún no acepta el dueño de la persona ni un administrador.
+	DelegationPending DelegationStatus = "pending"
+	// DelegationActive da al usuario la gestión de los datos de la persona.
+	DelegationActive DelegationStatus = "active"
+	// DelegationRejected es una solicitud rechazada.
+	DelegationRejected DelegationStatus = "rejected"
+	// DelegationRevoked es una delegación que dejó de estar vigente.
+	DelegationRevoked DelegationStatus = "revoked"
+)
+
+// IsValid indica si el estado es uno de los soportados.
+func (s DelegationStatus) IsValid() bool {
+	switch s {
+	case DelegationPending, DelegationActive, DelegationRejected, DelegationRevoked:
+		return true
+	}
+	return false
+}
+
+// Delegation da a un usuario (UserID) el derecho de gestionar el registro de una
+// persona que no es la suya, por ejemplo, un padre sobre el de su hijo. La concede
+// un administrador o nace como solicitud del usuario que acepta el dueño de la
+// persona o un administrador. Se guarda quién la pidió, quién la decidió y quién
+// la revocó.
+type Delegation struct {
+	ID          uint
+	UserID      uint             `gorm:"index:idx_delegations_user_person"`
+	PersonID    uint             `gorm:"index:idx_delegations_user_person;index"`
+	Person      *Person          `gorm:"foreignKey:PersonID"`
+	Status      DelegationStatus `gorm:"type:varchar(10);index"`
+	Reason      string
+	RequestedBy uint
+	DecidedBy   *uint
+	DecidedAt   *time.Time
+	RevokedBy   *uint
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsOpen indica si la delegación está pendiente o vigente.
+func (d *Delegation) IsOpen() bool {
+	return d.Status == DelegationPending || d.Status == DelegationActive
+}
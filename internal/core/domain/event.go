@@ -0,0 +1,123 @@
+package domain
+
+import "time"
+
+// Event es una reunión o actividad a la que asisten personas. Si tiene Recurrence
+// (una regla RRULE, ver ParseRecurrence), StartsAt es la primera ocurrencia de la serie.
+type Event struct {
+	ID          uint
+	Name        string
+	Description string
+	Place       string
+	StartsAt    time.Time
+	EndsAt      *time.Time
+	Recurrence  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Occurrences devuelve las fechas de inicio del evento que caen en [from, to).
+func (e *Event) Occurrences(from, to time.Time) ([]time.Time, error) {
+	if e.Recurrence == "" {
+		if e.StartsAt.Before(from) || !e.StartsAt.Before(to) {
+			return nil, nil
+		}
+		return []time.Time{e.StartsAt}, nil
+	}
+
+	rule, err := ParseRecurrence(e.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	return rule.Occurrences(e.StartsAt, from, to), nil
+}
+
+// OccursOn indica si el evento tiene una ocurrencia en el día indicado, según la
+// zona horaria de StartsAt.
+func (e *Event) OccursOn(day time.Time) (bool, error) {
+	loc := e.StartsAt.Location()
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	occurrences, err := e.Occurrences(from, from.AddDate(0, 0, 1))
+	return len(occurrences) > 0, err
+}
+
+// CheckInMethod indica cómo se identificó a la persona al registrar su asistencia.
+type CheckInMethod string
+
+const (
+	CheckInByPersonID CheckInMethod = "person_id"
+	CheckInByDocument CheckInMethod = "document"
+	CheckInByQR       CheckInMethod = "qr"
+)
+
+// Attendance registra la asistencia de una persona a una ocurrencia de un evento.
+// Date es el día de la ocurrencia (a medianoche UTC); una persona solo puede
+// registrarse una vez por ocurrencia.
+type Attendance struct {
+	ID          uint
+	EventID     uint          `gorm:"uniqueIndex:idx_attendances_occurrence"`
+	PersonID    uint          `gorm:"uniqueIndex:idx_attendances_occurrence;index"`
+	Date        time.Time     `gorm:"type:date;uniqueIndex:idx_attendances_occurrence;index"`
+	Method      CheckInMethod `gorm:"type:varchar(10)"`
+	CheckedInBy *uint
+	Event       Event
+	Person      Person
+	CreatedAt   time.Time
+}
+
+// AttendanceDate normaliza un día a la medianoche UTC, que es como se guarda en Attendance.Date.
+func AttendanceDate(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// PersonEventAttendance resume la asistencia de una persona a un evento.
+// Las rachas cuentan ocurrencias consecutivas a las que asistió; la actual
+// termina en la última ocurrencia ya pasada.
+type PersonEventAttendance struct {
+	Event         Event
+	Attended      int
+	Occurrences   int
+	CurrentStreak int
+	LongestStreak int
+	LastAttended  *time.Time
+}
+
+// PersonAttendanceReport es el reporte de asistencia de una persona en un periodo.
+type PersonAttendanceReport struct {
+	PersonID uint
+	From     time.Time
+	To       time.Time
+	Total    int
+	Events   []PersonEventAttendance
+	Records  []Attendance
+}
+
+// OccurrenceAttendance es la asistencia a una ocurrencia concreta de un evento.
+// FirstTimers son las personas cuya primera asistencia registrada fue esta.
+type OccurrenceAttendance struct {
+	Date        time.Time
+	Attendees   []Person
+	FirstTimers []Person
+}
+
+// EventAttendanceReport es el reporte de asistencia de un evento, por ocurrencia.
+type EventAttendanceReport struct {
+	Event       Event
+	Occurrences []OccurrenceAttendance
+}
+
+// PeriodAttendanceReport resume la asistencia a todos los eventos en un periodo.
+type PeriodAttendanceReport struct {
+	From              time.Time
+	To                time.Time
+	TotalCheckIns     int
+	UniquePersons     int
+	FirstTimeVisitors []Person
+	Events            []EventAttendanceSummary
+}
+
+// EventAttendanceSummary es el número de asistentes de cada ocurrencia de un evento.
+type EventAttendanceSummary struct {
+	Event  Event
+	Counts map[string]int // Fecha "YYYY-MM-DD" -> asistentes
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "math"
+
+// earthRadiusKm es el radio medio de la Tierra que usa la fórmula del haversine.
+const earthRadiusKm = 6371.0088
+
+// GeoPoint es un punto en grados WGS 84.
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// IsValid indica si las coordenadas están dentro de los rangos de latitud y longitud.
+func (p GeoPoint) IsValid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+// DistanceKm devuelve la distancia en kilómetros sobre la superficie de la Tierra
+// (fórmula del haversine).
+func (p GeoPoint) DistanceKm(other GeoPoint) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(other.Latitude - p.Latitude)
+	dLon := toRad(other.Longitude - p.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(p.Latitude))*math.Cos(toRad(other.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox devuelve los límites de latitud y longitud que contienen el círculo de
+// radio radiusKm alrededor del punto. Sirve para descartar direcciones lejanas antes
+// de calcular la distancia exacta.
+func (p GeoPoint) BoundingBox(radiusKm float64) (minLat, maxLat, minLon, maxLon float64) {
+	deltaLat := radiusKm / earthRadiusKm * 180 / math.Pi
+	minLat, maxLat = p.Latitude-deltaLat, p.Latitude+deltaLat
+	cosLat := math.Cos(p.Latitude * math.Pi / 180)
+	if minLat <= -90 || maxLat >= 90 || cosLat < 1e-6 {
+		// Cerca de los polos el círculo abarca todas las longitudes.
+		return math.Max(minLat, -90), math.Min(maxLat, 90), -180, 180
+	}
+	deltaLon := deltaLat / cosLat
+	minLon, maxLon = p.Longitude-deltaLon, p.Longitude+deltaLon
+	if minLon < -180 || maxLon > 180 {
+		// El círculo cruza el antimeridiano; no se filtra por longitud.
+		return minLat, maxLat, -180, 180
+	}
+	return minLat, maxLat, minLon, maxLon
+}
+
+// NearbyPerson es una persona cercana a un punto, con su dirección más próxima.
+type NearbyPerson struct {
+	Person     Person
+	Address    Address
+	DistanceKm float64
+}
@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// GroupType clasifica los grupos de la organización.
+type GroupType string
+
+const (
+	MinistryGroup  GroupType = "ministry"
+	ClassGroup     GroupType = "class"
+	CommitteeGroup GroupType = "committee"
+	OtherGroup     GroupType = "other"
+)
+
+func (t GroupType) IsValid() bool {
+	switch t {
+	case MinistryGroup, ClassGroup, CommitteeGroup, OtherGroup:
+		return true
+	}
+	return false
+}
+
+// GroupRole es el rol de una persona dentro de un grupo.
+type GroupRole string
+
+const (
+	GroupLeader GroupRole = "leader"
+	GroupMember GroupRole = "member"
+)
+
+func (r GroupRole) IsValid() bool {
+	return r == GroupLeader || r == GroupMember
+}
+
+// Group representa un ministerio, clase, comité u otra agrupación de personas.
+type Group struct {
+	ID          uint
+	Name        string    `gorm:"uniqueIndex"`
+	Type        GroupType `gorm:"type:varchar(20)"`
+	Description string
+	Members     []GroupMembership
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// GroupMembership es la participación de una persona en un grupo.
+// Una persona aparece una sola vez por grupo; EndDate marca el fin de su participación.
+type GroupMembership struct {
+	ID        uint
+	GroupID   uint      `gorm:"uniqueIndex:idx_group_memberships_pair"`
+	PersonID  uint      `gorm:"uniqueIndex:idx_group_memberships_pair;index"`
+	Role      GroupRole `gorm:"type:varchar(10)"`
+	StartDate *time.Time
+	EndDate   *time.Time
+	Person    Person
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IsActive indica si la participación está vigente en la fecha dada.
+func (m *GroupMembership) IsActive(at time.Time) bool {
+	if m.StartDate != nil && m.StartDate.After(at) {
+		return false
+	}
+	return m.EndDate == nil || !m.EndDate.Before(at)
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+// Household agrupa a las personas de una misma familia que comparten
+// direcciones y teléfonos. HeadPersonID, si está asignado, es siempre un miembro.
+type Household struct {
+	ID           uint
+	Name         string
+	HeadPersonID *uint
+	Members      []Person `gorm:"foreignKey:HouseholdID"`
+	Addresses    []HouseholdAddress
+	Phones       []HouseholdPhone
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// HouseholdAddress es una dirección compartida por todos los miembros del hogar, con
+// los mismos campos de ubicación que Address. Corresponde a la tabla 'household_addresses'.
+type HouseholdAddress struct {
+	ID          uint
+	HouseholdID uint `gorm:"index"`
+	Address     string
+	Street      string
+	Number      string `gorm:"type:varchar(20)"`
+	Reference   string
+	District    string
+	Province    string
+	Department  string
+	Ubigeo      string `gorm:"type:varchar(6)"`
+	Country     string `gorm:"type:varchar(2);default:PE"`
+	PostalCode  string `gorm:"type:varchar(10)"`
+	Latitude    *float64
+	Longitude   *float64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewHouseholdAddress copia la dirección de una persona como dirección del hogar.
+func NewHouseholdAddress(householdID uint, a *Address) HouseholdAddress {
+	return HouseholdAddress{
+		HouseholdID: householdID,
+		Address:     a.Address,
+		Street:      a.Street,
+		Number:      a.Number,
+		Reference:   a.Reference,
+		District:    a.District,
+		Province:    a.Province,
+		Department:  a.Department,
+		Ubigeo:      a.Ubigeo,
+		Country:     a.Country,
+		PostalCode:  a.PostalCode,
+		Latitude:    a.Latitude,
+		Longitude:   a.Longitude,
+	}
+}
+
+// HouseholdPhone es un teléfono compartido por todos los miembros del hogar.
+// Corresponde a la tabla 'household_phones'.
+type HouseholdPhone struct {
+	ID          uint
+	HouseholdID uint `gorm:"index"`
+	Phone       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// HouseholdMigrationReport resume el resultado de agrupar en hogares a las
+// personas que comparten la misma dirección.
+type HouseholdMigrationReport struct {
+	DryRun            bool
+	HouseholdsCreated int
+	PersonsGrouped    int
+	AddressesMerged   int
+}
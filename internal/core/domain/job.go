@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// JobState es el estado persistido de una tarea programada. Permite saber, tras un
+// reinicio, si la última ejecución prevista se perdió.
+type JobState struct {
+	Name          string `gorm:"primaryKey;type:varchar(100)"`
+	LastRunAt     *time.Time
+	LastSuccessAt *time.Time
+	LastError     string
+	NextRunAt     *time.Time
+	Runs          int
+	UpdatedAt     time.Time
+}
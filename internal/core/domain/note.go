@@ -0,0 +1,88 @@
+package domain
+
+import "time"
+
+// NoteVisibility define quién puede leer una nota de seguimiento.
+type NoteVisibility string
+
+const (
+	// PrivateNote solo la leen su autor y la persona asignada.
+	PrivateNote NoteVisibility = "private"
+	// RestrictedNote la leen además los usuarios con el rol RestrictedTo y los administradores.
+	RestrictedNote NoteVisibility = "restricted"
+	// PublicNote la lee cualquiera que tenga acceso a la persona, incluida ella misma.
+	PublicNote NoteVisibility = "public"
+)
+
+// IsValid indica si la visibilidad es una de las soportadas.
+func (v NoteVisibility) IsValid() bool {
+	return v == PrivateNote || v == RestrictedNote || v == PublicNote
+}
+
+// NoteType clasifica la interacción registrada en la nota.
+type NoteType string
+
+const (
+	ConversationNote NoteType = "conversation"
+	VisitNote        NoteType = "visit"
+	CallNote         NoteType = "call"
+	PrayerNote       NoteType = "prayer"
+	FollowUpNote     NoteType = "follow_up"
+	OtherNote        NoteType = "other"
+)
+
+// IsValid indica si el tipo de nota es uno de los soportados.
+func (t NoteType) IsValid() bool {
+	switch t {
+	case ConversationNote, VisitNote, CallNote, PrayerNote, FollowUpNote, OtherNote:
+		return true
+	}
+	return false
+}
+
+// Note es una nota pastoral o de seguimiento sobre una persona. Date es cuándo ocurrió
+// la interacción. Si tiene DueDate es una tarea pendiente para AssigneeID (un usuario)
+// hasta que se marca como completada.
+type Note struct {
+	ID           uint
+	PersonID     uint           `gorm:"index"`
+	AuthorID     uint           `gorm:"index"`
+	Type         NoteType       `gorm:"type:varchar(20)"`
+	Visibility   NoteVisibility `gorm:"type:varchar(20)"`
+	RestrictedTo Role           `gorm:"type:varchar(10)"`
+	Content      string         `gorm:"type:text"`
+	Date         time.Time
+	DueDate      *time.Time `gorm:"type:date"`
+	AssigneeID   *uint      `gorm:"index"`
+	CompletedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// CanRead indica si el actor puede leer la nota según su visibilidad. No comprueba
+// el acceso a la persona, que se valida aparte.
+func (n *Note) CanRead(actor Actor) bool {
+	if n.AuthorID == actor.UserID || (n.AssigneeID != nil && *n.AssigneeID == actor.UserID) {
+		return true
+	}
+	switch n.Visibility {
+	case PublicNote:
+		return true
+	case RestrictedNote:
+		return actor.IsAdmin() || actor.Role == n.RestrictedTo
+	}
+	return false
+}
+
+// IsOpen indica si la nota es una tarea pendiente.
+func (n *Note) IsOpen() bool {
+	return n.DueDate != nil && n.CompletedAt == nil
+}
+
+// TimelineEntry es un elemento de la línea de tiempo de una persona: una nota o un
+// cambio en sus datos (una versión de su historial). At es la fecha por la que se ordena.
+type TimelineEntry struct {
+	At      time.Time
+	Note    *Note
+	Version *EntityVersion
+}
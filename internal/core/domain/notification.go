@@ -0,0 +1,8 @@
+package domain
+
+// Notification es un mensaje para enviar por el canal de notificaciones configurado.
+type Notification struct {
+	Recipients []string
+	Subject    string
+	Body       string
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Sex define el tipo para el sexo de una persona.
 type Sex string
@@ -20,20 +23,35 @@ const (
 )
 
 // Person representa la entidad de una persona en el sistema.
+// Si pertenece a un hogar (HouseholdID), sus direcciones y teléfonos propios
+// tienen prioridad sobre los del hogar. El documento (TypeDoc y DocNumber) es
+// único; los registros sin número no cuentan.
 type Person struct {
-	ID         uint
-	UserID     *uint
-	Name       string
-	MiddleName string
-	LastName   string
-	Sex        Sex
-	Birthday   *time.Time
-	DocNumber  *string
-	TypeDoc    *DocType
-	Email      *string
-	Photo      *string
-	Addresses  []Address
-	Phones     []Phone
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID           uint
+	UserID       *uint
+	Name         string
+	MiddleName   string
+	LastName     string
+	Sex          Sex
+	Birthday     *time.Time
+	DocNumber    *string  `gorm:"uniqueIndex:idx_people_document,where:doc_number <> ''"`
+	TypeDoc      *DocType `gorm:"uniqueIndex:idx_people_document,where:doc_number <> ''"`
+	Email        *string
+	Photo        *string
+	HouseholdID  *uint `gorm:"index"`
+	Household    *Household
+	Tags         []Tag             `gorm:"many2many:person_tags"`
+	CustomFields CustomFieldValues `gorm:"type:jsonb;serializer:json"`
+	// CredentialVersion es la versión vigente de la credencial del carné. Solo la cambia
+	// PersonRepository.RevokeCredential; las credenciales de otra versión no valen.
+	CredentialVersion uint `gorm:"not null;default:0"`
+	Addresses         []Address
+	Phones            []Phone
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// FullName devuelve los nombres y apellidos de la persona, omitiendo los vacíos.
+func (p *Person) FullName() string {
+	return strings.Join(strings.Fields(p.Name+" "+p.MiddleName+" "+p.LastName), " ")
 }
@@ -2,12 +2,49 @@ package domain
 
 import "time"
 
+// PhoneType indica para qué se usa un teléfono.
+type PhoneType string
+
+const (
+	MobilePhone   PhoneType = "mobile"
+	HomePhone     PhoneType = "home"
+	WorkPhone     PhoneType = "work"
+	WhatsAppPhone PhoneType = "whatsapp"
+)
+
+// IsValid indica si el tipo de teléfono es uno de los soportados.
+func (t PhoneType) IsValid() bool {
+	return t == MobilePhone || t == HomePhone || t == WorkPhone || t == WhatsAppPhone
+}
+
+// IsMobile indica si el tipo exige un número de celular.
+func (t PhoneType) IsMobile() bool {
+	return t == MobilePhone || t == WhatsAppPhone
+}
+
 // Phone representa un teléfono asociado a una persona.
 // Corresponde a la tabla 'phones'.
+//
+// Phone se guarda en formato E.164 (por ejemplo "+51999888777") para que el mismo
+// número escrito de formas distintas sea siempre igual. Cada persona tiene como mucho
+// un teléfono principal (IsPrimary). SortOrder define el orden en que se muestran.
 type Phone struct {
 	ID        uint
 	PersonID  uint
 	Phone     string
+	Type      PhoneType `gorm:"type:varchar(20)"`
+	IsPrimary bool
+	SortOrder int
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// PhoneMigrationReport resume el resultado de normalizar los teléfonos guardados.
+// Los inválidos y los que duplican otro teléfono de la persona quedan sin cambios.
+type PhoneMigrationReport struct {
+	DryRun     bool
+	Scanned    int
+	Normalized int
+	Invalid    int
+	Duplicates int
+}
@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceFreq es la frecuencia de una regla de recurrencia.
+type RecurrenceFreq string
+
+const (
+	Daily   RecurrenceFreq = "DAILY"
+	Weekly  RecurrenceFreq = "WEEKLY"
+	Monthly RecurrenceFreq = "MONTHLY"
+)
+
+// maxOccurrences limita el número de fechas que se devuelven de una regla,
+// para que una regla sin fin no produzca listas ilimitadas.
+const maxOccurrences = 5000
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// Recurrence es un subconjunto de RRULE (RFC 5545): FREQ (DAILY, WEEKLY o MONTHLY),
+// INTERVAL, BYDAY (solo con WEEKLY), COUNT y UNTIL (fecha "YYYYMMDD", inclusive).
+// Por ejemplo: "FREQ=WEEKLY;BYDAY=SU" o "FREQ=MONTHLY;INTERVAL=2;COUNT=6".
+type Recurrence struct {
+	Freq     RecurrenceFreq
+	Interval int
+	ByDay    []time.Weekday
+	Count    int
+	Until    *time.Time
+}
+
+// ParseRecurrence interpreta una regla RRULE. El prefijo "RRULE:" es opcional.
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return nil, errors.New("empty recurrence rule")
+	}
+
+	r := &Recurrence{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid recurrence part %q", part)
+		}
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Freq = RecurrenceFreq(strings.ToUpper(value))
+			if r.Freq != Daily && r.Freq != Weekly && r.Freq != Monthly {
+				return nil, fmt.Errorf("unsupported FREQ %q, use DAILY, WEEKLY or MONTHLY", value)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			r.Interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := weekdayCodes[strings.ToUpper(code)]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY %q", code)
+				}
+				r.ByDay = append(r.ByDay, day)
+			}
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", value)
+			}
+			r.Count = count
+		case "UNTIL":
+			until, err := time.Parse("20060102", value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UNTIL %q, use YYYYMMDD", value)
+			}
+			r.Until = &until
+		default:
+			return nil, fmt.Errorf("unsupported recurrence part %q", name)
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, errors.New("FREQ is required")
+	}
+	if len(r.ByDay) > 0 && r.Freq != Weekly {
+		return nil, errors.New("BYDAY is only supported with FREQ=WEEKLY")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return nil, errors.New("COUNT and UNTIL cannot be used together")
+	}
+	sort.Slice(r.ByDay, func(i, j int) bool { return r.ByDay[i] < r.ByDay[j] })
+	return r, nil
+}
+
+// String devuelve la regla en su forma canónica.
+func (r *Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			codes[i] = strings.ToUpper(day.String()[:2])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Occurrences devuelve las fechas de la serie que empieza en start y caen en [from, to).
+// Todas las fechas conservan la hora y la zona horaria de start.
+func (r *Recurrence) Occurrences(start, from, to time.Time) []time.Time {
+	var until time.Time
+	if r.Until != nil {
+		// UNTIL es inclusive: se admite cualquier hora de ese día.
+		until = time.Date(r.Until.Year(), r.Until.Month(), r.Until.Day(), 0, 0, 0, 0, start.Location()).AddDate(0, 0, 1)
+	}
+
+	var result []time.Time
+	generated := 0
+	emit := func(t time.Time) bool {
+		if t.Before(start) {
+			return true
+		}
+		if (r.Until != nil && !t.Before(until)) || !t.Before(to) {
+			return false
+		}
+		generated++
+		if r.Count > 0 && generated > r.Count {
+			return false
+		}
+		if !t.Before(from) {
+			result = append(result, t)
+		}
+		return len(result) < maxOccurrences
+	}
+
+	// Sin COUNT no hace falta contar las fechas anteriores a from: se empieza cerca de from.
+	first := 0
+	if r.Count == 0 {
+		first = r.periodsBefore(start, from)
+	}
+
+	switch r.Freq {
+	case Daily:
+		for i := first; ; i++ {
+			if !emit(start.AddDate(0, 0, i*r.Interval)) {
+				return result
+			}
+		}
+
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		// Las semanas empiezan el domingo de la semana de start.
+		weekStart := start.AddDate(0, 0, -int(start.Weekday()))
+		for i := first; ; i++ {
+			week := weekStart.AddDate(0, 0, 7*i*r.Interval)
+			for _, day := range days {
+				if !emit(week.AddDate(0, 0, int(day))) {
+					return result
+				}
+			}
+		}
+
+	case Monthly:
+		for i := first; ; i++ {
+			t := start.AddDate(0, i*r.Interval, 0)
+			if t.Day() != start.Day() {
+				// El mes no tiene ese día (por ejemplo, el 31); se omite como indica RFC 5545.
+				if t.After(to) {
+					return result
+				}
+				continue
+			}
+			if !emit(t) {
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// periodsBefore devuelve cuántos periodos completos de la regla (de INTERVAL días, semanas
+// o meses) se pueden saltar desde start sin pasar de from. Se queda un periodo corto para
+// no saltarse ninguna fecha por los cambios de horario.
+func (r *Recurrence) periodsBefore(start, from time.Time) int {
+	if !from.After(start) {
+		return 0
+	}
+	from = from.In(start.Location())
+	days := int(time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC).
+		Sub(time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)).Hours() / 24)
+
+	var periods int
+	switch r.Freq {
+	case Daily:
+		periods = days / r.Interval
+	case Weekly:
+		periods = days / (7 * r.Interval)
+	case Monthly:
+		periods = ((from.Year()-start.Year())*12 + int(from.Month()) - int(start.Month())) / r.Interval
+	}
+	return max(periods-1, 0)
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+var lima = time.FixedZone("America/Lima", -5*60*60)
+
+// at devuelve el día indicado a las 10:00 en Lima.
+func at(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 10, 0, 0, 0, lima)
+}
+
+// midnight devuelve el inicio del día indicado en Lima.
+func midnight(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, lima)
+}
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		from  time.Time
+		to    time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "weekly by day",
+			rule:  "FREQ=WEEKLY;BYDAY=WE,SU",
+			start: at(2024, 1, 7),
+			from:  midnight(2024, 1, 1),
+			to:    midnight(2024, 1, 21),
+			want:  []time.Time{at(2024, 1, 7), at(2024, 1, 10), at(2024, 1, 14), at(2024, 1, 17)},
+		},
+		{
+			name:  "weekly by day skips days before start",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,FR",
+			start: at(2024, 1, 10),
+			from:  midnight(2024, 1, 1),
+			to:    midnight(2024, 1, 16),
+			want:  []time.Time{at(2024, 1, 12), at(2024, 1, 15)},
+		},
+		{
+			name:  "monthly on the 31st skips shorter months",
+			rule:  "FREQ=MONTHLY",
+			start: at(2024, 1, 31),
+			from:  midnight(2024, 1, 1),
+			to:    midnight(2024, 8, 1),
+			want:  []time.Time{at(2024, 1, 31), at(2024, 3, 31), at(2024, 5, 31), at(2024, 7, 31)},
+		},
+		{
+			name:  "count",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: at(2024, 1, 1),
+			from:  midnight(2024, 1, 1),
+			to:    midnight(2024, 2, 1),
+			want:  []time.Time{at(2024, 1, 1), at(2024, 1, 2), at(2024, 1, 3)},
+		},
+		{
+			name:  "count includes the dates before from",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: at(2024, 1, 1),
+			from:  midnight(2024, 1, 2),
+			to:    midnight(2024, 2, 1),
+			want:  []time.Time{at(2024, 1, 2), at(2024, 1, 3)},
+		},
+		{
+			name:  "until is inclusive",
+			rule:  "FREQ=DAILY;UNTIL=20240103",
+			start: at(2024, 1, 1),
+			from:  midnight(2024, 1, 1),
+			to:    midnight(2024, 2, 1),
+			want:  []time.Time{at(2024, 1, 1), at(2024, 1, 2), at(2024, 1, 3)},
+		},
+		{
+			name:  "weekly interval",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU",
+			start: at(2024, 1, 7),
+			from:  midnight(2024, 1, 1),
+			to:    midnight(2024, 2, 5),
+			want:  []time.Time{at(2024, 1, 7), at(2024, 1, 21), at(2024, 2, 4)},
+		},
+		{
+			name:  "monthly interval",
+			rule:  "FREQ=MONTHLY;INTERVAL=2",
+			start: at(2024, 1, 15),
+			from:  midnight(2024, 3, 1),
+			to:    midnight(2024, 8, 1),
+			want:  []time.Time{at(2024, 3, 15), at(2024, 5, 15), at(2024, 7, 15)},
+		},
+		{
+			name:  "daily event that started long ago",
+			rule:  "FREQ=DAILY",
+			start: at(2000, 1, 1),
+			from:  midnight(2024, 1, 1),
+			to:    midnight(2024, 1, 4),
+			want:  []time.Time{at(2024, 1, 1), at(2024, 1, 2), at(2024, 1, 3)},
+		},
+		{
+			name:  "daily interval that started long ago",
+			rule:  "FREQ=DAILY;INTERVAL=3",
+			start: at(2000, 1, 1),
+			from:  midnight(2024, 1, 1),
+			to:    midnight(2024, 1, 10),
+			want:  []time.Time{at(2024, 1, 1), at(2024, 1, 4), at(2024, 1, 7)},
+		},
+		{
+			name:  "weekly event that started long ago",
+			rule:  "FREQ=WEEKLY;BYDAY=SU",
+			start: at(1990, 1, 7),
+			from:  midnight(2024, 1, 1),
+			to:    midnight(2024, 1, 15),
+			want:  []time.Time{at(2024, 1, 7), at(2024, 1, 14)},
+		},
+		{
+			name:  "range before start",
+			rule:  "FREQ=DAILY",
+			start: at(2024, 1, 10),
+			from:  midnight(2024, 1, 1),
+			to:    midnight(2024, 1, 5),
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q): %v", tt.rule, err)
+			}
+			got := rule.Occurrences(tt.start, tt.from, tt.to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Fatalf("Occurrences = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestOccurrencesAreLimited(t *testing.T) {
+	rule, err := ParseRecurrence("FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := rule.Occurrences(at(2000, 1, 1), midnight(2024, 1, 1), midnight(2100, 1, 1))
+	if len(got) != maxOccurrences {
+		t.Fatalf("len(Occurrences) = %d, want %d", len(got), maxOccurrences)
+	}
+	if !got[0].Equal(at(2024, 1, 1)) {
+		t.Fatalf("first occurrence = %v, want %v", got[0], at(2024, 1, 1))
+	}
+}
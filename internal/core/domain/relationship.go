@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// RelationshipType define el tipo de vínculo entre dos personas.
+type RelationshipType string
+
+const (
+	SpouseRelationship   RelationshipType = "spouse"
+	ParentRelationship   RelationshipType = "parent"
+	ChildRelationship    RelationshipType = "child"
+	GuardianRelationship RelationshipType = "guardian"
+	WardRelationship     RelationshipType = "ward"
+	SiblingRelationship  RelationshipType = "sibling"
+)
+
+// IsValid indica si el tipo de relación es uno de los soportados.
+func (t RelationshipType) IsValid() bool {
+	switch t {
+	case SpouseRelationship, ParentRelationship, ChildRelationship, GuardianRelationship, WardRelationship, SiblingRelationship:
+		return true
+	}
+	return false
+}
+
+// Inverse devuelve el tipo de relación visto desde la otra persona.
+// Por ejemplo, si B es el padre (parent) de A, A es hijo (child) de B.
+func (t RelationshipType) Inverse() RelationshipType {
+	switch t {
+	case ParentRelationship:
+		return ChildRelationship
+	case ChildRelationship:
+		return ParentRelationship
+	case GuardianRelationship:
+		return WardRelationship
+	case WardRelationship:
+		return GuardianRelationship
+	}
+	return t // spouse y sibling son simétricas.
+}
+
+// Relationship es un vínculo dirigido entre dos personas: RelatedPersonID es
+// el <Type> de PersonID (p. ej. Type=parent: RelatedPersonID es padre o madre de PersonID).
+// Las relaciones recíprocas se guardan como dos filas, una en cada sentido.
+// Since es opcional y guarda, por ejemplo, la fecha de matrimonio.
+type Relationship struct {
+	ID              uint
+	PersonID        uint             `gorm:"uniqueIndex:idx_relationships_pair"`
+	RelatedPersonID uint             `gorm:"uniqueIndex:idx_relationships_pair;index"`
+	Type            RelationshipType `gorm:"type:varchar(20);uniqueIndex:idx_relationships_pair"`
+	Since           *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// HouseholdMember es una persona del entorno familiar inmediato de otra,
+// junto con su relación con ella.
+type HouseholdMember struct {
+	Person   Person
+	Relation RelationshipType
+}
+
+// FamilyTreeNode es un nodo del árbol genealógico. En el nodo raíz se completan
+// tanto Parents como Children; en los ancestros solo Parents y en los descendientes
+// solo Children.
+type FamilyTreeNode struct {
+	Person   Person
+	Spouse   *Person
+	Parents  []FamilyTreeNode
+	Children []FamilyTreeNode
+}
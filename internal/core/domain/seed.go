@@ -0,0 +1,14 @@
+package domain
+
+// SeedReport resume una carga de datos de demostración. Existing cuenta las personas
+// que ya estaban de una carga anterior con la misma semilla y no se tocaron.
+type SeedReport struct {
+	Seed     int64
+	Persons  int
+	Created  int
+	Existing int
+	Accounts int
+	Failed   int
+	// Errors son los primeros errores, con la persona que los causó.
+	Errors []string
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Claves de la configuración que los administradores editan en tiempo de ejecución.
+const (
+	// SettingMaxAddresses y SettingMaxPhones limitan los contactos propios de cada persona; 0 es sin límite.
+	SettingMaxAddresses = "contacts.max_addresses"
+	SettingMaxPhones    = "contacts.max_phones"
+	// SettingRequirePrimaryPhone exige que toda persona con teléfonos tenga uno principal.
+	SettingRequirePrimaryPhone = "contacts.require_primary_phone"
+	// SettingRequiredPersonFields es la lista, separada por comas, de campos obligatorios de la persona.
+	SettingRequiredPersonFields = "person.required_fields"
+)
+
+// Campos de la persona que se pueden declarar obligatorios.
+const (
+	PersonFieldMiddleName = "middleName"
+	PersonFieldLastName   = "lastName"
+	PersonFieldSex        = "sex"
+	PersonFieldBirthday   = "birthday"
+	PersonFieldDocument   = "document"
+	PersonFieldEmail      = "email"
+	PersonFieldPhoto      = "photo"
+	PersonFieldAddress    = "address"
+	PersonFieldPhone      = "phone"
+)
+
+// PersonFields son los campos que admite SettingRequiredPersonFields.
+var PersonFields = []string{
+	PersonFieldMiddleName, PersonFieldLastName, PersonFieldSex, PersonFieldBirthday, PersonFieldDocument,
+	PersonFieldEmail, PersonFieldPhoto, PersonFieldAddress, PersonFieldPhone,
+}
+
+// Setting es un valor de configuración guardado como texto.
+// Corresponde a la tabla 'settings'; las claves sin fila usan su valor por defecto.
+type Setting struct {
+	Key       string `gorm:"primaryKey;type:varchar(100)"`
+	Value     string
+	UpdatedBy *uint
+	UpdatedAt time.Time
+}
+
+// SettingInfo describe una clave de configuración con su valor vigente.
+// UpdatedAt es nil mientras la clave conserve su valor por defecto.
+type SettingInfo struct {
+	Key          string
+	Value        string
+	DefaultValue string
+	Description  string
+	UpdatedBy    *uint
+	UpdatedAt    *time.Time
+}
+
+// Settings es la configuración de la organización ya interpretada.
+type Settings struct {
+	MaxAddresses         int
+	MaxPhones            int
+	RequirePrimaryPhone  bool
+	RequiredPersonFields []string
+}
+
+// Requires indica si el campo de la persona es obligatorio.
+func (s *Settings) Requires(field string) bool {
+	return slices.Contains(s.RequiredPersonFields, field)
+}
+
+// AllowsMore indica si un límite de contactos admite uno más sobre count; 0 es sin límite.
+func AllowsMore(limit int, count int64) bool {
+	return limit <= 0 || count < int64(limit)
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "strings"
+
+// Tag es una etiqueta libre que se asigna a las personas.
+// Los nombres se guardan normalizados (ver NormalizeTagName).
+type Tag struct {
+	ID   uint
+	Name string `gorm:"type:varchar(50);uniqueIndex"`
+}
+
+// NormalizeTagName pasa el nombre a minúsculas y colapsa los espacios,
+// para que "Jóvenes " y "jóvenes" sean la misma etiqueta.
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
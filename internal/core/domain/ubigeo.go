@@ -0,0 +1,31 @@
+package domain
+
+import "strings"
+
+// PeruCountryCode es el código ISO 3166-1 del país por defecto de las direcciones.
+const PeruCountryCode = "PE"
+
+// Ubigeo es un distrito del catálogo de ubicaciones geográficas del INEI. El código
+// tiene 6 dígitos: los 2 primeros son el departamento y los 4 primeros la provincia.
+type Ubigeo struct {
+	Code       string
+	Department string
+	Province   string
+	District   string
+}
+
+// UbigeoArea es un departamento, provincia o distrito del catálogo, para listas en cascada.
+type UbigeoArea struct {
+	Code string
+	Name string
+}
+
+var placeKeyReplacer = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+)
+
+// PlaceKey normaliza el nombre de un lugar para compararlo: minúsculas, sin tildes
+// y con los espacios colapsados. "Jesús  María" y "jesus maria" tienen la misma clave.
+func PlaceKey(name string) string {
+	return strings.Join(strings.Fields(placeKeyReplacer.Replace(strings.ToLower(name))), " ")
+}
@@ -16,6 +16,11 @@ const (
 	UserRole  Role = "user"
 )
 
+// IsValid indica si el rol es uno de los soportados.
+func (r Role) IsValid() bool {
+	return r == AdminRole || r == UserRole
+}
+
 // Scan implementa la interfaz Scanner para el tipo Role.
 func (r *Role) Scan(value interface{}) error {
 	// El driver de la base de datos puede devolver un string o []byte.
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// VersionEntity identifica el tipo de entidad versionada.
+type VersionEntity string
+
+const (
+	PersonEntity  VersionEntity = "person"
+	AddressEntity VersionEntity = "address"
+	PhoneEntity   VersionEntity = "phone"
+)
+
+// VersionAction describe la operación que originó una versión.
+type VersionAction string
+
+const (
+	VersionCreated  VersionAction = "create"
+	VersionUpdated  VersionAction = "update"
+	VersionDeleted  VersionAction = "delete"
+	VersionReverted VersionAction = "revert"
+)
+
+// FieldChange guarda el valor anterior y el nuevo de un campo modificado.
+// Un valor nil indica que el campo estaba (o quedó) vacío.
+type FieldChange struct {
+	Old *string `json:"old"`
+	New *string `json:"new"`
+}
+
+// EntityVersion es un registro inmutable de un cambio sobre Person, Address o Phone.
+// PersonID siempre apunta a la persona raíz para poder reconstruir su historial completo.
+// Snapshot contiene el estado de la entidad después del cambio (nil en las eliminaciones).
+type EntityVersion struct {
+	ID         uint
+	EntityType VersionEntity `gorm:"type:varchar(20);index:idx_versions_entity"`
+	EntityID   uint          `gorm:"index:idx_versions_entity"`
+	PersonID   uint          `gorm:"index"`
+	Version    int
+	Action     VersionAction `gorm:"type:varchar(20)"`
+	ChangedBy  *uint
+	Changes    map[string]FieldChange `gorm:"serializer:json"`
+	Snapshot   map[string]*string     `gorm:"serializer:json"`
+	CreatedAt  time.Time
+}
@@ -7,4 +7,15 @@ type AddressRepository interface {
 	Delete(id uint) error
 	FindByID(id uint) (*domain.Address, error)
 	CountByPersonID(personID uint) (int64, error)
+	// FindByPersonID devuelve las direcciones propias de la persona según su orden.
+	FindByPersonID(personID uint) ([]domain.Address, error)
+	// Reorder asigna a cada dirección de ids su posición en la lista como orden.
+	Reorder(ids []uint) error
+	// FindUnstructured devuelve las direcciones en Perú que aún no tienen ubigeo.
+	FindUnstructured() ([]domain.Address, error)
+	// FindWithoutCoordinates devuelve las direcciones que aún no tienen latitud y longitud.
+	FindWithoutCoordinates() ([]domain.Address, error)
+	// FindNearby devuelve las personas con una dirección geocodificada cerca del punto,
+	// cada una con su dirección más cercana, de la más próxima a la más lejana.
+	FindNearby(query NearbyQuery) ([]domain.NearbyPerson, error)
 }
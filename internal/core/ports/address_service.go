@@ -1,8 +1,46 @@
 package ports
 
-import "github.com/riada2/internal/core/domain"
+import (
+	"errors"
 
+	"github.com/riada2/internal/core/domain"
+)
+
+var (
+	ErrAddressNotFound = errors.New("address not found")
+	ErrInvalidAddress  = errors.New("invalid address")
+	// ErrInvalidNearbyQuery indica un centro, radio o límite no válidos, o una persona sin dirección geocodificada.
+	ErrInvalidNearbyQuery = errors.New("invalid nearby search")
+)
+
+// NearbyQuery define una búsqueda de personas cercanas a un punto.
+type NearbyQuery struct {
+	Center domain.GeoPoint
+	// RadiusKm limita la distancia; 0 devuelve las Limit personas más cercanas sin importar la distancia.
+	RadiusKm float64
+	Limit    int
+	// ExcludePersonID omite a esa persona del resultado (por ejemplo, la del centro de la búsqueda).
+	ExcludePersonID uint
+}
+
+// Las operaciones sobre las direcciones de una persona solo las puede hacer su dueño o un administrador.
 type AddressService interface {
-	CreateOrUpdateAddress(address *domain.Address) (*domain.Address, error)
-	DeleteAddress(addressID uint) error
+	CreateOrUpdateAddress(address *domain.Address, actor domain.Actor) (*domain.Address, error)
+	DeleteAddress(addressID uint, actor domain.Actor) error
+	// ListAddresses devuelve las direcciones propias de la persona en su orden.
+	ListAddresses(personID uint, actor domain.Actor) ([]domain.Address, error)
+	// GetAddress devuelve una dirección de la persona; ErrAddressNotFound si pertenece a otra.
+	GetAddress(personID, addressID uint, actor domain.Actor) (*domain.Address, error)
+	// ReorderAddresses ordena las direcciones de la persona según ids, que debe incluirlas
+	// todas una sola vez, y las devuelve en el nuevo orden.
+	ReorderAddresses(personID uint, ids []uint, actor domain.Actor) ([]domain.Address, error)
+	// MigrateAddresses completa el ubigeo de las direcciones en texto libre cuyo distrito
+	// se reconoce sin ambigüedad; el texto original se conserva. Solo administradores.
+	MigrateAddresses(dryRun bool, actor domain.Actor) (*domain.AddressMigrationReport, error)
+	// GeocodeAddresses obtiene las coordenadas de las direcciones que no las tienen. Solo administradores.
+	GeocodeAddresses(actor domain.Actor) (*domain.GeocodeReport, error)
+	// FindNearby busca personas cercanas a un punto o, si centerPersonID no es nil, a la
+	// primera dirección geocodificada de esa persona, que se excluye del resultado.
+	// Solo administradores.
+	FindNearby(query NearbyQuery, centerPersonID *uint, actor domain.Actor) ([]domain.NearbyPerson, error)
 }
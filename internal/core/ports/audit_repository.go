@@ -0,0 +1,39 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// AuditFilter define los criterios de búsqueda sobre la auditoría.
+// Los campos vacíos no se aplican.
+type AuditFilter struct {
+	ActorID    *uint
+	Action     string
+	EntityType string
+	EntityID   *uint
+	Outcome    domain.AuditOutcome
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// AuditRepository es el puerto para la persistencia de la auditoría.
+// Es de solo inserción: no expone métodos para modificar ni borrar entradas.
+type AuditRepository interface {
+	// LockChain impide que otra transacción agregue entradas hasta que termine la actual,
+	// para que dos entradas no enlacen con la misma anterior. Solo tiene efecto dentro de
+	// una unidad de trabajo.
+	LockChain() error
+	Append(entry *domain.AuditEntry) error
+	// Last devuelve la última entrada de la cadena, o nil si está vacía.
+	Last() (*domain.AuditEntry, error)
+	// Search devuelve las entradas que cumplen el filtro, de la más reciente a la más antigua,
+	// junto con el total de coincidencias.
+	Search(filter AuditFilter) ([]domain.AuditEntry, int64, error)
+	// ListAfter devuelve hasta limit entradas con ID mayor a afterID, en orden ascendente.
+	ListAfter(afterID uint, limit int) ([]domain.AuditEntry, error)
+}
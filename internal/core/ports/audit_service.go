@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// ErrAuditUnavailable indica que no se pudo registrar una acción en la auditoría. Las
+// acciones que modifican datos se registran en la misma transacción, así que no se aplican.
+var ErrAuditUnavailable = errors.New("the action could not be recorded in the audit log")
+
+// AuditVerification es el resultado de recorrer la cadena de hashes.
+type AuditVerification struct {
+	Valid    bool   `json:"valid"`
+	Checked  int    `json:"checked"`
+	BrokenAt *uint  `json:"brokenAt,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	LastHash string `json:"lastHash,omitempty"`
+}
+
+// AuditService registra y consulta las acciones relevantes para la seguridad.
+type AuditService interface {
+	// Record agrega una entrada a la cadena en su propia transacción. Es para las acciones
+	// que fallaron o que no modifican datos. Si no la puede escribir devuelve ErrAuditUnavailable.
+	Record(entry domain.AuditEntry) error
+	// RecordIn agrega la entrada con los repositorios de una unidad de trabajo en curso:
+	// se confirma junto con los cambios de la acción, o no se confirma ninguno.
+	RecordIn(repos Repositories, entry domain.AuditEntry) error
+	Search(filter AuditFilter) ([]domain.AuditEntry, int64, error)
+	Verify() (*AuditVerification, error)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "github.com/riada2/internal/core/domain"
+
+// CardRenderer genera los carnés de identificación en un formato imprimible.
+type CardRenderer interface {
+	// RenderCard genera un documento con un solo carné, del tamaño del carné.
+	RenderCard(card domain.IDCard) ([]byte, error)
+	// RenderSheet genera hojas A4 con varios carnés cada una, listas para recortar.
+	RenderSheet(cards []domain.IDCard) ([]byte, error)
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var ErrInvalidCardBatch = errors.New("invalid card batch")
+
+// CardService genera los carnés de las personas y verifica sus credenciales.
+type CardService interface {
+	// GetCard genera el carné de una persona. Solo administradores.
+	GetCard(personID uint, actor domain.Actor) ([]byte, error)
+	// GetCards genera una hoja de carnés para las personas indicadas o para los miembros
+	// vigentes de un grupo. Solo administradores.
+	GetCards(personIDs []uint, groupID *uint, actor domain.Actor) ([]byte, error)
+	// VerifyCredential devuelve la persona de una credencial válida, o ErrInvalidCredential.
+	VerifyCredential(token string) (*domain.Person, error)
+	// RevokeCredential invalida las credenciales emitidas a la persona, por ejemplo, si
+	// perdió el carné. Los carnés que se impriman después llevan una credencial nueva.
+	// Solo administradores.
+	RevokeCredential(personID uint, actor domain.Actor) error
+}
@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var ErrInvalidCelebrationFilter = errors.New("invalid celebration filter")
+
+// CelebrationFilter define el periodo de la consulta de cumpleaños y aniversarios.
+type CelebrationFilter struct {
+	// Period es "week" (de lunes a domingo) o "month", contados desde hoy en la zona
+	// horaria de la organización. Se ignora si se indican From y To.
+	Period string
+	// From y To son días, ambos inclusive.
+	From *time.Time
+	To   *time.Time
+	// Kind limita el resultado a un tipo de celebración; vacío incluye todos.
+	Kind domain.CelebrationKind
+}
+
+// CelebrationService consulta los cumpleaños y aniversarios de bodas y envía sus recordatorios.
+type CelebrationService interface {
+	ListCelebrations(filter CelebrationFilter) ([]domain.Celebration, error)
+	// SendReminders notifica las celebraciones del día indicado a los destinatarios
+	// configurados. Devuelve cuántas celebraciones se notificaron.
+	SendReminders(ctx context.Context, day time.Time) (int, error)
+}
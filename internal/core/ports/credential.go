@@ -0,0 +1,15 @@
+package ports
+
+import "errors"
+
+var ErrInvalidCredential = errors.New("invalid or unknown credential")
+
+// CredentialSigner emite y verifica las credenciales firmadas de las personas,
+// las que se codifican en el QR para registrar la asistencia.
+type CredentialSigner interface {
+	// Issue emite una credencial de la persona con la versión vigente de su credencial.
+	Issue(personID, version uint) (string, error)
+	// Verify devuelve el ID de la persona y la versión de una credencial firmada y no
+	// vencida, o ErrInvalidCredential. Quien la usa comprueba que la versión siga vigente.
+	Verify(token string) (personID, version uint, err error)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import "github.com/riada2/internal/core/domain"
+
+// CustomFieldRepository es el puerto para la persistencia de las definiciones de campos personalizados.
+type CustomFieldRepository interface {
+	Save(definition *domain.CustomFieldDefinition) error
+	Delete(id uint) error
+	FindByID(id uint) (*domain.CustomFieldDefinition, error)
+	FindByKey(key string) (*domain.CustomFieldDefinition, error)
+	// List devuelve todas las definiciones ordenadas por clave.
+	List() ([]domain.CustomFieldDefinition, error)
+	// RemoveValues quita el valor del campo en todas las personas que lo tengan.
+	RemoveValues(key string) error
+	// ListValues devuelve el valor del campo de cada persona que lo tiene, por ID de persona.
+	ListValues(key string) (map[uint]any, error)
+	// CountMissing cuenta las personas que no tienen valor en el campo.
+	CountMissing(key string) (int64, error)
+}
@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var (
+	ErrCustomFieldNotFound = errors.New("custom field not found")
+	ErrCustomFieldExists   = errors.New("a custom field with that key already exists")
+	// ErrInvalidCustomField se usa tanto para definiciones inválidas como para
+	// valores de una persona que no cumplen su definición.
+	ErrInvalidCustomField = errors.New("invalid custom field")
+)
+
+// CustomFieldService gestiona las definiciones de campos personalizados de las personas.
+// Las operaciones de escritura están reservadas a administradores.
+type CustomFieldService interface {
+	CreateDefinition(definition *domain.CustomFieldDefinition, actor domain.Actor) (*domain.CustomFieldDefinition, error)
+	// UpdateDefinition actualiza la definición; la clave no se puede cambiar. Rechaza el
+	// cambio si algún valor ya guardado no cumpliría la nueva definición.
+	UpdateDefinition(definition *domain.CustomFieldDefinition, actor domain.Actor) (*domain.CustomFieldDefinition, error)
+	// DeleteDefinition elimina la definición y el valor del campo en todas las personas.
+	DeleteDefinition(id uint, actor domain.Actor) error
+	ListDefinitions() ([]domain.CustomFieldDefinition, error)
+}
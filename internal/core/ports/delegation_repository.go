@@ -0,0 +1,25 @@
+package ports
+
+import "github.com/riada2/internal/core/domain"
+
+// DelegationFilter define los criterios de búsqueda de delegaciones. Los campos vacíos no se aplican.
+type DelegationFilter struct {
+	UserID   *uint
+	PersonID *uint
+	// PersonIDs limita el resultado a las delegaciones sobre alguna de esas personas.
+	// Junto con UserID, basta con cumplir uno de los dos: las del usuario o las de sus personas.
+	PersonIDs []uint
+	Status    domain.DelegationStatus
+}
+
+// DelegationRepository es el puerto para la persistencia de las delegaciones.
+// También responde a la política de acceso si un usuario gestiona a una persona.
+type DelegationRepository interface {
+	DelegationChecker
+	Save(delegation *domain.Delegation) error
+	FindByID(id uint) (*domain.Delegation, error)
+	// FindOpen devuelve la delegación pendiente o vigente del usuario sobre la persona.
+	FindOpen(userID, personID uint) (*domain.Delegation, error)
+	// Search devuelve las delegaciones que cumplen el filtro, con su persona, de la más reciente a la más antigua.
+	Search(filter DelegationFilter) ([]domain.Delegation, error)
+}
@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var (
+	ErrDelegationNotFound = errors.New("delegation not found")
+	ErrDelegationExists   = errors.New("the user already has a pending or active delegation for this person")
+	ErrInvalidDelegation  = errors.New("invalid delegation")
+)
+
+// DelegationService gestiona quién puede administrar los registros de otras personas.
+// Las delegaciones que el actor no puede ver se tratan como inexistentes.
+type DelegationService interface {
+	// GrantDelegation concede al usuario la gestión de la persona sin solicitud previa. Solo administradores.
+	GrantDelegation(userID, personID uint, reason string, actor domain.Actor) (*domain.Delegation, error)
+	// RequestDelegation registra la solicitud del actor para gestionar a la persona.
+	RequestDelegation(personID uint, reason string, actor domain.Actor) (*domain.Delegation, error)
+	// AcceptDelegation activa una solicitud pendiente. Dueño de la persona o administrador.
+	AcceptDelegation(id uint, actor domain.Actor) (*domain.Delegation, error)
+	// RejectDelegation rechaza una solicitud pendiente. Dueño de la persona o administrador.
+	RejectDelegation(id uint, actor domain.Actor) (*domain.Delegation, error)
+	// RevokeDelegation retira una delegación pendiente o vigente. El delegado, el dueño
+	// de la persona o un administrador.
+	RevokeDelegation(id uint, actor domain.Actor) (*domain.Delegation, error)
+	// ListDelegations devuelve las delegaciones del actor y las que afectan a su propio registro.
+	ListDelegations(actor domain.Actor) ([]domain.Delegation, error)
+	// SearchDelegations busca entre todas las delegaciones. Solo administradores.
+	SearchDelegations(filter DelegationFilter, actor domain.Actor) ([]domain.Delegation, error)
+	// ListManagedPersons devuelve las personas que el actor gestiona por una delegación vigente.
+	ListManagedPersons(actor domain.Actor) ([]domain.Person, error)
+}
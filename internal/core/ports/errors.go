@@ -4,3 +4,40 @@ import "errors"
 
 // ... otros errores
 var ErrPersonDocumentExists = errors.New("Ya existe una persona con esa identificación")
+
+// ErrForbidden indica que el actor no tiene permisos sobre el recurso solicitado.
+var ErrForbidden = errors.New("authorization failed: you are not allowed to access this resource")
+
+// Errores que devuelven los repositorios, independientes de la base de datos. Cada
+// adaptador traduce los suyos para que los servicios no dependan del ORM.
+var (
+	// ErrNotFound indica que el registro buscado no existe.
+	ErrNotFound = errors.New("record not found")
+	// ErrConflict indica que el registro choca con otro ya guardado (clave única duplicada).
+	ErrConflict = errors.New("record conflicts with an existing one")
+	// ErrConstraintViolation indica que el registro incumple otra restricción de la base
+	// de datos: una referencia inexistente, un campo obligatorio vacío o una comprobación.
+	ErrConstraintViolation = errors.New("record violates a database constraint")
+)
+
+// ValidationError es un error de validación de un campo con un código estable, para que
+// los clientes puedan interpretarlo sin depender del mensaje. Envuelve el error general
+// (Err) para que errors.Is siga funcionando.
+type ValidationError struct {
+	Err     error
+	Field   string
+	Code    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error() + ": " + e.Message
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidOrderCode indica que la lista para reordenar no incluye exactamente una vez
+// cada elemento de la persona.
+const InvalidOrderCode = "order_invalid"
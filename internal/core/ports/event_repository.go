@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// EventRepository es el puerto para la persistencia de los eventos.
+type EventRepository interface {
+	Save(event *domain.Event) error
+	// Delete elimina el evento junto con sus registros de asistencia.
+	Delete(id uint) error
+	FindByID(id uint) (*domain.Event, error)
+	// List devuelve todos los eventos ordenados por fecha de inicio.
+	List() ([]domain.Event, error)
+}
+
+// AttendanceRepository es el puerto para la persistencia de las asistencias.
+// Los rangos de fechas son [from, to) sobre Attendance.Date.
+type AttendanceRepository interface {
+	Save(attendance *domain.Attendance) error
+	Delete(id uint) error
+	FindByID(id uint) (*domain.Attendance, error)
+	// Find busca la asistencia de la persona a una ocurrencia; devuelve gorm.ErrRecordNotFound si no existe.
+	Find(eventID, personID uint, date time.Time) (*domain.Attendance, error)
+	// ListByPerson devuelve las asistencias de la persona con su evento, por fecha.
+	ListByPerson(personID uint, from, to time.Time) ([]domain.Attendance, error)
+	// ListByEvent devuelve las asistencias al evento con los datos de cada persona, por fecha.
+	ListByEvent(eventID uint, from, to time.Time) ([]domain.Attendance, error)
+	// ListInPeriod devuelve todas las asistencias del periodo con su evento y su persona.
+	ListInPeriod(from, to time.Time) ([]domain.Attendance, error)
+	// FirstAttendances devuelve la primera asistencia registrada de cada persona, con sus
+	// datos, cuando cae en el periodo. Si ese día asistió a varios eventos, devuelve todas.
+	FirstAttendances(from, to time.Time) ([]domain.Attendance, error)
+}
@@ -0,0 +1,51 @@
+package ports
+
+import (
+	"errors"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var (
+	ErrEventNotFound      = errors.New("event not found")
+	ErrInvalidEvent       = errors.New("invalid event")
+	ErrAttendanceNotFound = errors.New("attendance not found")
+	ErrAlreadyCheckedIn   = errors.New("the person is already checked in to this occurrence")
+	ErrInvalidCheckIn     = errors.New("invalid check-in")
+)
+
+// EventService gestiona los eventos. Las operaciones de escritura están reservadas a administradores.
+type EventService interface {
+	CreateEvent(event *domain.Event, actor domain.Actor) (*domain.Event, error)
+	UpdateEvent(event *domain.Event, actor domain.Actor) (*domain.Event, error)
+	DeleteEvent(id uint, actor domain.Actor) error
+	GetEvent(id uint) (*domain.Event, error)
+	ListEvents() ([]domain.Event, error)
+	// GetOccurrences devuelve las fechas del evento en [from, to).
+	GetOccurrences(eventID uint, from, to time.Time) ([]time.Time, error)
+}
+
+// CheckIn identifica a la persona que asiste, por una sola de estas vías:
+// PersonID, TypeDoc con DocNumber, o QRToken. Si Date es nil se usa el día actual.
+type CheckIn struct {
+	PersonID  *uint
+	TypeDoc   *domain.DocType
+	DocNumber *string
+	QRToken   *string
+	Date      *time.Time
+}
+
+// AttendanceService registra las asistencias y genera sus reportes.
+type AttendanceService interface {
+	// CheckIn registra la asistencia a la ocurrencia del evento en la fecha indicada. Solo administradores.
+	CheckIn(eventID uint, checkIn CheckIn, actor domain.Actor) (*domain.Attendance, error)
+	RemoveAttendance(eventID, attendanceID uint, actor domain.Actor) error
+	// GetCredential devuelve la credencial firmada de la persona para su QR. Dueño o administrador.
+	GetCredential(personID uint, actor domain.Actor) (string, error)
+
+	// GetPersonReport incluye rachas por evento. Dueño o administrador.
+	GetPersonReport(personID uint, from, to time.Time, actor domain.Actor) (*domain.PersonAttendanceReport, error)
+	GetEventReport(eventID uint, from, to time.Time) (*domain.EventAttendanceReport, error)
+	GetPeriodReport(from, to time.Time) (*domain.PeriodAttendanceReport, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var ErrGeocodeNotFound = errors.New("address could not be geocoded")
+
+// Geocoder obtiene las coordenadas de una dirección.
+type Geocoder interface {
+	// Geocode devuelve ErrGeocodeNotFound si no reconoce la dirección.
+	Geocode(ctx context.Context, address *domain.Address) (*domain.GeoPoint, error)
+}
@@ -0,0 +1,21 @@
+package ports
+
+import "github.com/riada2/internal/core/domain"
+
+// GroupRepository es el puerto para la persistencia de los grupos y sus miembros.
+type GroupRepository interface {
+	// Save guarda solo los datos del grupo; los miembros se gestionan por separado.
+	Save(group *domain.Group) error
+	// Delete elimina el grupo junto con sus membresías.
+	Delete(id uint) error
+	// FindByID devuelve el grupo con sus miembros y los datos de cada persona.
+	FindByID(id uint) (*domain.Group, error)
+	FindByName(name string) (*domain.Group, error)
+	// List devuelve los grupos, filtrados por tipo si groupType no está vacío.
+	List(groupType domain.GroupType) ([]domain.Group, error)
+
+	SaveMembership(membership *domain.GroupMembership) error
+	FindMembership(groupID, personID uint) (*domain.GroupMembership, error)
+	// DeleteMemberships elimina las membresías de las personas indicadas y devuelve cuántas se eliminaron.
+	DeleteMemberships(groupID uint, personIDs []uint) (int64, error)
+}
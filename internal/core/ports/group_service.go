@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var (
+	ErrGroupNotFound = errors.New("group not found")
+	ErrGroupExists   = errors.New("a group with that name already exists")
+	ErrInvalidGroup  = errors.New("invalid group")
+)
+
+// GroupService gestiona los grupos (ministerios, clases, comités) y sus miembros.
+// Las operaciones de escritura están reservadas a administradores.
+type GroupService interface {
+	CreateGroup(group *domain.Group, actor domain.Actor) (*domain.Group, error)
+	UpdateGroup(group *domain.Group, actor domain.Actor) (*domain.Group, error)
+	DeleteGroup(id uint, actor domain.Actor) error
+	GetGroup(id uint) (*domain.Group, error)
+	ListGroups(groupType domain.GroupType) ([]domain.Group, error)
+
+	// AddMembers agrega las membresías indicadas. Si la persona ya es miembro, se
+	// actualizan su rol y sus fechas.
+	AddMembers(groupID uint, members []domain.GroupMembership, actor domain.Actor) (*domain.Group, error)
+	// RemoveMembers quita a las personas del grupo y devuelve cuántas se quitaron.
+	RemoveMembers(groupID uint, personIDs []uint, actor domain.Actor) (int, error)
+}
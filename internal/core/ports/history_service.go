@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var ErrVersionNotFound = errors.New("version not found")
+
+// HistoryService expone el historial de cambios de una persona y la reversión de versiones.
+type HistoryService interface {
+	GetPersonHistory(personID uint, actor domain.Actor) ([]domain.EntityVersion, error)
+	// RevertToVersion restaura la entidad al estado registrado en la versión indicada
+	// y devuelve la nueva versión generada por la reversión.
+	RevertToVersion(versionID uint, actor domain.Actor) (*domain.EntityVersion, error)
+}
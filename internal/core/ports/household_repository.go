@@ -0,0 +1,27 @@
+package ports
+
+import "github.com/riada2/internal/core/domain"
+
+// HouseholdRepository es el puerto para la persistencia de los hogares y sus contactos compartidos.
+type HouseholdRepository interface {
+	// Save guarda solo los datos del hogar; miembros y contactos se gestionan por separado.
+	Save(household *domain.Household) error
+	// Delete elimina el hogar y sus contactos, y desvincula a sus miembros.
+	Delete(id uint) error
+	// FindByID devuelve el hogar con sus miembros, direcciones y teléfonos.
+	FindByID(id uint) (*domain.Household, error)
+	// Search busca por nombre del hogar, nombre de sus miembros o dirección.
+	Search(searchTerm string) ([]domain.Household, error)
+	// SetHousehold asigna (o quita, si householdID es nil) el hogar de las personas indicadas.
+	SetHousehold(personIDs []uint, householdID *uint) error
+
+	SaveAddress(address *domain.HouseholdAddress) error
+	DeleteAddress(id uint) error
+	FindAddressByID(id uint) (*domain.HouseholdAddress, error)
+	SavePhone(phone *domain.HouseholdPhone) error
+	DeletePhone(id uint) error
+	FindPhoneByID(id uint) (*domain.HouseholdPhone, error)
+
+	// FindUngroupedAddresses devuelve las direcciones de las personas que aún no pertenecen a un hogar.
+	FindUngroupedAddresses() ([]domain.Address, error)
+}
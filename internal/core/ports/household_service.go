@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var (
+	ErrHouseholdNotFound        = errors.New("household not found")
+	ErrHouseholdContactNotFound = errors.New("household address or phone not found")
+	ErrInvalidHousehold         = errors.New("invalid household")
+)
+
+// HouseholdService gestiona los hogares: sus miembros y los contactos que comparten.
+// Las operaciones de escritura están reservadas a administradores.
+type HouseholdService interface {
+	CreateHousehold(household *domain.Household, actor domain.Actor) (*domain.Household, error)
+	// UpdateHousehold actualiza el nombre y el jefe de hogar, que debe ser miembro.
+	UpdateHousehold(household *domain.Household, actor domain.Actor) (*domain.Household, error)
+	DeleteHousehold(id uint, actor domain.Actor) error
+	GetHousehold(id uint) (*domain.Household, error)
+	SearchHouseholds(searchTerm string) ([]domain.Household, error)
+
+	// AddMembers agrega personas al hogar. Una persona solo puede pertenecer a un hogar.
+	AddMembers(householdID uint, personIDs []uint, actor domain.Actor) (*domain.Household, error)
+	RemoveMember(householdID, personID uint, actor domain.Actor) error
+
+	AddAddress(householdID uint, address string, actor domain.Actor) (*domain.HouseholdAddress, error)
+	RemoveAddress(householdID, addressID uint, actor domain.Actor) error
+	AddPhone(householdID uint, phone string, actor domain.Actor) (*domain.HouseholdPhone, error)
+	RemovePhone(householdID, phoneID uint, actor domain.Actor) error
+
+	// MigrateSharedAddresses agrupa en un hogar a las personas sin hogar que tienen
+	// la misma dirección, y reemplaza sus copias por una única dirección del hogar.
+	// Con dryRun solo se calcula el reporte.
+	MigrateSharedAddresses(dryRun bool, actor domain.Actor) (*domain.HouseholdMigrationReport, error)
+}
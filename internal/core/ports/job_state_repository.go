@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// JobStateRepository es el puerto para la persistencia del estado de las tareas programadas.
+type JobStateRepository interface {
+	// Find devuelve el estado de la tarea; devuelve gorm.ErrRecordNotFound si nunca se ejecutó.
+	Find(name string) (*domain.JobState, error)
+	Save(state *domain.JobState) error
+	List() ([]domain.JobState, error)
+	// Claim reserva la ejecución de la tarea prevista para scheduledAt: si la próxima
+	// ejecución guardada no es posterior a scheduledAt, la mueve a next y devuelve true.
+	// Devuelve false si otra instancia ya la reservó. Crea el estado si no existe.
+	Claim(name string, scheduledAt, next time.Time) (bool, error)
+}
@@ -0,0 +1,15 @@
+package ports
+
+import "github.com/riada2/internal/core/domain"
+
+// NoteRepository es el puerto para la persistencia de las notas de seguimiento.
+type NoteRepository interface {
+	Save(note *domain.Note) error
+	Delete(id uint) error
+	FindByID(id uint) (*domain.Note, error)
+	// FindByPerson devuelve todas las notas de la persona, de la más reciente a la más antigua.
+	FindByPerson(personID uint) ([]domain.Note, error)
+	// FindAssigned devuelve las tareas asignadas al usuario por fecha de vencimiento;
+	// si openOnly es true, solo las no completadas.
+	FindAssigned(userID uint, openOnly bool) ([]domain.Note, error)
+}
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var (
+	ErrNoteNotFound = errors.New("note not found")
+	ErrInvalidNote  = errors.New("invalid note")
+)
+
+// NoteService gestiona las notas de seguimiento de las personas. Las notas que el actor
+// no puede leer se tratan como inexistentes.
+type NoteService interface {
+	// CreateNote registra una nota con el actor como autor. Solo administradores.
+	CreateNote(note *domain.Note, actor domain.Actor) (*domain.Note, error)
+	// UpdateNote modifica una nota. Solo su autor.
+	UpdateNote(note *domain.Note, actor domain.Actor) (*domain.Note, error)
+	// DeleteNote elimina una nota. Su autor, o un administrador que pueda leerla.
+	DeleteNote(id uint, actor domain.Actor) error
+	// CompleteNote marca una tarea como completada. Su autor o la persona asignada.
+	CompleteNote(id uint, actor domain.Actor) (*domain.Note, error)
+	// ListNotes devuelve las notas de la persona que el actor puede leer. Dueño o administrador.
+	ListNotes(personID uint, actor domain.Actor) ([]domain.Note, error)
+	// ListAssignedNotes devuelve las tareas asignadas al actor.
+	ListAssignedNotes(actor domain.Actor, openOnly bool) ([]domain.Note, error)
+	// GetTimeline combina las notas legibles y el historial de cambios de la persona,
+	// de lo más reciente a lo más antiguo. Dueño o administrador.
+	GetTimeline(personID uint, actor domain.Actor) ([]domain.TimelineEntry, error)
+}
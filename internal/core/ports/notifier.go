@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// Notifier envía notificaciones por un canal (correo, webhook, log...).
+type Notifier interface {
+	Notify(ctx context.Context, notification domain.Notification) error
+}
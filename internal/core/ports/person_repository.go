@@ -2,6 +2,27 @@ package ports
 
 import "github.com/riada2/internal/core/domain"
 
+// PersonFilter define los criterios de búsqueda de personas. Los campos vacíos no se aplican.
+type PersonFilter struct {
+	// Term busca en el nombre completo o en el número de documento.
+	Term string
+	// Phone son los dígitos con los que Term se compara también con los teléfonos
+	// de la persona. Lo completa el servicio cuando el término parece un teléfono.
+	Phone string
+	// GroupID limita el resultado a los miembros vigentes del grupo.
+	GroupID *uint
+	// Tags limita el resultado a las personas que tienen todas las etiquetas.
+	Tags []string
+	// CustomFields limita el resultado a las personas cuyo campo personalizado
+	// tiene exactamente ese valor (comparado como texto).
+	CustomFields map[string]string
+	// Ubigeo limita el resultado a las personas con una dirección en ese departamento
+	// (2 dígitos), provincia (4 dígitos) o distrito (6 dígitos).
+	Ubigeo string
+	// Limit es el máximo de resultados; 0 significa sin límite.
+	Limit int
+}
+
 // PersonRepository defines the methods that any
 // data storage provider needs to implement to get and store persons.
 type PersonRepository interface {
@@ -9,6 +30,11 @@ type PersonRepository interface {
 	Save(person *domain.Person) error
 	Delete(id uint) error
 	FindByID(id uint) (*domain.Person, error)
-	Search(searchTerm string) ([]domain.Person, error)
+	Search(filter PersonFilter) ([]domain.Person, error)
 	FindByDocument(docType domain.DocType, docNumber string) (*domain.Person, error)
+	// ListWithBirthday devuelve, sin sus contactos, las personas con fecha de nacimiento.
+	ListWithBirthday() ([]domain.Person, error)
+	// RevokeCredential incrementa la versión de la credencial de la persona, lo que
+	// invalida todas las credenciales emitidas hasta ahora. Save nunca la cambia.
+	RevokeCredential(id uint) error
 }
@@ -9,9 +9,15 @@ import (
 var ErrPersonNotFound = errors.New("person not found")
 
 type PersonService interface {
-	CreateOrUpdatePersonForUser(person *domain.Person) (*domain.Person, error)
-	CreatePerson(person *domain.Person) (*domain.Person, error)
-	DeletePerson(id uint) error
-	GetPersonByID(id uint) (*domain.Person, error)
-	SearchPersons(searchTerm string) ([]domain.Person, error)
+	CreateOrUpdatePersonForUser(person *domain.Person, actor domain.Actor) (*domain.Person, error)
+	CreatePerson(person *domain.Person, actor domain.Actor) (*domain.Person, error)
+	DeletePerson(id uint, actor domain.Actor) error
+	// GetPerson devuelve la persona si el actor puede leerla: dueño, delegado o administrador.
+	GetPerson(id uint, actor domain.Actor) (*domain.Person, error)
+	// UpdatePerson actualiza los datos de una persona existente. Dueño, delegado o administrador.
+	UpdatePerson(person *domain.Person, actor domain.Actor) (*domain.Person, error)
+	// SearchPersons devuelve como máximo 300 resultados si el filtro no indica un límite.
+	SearchPersons(filter PersonFilter) ([]domain.Person, error)
+	// ExportPersons devuelve todas las personas que cumplen el filtro, sin límite.
+	ExportPersons(filter PersonFilter) ([]domain.Person, error)
 }
@@ -10,4 +10,10 @@ type PhoneRepository interface {
 	Delete(id uint) error
 	// CountByPersonID counts the number of phones associated with a person.
 	CountByPersonID(personID uint) (int64, error)
+	// FindByPersonID returns the phones of a person by sort order, oldest first on ties.
+	FindByPersonID(personID uint) ([]domain.Phone, error)
+	// Reorder sets the sort order of each phone in ids to its position in the list.
+	Reorder(ids []uint) error
+	// FindAll returns every phone ordered by person and age.
+	FindAll() ([]domain.Phone, error)
 }
@@ -1,8 +1,40 @@
 package ports
 
-import "github.com/riada2/internal/core/domain"
+import (
+	"errors"
 
+	"github.com/riada2/internal/core/domain"
+)
+
+var (
+	ErrPhoneNotFound = errors.New("phone not found")
+	ErrInvalidPhone  = errors.New("invalid phone")
+	// ErrPhoneExists indica que la persona ya tiene ese número, escrito en cualquier formato.
+	ErrPhoneExists = errors.New("phone already exists")
+)
+
+// Códigos de ValidationError para los teléfonos.
+const (
+	PhoneRequiredCode          = "phone_required"
+	PhoneInvalidCharactersCode = "phone_invalid_characters"
+	PhoneInvalidLengthCode     = "phone_invalid_length"
+	PhoneNotMobileCode         = "phone_not_mobile"
+	PhoneInvalidTypeCode       = "phone_invalid_type"
+	PhoneDuplicateCode         = "phone_duplicate"
+)
+
+// Las operaciones sobre los teléfonos de una persona solo las puede hacer su dueño o un administrador.
 type PhoneService interface {
-	CreateOrUpdatePhone(phone *domain.Phone) (*domain.Phone, error)
-	DeletePhoneForUser(phoneID uint, userID uint) error
+	CreateOrUpdatePhone(phone *domain.Phone, actor domain.Actor) (*domain.Phone, error)
+	DeletePhoneForUser(phoneID uint, actor domain.Actor) error
+	// ListPhones devuelve los teléfonos propios de la persona en su orden.
+	ListPhones(personID uint, actor domain.Actor) ([]domain.Phone, error)
+	// GetPhone devuelve un teléfono de la persona; ErrPhoneNotFound si pertenece a otra.
+	GetPhone(personID, phoneID uint, actor domain.Actor) (*domain.Phone, error)
+	// ReorderPhones ordena los teléfonos de la persona según ids, que debe incluirlos
+	// todos una sola vez, y los devuelve en el nuevo orden.
+	ReorderPhones(personID uint, ids []uint, actor domain.Actor) ([]domain.Phone, error)
+	// NormalizePhones convierte a E.164 los teléfonos guardados antes de la normalización
+	// y asigna el tipo y el teléfono principal que falten. Solo administradores.
+	NormalizePhones(dryRun bool, actor domain.Actor) (*domain.PhoneMigrationReport, error)
 }
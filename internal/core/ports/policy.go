@@ -0,0 +1,42 @@
+package ports
+
+import "github.com/riada2/internal/core/domain"
+
+// PolicyAction es la operación que el actor quiere realizar sobre un recurso.
+type PolicyAction string
+
+const (
+	ActionRead   PolicyAction = "read"
+	ActionCreate PolicyAction = "create"
+	ActionUpdate PolicyAction = "update"
+	ActionDelete PolicyAction = "delete"
+)
+
+// Recursos de la persona que no se versionan, pero cuyo acceso también decide la política.
+const (
+	RelationshipResource domain.VersionEntity = "relationship"
+	AttendanceResource   domain.VersionEntity = "attendance"
+	CredentialResource   domain.VersionEntity = "credential"
+	NoteResource         domain.VersionEntity = "note"
+	DelegationResource   domain.VersionEntity = "delegation"
+)
+
+// PolicyResource identifica el recurso sobre el que se decide: su tipo y la persona
+// a la que pertenece. Para direcciones y teléfonos, Person es la persona del contacto;
+// al crear una persona, es el registro que se va a guardar.
+type PolicyResource struct {
+	Type   domain.VersionEntity
+	Person *domain.Person
+}
+
+// DelegationChecker indica si un usuario recibió la gestión de los datos de una persona.
+type DelegationChecker interface {
+	HasDelegation(userID, personID uint) (bool, error)
+}
+
+// Policy decide si un actor puede realizar una acción sobre los datos de una persona.
+// Es el único lugar donde viven las reglas de dueño, administrador y acceso delegado.
+type Policy interface {
+	// Authorize devuelve nil si la acción está permitida y ErrForbidden si no.
+	Authorize(actor domain.Actor, action PolicyAction, resource PolicyResource) error
+}
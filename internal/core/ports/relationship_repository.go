@@ -0,0 +1,16 @@
+package ports
+
+import "github.com/riada2/internal/core/domain"
+
+// RelationshipRepository es el puerto para la persistencia de las relaciones entre personas.
+type RelationshipRepository interface {
+	Save(relationship *domain.Relationship) error
+	Delete(id uint) error
+	FindByID(id uint) (*domain.Relationship, error)
+	// FindInvolving devuelve las relaciones en las que participa la persona, en cualquier sentido.
+	FindInvolving(personID uint) ([]domain.Relationship, error)
+	// Find busca una relación concreta; devuelve gorm.ErrRecordNotFound si no existe.
+	Find(personID, relatedPersonID uint, relType domain.RelationshipType) (*domain.Relationship, error)
+	// ListWithSince devuelve las relaciones del tipo que tienen fecha de inicio, en ambos sentidos.
+	ListWithSince(relType domain.RelationshipType) ([]domain.Relationship, error)
+}
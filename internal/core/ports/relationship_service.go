@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var (
+	ErrRelationshipNotFound = errors.New("relationship not found")
+	ErrRelationshipExists   = errors.New("the relationship already exists")
+	ErrInvalidRelationship  = errors.New("invalid relationship")
+)
+
+// RelationshipService gestiona el grafo familiar entre personas.
+type RelationshipService interface {
+	// AddRelationship crea la relación y, si reciprocal es true, también la inversa. El actor
+	// debe poder gestionar las relaciones de las dos personas.
+	AddRelationship(relationship *domain.Relationship, reciprocal bool, actor domain.Actor) (*domain.Relationship, error)
+	// RemoveRelationship elimina la relación de la persona y su inversa, si existe.
+	RemoveRelationship(personID, relationshipID uint, actor domain.Actor) error
+	// GetRelationships devuelve las relaciones en las que participa la persona, en cualquier sentido.
+	GetRelationships(personID uint, actor domain.Actor) ([]domain.Relationship, error)
+	GetHousehold(personID uint, actor domain.Actor) ([]domain.HouseholdMember, error)
+	GetFamilyTree(personID uint, depth int, actor domain.Actor) (*domain.FamilyTreeNode, error)
+}
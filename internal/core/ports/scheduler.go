@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var ErrJobNotFound = errors.New("job not found")
+
+// JobScheduler expone las tareas programadas para su consulta y ejecución manual.
+type JobScheduler interface {
+	// Jobs devuelve el estado de cada tarea registrada.
+	Jobs() ([]domain.JobState, error)
+	// RunNow ejecuta la tarea inmediatamente, como si fuera su hora prevista.
+	RunNow(ctx context.Context, name string) error
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var ErrInvalidSeedOptions = errors.New("invalid seed options")
+
+// MaxSeedPersons es el máximo de personas de una carga de demostración.
+const MaxSeedPersons = 10000
+
+// SeedOptions describe los datos de demostración que se generan. La misma semilla
+// genera siempre las mismas personas, así que repetir una carga no duplica nada y
+// aumentar Persons solo agrega las que faltan.
+type SeedOptions struct {
+	Seed    int64
+	Persons int
+	// Accounts es cuántas de las primeras personas tienen una cuenta de usuario
+	// vinculada, con el rol 'user' y la contraseña Password.
+	Accounts int
+	Password string
+}
+
+// SeedService carga personas de demostración con datos peruanos verosímiles.
+type SeedService interface {
+	// Seed genera y guarda las personas de options. Solo administradores.
+	Seed(options SeedOptions, actor domain.Actor) (*domain.SeedReport, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "github.com/riada2/internal/core/domain"
+
+// SettingRepository es el puerto para la persistencia de la configuración editable.
+type SettingRepository interface {
+	List() ([]domain.Setting, error)
+	// SaveAll guarda los valores en una sola transacción.
+	SaveAll(settings []domain.Setting) error
+}
@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var (
+	ErrInvalidSetting = errors.New("invalid setting")
+	// ErrContactLimitReached indica que la persona ya tiene el máximo de direcciones o teléfonos.
+	ErrContactLimitReached = errors.New("contact limit reached")
+	// ErrInvalidPerson indica que falta un campo que la configuración declara obligatorio.
+	ErrInvalidPerson = errors.New("invalid person")
+)
+
+// Códigos de ValidationError de la configuración y de las reglas que aplica.
+const (
+	SettingUnknownCode       = "setting_unknown"
+	SettingInvalidCode       = "setting_invalid"
+	AddressLimitCode         = "address_limit"
+	PhoneLimitCode           = "phone_limit"
+	FieldRequiredCode        = "field_required"
+	PrimaryPhoneRequiredCode = "primary_phone_required"
+)
+
+type SettingsService interface {
+	// Current devuelve la configuración vigente. Se guarda en caché y se recarga
+	// al modificarla o, como mucho, un minuto después de que otra instancia la cambie.
+	Current() (domain.Settings, error)
+	// ListSettings devuelve todas las claves con su valor vigente y su valor por defecto. Solo administradores.
+	ListSettings(actor domain.Actor) ([]domain.SettingInfo, error)
+	// UpdateSettings valida y guarda los valores indicados; si uno no es válido no se guarda ninguno.
+	// Solo administradores.
+	UpdateSettings(values map[string]string, actor domain.Actor) ([]domain.SettingInfo, error)
+}
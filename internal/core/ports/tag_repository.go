@@ -0,0 +1,13 @@
+package ports
+
+import "github.com/riada2/internal/core/domain"
+
+// TagRepository es el puerto para la persistencia de las etiquetas de personas.
+type TagRepository interface {
+	// List devuelve todas las etiquetas ordenadas por nombre.
+	List() ([]domain.Tag, error)
+	// FindOrCreate devuelve las etiquetas con esos nombres, creando las que no existan.
+	FindOrCreate(names []string) ([]domain.Tag, error)
+	// ReplacePersonTags reemplaza las etiquetas de la persona por las indicadas.
+	ReplacePersonTags(personID uint, tags []domain.Tag) error
+}
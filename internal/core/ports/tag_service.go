@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var ErrInvalidTag = errors.New("invalid tag")
+
+// TagService gestiona las etiquetas libres de las personas.
+type TagService interface {
+	ListTags() ([]domain.Tag, error)
+	// SetPersonTags reemplaza las etiquetas de la persona. Solo administradores.
+	SetPersonTags(personID uint, names []string, actor domain.Actor) ([]domain.Tag, error)
+}
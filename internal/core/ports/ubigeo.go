@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+var ErrUbigeoNotFound = errors.New("ubigeo not found")
+
+// UbigeoCatalog es el catálogo de departamentos, provincias y distritos del Perú.
+type UbigeoCatalog interface {
+	// Departments devuelve los departamentos ordenados por nombre.
+	Departments() []domain.UbigeoArea
+	// Provinces devuelve las provincias del departamento (código de 2 dígitos).
+	Provinces(departmentCode string) ([]domain.UbigeoArea, error)
+	// Districts devuelve los distritos de la provincia (código de 4 dígitos).
+	Districts(provinceCode string) ([]domain.UbigeoArea, error)
+	// Find devuelve el distrito con ese código de 6 dígitos.
+	Find(code string) (*domain.Ubigeo, error)
+	// FindByDistrict devuelve todos los distritos con ese nombre, comparado con domain.PlaceKey.
+	FindByDistrict(name string) []domain.Ubigeo
+	// Complete indica si el catálogo tiene todos los distritos del país. En un catálogo
+	// parcial, que un distrito no figure no significa que no exista.
+	Complete() bool
+}
@@ -0,0 +1,29 @@
+package ports
+
+// Repositories agrupa los repositorios que comparten una unidad de trabajo. Incluye la
+// auditoría, para que cada acción se registre en la misma transacción que sus cambios.
+type Repositories struct {
+	Persons       PersonRepository
+	Addresses     AddressRepository
+	Phones        PhoneRepository
+	Versions      VersionRepository
+	Households    HouseholdRepository
+	Relationships RelationshipRepository
+	Users         UserRepository
+	Tags          TagRepository
+	Groups        GroupRepository
+	CustomFields  CustomFieldRepository
+	Events        EventRepository
+	Attendances   AttendanceRepository
+	Notes         NoteRepository
+	Delegations   DelegationRepository
+	Settings      SettingRepository
+	Audit         AuditRepository
+}
+
+// UnitOfWork ejecuta varias escrituras como una sola operación atómica.
+type UnitOfWork interface {
+	// Do ejecuta fn con repositorios ligados a una transacción. Si fn devuelve un
+	// error no se guarda ninguno de sus cambios; si no, se confirman todos juntos.
+	Do(fn func(repos Repositories) error) error
+}
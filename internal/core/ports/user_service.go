@@ -4,11 +4,17 @@ import "github.com/riada2/internal/core/domain"
 
 // UserService es el puerto para la lógica de negocio de usuarios.
 type UserService interface {
-	Register(username, password string) (*domain.User, error)
-	Login(username, password string) (string, *domain.Role, error) // Devuelve el token JWT
+	Register(username, password string, actor domain.Actor) (*domain.User, error)
+	// CreateUser crea una cuenta con el rol indicado. Register equivale a CreateUser con el rol 'user'.
+	CreateUser(username, password string, role domain.Role, actor domain.Actor) (*domain.User, error)
+	Login(username, password string, actor domain.Actor) (string, *domain.Role, error) // Devuelve el token JWT
 
 	// GetAllUsers devuelve una lista de todos los usuarios sin sus contraseñas.
 	GetAllUsers() ([]domain.UserResponse, error)
 	// UpdateUser actualiza la información de un usuario (e.g., username, role).
-	UpdateUser(id uint, username *string, role *domain.Role) (*domain.UserResponse, error)
+	UpdateUser(id uint, username *string, role *domain.Role, actor domain.Actor) (*domain.UserResponse, error)
+	// GetUserByUsername busca un usuario por su nombre, sin su contraseña.
+	GetUserByUsername(username string) (*domain.UserResponse, error)
+	// SetPassword reemplaza la contraseña del usuario.
+	SetPassword(id uint, password string, actor domain.Actor) error
 }
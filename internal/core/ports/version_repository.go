@@ -0,0 +1,14 @@
+package ports
+
+import "github.com/riada2/internal/core/domain"
+
+// VersionRepository es el puerto para la persistencia del historial de versiones.
+type VersionRepository interface {
+	Save(version *domain.EntityVersion) error
+	FindByID(id uint) (*domain.EntityVersion, error)
+	// FindByPersonID devuelve todas las versiones de la persona y de sus contactos,
+	// de la más reciente a la más antigua.
+	FindByPersonID(personID uint) ([]domain.EntityVersion, error)
+	// LatestVersion devuelve el último número de versión de una entidad (0 si no tiene).
+	LatestVersion(entityType domain.VersionEntity, entityID uint) (int, error)
+}
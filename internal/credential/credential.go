@@ -0,0 +1,65 @@
+package credential
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/riada2/internal/core/ports"
+)
+
+// credentialType distingue las credenciales de los tokens de sesión.
+const credentialType = "person_credential"
+
+type jwtSigner struct {
+	key      []byte
+	lifetime time.Duration
+}
+
+// NewJWTSigner crea un firmador de credenciales basado en JWT (HS256) que vencen a
+// los lifetime de emitidas. La clave se deriva del secreto de la aplicación para que
+// una credencial nunca sea aceptada como token de sesión, ni al revés.
+func NewJWTSigner(secret string, lifetime time.Duration) ports.CredentialSigner {
+	key := sha256.Sum256([]byte(credentialType + ":" + secret))
+	return &jwtSigner{key: key[:], lifetime: lifetime}
+}
+
+func (s *jwtSigner) Issue(personID, version uint) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub": strconv.FormatUint(uint64(personID), 10),
+		"typ": credentialType,
+		"ver": version,
+		"iat": now.Unix(),
+		"exp": now.Add(s.lifetime).Unix(),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.key)
+}
+
+func (s *jwtSigner) Verify(token string) (personID, version uint, err error) {
+	// Las credenciales sin vencimiento, emitidas antes de que lo tuvieran, no se aceptan.
+	parsed, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		return s.key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || !parsed.Valid {
+		return 0, 0, ports.ErrInvalidCredential
+	}
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || claims["typ"] != credentialType {
+		return 0, 0, ports.ErrInvalidCredential
+	}
+	subject, _ := claims["sub"].(string)
+	id, err := strconv.ParseUint(subject, 10, 32)
+	if err != nil || id == 0 {
+		return 0, 0, fmt.Errorf("%w: bad subject", ports.ErrInvalidCredential)
+	}
+	// Los números de JSON se decodifican como float64.
+	ver, ok := claims["ver"].(float64)
+	if !ok || ver < 0 || ver != float64(uint(ver)) {
+		return 0, 0, fmt.Errorf("%w: bad version", ports.ErrInvalidCredential)
+	}
+	return uint(id), uint(ver), nil
+}
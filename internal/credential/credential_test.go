@@ -0,0 +1,88 @@
+package credential
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/riada2/internal/core/ports"
+)
+
+func TestVerify(t *testing.T) {
+	signer := NewJWTSigner("secret", time.Hour).(*jwtSigner)
+	now := time.Now()
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{"sub": "7", "typ": credentialType, "ver": 2, "iat": now.Unix(), "exp": now.Add(time.Hour).Unix()}
+	}
+	with := func(name string, value interface{}) jwt.MapClaims {
+		claims := valid()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+	sign := func(method jwt.SigningMethod, claims jwt.MapClaims, key interface{}) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatalf("SignedString: %v", err)
+		}
+		return token
+	}
+	issued, err := signer.Issue(7, 2)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantID  uint
+		wantVer uint
+		wantErr bool
+	}{
+		{name: "issued credential", token: issued, wantID: 7, wantVer: 2},
+		{name: "valid claims", token: sign(jwt.SigningMethodHS256, valid(), signer.key), wantID: 7, wantVer: 2},
+		{name: "version before a bump is returned as is", token: sign(jwt.SigningMethodHS256, with("ver", 1), signer.key), wantID: 7, wantVer: 1},
+		{name: "bad signature", token: sign(jwt.SigningMethodHS256, valid(), []byte("another key")), wantErr: true},
+		{name: "session token signed with the secret", token: sign(jwt.SigningMethodHS256, valid(), []byte("secret")), wantErr: true},
+		{name: "wrong alg", token: sign(jwt.SigningMethodHS512, valid(), signer.key), wantErr: true},
+		{name: "alg none", token: sign(jwt.SigningMethodNone, valid(), jwt.UnsafeAllowNoneSignatureType), wantErr: true},
+		{name: "missing exp", token: sign(jwt.SigningMethodHS256, with("exp", nil), signer.key), wantErr: true},
+		{name: "expired", token: sign(jwt.SigningMethodHS256, with("exp", now.Add(-time.Minute).Unix()), signer.key), wantErr: true},
+		{name: "wrong typ", token: sign(jwt.SigningMethodHS256, with("typ", "session"), signer.key), wantErr: true},
+		{name: "missing typ", token: sign(jwt.SigningMethodHS256, with("typ", nil), signer.key), wantErr: true},
+		{name: "zero subject", token: sign(jwt.SigningMethodHS256, with("sub", "0"), signer.key), wantErr: true},
+		{name: "non-numeric subject", token: sign(jwt.SigningMethodHS256, with("sub", "ana"), signer.key), wantErr: true},
+		{name: "missing ver", token: sign(jwt.SigningMethodHS256, with("ver", nil), signer.key), wantErr: true},
+		{name: "negative ver", token: sign(jwt.SigningMethodHS256, with("ver", -1), signer.key), wantErr: true},
+		{name: "fractional ver", token: sign(jwt.SigningMethodHS256, with("ver", 1.5), signer.key), wantErr: true},
+		{name: "malformed token", token: "not-a-token", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ver, err := signer.Verify(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ports.ErrInvalidCredential) {
+					t.Fatalf("Verify = %d, %d, %v, want ErrInvalidCredential", id, ver, err)
+				}
+				return
+			}
+			if err != nil || id != tt.wantID || ver != tt.wantVer {
+				t.Fatalf("Verify = %d, %d, %v, want %d, %d", id, ver, err, tt.wantID, tt.wantVer)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsAnotherSecret(t *testing.T) {
+	token, err := NewJWTSigner("old secret", time.Hour).Issue(7, 0)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if _, _, err := NewJWTSigner("new secret", time.Hour).Verify(token); !errors.Is(err, ports.ErrInvalidCredential) {
+		t.Fatalf("Verify = %v, want ErrInvalidCredential", err)
+	}
+}
@@ -0,0 +1,99 @@
+// Package geocode contiene los adaptadores que obtienen las coordenadas de una dirección.
+package geocode
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// lookupGeocoder resuelve las direcciones con una tabla local, sin servicios externos.
+// Busca primero el texto exacto de la dirección y, si no lo encuentra, el centro del
+// distrito por su ubigeo.
+type lookupGeocoder struct {
+	byAddress map[string]domain.GeoPoint
+	byUbigeo  map[string]domain.GeoPoint
+}
+
+// NewLookupGeocoder crea un geocodificador con la tabla dada. Cada clave es un ubigeo
+// de 6 dígitos (el punto es el centro del distrito) o el texto de una dirección, que se
+// compara con domain.PlaceKey.
+func NewLookupGeocoder(entries map[string]domain.GeoPoint) ports.Geocoder {
+	g := &lookupGeocoder{byAddress: make(map[string]domain.GeoPoint), byUbigeo: make(map[string]domain.GeoPoint)}
+	for key, point := range entries {
+		key = strings.TrimSpace(key)
+		if isUbigeo(key) {
+			g.byUbigeo[key] = point
+		} else {
+			g.byAddress[domain.PlaceKey(key)] = point
+		}
+	}
+	return g
+}
+
+// LoadLookupTable lee la tabla de un CSV con cabecera y las columnas clave, latitud y
+// longitud. Si path está vacío devuelve un geocodificador sin entradas, que no reconoce
+// ninguna dirección.
+func LoadLookupTable(path string) (ports.Geocoder, error) {
+	entries := make(map[string]domain.GeoPoint)
+	if path == "" {
+		return NewLookupGeocoder(entries), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid geocoding table: %w", err)
+	}
+	for i, row := range rows {
+		if i == 0 {
+			continue // Cabecera.
+		}
+		latitude, latErr := strconv.ParseFloat(strings.TrimSpace(row[1]), 64)
+		longitude, lonErr := strconv.ParseFloat(strings.TrimSpace(row[2]), 64)
+		point := domain.GeoPoint{Latitude: latitude, Longitude: longitude}
+		if strings.TrimSpace(row[0]) == "" || latErr != nil || lonErr != nil || !point.IsValid() {
+			return nil, fmt.Errorf("invalid geocoding table: line %d is not a valid entry", i+1)
+		}
+		entries[row[0]] = point
+	}
+	return NewLookupGeocoder(entries), nil
+}
+
+func (g *lookupGeocoder) Geocode(_ context.Context, address *domain.Address) (*domain.GeoPoint, error) {
+	for _, text := range []string{address.Address, address.FormatLine()} {
+		if point, ok := g.byAddress[domain.PlaceKey(text)]; ok && text != "" {
+			return &point, nil
+		}
+	}
+	if point, ok := g.byUbigeo[address.Ubigeo]; ok {
+		return &point, nil
+	}
+	return nil, ports.ErrGeocodeNotFound
+}
+
+func isUbigeo(key string) bool {
+	if len(key) != 6 {
+		return false
+	}
+	for _, r := range key {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package geocode
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+var (
+	brasil      = domain.GeoPoint{Latitude: -12.0702, Longitude: -77.0501}
+	jesusMaria  = domain.GeoPoint{Latitude: -12.0764, Longitude: -77.0453}
+	structured  = domain.GeoPoint{Latitude: -12.1211, Longitude: -77.0297}
+	lookupTable = map[string]domain.GeoPoint{
+		"Av. Brasil 1234, Jesús María":           brasil,
+		"150113":                                 jesusMaria,
+		"Av. Larco 345, Miraflores, Lima, Lima ": structured,
+	}
+)
+
+func TestGeocode(t *testing.T) {
+	geocoder := NewLookupGeocoder(lookupTable)
+
+	tests := []struct {
+		name    string
+		address domain.Address
+		want    *domain.GeoPoint
+	}{
+		{name: "exact text", address: domain.Address{Address: "Av. Brasil 1234, Jesús María"}, want: &brasil},
+		{name: "text without accents or case", address: domain.Address{Address: "AV. BRASIL 1234, JESUS MARIA"}, want: &brasil},
+		{name: "structured fields", address: domain.Address{Street: "Av. Larco", Number: "345", District: "Miraflores", Province: "Lima", Department: "Lima"}, want: &structured},
+		{name: "district center by ubigeo", address: domain.Address{Address: "Jr. Huiracocha 1500", Ubigeo: "150113"}, want: &jesusMaria},
+		{name: "the text wins over the ubigeo", address: domain.Address{Address: "Av. Brasil 1234, Jesús María", Ubigeo: "150113"}, want: &brasil},
+		{name: "unknown address", address: domain.Address{Address: "Calle Falsa 123", Ubigeo: "150101"}},
+		{name: "empty address", address: domain.Address{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := geocoder.Geocode(context.Background(), &tt.address)
+			if tt.want == nil {
+				if !errors.Is(err, ports.ErrGeocodeNotFound) {
+					t.Fatalf("Geocode = %+v, %v, want ErrGeocodeNotFound", got, err)
+				}
+				return
+			}
+			if err != nil || *got != *tt.want {
+				t.Fatalf("Geocode = %+v, %v, want %+v", got, err, *tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadLookupTable(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	geocoder, err := LoadLookupTable(write("valid.csv", "clave,latitud,longitud\n150113, -12.0764, -77.0453\n\"Av. Brasil 1234, Jesús María\",-12.0702,-77.0501\n"))
+	if err != nil {
+		t.Fatalf("LoadLookupTable: %v", err)
+	}
+	if got, err := geocoder.Geocode(context.Background(), &domain.Address{Address: "av. brasil 1234, jesus maria"}); err != nil || *got != brasil {
+		t.Fatalf("Geocode = %+v, %v, want %+v", got, err, brasil)
+	}
+	if got, err := geocoder.Geocode(context.Background(), &domain.Address{Ubigeo: "150113"}); err != nil || *got != jesusMaria {
+		t.Fatalf("Geocode = %+v, %v, want %+v", got, err, jesusMaria)
+	}
+
+	empty, err := LoadLookupTable("")
+	if err != nil {
+		t.Fatalf("LoadLookupTable without a path: %v", err)
+	}
+	if _, err := empty.Geocode(context.Background(), &domain.Address{Ubigeo: "150113"}); !errors.Is(err, ports.ErrGeocodeNotFound) {
+		t.Fatalf("an empty table should not recognize any address, got %v", err)
+	}
+
+	for name, content := range map[string]string{
+		"latitude.csv":     "clave,latitud,longitud\n150113,sur,-77.0453\n",
+		"out-of-range.csv": "clave,latitud,longitud\n150113,-120,-77.0453\n",
+		"empty-key.csv":    "clave,latitud,longitud\n,-12.0764,-77.0453\n",
+		"columns.csv":      "clave,latitud,longitud\n150113,-12.0764\n",
+	} {
+		if _, err := LoadLookupTable(write(name, content)); err == nil {
+			t.Errorf("%s: LoadLookupTable should fail", name)
+		}
+	}
+	if _, err := LoadLookupTable(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Error("LoadLookupTable of a missing file should fail")
+	}
+}
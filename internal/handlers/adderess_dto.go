@@ -1,8 +1,118 @@
 package handlers
 
+import (
+	"math"
+
+	"github.com/riada2/internal/core/domain"
+)
+
 // AddressDTO es el DTO para la información de la dirección.
+// En las respuestas, HouseholdID indica que la dirección es la compartida del hogar.
+// En Perú basta con enviar ubigeo, o el distrito (y su provincia si el nombre se repite);
+// los nombres oficiales se completan desde el catálogo del INEI.
 type AddressDTO struct {
-	ID       uint   `json:"id,omitempty"`
-	PersonID uint   `json:"personId,omitempty"`
-	Address  string `json:"address" binding:"required"`
+	ID          uint     `json:"id,omitempty"`
+	PersonID    uint     `json:"personId,omitempty"`
+	HouseholdID *uint    `json:"householdId,omitempty"`
+	Address     string   `json:"address" example:"Av. Brasil 1234, Jesús María, Lima"` // Texto libre; si se omite, se arma con los demás campos
+	Street      string   `json:"street,omitempty" example:"Av. Brasil"`
+	Number      string   `json:"number,omitempty" example:"1234"`
+	Reference   string   `json:"reference,omitempty" example:"Frente al parque"`
+	District    string   `json:"district,omitempty" example:"Jesús María"`
+	Province    string   `json:"province,omitempty" example:"Lima"`
+	Department  string   `json:"department,omitempty" example:"Lima"`
+	Ubigeo      string   `json:"ubigeo,omitempty" example:"150113"`
+	Country     string   `json:"country,omitempty" example:"PE"` // ISO 3166-1 alfa-2; por defecto PE
+	PostalCode  string   `json:"postalCode,omitempty" example:"15072"`
+	Latitude    *float64 `json:"latitude,omitempty" example:"-12.0776"` // Si se omite, se geocodifica
+	Longitude   *float64 `json:"longitude,omitempty" example:"-77.0469"`
+	SortOrder   int      `json:"sortOrder"` // Solo lectura; se cambia al reordenar
+}
+
+func (d *AddressDTO) ToDomain() domain.Address {
+	return domain.Address{
+		ID:         d.ID,
+		PersonID:   d.PersonID,
+		Address:    d.Address,
+		Street:     d.Street,
+		Number:     d.Number,
+		Reference:  d.Reference,
+		District:   d.District,
+		Province:   d.Province,
+		Department: d.Department,
+		Ubigeo:     d.Ubigeo,
+		Country:    d.Country,
+		PostalCode: d.PostalCode,
+		Latitude:   d.Latitude,
+		Longitude:  d.Longitude,
+	}
+}
+
+func NewAddressDTO(address *domain.Address) AddressDTO {
+	return AddressDTO{
+		ID:         address.ID,
+		PersonID:   address.PersonID,
+		Address:    address.Address,
+		Street:     address.Street,
+		Number:     address.Number,
+		Reference:  address.Reference,
+		District:   address.District,
+		Province:   address.Province,
+		Department: address.Department,
+		Ubigeo:     address.Ubigeo,
+		Country:    address.Country,
+		PostalCode: address.PostalCode,
+		Latitude:   address.Latitude,
+		Longitude:  address.Longitude,
+		SortOrder:  address.SortOrder,
+	}
+}
+
+func newAddressDTOs(addresses []domain.Address) []AddressDTO {
+	dtos := make([]AddressDTO, len(addresses))
+	for i := range addresses {
+		dtos[i] = NewAddressDTO(&addresses[i])
+	}
+	return dtos
+}
+
+// AddressMigrationResponse es el resultado de estructurar las direcciones en texto libre.
+type AddressMigrationResponse struct {
+	DryRun     bool `json:"dryRun"`
+	Scanned    int  `json:"scanned"`
+	Structured int  `json:"structured"`
+	Ambiguous  int  `json:"ambiguous"`
+	Unmatched  int  `json:"unmatched"`
+}
+
+func NewAddressMigrationResponse(report *domain.AddressMigrationReport) AddressMigrationResponse {
+	return AddressMigrationResponse{
+		DryRun:     report.DryRun,
+		Scanned:    report.Scanned,
+		Structured: report.Structured,
+		Ambiguous:  report.Ambiguous,
+		Unmatched:  report.Unmatched,
+	}
+}
+
+// GeocodeResponse es el resultado de geocodificar las direcciones sin coordenadas.
+type GeocodeResponse struct {
+	Scanned  int `json:"scanned"`
+	Geocoded int `json:"geocoded"`
+	NotFound int `json:"notFound"`
+}
+
+// NearbyPersonResponse es una persona cercana con su dirección más próxima.
+type NearbyPersonResponse struct {
+	Person     PersonSummaryResponse `json:"person"`
+	Address    AddressDTO            `json:"address"`
+	DistanceKm float64               `json:"distanceKm" example:"1.25"`
+}
+
+func NewNearbyPersonResponse(nearby *domain.NearbyPerson) NearbyPersonResponse {
+	return NearbyPersonResponse{
+		Person:     NewPersonSummaryResponse(&nearby.Person),
+		Address:    NewAddressDTO(&nearby.Address),
+		DistanceKm: math.Round(nearby.DistanceKm*1000) / 1000,
+	}
 }
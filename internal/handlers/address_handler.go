@@ -23,27 +23,36 @@ func (h *AddressHandler) CreateOrUpdateAddress(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
 	}
 
-	// El ID del DTO se usará para actualizaciones. Si es 0, es una creación.
-	address := &domain.Address{
-		ID:       req.ID,
-		PersonID: req.PersonID,
-		Address:  req.Address,
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
 	}
 
-	savedAddress, err := h.addressService.CreateOrUpdateAddress(address)
+	// El ID del DTO se usará para actualizaciones. Si es 0, es una creación.
+	address := req.ToDomain()
+
+	savedAddress, err := h.addressService.CreateOrUpdateAddress(&address, actor)
 	if err != nil {
 		if errors.Is(err, ports.ErrPersonNotFound) {
 			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "la persona especificada no existe"})
 		}
+		if errors.Is(err, ports.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		}
+		if errors.Is(err, ports.ErrAddressNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{Error: err.Error()})
+		}
+		if errors.Is(err, ports.ErrInvalidAddress) {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+		}
+		if errors.Is(err, ports.ErrContactLimitReached) {
+			return c.Status(fiber.StatusConflict).JSON(newErrorResponse(err))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
 	}
 
 	// Devolver la dirección guardada como un DTO
-	responseDTO := AddressDTO{
-		ID:       savedAddress.ID,
-		PersonID: savedAddress.PersonID,
-		Address:  savedAddress.Address,
-	}
+	responseDTO := NewAddressDTO(savedAddress)
 
 	// return c.Status(fiber.StatusOK).JSON(responseDTO)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": responseDTO})
@@ -60,9 +69,375 @@ func (h *AddressHandler) DeleteAddress(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid address ID format"})
 	}
 
-	if err := h.addressService.DeleteAddress(uint(id)); err != nil {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.addressService.DeleteAddress(uint(id), actor); err != nil {
+		if errors.Is(err, ports.ErrAddressNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{Error: err.Error()})
+		}
+		if errors.Is(err, ports.ErrInvalidPerson) {
+			return c.Status(fiber.StatusConflict).JSON(newErrorResponse(err))
+		}
+		if errors.Is(err, ports.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// MigrateAddresses godoc
+// @Summary Structure free-text addresses with their ubigeo
+// @Description For addresses in Peru without ubigeo, recognizes the district in the free text written as "street, district, province" and fills the ubigeo, district, province and department. The free text is kept; ambiguous and unrecognized addresses are left unchanged. Use dryRun=true to only get the report. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param dryRun query bool false "Only report what would change"
+// @Success 200 {object} handlers.AddressMigrationResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/addresses/migrate [post]
+func (h *AddressHandler) MigrateAddresses(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	report, err := h.addressService.MigrateAddresses(c.QueryBool("dryRun"), actor)
+	if err != nil {
+		if errors.Is(err, ports.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewAddressMigrationResponse(report))
+}
+
+// GeocodeAddresses godoc
+// @Summary Geocode addresses without coordinates
+// @Description Looks up the coordinates of every address without latitude and longitude using the configured geocoder. Addresses that cannot be geocoded are left unchanged. Admin only.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} handlers.GeocodeResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/addresses/geocode [post]
+func (h *AddressHandler) GeocodeAddresses(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	report, err := h.addressService.GeocodeAddresses(actor)
+	if err != nil {
+		if errors.Is(err, ports.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(GeocodeResponse{Scanned: report.Scanned, Geocoded: report.Geocoded, NotFound: report.NotFound})
+}
+
+// FindNearby godoc
+// @Summary Find persons living near a point
+// @Description Returns the persons with a geocoded address near the given point, or near the address of personId, each with its nearest address, closest first. Without radiusKm it returns the nearest persons at any distance. Admin only.
+// @Tags Person
+// @Produce json
+// @Param lat query number false "Latitude of the center (required without personId)"
+// @Param lon query number false "Longitude of the center (required without personId)"
+// @Param personId query int false "Use the first geocoded address of this person as center"
+// @Param radiusKm query number false "Maximum distance in km (max 500)"
+// @Param limit query int false "Maximum number of persons (default 20, max 100)"
+// @Success 200 {array} handlers.NearbyPersonResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/nearby [get]
+func (h *AddressHandler) FindNearby(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	centerPersonID, err := optionalUintQuery(c, "personId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid personId"})
+	}
+	var query ports.NearbyQuery
+	if centerPersonID == nil {
+		lat, latErr := strconv.ParseFloat(c.Query("lat"), 64)
+		lon, lonErr := strconv.ParseFloat(c.Query("lon"), 64)
+		if latErr != nil || lonErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "lat and lon are required when personId is not given"})
+		}
+		query.Center = domain.GeoPoint{Latitude: lat, Longitude: lon}
+	}
+	if raw := c.Query("radiusKm"); raw != "" {
+		if query.RadiusKm, err = strconv.ParseFloat(raw, 64); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid radiusKm"})
+		}
+	}
+	query.Limit = c.QueryInt("limit")
+
+	nearby, err := h.addressService.FindNearby(query, centerPersonID, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrInvalidNearbyQuery):
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+		case errors.Is(err, ports.ErrPersonNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{Error: err.Error()})
+		case errors.Is(err, ports.ErrForbidden):
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	responses := make([]NearbyPersonResponse, len(nearby))
+	for i := range nearby {
+		responses[i] = NewNearbyPersonResponse(&nearby[i])
+	}
+	return c.JSON(responses)
+}
+
+// addressErrorStatus traduce los errores de las rutas anidadas de direcciones a códigos HTTP.
+func addressErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrPersonNotFound), errors.Is(err, ports.ErrAddressNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrInvalidAddress):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ports.ErrContactLimitReached), errors.Is(err, ports.ErrInvalidPerson):
+		return fiber.StatusConflict
+	}
+	return fiber.StatusInternalServerError
+}
+
+// ListPersonAddresses godoc
+// @Summary List the addresses of a person
+// @Description Returns the person's own addresses in their sort order. Only the owner or an admin can list them.
+// @Tags Person
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {array} handlers.AddressDTO
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/addresses [get]
+func (h *AddressHandler) ListPersonAddresses(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID"})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	addresses, err := h.addressService.ListAddresses(personID, actor)
+	if err != nil {
+		return c.Status(addressErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.JSON(newAddressDTOs(addresses))
+}
+
+// GetPersonAddress godoc
+// @Summary Get a address of a person
+// @Description Returns one of the person's own addresses. Only the owner or an admin can read it.
+// @Tags Person
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param addressId path int true "Address ID"
+// @Success 200 {object} handlers.AddressDTO
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person or address not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/addresses/{addressId} [get]
+func (h *AddressHandler) GetPersonAddress(c *fiber.Ctx) error {
+	personID, addressID, err := parsePersonContactParams(c, "addressId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	address, err := h.addressService.GetAddress(personID, addressID, actor)
+	if err != nil {
+		return c.Status(addressErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.JSON(NewAddressDTO(address))
+}
+
+// CreatePersonAddress godoc
+// @Summary Add a address to a person
+// @Description Adds a address at the end of the person's addresses. Only the owner or an admin can add it.
+// @Tags Person
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param address body handlers.AddressDTO true "Address"
+// @Success 201 {object} handlers.AddressDTO
+// @Failure 400 {object} ErrorResponse "Invalid address"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 409 {object} ErrorResponse "Address limit reached"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/addresses [post]
+func (h *AddressHandler) CreatePersonAddress(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID"})
+	}
+	var req AddressDTO
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	address := req.ToDomain()
+	address.ID, address.PersonID = 0, personID
+	saved, err := h.addressService.CreateOrUpdateAddress(&address, actor)
+	if err != nil {
+		return c.Status(addressErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.Status(fiber.StatusCreated).JSON(NewAddressDTO(saved))
+}
+
+// UpdatePersonAddress godoc
+// @Summary Update a address of a person
+// @Description Replaces one of the person's own addresses; its sort order is kept. Only the owner or an admin can update it.
+// @Tags Person
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param addressId path int true "Address ID"
+// @Param address body handlers.AddressDTO true "Address"
+// @Success 200 {object} handlers.AddressDTO
+// @Failure 400 {object} ErrorResponse "Invalid address"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person or address not found"
+// @Failure 409 {object} ErrorResponse "Address limit reached"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/addresses/{addressId} [put]
+func (h *AddressHandler) UpdatePersonAddress(c *fiber.Ctx) error {
+	personID, addressID, err := parsePersonContactParams(c, "addressId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+	var req AddressDTO
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	address := req.ToDomain()
+	address.ID, address.PersonID = addressID, personID
+	saved, err := h.addressService.CreateOrUpdateAddress(&address, actor)
+	if err != nil {
+		return c.Status(addressErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.JSON(NewAddressDTO(saved))
+}
+
+// DeletePersonAddress godoc
+// @Summary Delete a address of a person
+// @Description Deletes one of the person's own addresses. Only the owner or an admin can delete it.
+// @Tags Person
+// @Param id path int true "Person ID"
+// @Param addressId path int true "Address ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person or address not found"
+// @Failure 409 {object} ErrorResponse "The address is required"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/addresses/{addressId} [delete]
+func (h *AddressHandler) DeletePersonAddress(c *fiber.Ctx) error {
+	personID, addressID, err := parsePersonContactParams(c, "addressId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	// Se comprueba primero que la dirección sea de la persona de la ruta.
+	if _, err := h.addressService.GetAddress(personID, addressID, actor); err != nil {
+		return c.Status(addressErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	if err := h.addressService.DeleteAddress(addressID, actor); err != nil {
+		return c.Status(addressErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ReorderPersonAddresses godoc
+// @Summary Reorder the addresses of a person
+// @Description Sets the sort order of the person's addresses. The list must contain every address ID of the person exactly once. Only the owner or an admin can reorder them.
+// @Tags Person
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param order body handlers.ReorderRequest true "Address IDs in the new order"
+// @Success 200 {array} handlers.AddressDTO
+// @Failure 400 {object} ErrorResponse "Invalid order"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/addresses/order [put]
+func (h *AddressHandler) ReorderPersonAddresses(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID"})
+	}
+	var req ReorderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	addresses, err := h.addressService.ReorderAddresses(personID, req.IDs, actor)
+	if err != nil {
+		return c.Status(addressErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.JSON(newAddressDTOs(addresses))
+}
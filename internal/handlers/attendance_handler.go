@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/ports"
+)
+
+type AttendanceHandler struct {
+	attendanceService ports.AttendanceService
+}
+
+func NewAttendanceHandler(attendanceService ports.AttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{attendanceService: attendanceService}
+}
+
+// CheckIn godoc
+// @Summary Check in to an event
+// @Description Records that a person attended an occurrence of the event. The person is identified by exactly one of personId, typeDoc with docNumber, or the qrToken of their credential. The date defaults to today, cannot be after today and must be a day the event occurs. Admin only.
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Param id path int true "Event ID"
+// @Param checkIn body handlers.CheckInRequest true "Check-in"
+// @Success 201 {object} handlers.AttendanceResponse
+// @Failure 400 {object} ErrorResponse "Invalid check-in or credential"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Event or person not found"
+// @Failure 409 {object} ErrorResponse "Already checked in"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/events/{id}/check-in [post]
+func (h *AttendanceHandler) CheckIn(c *fiber.Ctx) error {
+	eventID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid event ID format"})
+	}
+
+	var req CheckInRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+	checkIn, err := req.ToDomain()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid date format, use YYYY-MM-DD"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	attendance, err := h.attendanceService.CheckIn(eventID, checkIn, actor)
+	if err != nil {
+		return c.Status(eventErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewAttendanceResponse(attendance))
+}
+
+// RemoveAttendance godoc
+// @Summary Undo a check-in
+// @Description Deletes an attendance record of the event. Admin only.
+// @Tags Attendance
+// @Param id path int true "Event ID"
+// @Param attendanceId path int true "Attendance ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Attendance not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/events/{id}/attendance/{attendanceId} [delete]
+func (h *AttendanceHandler) RemoveAttendance(c *fiber.Ctx) error {
+	eventID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid event ID format"})
+	}
+	attendanceID, err := parseIDParam(c, "attendanceId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid attendance ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.attendanceService.RemoveAttendance(eventID, attendanceID, actor); err != nil {
+		return c.Status(eventErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetEventReport godoc
+// @Summary Attendance report of an event
+// @Description Returns the attendees of each occurrence of the event between two days, both inclusive, and who attended for the first time. By default, the last 90 days. Admin only.
+// @Tags Attendance
+// @Produce json
+// @Param id path int true "Event ID"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Success 200 {object} handlers.EventAttendanceReportResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/events/{id}/attendance [get]
+func (h *AttendanceHandler) GetEventReport(c *fiber.Ctx) error {
+	eventID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid event ID format"})
+	}
+
+	today := time.Now()
+	from, to, err := dateRangeQuery(c, today.AddDate(0, 0, -89), today)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid date format, use YYYY-MM-DD"})
+	}
+
+	report, err := h.attendanceService.GetEventReport(eventID, from, to)
+	if err != nil {
+		return c.Status(eventErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewEventAttendanceReportResponse(report))
+}
+
+// GetPersonReport godoc
+// @Summary Attendance report of a person
+// @Description Returns the check-ins of the person between two days, both inclusive, with a summary per event: attended occurrences and current and longest streaks of consecutive occurrences. By default, the last 365 days. Only the owner of the record, a delegate or an admin can see it.
+// @Tags Attendance
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Success 200 {object} handlers.PersonAttendanceReportResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/attendance [get]
+func (h *AttendanceHandler) GetPersonReport(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	today := time.Now()
+	from, to, err := dateRangeQuery(c, today.AddDate(0, 0, -364), today)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid date format, use YYYY-MM-DD"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	report, err := h.attendanceService.GetPersonReport(personID, from, to, actor)
+	if err != nil {
+		return c.Status(eventErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewPersonAttendanceReportResponse(report))
+}
+
+// GetPeriodReport godoc
+// @Summary Attendance report of a period
+// @Description Summarizes the attendance to all events between two days, both inclusive: total check-ins, unique persons, first-time visitors and attendees per occurrence of each event. By default, the last 30 days. Admin only.
+// @Tags Attendance
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Success 200 {object} handlers.PeriodAttendanceReportResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/attendance/report [get]
+func (h *AttendanceHandler) GetPeriodReport(c *fiber.Ctx) error {
+	today := time.Now()
+	from, to, err := dateRangeQuery(c, today.AddDate(0, 0, -29), today)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid date format, use YYYY-MM-DD"})
+	}
+
+	report, err := h.attendanceService.GetPeriodReport(from, to)
+	if err != nil {
+		return c.Status(eventErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewPeriodAttendanceReportResponse(report))
+}
+
+// GetCredential godoc
+// @Summary Get the credential of a person
+// @Description Returns the signed token to encode in the QR of the person's credential, used to check in to events. Only the owner of the record, a delegate or an admin can get it.
+// @Tags Attendance
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {object} handlers.CredentialResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/credential [get]
+func (h *AttendanceHandler) GetCredential(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	token, err := h.attendanceService.GetCredential(personID, actor)
+	if err != nil {
+		return c.Status(eventErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(CredentialResponse{PersonID: personID, QRToken: token})
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// AuditEntryResponse es el DTO para una entrada de auditoría.
+type AuditEntryResponse struct {
+	ID         uint                          `json:"id"`
+	ActorID    *uint                         `json:"actorId,omitempty"`
+	ActorRole  domain.Role                   `json:"actorRole,omitempty"`
+	Action     string                        `json:"action" example:"person.delete"`
+	EntityType string                        `json:"entityType,omitempty" example:"person"`
+	EntityID   *uint                         `json:"entityId,omitempty"`
+	Changes    map[string]domain.FieldChange `json:"changes,omitempty"`
+	RequestID  string                        `json:"requestId,omitempty"`
+	IP         string                        `json:"ip,omitempty"`
+	Outcome    domain.AuditOutcome           `json:"outcome" example:"success"`
+	Error      string                        `json:"error,omitempty"`
+	PrevHash   string                        `json:"prevHash"`
+	Hash       string                        `json:"hash"`
+	CreatedAt  time.Time                     `json:"createdAt"`
+}
+
+// AuditSearchResponse es la respuesta paginada de la búsqueda de auditoría.
+type AuditSearchResponse struct {
+	Data  []AuditEntryResponse `json:"data"`
+	Total int64                `json:"total"`
+}
+
+// NewAuditEntryResponse convierte una domain.AuditEntry a su DTO de respuesta.
+func NewAuditEntryResponse(entry *domain.AuditEntry) AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		ActorRole:  entry.ActorRole,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Changes:    entry.Changes,
+		RequestID:  entry.RequestID,
+		IP:         entry.IP,
+		Outcome:    entry.Outcome,
+		Error:      entry.Error,
+		PrevHash:   entry.PrevHash,
+		Hash:       entry.Hash,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
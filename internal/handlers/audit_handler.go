@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type AuditHandler struct {
+	auditService ports.AuditService
+}
+
+func NewAuditHandler(auditService ports.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// SearchAudit godoc
+// @Summary Search the audit log (Admin)
+// @Description Returns audit entries matching the filters, newest first.
+// @Tags Admin
+// @Produce json
+// @Param actorId query int false "Actor user ID"
+// @Param action query string false "Action, e.g. person.delete"
+// @Param entityType query string false "Entity type, e.g. person"
+// @Param entityId query int false "Entity ID"
+// @Param outcome query string false "success or failure"
+// @Param requestId query string false "Request ID"
+// @Param from query string false "From date (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "To date (RFC3339 or YYYY-MM-DD)"
+// @Param limit query int false "Page size (default 50, max 500)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} handlers.AuditSearchResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/audit [get]
+func (h *AuditHandler) SearchAudit(c *fiber.Ctx) error {
+	filter := ports.AuditFilter{
+		Action:     c.Query("action"),
+		EntityType: c.Query("entityType"),
+		Outcome:    domain.AuditOutcome(c.Query("outcome")),
+		RequestID:  c.Query("requestId"),
+		Limit:      c.QueryInt("limit"),
+		Offset:     c.QueryInt("offset"),
+	}
+
+	var err error
+	if filter.ActorID, err = optionalUintQuery(c, "actorId"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid actorId"})
+	}
+	if filter.EntityID, err = optionalUintQuery(c, "entityId"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid entityId"})
+	}
+	if filter.From, err = optionalTimeQuery(c, "from", false); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid from date, use RFC3339 or YYYY-MM-DD"})
+	}
+	if filter.To, err = optionalTimeQuery(c, "to", true); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid to date, use RFC3339 or YYYY-MM-DD"})
+	}
+
+	entries, total, err := h.auditService.Search(filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	responseDTOs := make([]AuditEntryResponse, len(entries))
+	for i := range entries {
+		responseDTOs[i] = NewAuditEntryResponse(&entries[i])
+	}
+
+	return c.JSON(AuditSearchResponse{Data: responseDTOs, Total: total})
+}
+
+// VerifyAudit godoc
+// @Summary Verify the audit hash chain (Admin)
+// @Description Walks the whole audit log recomputing each hash. Reports the first entry whose content or link was altered.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} ports.AuditVerification
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/audit/verify [get]
+func (h *AuditHandler) VerifyAudit(c *fiber.Ctx) error {
+	result, err := h.auditService.Verify()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+	return c.JSON(result)
+}
+
+func optionalUintQuery(c *fiber.Ctx, key string) (*uint, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	id := uint(v)
+	return &id, nil
+}
+
+// optionalTimeQuery acepta RFC3339 o una fecha "YYYY-MM-DD". Con endOfDay, una
+// fecha sin hora se interpreta como el final de ese día.
+func optionalTimeQuery(c *fiber.Ctx, key string, endOfDay bool) (*time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
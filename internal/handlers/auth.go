@@ -68,7 +68,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	}
 
 	// Autenticar usuario usando el servicio
-	token, role, err := h.userService.Login(req.Username, req.Password)
+	token, role, err := h.userService.Login(req.Username, req.Password, requestActor(c))
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "Credenciales inválidas"})
 	}
@@ -0,0 +1,15 @@
+package handlers
+
+// CardBatchRequest es el DTO para imprimir carnés en lote: una lista de personas o un grupo.
+type CardBatchRequest struct {
+	PersonIDs []uint `json:"personIds,omitempty" example:"1,2,3"`
+	GroupID   *uint  `json:"groupId,omitempty" example:"1"`
+}
+
+// CredentialVerificationResponse es el resultado de verificar la credencial de un carné.
+// La persona solo se informa a los usuarios autenticados.
+type CredentialVerificationResponse struct {
+	Valid    bool   `json:"valid"`
+	PersonID uint   `json:"personId,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/ports"
+)
+
+type CardHandler struct {
+	cardService ports.CardService
+}
+
+func NewCardHandler(cardService ports.CardService) *CardHandler {
+	return &CardHandler{cardService: cardService}
+}
+
+// cardErrorStatus traduce los errores del servicio de carnés a códigos HTTP.
+func cardErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrPersonNotFound), errors.Is(err, ports.ErrGroupNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrInvalidCardBatch):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
+// GetCard godoc
+// @Summary Print the ID card of a person
+// @Description Returns a PDF with the ID card of the person: name, photo, document number and a QR code with a signed credential that can be used to check in to events. Only photos stored as PNG or JPEG data URIs are printed. Admin only.
+// @Tags Cards
+// @Produce application/pdf
+// @Param id path int true "Person ID"
+// @Success 200 {file} file "PDF card"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/card [get]
+func (h *CardHandler) GetCard(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	pdf, err := h.cardService.GetCard(personID, actor)
+	if err != nil {
+		return c.Status(cardErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="card-%d.pdf"`, personID))
+	return c.Send(pdf)
+}
+
+// GetCards godoc
+// @Summary Print ID cards in batch
+// @Description Returns a PDF of A4 sheets with ten ID cards each, for a list of persons or for the current members of a group. Send either personIds or groupId. At most 200 cards per request. Admin only.
+// @Tags Cards
+// @Accept json
+// @Produce application/pdf
+// @Param batch body handlers.CardBatchRequest true "Persons or group"
+// @Success 200 {file} file "PDF sheets"
+// @Failure 400 {object} ErrorResponse "Invalid batch"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person or group not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/cards [post]
+func (h *CardHandler) GetCards(c *fiber.Ctx) error {
+	var req CardBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	pdf, err := h.cardService.GetCards(req.PersonIDs, req.GroupID, actor)
+	if err != nil {
+		return c.Status(cardErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="cards.pdf"`)
+	return c.Send(pdf)
+}
+
+// RevokeCredential godoc
+// @Summary Revoke the credential of a person
+// @Description Invalidates the QR credentials issued to the person, e.g. when the ID card is lost. Cards printed afterwards carry a new credential. Admin only.
+// @Tags Cards
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 204 "Credential revoked"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/credential [delete]
+func (h *CardHandler) RevokeCredential(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.cardService.RevokeCredential(personID, actor); err != nil {
+		return c.Status(cardErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// VerifyCredential godoc
+// @Summary Verify an ID card credential
+// @Description Checks the signed token of the QR code printed on an ID card. Public endpoint: anonymous callers only get whether the credential is valid; authenticated callers also get the person ID and name.
+// @Tags Cards
+// @Produce json
+// @Param token query string true "Credential token read from the QR code"
+// @Success 200 {object} handlers.CredentialVerificationResponse
+// @Failure 400 {object} ErrorResponse "Missing token"
+// @Failure 401 {object} ErrorResponse "Invalid session token"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /credentials/verify [get]
+func (h *CardHandler) VerifyCredential(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "token is required"})
+	}
+
+	person, err := h.cardService.VerifyCredential(token)
+	if err != nil {
+		if errors.Is(err, ports.ErrInvalidCredential) {
+			return c.JSON(CredentialVerificationResponse{Valid: false})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	// Sin sesión no se revela a quién pertenece la credencial: un token filtrado no debe
+	// servir para averiguar el nombre de la persona.
+	if _, authenticated := actorFromContext(c); !authenticated {
+		return c.JSON(CredentialVerificationResponse{Valid: true})
+	}
+	return c.JSON(CredentialVerificationResponse{Valid: true, PersonID: person.ID, Name: person.FullName()})
+}
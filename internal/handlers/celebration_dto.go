@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// CelebrationResponse es un cumpleaños o un aniversario de bodas.
+type CelebrationResponse struct {
+	Kind   domain.CelebrationKind `json:"kind" example:"birthday"`
+	Date   string                 `json:"date" example:"2024-05-12"`
+	Years  int                    `json:"years" example:"30"`
+	Person PersonSummaryResponse  `json:"person"`
+	Spouse *PersonSummaryResponse `json:"spouse,omitempty"`
+}
+
+func NewCelebrationResponse(c *domain.Celebration) CelebrationResponse {
+	response := CelebrationResponse{
+		Kind:   c.Kind,
+		Date:   c.Date.Format("2006-01-02"),
+		Years:  c.Years,
+		Person: NewPersonSummaryResponse(&c.Person),
+	}
+	if c.Spouse != nil {
+		spouse := NewPersonSummaryResponse(c.Spouse)
+		response.Spouse = &spouse
+	}
+	return response
+}
+
+// JobStateResponse es el estado de una tarea programada.
+type JobStateResponse struct {
+	Name          string     `json:"name" example:"celebration_reminders"`
+	LastRunAt     *time.Time `json:"lastRunAt,omitempty"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"`
+	LastError     string     `json:"lastError,omitempty"`
+	NextRunAt     *time.Time `json:"nextRunAt,omitempty"`
+	Runs          int        `json:"runs"`
+}
+
+func NewJobStateResponse(state *domain.JobState) JobStateResponse {
+	return JobStateResponse{
+		Name:          state.Name,
+		LastRunAt:     state.LastRunAt,
+		LastSuccessAt: state.LastSuccessAt,
+		LastError:     state.LastError,
+		NextRunAt:     state.NextRunAt,
+		Runs:          state.Runs,
+	}
+}
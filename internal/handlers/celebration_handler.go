@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type CelebrationHandler struct {
+	celebrationService ports.CelebrationService
+	scheduler          ports.JobScheduler
+}
+
+func NewCelebrationHandler(celebrationService ports.CelebrationService, scheduler ports.JobScheduler) *CelebrationHandler {
+	return &CelebrationHandler{celebrationService: celebrationService, scheduler: scheduler}
+}
+
+// ListCelebrations godoc
+// @Summary List birthdays and anniversaries
+// @Description Returns the birthdays and wedding anniversaries (from the "since" date of spouse relationships) of this week (Monday to Sunday) or this month, in the organization timezone, or between two days, both inclusive.
+// @Tags Celebrations
+// @Produce json
+// @Param period query string false "week (default) or month"
+// @Param from query string false "First day (YYYY-MM-DD); requires to"
+// @Param to query string false "Last day (YYYY-MM-DD); requires from"
+// @Param kind query string false "birthday or anniversary; both by default"
+// @Success 200 {array} handlers.CelebrationResponse
+// @Failure 400 {object} ErrorResponse "Invalid filter"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/celebrations [get]
+func (h *CelebrationHandler) ListCelebrations(c *fiber.Ctx) error {
+	filter := ports.CelebrationFilter{Period: c.Query("period"), Kind: domain.CelebrationKind(c.Query("kind"))}
+	var err error
+	if filter.From, err = parseOptionalDate(optionalQuery(c, "from")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid from date, use YYYY-MM-DD"})
+	}
+	if filter.To, err = parseOptionalDate(optionalQuery(c, "to")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid to date, use YYYY-MM-DD"})
+	}
+
+	celebrations, err := h.celebrationService.ListCelebrations(filter)
+	if err != nil {
+		if errors.Is(err, ports.ErrInvalidCelebrationFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	responseDTOs := make([]CelebrationResponse, len(celebrations))
+	for i := range celebrations {
+		responseDTOs[i] = NewCelebrationResponse(&celebrations[i])
+	}
+	return c.JSON(responseDTOs)
+}
+
+// ListJobs godoc
+// @Summary List scheduled jobs
+// @Description Returns the state of each scheduled job: last run, last success, last error and next run. Admin only.
+// @Tags Celebrations
+// @Produce json
+// @Success 200 {array} handlers.JobStateResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/jobs [get]
+func (h *CelebrationHandler) ListJobs(c *fiber.Ctx) error {
+	states, err := h.scheduler.Jobs()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: "failed to list jobs"})
+	}
+
+	responseDTOs := make([]JobStateResponse, len(states))
+	for i := range states {
+		responseDTOs[i] = NewJobStateResponse(&states[i])
+	}
+	return c.JSON(responseDTOs)
+}
+
+// RunJob godoc
+// @Summary Run a scheduled job now
+// @Description Runs the job immediately, for example to resend today's reminders. Admin only.
+// @Tags Celebrations
+// @Param name path string true "Job name"
+// @Success 204 "No Content"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Job not found"
+// @Failure 500 {object} ErrorResponse "The job failed"
+// @Security ApiKeyAuth
+// @Router /protected/admin/jobs/{name}/run [post]
+func (h *CelebrationHandler) RunJob(c *fiber.Ctx) error {
+	if err := h.scheduler.RunNow(c.UserContext(), c.Params("name")); err != nil {
+		if errors.Is(err, ports.ErrJobNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{Error: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// optionalQuery devuelve el parámetro de la consulta, o nil si no viene.
+func optionalQuery(c *fiber.Ctx, key string) *string {
+	value := c.Query(key)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/domain"
+)
+
+// actorFromContext construye el domain.Actor a partir de los claims que el
+// middleware AuthRequired deja en c.Locals.
+// El ID de usuario del token JWT (claim "sub") se decodifica como float64 por defecto en Go.
+func actorFromContext(c *fiber.Ctx) (domain.Actor, bool) {
+	userID, ok := c.Locals("userID").(float64)
+	if !ok {
+		return domain.Actor{}, false
+	}
+	role, _ := c.Locals("userRole").(string)
+
+	actor := requestActor(c)
+	actor.UserID = uint(userID)
+	actor.Role = domain.Role(role)
+	return actor, true
+}
+
+// requestActor devuelve un actor anónimo con los datos de la petición (IP y
+// RequestID). Se usa en rutas públicas, como el login.
+func requestActor(c *fiber.Ctx) domain.Actor {
+	requestID, _ := c.Locals("requestid").(string)
+	return domain.Actor{IP: c.IP(), RequestID: requestID}
+}
+
+// parseIDParam lee un parámetro de ruta numérico, como ":id".
+func parseIDParam(c *fiber.Ctx, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.Params(name), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+// parsePersonContactParams lee ":id" y el ID del contacto anidado (dirección o teléfono).
+func parsePersonContactParams(c *fiber.Ctx, name string) (personID, contactID uint, err error) {
+	if personID, err = parseIDParam(c, "id"); err != nil {
+		return 0, 0, errors.New("invalid person ID")
+	}
+	if contactID, err = parseIDParam(c, name); err != nil {
+		return 0, 0, fmt.Errorf("invalid %s", name)
+	}
+	return personID, contactID, nil
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// CustomFieldRequest es el DTO para crear o actualizar la definición de un campo personalizado.
+// Al actualizar, la clave se ignora: no se puede cambiar.
+type CustomFieldRequest struct {
+	Key      string                 `json:"key" example:"baptism_date"`
+	Label    string                 `json:"label" example:"Fecha de bautismo"`
+	Type     domain.CustomFieldType `json:"type" example:"date"`
+	Required bool                   `json:"required"`
+	Options  []string               `json:"options,omitempty"`
+	Min      *float64               `json:"min,omitempty"`
+	Max      *float64               `json:"max,omitempty"`
+	Pattern  string                 `json:"pattern,omitempty"`
+}
+
+func (cr *CustomFieldRequest) ToDomain(id uint) *domain.CustomFieldDefinition {
+	return &domain.CustomFieldDefinition{
+		ID:       id,
+		Key:      cr.Key,
+		Label:    cr.Label,
+		Type:     cr.Type,
+		Required: cr.Required,
+		Options:  cr.Options,
+		Min:      cr.Min,
+		Max:      cr.Max,
+		Pattern:  cr.Pattern,
+	}
+}
+
+// CustomFieldResponse es el DTO de la definición de un campo personalizado.
+type CustomFieldResponse struct {
+	ID        uint                   `json:"id"`
+	Key       string                 `json:"key"`
+	Label     string                 `json:"label"`
+	Type      domain.CustomFieldType `json:"type"`
+	Required  bool                   `json:"required"`
+	Options   []string               `json:"options,omitempty"`
+	Min       *float64               `json:"min,omitempty"`
+	Max       *float64               `json:"max,omitempty"`
+	Pattern   string                 `json:"pattern,omitempty"`
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+}
+
+func NewCustomFieldResponse(d *domain.CustomFieldDefinition) CustomFieldResponse {
+	return CustomFieldResponse{
+		ID:        d.ID,
+		Key:       d.Key,
+		Label:     d.Label,
+		Type:      d.Type,
+		Required:  d.Required,
+		Options:   d.Options,
+		Min:       d.Min,
+		Max:       d.Max,
+		Pattern:   d.Pattern,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
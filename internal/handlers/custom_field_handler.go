@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/ports"
+)
+
+type CustomFieldHandler struct {
+	customFieldService ports.CustomFieldService
+}
+
+func NewCustomFieldHandler(customFieldService ports.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{customFieldService: customFieldService}
+}
+
+// customFieldErrorStatus traduce los errores del servicio de campos personalizados a códigos HTTP.
+func customFieldErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrCustomFieldNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrCustomFieldExists):
+		return fiber.StatusConflict
+	case errors.Is(err, ports.ErrInvalidCustomField):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
+// ListCustomFields godoc
+// @Summary List custom field definitions
+// @Description Returns the custom fields that persons can have, ordered by key, so clients can render and validate them.
+// @Tags Custom fields
+// @Produce json
+// @Success 200 {array} handlers.CustomFieldResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/custom-fields [get]
+func (h *CustomFieldHandler) ListCustomFields(c *fiber.Ctx) error {
+	definitions, err := h.customFieldService.ListDefinitions()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: "failed to list custom fields"})
+	}
+
+	responseDTOs := make([]CustomFieldResponse, len(definitions))
+	for i := range definitions {
+		responseDTOs[i] = NewCustomFieldResponse(&definitions[i])
+	}
+	return c.JSON(responseDTOs)
+}
+
+// CreateCustomField godoc
+// @Summary Create a custom field (Admin)
+// @Description Defines a new custom field for persons. Types: text (min/max length, pattern), number (min/max), date (YYYY-MM-DD), enum (options) and boolean.
+// @Tags Custom fields
+// @Accept json
+// @Produce json
+// @Param field body handlers.CustomFieldRequest true "Custom field definition"
+// @Success 201 {object} handlers.CustomFieldResponse
+// @Failure 400 {object} ErrorResponse "Invalid definition"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 409 {object} ErrorResponse "Key already exists"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/custom-fields [post]
+func (h *CustomFieldHandler) CreateCustomField(c *fiber.Ctx) error {
+	var req CustomFieldRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	definition, err := h.customFieldService.CreateDefinition(req.ToDomain(0), actor)
+	if err != nil {
+		return c.Status(customFieldErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewCustomFieldResponse(definition))
+}
+
+// UpdateCustomField godoc
+// @Summary Update a custom field (Admin)
+// @Description Updates a custom field definition. The key cannot be changed. Existing values are checked against the new rules the next time each person is saved.
+// @Tags Custom fields
+// @Accept json
+// @Produce json
+// @Param id path int true "Custom field ID"
+// @Param field body handlers.CustomFieldRequest true "Custom field definition"
+// @Success 200 {object} handlers.CustomFieldResponse
+// @Failure 400 {object} ErrorResponse "Invalid definition"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Custom field not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/custom-fields/{id} [put]
+func (h *CustomFieldHandler) UpdateCustomField(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid custom field ID format"})
+	}
+
+	var req CustomFieldRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	definition, err := h.customFieldService.UpdateDefinition(req.ToDomain(id), actor)
+	if err != nil {
+		return c.Status(customFieldErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewCustomFieldResponse(definition))
+}
+
+// DeleteCustomField godoc
+// @Summary Delete a custom field (Admin)
+// @Description Deletes a custom field definition and removes its value from every person.
+// @Tags Custom fields
+// @Param id path int true "Custom field ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Custom field not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/custom-fields/{id} [delete]
+func (h *CustomFieldHandler) DeleteCustomField(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid custom field ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.customFieldService.DeleteDefinition(id, actor); err != nil {
+		return c.Status(customFieldErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// DelegationRequest es el DTO con el que un usuario pide gestionar el registro de otra persona.
+type DelegationRequest struct {
+	PersonID uint   `json:"personId" example:"42"`
+	Reason   string `json:"reason,omitempty" example:"Soy su madre"`
+}
+
+// GrantDelegationRequest es el DTO con el que un administrador concede una delegación.
+type GrantDelegationRequest struct {
+	UserID   uint   `json:"userId" example:"7"` // Usuario que gestionará a la persona
+	PersonID uint   `json:"personId" example:"42"`
+	Reason   string `json:"reason,omitempty" example:"Padre de un menor de edad"`
+}
+
+// DelegationResponse es el DTO de una delegación.
+type DelegationResponse struct {
+	ID          uint                    `json:"id"`
+	UserID      uint                    `json:"userId"`
+	PersonID    uint                    `json:"personId"`
+	PersonName  string                  `json:"personName,omitempty"` // Solo cuando el llamador puede ver a la persona
+	Status      domain.DelegationStatus `json:"status" example:"active"`
+	Reason      string                  `json:"reason,omitempty"`
+	RequestedBy uint                    `json:"requestedBy"`
+	DecidedBy   *uint                   `json:"decidedBy,omitempty"`
+	DecidedAt   *time.Time              `json:"decidedAt,omitempty"`
+	RevokedBy   *uint                   `json:"revokedBy,omitempty"`
+	RevokedAt   *time.Time              `json:"revokedAt,omitempty"`
+	CreatedAt   time.Time               `json:"createdAt"`
+	UpdatedAt   time.Time               `json:"updatedAt"`
+}
+
+func NewDelegationResponse(delegation *domain.Delegation) DelegationResponse {
+	response := DelegationResponse{
+		ID:          delegation.ID,
+		UserID:      delegation.UserID,
+		PersonID:    delegation.PersonID,
+		Status:      delegation.Status,
+		Reason:      delegation.Reason,
+		RequestedBy: delegation.RequestedBy,
+		DecidedBy:   delegation.DecidedBy,
+		DecidedAt:   delegation.DecidedAt,
+		RevokedBy:   delegation.RevokedBy,
+		RevokedAt:   delegation.RevokedAt,
+		CreatedAt:   delegation.CreatedAt,
+		UpdatedAt:   delegation.UpdatedAt,
+	}
+	if person := delegation.Person; person != nil {
+		response.PersonName = person.FullName()
+	}
+	return response
+}
+
+func newDelegationResponses(delegations []domain.Delegation) []DelegationResponse {
+	responses := make([]DelegationResponse, len(delegations))
+	for i := range delegations {
+		responses[i] = NewDelegationResponse(&delegations[i])
+	}
+	return responses
+}
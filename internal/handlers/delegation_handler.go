@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type DelegationHandler struct {
+	delegationService ports.DelegationService
+}
+
+func NewDelegationHandler(delegationService ports.DelegationService) *DelegationHandler {
+	return &DelegationHandler{delegationService: delegationService}
+}
+
+// delegationErrorStatus traduce los errores del servicio de delegaciones a códigos HTTP.
+func delegationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrDelegationNotFound), errors.Is(err, ports.ErrPersonNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrDelegationExists):
+		return fiber.StatusConflict
+	case errors.Is(err, ports.ErrInvalidDelegation):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
+// ListDelegations godoc
+// @Summary List my delegations
+// @Description Returns the delegations of the caller, both as delegate and over the caller's own person record, including pending requests, newest first.
+// @Tags Delegations
+// @Produce json
+// @Success 200 {array} handlers.DelegationResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/delegations [get]
+func (h *DelegationHandler) ListDelegations(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	delegations, err := h.delegationService.ListDelegations(actor)
+	if err != nil {
+		return c.Status(delegationErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(newDelegationResponses(delegations))
+}
+
+// RequestDelegation godoc
+// @Summary Request to manage a person
+// @Description Asks for the right to manage the record of another person, e.g. a child. The request stays pending until the person's linked user or an admin accepts it. The response does not tell whether the person exists nor its name until the request is accepted.
+// @Tags Delegations
+// @Accept json
+// @Produce json
+// @Param request body handlers.DelegationRequest true "Person to manage"
+// @Success 201 {object} handlers.DelegationResponse
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 409 {object} ErrorResponse "Pending or active delegation already exists"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/delegations [post]
+func (h *DelegationHandler) RequestDelegation(c *fiber.Ctx) error {
+	var req DelegationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	delegation, err := h.delegationService.RequestDelegation(req.PersonID, req.Reason, actor)
+	if err != nil {
+		return c.Status(delegationErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewDelegationResponse(delegation))
+}
+
+// AcceptDelegation godoc
+// @Summary Accept a delegation request
+// @Description Activates a pending request, giving the requesting user manage rights over the person. Only the person's linked user or an admin can accept it.
+// @Tags Delegations
+// @Produce json
+// @Param id path int true "Delegation ID"
+// @Success 200 {object} handlers.DelegationResponse
+// @Failure 400 {object} ErrorResponse "The delegation is not pending"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Delegation not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/delegations/{id}/accept [post]
+func (h *DelegationHandler) AcceptDelegation(c *fiber.Ctx) error {
+	return h.changeDelegation(c, h.delegationService.AcceptDelegation)
+}
+
+// RejectDelegation godoc
+// @Summary Reject a delegation request
+// @Description Rejects a pending request. Only the person's linked user or an admin can reject it.
+// @Tags Delegations
+// @Produce json
+// @Param id path int true "Delegation ID"
+// @Success 200 {object} handlers.DelegationResponse
+// @Failure 400 {object} ErrorResponse "The delegation is not pending"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Delegation not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/delegations/{id}/reject [post]
+func (h *DelegationHandler) RejectDelegation(c *fiber.Ctx) error {
+	return h.changeDelegation(c, h.delegationService.RejectDelegation)
+}
+
+// RevokeDelegation godoc
+// @Summary Revoke a delegation
+// @Description Ends a pending or active delegation. The delegate can give it up; the person's linked user or an admin can revoke it.
+// @Tags Delegations
+// @Produce json
+// @Param id path int true "Delegation ID"
+// @Success 200 {object} handlers.DelegationResponse
+// @Failure 400 {object} ErrorResponse "The delegation is already closed"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Delegation not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/delegations/{id} [delete]
+func (h *DelegationHandler) RevokeDelegation(c *fiber.Ctx) error {
+	return h.changeDelegation(c, h.delegationService.RevokeDelegation)
+}
+
+// changeDelegation aplica una transición de estado a la delegación indicada en la ruta.
+func (h *DelegationHandler) changeDelegation(c *fiber.Ctx, change func(id uint, actor domain.Actor) (*domain.Delegation, error)) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid delegation ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	delegation, err := change(id, actor)
+	if err != nil {
+		return c.Status(delegationErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewDelegationResponse(delegation))
+}
+
+// ListManagedPersons godoc
+// @Summary List the persons I manage
+// @Description Returns the person records the caller manages through an active delegation, with their addresses and phones. They can be edited with PUT /protected/person/{id} and the nested address and phone endpoints.
+// @Tags Delegations
+// @Produce json
+// @Success 200 {array} handlers.PersonResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/delegations/persons [get]
+func (h *DelegationHandler) ListManagedPersons(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	persons, err := h.delegationService.ListManagedPersons(actor)
+	if err != nil {
+		return c.Status(delegationErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	responses := make([]PersonResponse, len(persons))
+	for i := range persons {
+		responses[i] = NewPersonResponse(&persons[i])
+	}
+	return c.JSON(responses)
+}
+
+// SearchDelegations godoc
+// @Summary Search delegations (Admin)
+// @Description Returns the delegations matching the filters, newest first.
+// @Tags Admin
+// @Produce json
+// @Param userId query int false "Delegate user ID"
+// @Param personId query int false "Person ID"
+// @Param status query string false "pending, active, rejected or revoked"
+// @Success 200 {array} handlers.DelegationResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/delegations [get]
+func (h *DelegationHandler) SearchDelegations(c *fiber.Ctx) error {
+	filter := ports.DelegationFilter{Status: domain.DelegationStatus(c.Query("status"))}
+	var err error
+	if filter.UserID, err = optionalUintQuery(c, "userId"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid userId"})
+	}
+	if filter.PersonID, err = optionalUintQuery(c, "personId"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid personId"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	delegations, err := h.delegationService.SearchDelegations(filter, actor)
+	if err != nil {
+		return c.Status(delegationErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(newDelegationResponses(delegations))
+}
+
+// GrantDelegation godoc
+// @Summary Grant a delegation (Admin)
+// @Description Gives a user manage rights over a person record right away, without a prior request.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body handlers.GrantDelegationRequest true "Delegate and person"
+// @Success 201 {object} handlers.DelegationResponse
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 409 {object} ErrorResponse "Pending or active delegation already exists"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/delegations [post]
+func (h *DelegationHandler) GrantDelegation(c *fiber.Ctx) error {
+	var req GrantDelegationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	delegation, err := h.delegationService.GrantDelegation(req.UserID, req.PersonID, req.Reason, actor)
+	if err != nil {
+		return c.Status(delegationErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewDelegationResponse(delegation))
+}
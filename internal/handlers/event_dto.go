@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// EventRequest es el DTO para crear o actualizar un evento.
+type EventRequest struct {
+	Name        string     `json:"name" example:"Culto dominical"`
+	Description string     `json:"description,omitempty"`
+	Place       string     `json:"place,omitempty" example:"Templo central"`
+	StartsAt    time.Time  `json:"startsAt" example:"2024-01-07T10:00:00-05:00"`
+	EndsAt      *time.Time `json:"endsAt,omitempty" example:"2024-01-07T12:00:00-05:00"`
+	Recurrence  string     `json:"recurrence,omitempty" example:"FREQ=WEEKLY;BYDAY=SU"` // Subconjunto de RRULE
+}
+
+func (er *EventRequest) ToDomain(id uint) *domain.Event {
+	return &domain.Event{
+		ID:          id,
+		Name:        er.Name,
+		Description: er.Description,
+		Place:       er.Place,
+		StartsAt:    er.StartsAt,
+		EndsAt:      er.EndsAt,
+		Recurrence:  er.Recurrence,
+	}
+}
+
+// EventResponse es el DTO de un evento.
+type EventResponse struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Place       string     `json:"place,omitempty"`
+	StartsAt    time.Time  `json:"startsAt"`
+	EndsAt      *time.Time `json:"endsAt,omitempty"`
+	Recurrence  string     `json:"recurrence,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+func NewEventResponse(event *domain.Event) EventResponse {
+	return EventResponse{
+		ID:          event.ID,
+		Name:        event.Name,
+		Description: event.Description,
+		Place:       event.Place,
+		StartsAt:    event.StartsAt,
+		EndsAt:      event.EndsAt,
+		Recurrence:  event.Recurrence,
+		CreatedAt:   event.CreatedAt,
+		UpdatedAt:   event.UpdatedAt,
+	}
+}
+
+// OccurrencesResponse son las fechas de inicio de un evento en un rango.
+type OccurrencesResponse struct {
+	EventID     uint        `json:"eventId"`
+	Occurrences []time.Time `json:"occurrences"`
+}
+
+// CheckInRequest es el DTO para registrar una asistencia. Se debe enviar una sola
+// forma de identificar a la persona: personId, typeDoc con docNumber, o qrToken.
+type CheckInRequest struct {
+	PersonID  *uint           `json:"personId,omitempty" example:"1"`
+	TypeDoc   *domain.DocType `json:"typeDoc,omitempty" example:"DNI"`
+	DocNumber *string         `json:"docNumber,omitempty" example:"12345678"`
+	QRToken   *string         `json:"qrToken,omitempty"`
+	Date      *string         `json:"date,omitempty" example:"2024-01-07"` // Formato "YYYY-MM-DD"; por defecto, hoy
+}
+
+func (cr *CheckInRequest) ToDomain() (ports.CheckIn, error) {
+	checkIn := ports.CheckIn{PersonID: cr.PersonID, TypeDoc: cr.TypeDoc, DocNumber: cr.DocNumber, QRToken: cr.QRToken}
+	if cr.Date != nil && *cr.Date != "" {
+		date, err := time.Parse("2006-01-02", *cr.Date)
+		if err != nil {
+			return ports.CheckIn{}, err
+		}
+		checkIn.Date = &date
+	}
+	return checkIn, nil
+}
+
+// AttendanceResponse es el DTO de un registro de asistencia.
+type AttendanceResponse struct {
+	ID          uint                  `json:"id"`
+	EventID     uint                  `json:"eventId"`
+	Person      PersonSummaryResponse `json:"person"`
+	Date        string                `json:"date" example:"2024-01-07"`
+	Method      domain.CheckInMethod  `json:"method" example:"qr"`
+	CheckedInBy *uint                 `json:"checkedInBy,omitempty"`
+	CreatedAt   time.Time             `json:"createdAt"`
+}
+
+func NewAttendanceResponse(attendance *domain.Attendance) AttendanceResponse {
+	return AttendanceResponse{
+		ID:          attendance.ID,
+		EventID:     attendance.EventID,
+		Person:      NewPersonSummaryResponse(&attendance.Person),
+		Date:        attendance.Date.Format("2006-01-02"),
+		Method:      attendance.Method,
+		CheckedInBy: attendance.CheckedInBy,
+		CreatedAt:   attendance.CreatedAt,
+	}
+}
+
+// CredentialResponse contiene la credencial firmada de una persona, a codificar en su QR.
+type CredentialResponse struct {
+	PersonID uint   `json:"personId"`
+	QRToken  string `json:"qrToken"`
+}
+
+// PersonEventAttendanceResponse resume la asistencia de una persona a un evento.
+type PersonEventAttendanceResponse struct {
+	Event         EventResponse `json:"event"`
+	Attended      int           `json:"attended"`
+	Occurrences   int           `json:"occurrences"`
+	CurrentStreak int           `json:"currentStreak"`
+	LongestStreak int           `json:"longestStreak"`
+	LastAttended  string        `json:"lastAttended,omitempty" example:"2024-01-07"`
+}
+
+// PersonAttendanceRecordResponse es una asistencia dentro del reporte de una persona.
+type PersonAttendanceRecordResponse struct {
+	ID        uint                 `json:"id"`
+	EventID   uint                 `json:"eventId"`
+	EventName string               `json:"eventName"`
+	Date      string               `json:"date" example:"2024-01-07"`
+	Method    domain.CheckInMethod `json:"method"`
+}
+
+// PersonAttendanceReportResponse es el reporte de asistencia de una persona.
+type PersonAttendanceReportResponse struct {
+	PersonID uint                             `json:"personId"`
+	From     string                           `json:"from" example:"2024-01-01"`
+	To       string                           `json:"to" example:"2024-03-31"`
+	Total    int                              `json:"total"`
+	Events   []PersonEventAttendanceResponse  `json:"events"`
+	Records  []PersonAttendanceRecordResponse `json:"records"`
+}
+
+func NewPersonAttendanceReportResponse(report *domain.PersonAttendanceReport) PersonAttendanceReportResponse {
+	response := PersonAttendanceReportResponse{
+		PersonID: report.PersonID,
+		From:     report.From.Format("2006-01-02"),
+		To:       lastDayOf(report.To),
+		Total:    report.Total,
+		Events:   make([]PersonEventAttendanceResponse, len(report.Events)),
+		Records:  make([]PersonAttendanceRecordResponse, len(report.Records)),
+	}
+	for i := range report.Events {
+		e := &report.Events[i]
+		response.Events[i] = PersonEventAttendanceResponse{
+			Event:         NewEventResponse(&e.Event),
+			Attended:      e.Attended,
+			Occurrences:   e.Occurrences,
+			CurrentStreak: e.CurrentStreak,
+			LongestStreak: e.LongestStreak,
+			LastAttended:  formatOptionalDate(e.LastAttended),
+		}
+	}
+	for i := range report.Records {
+		r := &report.Records[i]
+		response.Records[i] = PersonAttendanceRecordResponse{
+			ID:        r.ID,
+			EventID:   r.EventID,
+			EventName: r.Event.Name,
+			Date:      r.Date.Format("2006-01-02"),
+			Method:    r.Method,
+		}
+	}
+	return response
+}
+
+// OccurrenceAttendanceResponse es la asistencia a una ocurrencia de un evento.
+type OccurrenceAttendanceResponse struct {
+	Date        string                  `json:"date" example:"2024-01-07"`
+	Count       int                     `json:"count"`
+	Attendees   []PersonSummaryResponse `json:"attendees"`
+	FirstTimers []PersonSummaryResponse `json:"firstTimers"`
+}
+
+// EventAttendanceReportResponse es el reporte de asistencia de un evento, por ocurrencia.
+type EventAttendanceReportResponse struct {
+	Event       EventResponse                  `json:"event"`
+	Occurrences []OccurrenceAttendanceResponse `json:"occurrences"`
+}
+
+func NewEventAttendanceReportResponse(report *domain.EventAttendanceReport) EventAttendanceReportResponse {
+	response := EventAttendanceReportResponse{
+		Event:       NewEventResponse(&report.Event),
+		Occurrences: make([]OccurrenceAttendanceResponse, len(report.Occurrences)),
+	}
+	for i := range report.Occurrences {
+		o := &report.Occurrences[i]
+		response.Occurrences[i] = OccurrenceAttendanceResponse{
+			Date:        o.Date.Format("2006-01-02"),
+			Count:       len(o.Attendees),
+			Attendees:   newPersonSummaryResponses(o.Attendees),
+			FirstTimers: newPersonSummaryResponses(o.FirstTimers),
+		}
+	}
+	return response
+}
+
+// EventAttendanceSummaryResponse es el número de asistentes de cada ocurrencia de un evento.
+type EventAttendanceSummaryResponse struct {
+	Event  EventResponse  `json:"event"`
+	Total  int            `json:"total"`
+	Counts map[string]int `json:"counts"` // Fecha "YYYY-MM-DD" -> asistentes
+}
+
+// PeriodAttendanceReportResponse resume la asistencia a todos los eventos en un periodo.
+type PeriodAttendanceReportResponse struct {
+	From              string                           `json:"from" example:"2024-01-01"`
+	To                string                           `json:"to" example:"2024-03-31"`
+	TotalCheckIns     int                              `json:"totalCheckIns"`
+	UniquePersons     int                              `json:"uniquePersons"`
+	FirstTimeVisitors []PersonSummaryResponse          `json:"firstTimeVisitors"`
+	Events            []EventAttendanceSummaryResponse `json:"events"`
+}
+
+func NewPeriodAttendanceReportResponse(report *domain.PeriodAttendanceReport) PeriodAttendanceReportResponse {
+	response := PeriodAttendanceReportResponse{
+		From:              report.From.Format("2006-01-02"),
+		To:                lastDayOf(report.To),
+		TotalCheckIns:     report.TotalCheckIns,
+		UniquePersons:     report.UniquePersons,
+		FirstTimeVisitors: newPersonSummaryResponses(report.FirstTimeVisitors),
+		Events:            make([]EventAttendanceSummaryResponse, len(report.Events)),
+	}
+	for i := range report.Events {
+		e := &report.Events[i]
+		total := 0
+		for _, count := range e.Counts {
+			total += count
+		}
+		response.Events[i] = EventAttendanceSummaryResponse{Event: NewEventResponse(&e.Event), Total: total, Counts: e.Counts}
+	}
+	return response
+}
+
+func newPersonSummaryResponses(persons []domain.Person) []PersonSummaryResponse {
+	responses := make([]PersonSummaryResponse, len(persons))
+	for i := range persons {
+		responses[i] = NewPersonSummaryResponse(&persons[i])
+	}
+	return responses
+}
+
+// lastDayOf devuelve el último día incluido en un rango que termina (sin incluir) en to.
+func lastDayOf(to time.Time) string {
+	return to.AddDate(0, 0, -1).Format("2006-01-02")
+}
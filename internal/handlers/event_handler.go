@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/ports"
+)
+
+type EventHandler struct {
+	eventService ports.EventService
+}
+
+func NewEventHandler(eventService ports.EventService) *EventHandler {
+	return &EventHandler{eventService: eventService}
+}
+
+// eventErrorStatus traduce los errores de los servicios de eventos y asistencia a códigos HTTP.
+func eventErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrEventNotFound), errors.Is(err, ports.ErrAttendanceNotFound), errors.Is(err, ports.ErrPersonNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrAlreadyCheckedIn):
+		return fiber.StatusConflict
+	case errors.Is(err, ports.ErrInvalidEvent), errors.Is(err, ports.ErrInvalidCheckIn), errors.Is(err, ports.ErrInvalidCredential):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
+// dateRangeQuery lee el rango de días from/to (YYYY-MM-DD, ambos inclusive) y lo devuelve
+// como [from, to). Los servicios interpretan cada día en la zona horaria de la organización.
+// Los valores ausentes toman los días por defecto.
+func dateRangeQuery(c *fiber.Ctx, defaultFrom, defaultTo time.Time) (time.Time, time.Time, error) {
+	parse := func(key string, fallback time.Time) (time.Time, error) {
+		raw := c.Query(key)
+		if raw == "" {
+			return time.Date(fallback.Year(), fallback.Month(), fallback.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+		return time.Parse("2006-01-02", raw)
+	}
+
+	from, err := parse("from", defaultFrom)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := parse("to", defaultTo)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+// CreateEvent godoc
+// @Summary Create an event
+// @Description Creates an event. For recurring events, startsAt is the first occurrence and recurrence is an RRULE subset: FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, BYDAY (weekly only), COUNT and UNTIL (YYYYMMDD). Admin only.
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param event body handlers.EventRequest true "Event"
+// @Success 201 {object} handlers.EventResponse
+// @Failure 400 {object} ErrorResponse "Invalid event"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/events [post]
+func (h *EventHandler) CreateEvent(c *fiber.Ctx) error {
+	var req EventRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	event, err := h.eventService.CreateEvent(req.ToDomain(0), actor)
+	if err != nil {
+		return c.Status(eventErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewEventResponse(event))
+}
+
+// UpdateEvent godoc
+// @Summary Update an event
+// @Description Replaces the data of an event, including its recurrence rule. Admin only.
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param id path int true "Event ID"
+// @Param event body handlers.EventRequest true "Event"
+// @Success 200 {object} handlers.EventResponse
+// @Failure 400 {object} ErrorResponse "Invalid event"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/events/{id} [put]
+func (h *EventHandler) UpdateEvent(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid event ID format"})
+	}
+
+	var req EventRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	event, err := h.eventService.UpdateEvent(req.ToDomain(id), actor)
+	if err != nil {
+		return c.Status(eventErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewEventResponse(event))
+}
+
+// DeleteEvent godoc
+// @Summary Delete an event
+// @Description Deletes an event and all its attendance records. Admin only.
+// @Tags Events
+// @Param id path int true "Event ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/events/{id} [delete]
+func (h *EventHandler) DeleteEvent(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid event ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.eventService.DeleteEvent(id, actor); err != nil {
+		return c.Status(eventErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetEvent godoc
+// @Summary Get an event
+// @Tags Events
+// @Produce json
+// @Param id path int true "Event ID"
+// @Success 200 {object} handlers.EventResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/events/{id} [get]
+func (h *EventHandler) GetEvent(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid event ID format"})
+	}
+
+	event, err := h.eventService.GetEvent(id)
+	if err != nil {
+		return c.Status(eventErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewEventResponse(event))
+}
+
+// ListEvents godoc
+// @Summary List events
+// @Description Returns all events ordered by start date.
+// @Tags Events
+// @Produce json
+// @Success 200 {array} handlers.EventResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/events [get]
+func (h *EventHandler) ListEvents(c *fiber.Ctx) error {
+	events, err := h.eventService.ListEvents()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: "failed to list events"})
+	}
+
+	responseDTOs := make([]EventResponse, len(events))
+	for i := range events {
+		responseDTOs[i] = NewEventResponse(&events[i])
+	}
+	return c.JSON(responseDTOs)
+}
+
+// GetOccurrences godoc
+// @Summary List the occurrences of an event
+// @Description Returns the start of each occurrence of the event between two days, both inclusive. By default, the next 90 days.
+// @Tags Events
+// @Produce json
+// @Param id path int true "Event ID"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Success 200 {object} handlers.OccurrencesResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/events/{id}/occurrences [get]
+func (h *EventHandler) GetOccurrences(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid event ID format"})
+	}
+
+	today := time.Now()
+	from, to, err := dateRangeQuery(c, today, today.AddDate(0, 0, 89))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid date format, use YYYY-MM-DD"})
+	}
+
+	occurrences, err := h.eventService.GetOccurrences(id, from, to)
+	if err != nil {
+		return c.Status(eventErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+	if occurrences == nil {
+		occurrences = []time.Time{}
+	}
+
+	return c.JSON(OccurrencesResponse{EventID: id, Occurrences: occurrences})
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// GroupRequest es el DTO para crear o actualizar un grupo.
+type GroupRequest struct {
+	Name        string           `json:"name" example:"Ministerio de alabanza"`
+	Type        domain.GroupType `json:"type" example:"ministry"`
+	Description string           `json:"description,omitempty"`
+}
+
+func (gr *GroupRequest) ToDomain(id uint) *domain.Group {
+	return &domain.Group{ID: id, Name: gr.Name, Type: gr.Type, Description: gr.Description}
+}
+
+// GroupMemberRequest es una persona a agregar a un grupo.
+type GroupMemberRequest struct {
+	PersonID  uint             `json:"personId" example:"1"`
+	Role      domain.GroupRole `json:"role,omitempty" example:"member"`
+	StartDate *string          `json:"startDate,omitempty" example:"2024-01-15"` // Formato "YYYY-MM-DD"
+	EndDate   *string          `json:"endDate,omitempty"`                        // Formato "YYYY-MM-DD"
+}
+
+// GroupMembersRequest es el DTO para agregar personas a un grupo en lote.
+type GroupMembersRequest struct {
+	Members []GroupMemberRequest `json:"members"`
+}
+
+// ToDomain convierte el lote a membresías del dominio.
+func (gr *GroupMembersRequest) ToDomain() ([]domain.GroupMembership, error) {
+	members := make([]domain.GroupMembership, len(gr.Members))
+	for i, m := range gr.Members {
+		startDate, err := parseOptionalDate(m.StartDate)
+		if err != nil {
+			return nil, err
+		}
+		endDate, err := parseOptionalDate(m.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		members[i] = domain.GroupMembership{PersonID: m.PersonID, Role: m.Role, StartDate: startDate, EndDate: endDate}
+	}
+	return members, nil
+}
+
+// GroupMemberIDsRequest es el DTO para quitar personas de un grupo en lote.
+type GroupMemberIDsRequest struct {
+	PersonIDs []uint `json:"personIds" example:"1,2"`
+}
+
+// GroupMemberResponse es una membresía con los datos básicos de la persona.
+type GroupMemberResponse struct {
+	Person    PersonSummaryResponse `json:"person"`
+	Role      domain.GroupRole      `json:"role" example:"leader"`
+	StartDate string                `json:"startDate,omitempty"`
+	EndDate   string                `json:"endDate,omitempty"`
+	Active    bool                  `json:"active"`
+}
+
+// GroupResponse es el DTO de un grupo. Members solo se incluye al consultar un grupo concreto.
+type GroupResponse struct {
+	ID          uint                  `json:"id"`
+	Name        string                `json:"name"`
+	Type        domain.GroupType      `json:"type"`
+	Description string                `json:"description,omitempty"`
+	Members     []GroupMemberResponse `json:"members,omitempty"`
+	CreatedAt   time.Time             `json:"createdAt"`
+	UpdatedAt   time.Time             `json:"updatedAt"`
+}
+
+func NewGroupResponse(group *domain.Group) GroupResponse {
+	now := time.Now()
+	var members []GroupMemberResponse
+	for i := range group.Members {
+		m := &group.Members[i]
+		members = append(members, GroupMemberResponse{
+			Person:    NewPersonSummaryResponse(&m.Person),
+			Role:      m.Role,
+			StartDate: formatOptionalDate(m.StartDate),
+			EndDate:   formatOptionalDate(m.EndDate),
+			Active:    m.IsActive(now),
+		})
+	}
+	return GroupResponse{
+		ID:          group.ID,
+		Name:        group.Name,
+		Type:        group.Type,
+		Description: group.Description,
+		Members:     members,
+		CreatedAt:   group.CreatedAt,
+		UpdatedAt:   group.UpdatedAt,
+	}
+}
+
+// RemovedMembersResponse indica cuántas personas se quitaron del grupo.
+type RemovedMembersResponse struct {
+	Removed int `json:"removed"`
+}
+
+func parseOptionalDate(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func formatOptionalDate(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format("2006-01-02")
+}
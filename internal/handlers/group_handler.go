@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type GroupHandler struct {
+	groupService ports.GroupService
+}
+
+func NewGroupHandler(groupService ports.GroupService) *GroupHandler {
+	return &GroupHandler{groupService: groupService}
+}
+
+// groupErrorStatus traduce los errores del servicio de grupos a códigos HTTP.
+func groupErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrGroupNotFound), errors.Is(err, ports.ErrPersonNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrGroupExists):
+		return fiber.StatusConflict
+	case errors.Is(err, ports.ErrInvalidGroup):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
+// CreateGroup godoc
+// @Summary Create a group
+// @Description Creates a ministry, class, committee or other group. Admin only.
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param group body handlers.GroupRequest true "Group"
+// @Success 201 {object} handlers.GroupResponse
+// @Failure 400 {object} ErrorResponse "Invalid group"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 409 {object} ErrorResponse "Group name already exists"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/groups [post]
+func (h *GroupHandler) CreateGroup(c *fiber.Ctx) error {
+	var req GroupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	group, err := h.groupService.CreateGroup(req.ToDomain(0), actor)
+	if err != nil {
+		return c.Status(groupErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewGroupResponse(group))
+}
+
+// UpdateGroup godoc
+// @Summary Update a group
+// @Description Updates the name, type and description of a group. Admin only.
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param group body handlers.GroupRequest true "Group"
+// @Success 200 {object} handlers.GroupResponse
+// @Failure 400 {object} ErrorResponse "Invalid group"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Group not found"
+// @Failure 409 {object} ErrorResponse "Group name already exists"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/groups/{id} [put]
+func (h *GroupHandler) UpdateGroup(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid group ID format"})
+	}
+
+	var req GroupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	group, err := h.groupService.UpdateGroup(req.ToDomain(id), actor)
+	if err != nil {
+		return c.Status(groupErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewGroupResponse(group))
+}
+
+// DeleteGroup godoc
+// @Summary Delete a group
+// @Description Deletes a group and all its memberships. Admin only.
+// @Tags Groups
+// @Param id path int true "Group ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Group not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/groups/{id} [delete]
+func (h *GroupHandler) DeleteGroup(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid group ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.groupService.DeleteGroup(id, actor); err != nil {
+		return c.Status(groupErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetGroup godoc
+// @Summary Get a group
+// @Description Returns a group with all its memberships, past and current.
+// @Tags Groups
+// @Produce json
+// @Param id path int true "Group ID"
+// @Success 200 {object} handlers.GroupResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Group not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/groups/{id} [get]
+func (h *GroupHandler) GetGroup(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid group ID format"})
+	}
+
+	group, err := h.groupService.GetGroup(id)
+	if err != nil {
+		return c.Status(groupErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewGroupResponse(group))
+}
+
+// ListGroups godoc
+// @Summary List groups
+// @Description Returns all groups ordered by name, optionally filtered by type.
+// @Tags Groups
+// @Produce json
+// @Param type query string false "Group type (ministry, class, committee, other)"
+// @Success 200 {array} handlers.GroupResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/groups [get]
+func (h *GroupHandler) ListGroups(c *fiber.Ctx) error {
+	groups, err := h.groupService.ListGroups(domain.GroupType(c.Query("type")))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: "failed to list groups"})
+	}
+
+	responseDTOs := make([]GroupResponse, len(groups))
+	for i := range groups {
+		responseDTOs[i] = NewGroupResponse(&groups[i])
+	}
+	return c.JSON(responseDTOs)
+}
+
+// AddMembers godoc
+// @Summary Add members to a group
+// @Description Adds several persons to a group at once. Persons who are already members get their role and dates updated. If any entry is invalid, nothing is saved. Admin only.
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param members body handlers.GroupMembersRequest true "Members to add"
+// @Success 200 {object} handlers.GroupResponse
+// @Failure 400 {object} ErrorResponse "Invalid member"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Group or person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/groups/{id}/members [post]
+func (h *GroupHandler) AddMembers(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid group ID format"})
+	}
+
+	var req GroupMembersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+	members, err := req.ToDomain()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid date format, use YYYY-MM-DD"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	group, err := h.groupService.AddMembers(id, members, actor)
+	if err != nil {
+		return c.Status(groupErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewGroupResponse(group))
+}
+
+// RemoveMembers godoc
+// @Summary Remove members from a group
+// @Description Removes several persons from a group at once. Admin only.
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param members body handlers.GroupMemberIDsRequest true "Persons to remove"
+// @Success 200 {object} handlers.RemovedMembersResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Group not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/groups/{id}/members [delete]
+func (h *GroupHandler) RemoveMembers(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid group ID format"})
+	}
+
+	var req GroupMemberIDsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	removed, err := h.groupService.RemoveMembers(id, req.PersonIDs, actor)
+	if err != nil {
+		return c.Status(groupErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(RemovedMembersResponse{Removed: removed})
+}
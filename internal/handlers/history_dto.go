@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// VersionResponse es el DTO para una entrada del historial de cambios.
+type VersionResponse struct {
+	ID         uint                          `json:"id"`
+	EntityType domain.VersionEntity          `json:"entityType" example:"person"`
+	EntityID   uint                          `json:"entityId"`
+	PersonID   uint                          `json:"personId"`
+	Version    int                           `json:"version"`
+	Action     domain.VersionAction          `json:"action" example:"update"`
+	ChangedBy  *uint                         `json:"changedBy,omitempty"`
+	Changes    map[string]domain.FieldChange `json:"changes"`
+	CreatedAt  time.Time                     `json:"createdAt"`
+}
+
+// NewVersionResponse convierte una domain.EntityVersion a su DTO de respuesta.
+func NewVersionResponse(version *domain.EntityVersion) VersionResponse {
+	return VersionResponse{
+		ID:         version.ID,
+		EntityType: version.EntityType,
+		EntityID:   version.EntityID,
+		PersonID:   version.PersonID,
+		Version:    version.Version,
+		Action:     version.Action,
+		ChangedBy:  version.ChangedBy,
+		Changes:    version.Changes,
+		CreatedAt:  version.CreatedAt,
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/ports"
+)
+
+type HistoryHandler struct {
+	historyService ports.HistoryService
+}
+
+func NewHistoryHandler(historyService ports.HistoryService) *HistoryHandler {
+	return &HistoryHandler{historyService: historyService}
+}
+
+// GetPersonHistory godoc
+// @Summary Get the change history of a person
+// @Description Returns every recorded version of the person and its addresses and phones, newest first, with the user who made each change. Available to the owner, to users with an active delegation for the person and to admins.
+// @Tags Person
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {array} handlers.VersionResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/history [get]
+func (h *HistoryHandler) GetPersonHistory(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	versions, err := h.historyService.GetPersonHistory(id, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrPersonNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{Error: err.Error()})
+		case errors.Is(err, ports.ErrForbidden):
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	responseDTOs := make([]VersionResponse, len(versions))
+	for i := range versions {
+		responseDTOs[i] = NewVersionResponse(&versions[i])
+	}
+
+	return c.JSON(responseDTOs)
+}
+
+// RevertVersion godoc
+// @Summary Revert an entity to a previous version (Admin)
+// @Description Restores the person, address or phone to the state recorded in the given version. The revert itself is stored as a new version.
+// @Tags Admin
+// @Produce json
+// @Param versionId path int true "Version ID"
+// @Success 200 {object} handlers.VersionResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Version not found"
+// @Failure 409 {object} ErrorResponse "Conflict - Document already exists"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/history/{versionId}/revert [post]
+func (h *HistoryHandler) RevertVersion(c *fiber.Ctx) error {
+	versionID, err := parseIDParam(c, "versionId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid version ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	version, err := h.historyService.RevertToVersion(versionID, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrVersionNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{Error: err.Error()})
+		case errors.Is(err, ports.ErrForbidden):
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		case errors.Is(err, ports.ErrPersonDocumentExists):
+			return c.Status(fiber.StatusConflict).JSON(ErrorResponse{Error: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewVersionResponse(version))
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// HouseholdRequest es el DTO para crear o actualizar un hogar.
+// Al crear, el jefe de hogar se agrega como miembro; al actualizar, ya debe serlo.
+type HouseholdRequest struct {
+	Name         string `json:"name" example:"Familia Quispe"`
+	HeadPersonID *uint  `json:"headPersonId,omitempty" example:"1"`
+}
+
+func (hr *HouseholdRequest) ToDomain(id uint) *domain.Household {
+	return &domain.Household{ID: id, Name: hr.Name, HeadPersonID: hr.HeadPersonID}
+}
+
+// HouseholdMembersRequest es el DTO para agregar personas a un hogar.
+type HouseholdMembersRequest struct {
+	PersonIDs []uint `json:"personIds" example:"2,3"`
+}
+
+// HouseholdResponse es el DTO de un hogar con sus miembros y contactos compartidos.
+type HouseholdResponse struct {
+	ID           uint                    `json:"id"`
+	Name         string                  `json:"name"`
+	HeadPersonID *uint                   `json:"headPersonId,omitempty"`
+	Members      []PersonSummaryResponse `json:"members"`
+	Addresses    []AddressDTO            `json:"addresses"`
+	Phones       []PhoneDTO              `json:"phones"`
+	CreatedAt    time.Time               `json:"createdAt"`
+	UpdatedAt    time.Time               `json:"updatedAt"`
+}
+
+func NewHouseholdResponse(household *domain.Household) HouseholdResponse {
+	members := make([]PersonSummaryResponse, len(household.Members))
+	for i := range household.Members {
+		members[i] = NewPersonSummaryResponse(&household.Members[i])
+	}
+
+	addresses := householdAddressDTOs(household.Addresses)
+	if addresses == nil {
+		addresses = []AddressDTO{}
+	}
+	phones := householdPhoneDTOs(household.Phones)
+	if phones == nil {
+		phones = []PhoneDTO{}
+	}
+
+	return HouseholdResponse{
+		ID:           household.ID,
+		Name:         household.Name,
+		HeadPersonID: household.HeadPersonID,
+		Members:      members,
+		Addresses:    addresses,
+		Phones:       phones,
+		CreatedAt:    household.CreatedAt,
+		UpdatedAt:    household.UpdatedAt,
+	}
+}
+
+// NewHouseholdAddressDTO devuelve la dirección del hogar con el mismo formato que las
+// direcciones de las personas.
+func NewHouseholdAddressDTO(address *domain.HouseholdAddress) AddressDTO {
+	householdID := address.HouseholdID
+	return AddressDTO{
+		ID:          address.ID,
+		HouseholdID: &householdID,
+		Address:     address.Address,
+		Street:      address.Street,
+		Number:      address.Number,
+		Reference:   address.Reference,
+		District:    address.District,
+		Province:    address.Province,
+		Department:  address.Department,
+		Ubigeo:      address.Ubigeo,
+		Country:     address.Country,
+		PostalCode:  address.PostalCode,
+		Latitude:    address.Latitude,
+		Longitude:   address.Longitude,
+	}
+}
+
+func householdAddressDTOs(addresses []domain.HouseholdAddress) []AddressDTO {
+	var dtos []AddressDTO
+	for i := range addresses {
+		dtos = append(dtos, NewHouseholdAddressDTO(&addresses[i]))
+	}
+	return dtos
+}
+
+func householdPhoneDTOs(phones []domain.HouseholdPhone) []PhoneDTO {
+	var dtos []PhoneDTO
+	for _, phone := range phones {
+		householdID := phone.HouseholdID
+		dtos = append(dtos, PhoneDTO{ID: phone.ID, HouseholdID: &householdID, Phone: phone.Phone})
+	}
+	return dtos
+}
+
+// HouseholdMigrationResponse es el resultado de agrupar en hogares las direcciones compartidas.
+type HouseholdMigrationResponse struct {
+	DryRun            bool `json:"dryRun"`
+	HouseholdsCreated int  `json:"householdsCreated"`
+	PersonsGrouped    int  `json:"personsGrouped"`
+	AddressesMerged   int  `json:"addressesMerged"`
+}
+
+func NewHouseholdMigrationResponse(report *domain.HouseholdMigrationReport) HouseholdMigrationResponse {
+	return HouseholdMigrationResponse{
+		DryRun:            report.DryRun,
+		HouseholdsCreated: report.HouseholdsCreated,
+		PersonsGrouped:    report.PersonsGrouped,
+		AddressesMerged:   report.AddressesMerged,
+	}
+}
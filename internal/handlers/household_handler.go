@@ -0,0 +1,439 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/ports"
+)
+
+type HouseholdHandler struct {
+	householdService ports.HouseholdService
+}
+
+func NewHouseholdHandler(householdService ports.HouseholdService) *HouseholdHandler {
+	return &HouseholdHandler{householdService: householdService}
+}
+
+// householdErrorStatus traduce los errores del servicio de hogares a códigos HTTP.
+func householdErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrHouseholdNotFound), errors.Is(err, ports.ErrHouseholdContactNotFound), errors.Is(err, ports.ErrPersonNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrInvalidHousehold):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
+// CreateHousehold godoc
+// @Summary Create a household
+// @Description Creates a household. If headPersonId is given, that person becomes its first member. Admin only.
+// @Tags Households
+// @Accept json
+// @Produce json
+// @Param household body handlers.HouseholdRequest true "Household"
+// @Success 201 {object} handlers.HouseholdResponse
+// @Failure 400 {object} ErrorResponse "Invalid household"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/households [post]
+func (h *HouseholdHandler) CreateHousehold(c *fiber.Ctx) error {
+	var req HouseholdRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	household, err := h.householdService.CreateHousehold(req.ToDomain(0), actor)
+	if err != nil {
+		return c.Status(householdErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewHouseholdResponse(household))
+}
+
+// UpdateHousehold godoc
+// @Summary Update a household
+// @Description Updates the name and head of a household. The head must already be a member. Admin only.
+// @Tags Households
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param household body handlers.HouseholdRequest true "Household"
+// @Success 200 {object} handlers.HouseholdResponse
+// @Failure 400 {object} ErrorResponse "Invalid household"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Household not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/households/{id} [put]
+func (h *HouseholdHandler) UpdateHousehold(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid household ID format"})
+	}
+
+	var req HouseholdRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	household, err := h.householdService.UpdateHousehold(req.ToDomain(id), actor)
+	if err != nil {
+		return c.Status(householdErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewHouseholdResponse(household))
+}
+
+// DeleteHousehold godoc
+// @Summary Delete a household
+// @Description Deletes a household and its shared addresses and phones. Its members are kept, without a household. Admin only.
+// @Tags Households
+// @Param id path int true "Household ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Household not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/households/{id} [delete]
+func (h *HouseholdHandler) DeleteHousehold(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid household ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.householdService.DeleteHousehold(id, actor); err != nil {
+		return c.Status(householdErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetHousehold godoc
+// @Summary Get a household
+// @Description Returns a household with its members and shared addresses and phones.
+// @Tags Households
+// @Produce json
+// @Param id path int true "Household ID"
+// @Success 200 {object} handlers.HouseholdResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Household not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/households/{id} [get]
+func (h *HouseholdHandler) GetHousehold(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid household ID format"})
+	}
+
+	household, err := h.householdService.GetHousehold(id)
+	if err != nil {
+		return c.Status(householdErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewHouseholdResponse(household))
+}
+
+// SearchHouseholds godoc
+// @Summary Search households
+// @Description Searches households by name, by the name of any member or by a shared address.
+// @Tags Households
+// @Produce json
+// @Param q query string false "Search term"
+// @Success 200 {array} handlers.HouseholdResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/households [get]
+func (h *HouseholdHandler) SearchHouseholds(c *fiber.Ctx) error {
+	households, err := h.householdService.SearchHouseholds(c.Query("q"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: "failed to search households"})
+	}
+
+	responseDTOs := make([]HouseholdResponse, len(households))
+	for i := range households {
+		responseDTOs[i] = NewHouseholdResponse(&households[i])
+	}
+	return c.JSON(responseDTOs)
+}
+
+// AddMembers godoc
+// @Summary Add members to a household
+// @Description Adds persons to the household. A person can only belong to one household. Admin only.
+// @Tags Households
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param members body handlers.HouseholdMembersRequest true "Persons to add"
+// @Success 200 {object} handlers.HouseholdResponse
+// @Failure 400 {object} ErrorResponse "Invalid household"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Household or person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/households/{id}/members [post]
+func (h *HouseholdHandler) AddMembers(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid household ID format"})
+	}
+
+	var req HouseholdMembersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	household, err := h.householdService.AddMembers(id, req.PersonIDs, actor)
+	if err != nil {
+		return c.Status(householdErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewHouseholdResponse(household))
+}
+
+// RemoveMember godoc
+// @Summary Remove a member from a household
+// @Description Removes the person from the household. If they were the head, the household is left without one. Admin only.
+// @Tags Households
+// @Param id path int true "Household ID"
+// @Param personId path int true "Person ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Household or member not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/households/{id}/members/{personId} [delete]
+func (h *HouseholdHandler) RemoveMember(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid household ID format"})
+	}
+	personID, err := parseIDParam(c, "personId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.householdService.RemoveMember(id, personID, actor); err != nil {
+		return c.Status(householdErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// AddAddress godoc
+// @Summary Add a shared address to a household
+// @Description Adds an address shared by every member. Members with an address of their own keep using theirs. Admin only.
+// @Tags Households
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param address body handlers.AddressDTO true "Address"
+// @Success 201 {object} handlers.AddressDTO
+// @Failure 400 {object} ErrorResponse "Invalid household"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Household not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/households/{id}/addresses [post]
+func (h *HouseholdHandler) AddAddress(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid household ID format"})
+	}
+
+	var req AddressDTO
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	address, err := h.householdService.AddAddress(id, req.Address, actor)
+	if err != nil {
+		return c.Status(householdErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewHouseholdAddressDTO(address))
+}
+
+// RemoveAddress godoc
+// @Summary Remove a shared address from a household
+// @Description Admin only.
+// @Tags Households
+// @Param id path int true "Household ID"
+// @Param addressId path int true "Household address ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Address not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/households/{id}/addresses/{addressId} [delete]
+func (h *HouseholdHandler) RemoveAddress(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid household ID format"})
+	}
+	addressID, err := parseIDParam(c, "addressId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid address ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.householdService.RemoveAddress(id, addressID, actor); err != nil {
+		return c.Status(householdErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// AddPhone godoc
+// @Summary Add a shared phone to a household
+// @Description Adds a phone shared by every member. Members with a phone of their own keep using theirs. Admin only.
+// @Tags Households
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param phone body handlers.PhoneDTO true "Phone"
+// @Success 201 {object} handlers.PhoneDTO
+// @Failure 400 {object} ErrorResponse "Invalid household"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Household not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/households/{id}/phones [post]
+func (h *HouseholdHandler) AddPhone(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid household ID format"})
+	}
+
+	var req PhoneDTO
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	phone, err := h.householdService.AddPhone(id, req.Phone, actor)
+	if err != nil {
+		return c.Status(householdErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(PhoneDTO{ID: phone.ID, HouseholdID: &phone.HouseholdID, Phone: phone.Phone})
+}
+
+// RemovePhone godoc
+// @Summary Remove a shared phone from a household
+// @Description Admin only.
+// @Tags Households
+// @Param id path int true "Household ID"
+// @Param phoneId path int true "Household phone ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Phone not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/households/{id}/phones/{phoneId} [delete]
+func (h *HouseholdHandler) RemovePhone(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid household ID format"})
+	}
+	phoneID, err := parseIDParam(c, "phoneId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid phone ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.householdService.RemovePhone(id, phoneID, actor); err != nil {
+		return c.Status(householdErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// MigrateSharedAddresses godoc
+// @Summary Group persons sharing an address into households
+// @Description Persons without a household whose addresses are identical (ignoring case and spacing) are grouped into a new household, and their copies are replaced by one shared address. Use dryRun=true to only get the report. Admin only.
+// @Tags Households
+// @Produce json
+// @Param dryRun query bool false "Only report what would change"
+// @Success 200 {object} handlers.HouseholdMigrationResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/households/migrate [post]
+func (h *HouseholdHandler) MigrateSharedAddresses(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	report, err := h.householdService.MigrateSharedAddresses(c.QueryBool("dryRun"), actor)
+	if err != nil {
+		return c.Status(householdErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewHouseholdMigrationResponse(report))
+}
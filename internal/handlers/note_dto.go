@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// NoteRequest es el DTO para crear o actualizar una nota de seguimiento.
+type NoteRequest struct {
+	Type         domain.NoteType       `json:"type" example:"visit"`                   // conversation, visit, call, prayer, follow_up u other
+	Visibility   domain.NoteVisibility `json:"visibility" example:"restricted"`        // private, restricted (por defecto) o public
+	RestrictedTo domain.Role           `json:"restrictedTo,omitempty" example:"admin"` // Rol que puede leer una nota restricted
+	Content      string                `json:"content" example:"Visita en casa, pidió oración por su salud."`
+	Date         *time.Time            `json:"date,omitempty" example:"2024-03-10T16:00:00-05:00"` // Por defecto, ahora
+	DueDate      *string               `json:"dueDate,omitempty" example:"2024-03-17"`             // YYYY-MM-DD; convierte la nota en tarea
+	AssigneeID   *uint                 `json:"assigneeId,omitempty"`                               // Usuario responsable de la tarea
+}
+
+func (nr *NoteRequest) ToDomain(id, personID uint) (*domain.Note, error) {
+	dueDate, err := parseOptionalDate(nr.DueDate)
+	if err != nil {
+		return nil, err
+	}
+	note := &domain.Note{
+		ID:           id,
+		PersonID:     personID,
+		Type:         nr.Type,
+		Visibility:   nr.Visibility,
+		RestrictedTo: nr.RestrictedTo,
+		Content:      nr.Content,
+		DueDate:      dueDate,
+		AssigneeID:   nr.AssigneeID,
+	}
+	if nr.Date != nil {
+		note.Date = *nr.Date
+	}
+	return note, nil
+}
+
+// NoteResponse es el DTO de una nota de seguimiento.
+type NoteResponse struct {
+	ID           uint                  `json:"id"`
+	PersonID     uint                  `json:"personId"`
+	AuthorID     uint                  `json:"authorId"`
+	Type         domain.NoteType       `json:"type"`
+	Visibility   domain.NoteVisibility `json:"visibility"`
+	RestrictedTo domain.Role           `json:"restrictedTo,omitempty"`
+	Content      string                `json:"content"`
+	Date         time.Time             `json:"date"`
+	DueDate      string                `json:"dueDate,omitempty"`
+	AssigneeID   *uint                 `json:"assigneeId,omitempty"`
+	CompletedAt  *time.Time            `json:"completedAt,omitempty"`
+	CreatedAt    time.Time             `json:"createdAt"`
+	UpdatedAt    time.Time             `json:"updatedAt"`
+}
+
+func NewNoteResponse(note *domain.Note) NoteResponse {
+	return NoteResponse{
+		ID:           note.ID,
+		PersonID:     note.PersonID,
+		AuthorID:     note.AuthorID,
+		Type:         note.Type,
+		Visibility:   note.Visibility,
+		RestrictedTo: note.RestrictedTo,
+		Content:      note.Content,
+		Date:         note.Date,
+		DueDate:      formatOptionalDate(note.DueDate),
+		AssigneeID:   note.AssigneeID,
+		CompletedAt:  note.CompletedAt,
+		CreatedAt:    note.CreatedAt,
+		UpdatedAt:    note.UpdatedAt,
+	}
+}
+
+func newNoteResponses(notes []domain.Note) []NoteResponse {
+	responses := make([]NoteResponse, len(notes))
+	for i := range notes {
+		responses[i] = NewNoteResponse(&notes[i])
+	}
+	return responses
+}
+
+// TimelineEntryResponse es un elemento de la línea de tiempo: una nota (kind "note")
+// o un cambio en los datos de la persona (kind "change").
+type TimelineEntryResponse struct {
+	Kind   string           `json:"kind" example:"note"`
+	At     time.Time        `json:"at"`
+	Note   *NoteResponse    `json:"note,omitempty"`
+	Change *VersionResponse `json:"change,omitempty"`
+}
+
+func NewTimelineEntryResponse(entry *domain.TimelineEntry) TimelineEntryResponse {
+	response := TimelineEntryResponse{At: entry.At}
+	if entry.Note != nil {
+		note := NewNoteResponse(entry.Note)
+		response.Kind = "note"
+		response.Note = &note
+	} else if entry.Version != nil {
+		change := NewVersionResponse(entry.Version)
+		response.Kind = "change"
+		response.Change = &change
+	}
+	return response
+}
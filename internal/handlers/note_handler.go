@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/ports"
+)
+
+type NoteHandler struct {
+	noteService ports.NoteService
+}
+
+func NewNoteHandler(noteService ports.NoteService) *NoteHandler {
+	return &NoteHandler{noteService: noteService}
+}
+
+// noteErrorStatus traduce los errores del servicio de notas a códigos HTTP.
+func noteErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrNoteNotFound), errors.Is(err, ports.ErrPersonNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrInvalidNote):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
+// ListNotes godoc
+// @Summary List the notes of a person
+// @Description Returns the follow-up notes of the person that the caller may read, newest first. Private notes are only visible to their author and assignee; restricted notes to admins and the chosen role. Only the owner, a delegate or an admin can list them.
+// @Tags Notes
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {array} handlers.NoteResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/notes [get]
+func (h *NoteHandler) ListNotes(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	notes, err := h.noteService.ListNotes(personID, actor)
+	if err != nil {
+		return c.Status(noteErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(newNoteResponses(notes))
+}
+
+// CreateNote godoc
+// @Summary Add a note to a person
+// @Description Records a conversation, visit or other interaction with the person. A note with a due date and an assignee becomes a follow-up task. Admin only.
+// @Tags Notes
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param note body handlers.NoteRequest true "Note"
+// @Success 201 {object} handlers.NoteResponse
+// @Failure 400 {object} ErrorResponse "Invalid note"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/notes [post]
+func (h *NoteHandler) CreateNote(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	var req NoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+	note, err := req.ToDomain(0, personID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid dueDate, use YYYY-MM-DD"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	note, err = h.noteService.CreateNote(note, actor)
+	if err != nil {
+		return c.Status(noteErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewNoteResponse(note))
+}
+
+// UpdateNote godoc
+// @Summary Update a note
+// @Description Replaces the content, type, visibility, date and task data of a note. Only its author can edit it.
+// @Tags Notes
+// @Accept json
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param note body handlers.NoteRequest true "Note"
+// @Success 200 {object} handlers.NoteResponse
+// @Failure 400 {object} ErrorResponse "Invalid note"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Note not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/notes/{id} [put]
+func (h *NoteHandler) UpdateNote(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid note ID format"})
+	}
+
+	var req NoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+	note, err := req.ToDomain(id, 0)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid dueDate, use YYYY-MM-DD"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	note, err = h.noteService.UpdateNote(note, actor)
+	if err != nil {
+		return c.Status(noteErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewNoteResponse(note))
+}
+
+// DeleteNote godoc
+// @Summary Delete a note
+// @Description Deletes a note. Its author or an admin who can read it may delete it.
+// @Tags Notes
+// @Param id path int true "Note ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Note not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/notes/{id} [delete]
+func (h *NoteHandler) DeleteNote(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid note ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.noteService.DeleteNote(id, actor); err != nil {
+		return c.Status(noteErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// CompleteNote godoc
+// @Summary Complete a follow-up task
+// @Description Marks a note with a due date as done. Its author or assignee can complete it.
+// @Tags Notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 200 {object} handlers.NoteResponse
+// @Failure 400 {object} ErrorResponse "The note is not a task"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Note not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/notes/{id}/complete [post]
+func (h *NoteHandler) CompleteNote(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid note ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	note, err := h.noteService.CompleteNote(id, actor)
+	if err != nil {
+		return c.Status(noteErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewNoteResponse(note))
+}
+
+// ListAssignedNotes godoc
+// @Summary List my follow-up tasks
+// @Description Returns the notes assigned to the caller as tasks, by due date. Completed tasks are only included with all=true.
+// @Tags Notes
+// @Produce json
+// @Param all query bool false "Include completed tasks"
+// @Success 200 {array} handlers.NoteResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/notes/assigned [get]
+func (h *NoteHandler) ListAssignedNotes(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	notes, err := h.noteService.ListAssignedNotes(actor, !c.QueryBool("all"))
+	if err != nil {
+		return c.Status(noteErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(newNoteResponses(notes))
+}
+
+// GetTimeline godoc
+// @Summary Get the interaction timeline of a person
+// @Description Merges the notes the caller may read with the change history of the person and its addresses and phones, newest first. Only the owner, a delegate or an admin can read it.
+// @Tags Notes
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {array} handlers.TimelineEntryResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/timeline [get]
+func (h *NoteHandler) GetTimeline(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	timeline, err := h.noteService.GetTimeline(personID, actor)
+	if err != nil {
+		return c.Status(noteErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	responses := make([]TimelineEntryResponse, len(timeline))
+	for i := range timeline {
+		responses[i] = NewTimelineEntryResponse(&timeline[i])
+	}
+	return c.JSON(responses)
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/riada2/internal/core/domain"
@@ -20,79 +22,107 @@ type PersonRequest struct {
 	TypeDoc    *domain.DocType `json:"typeDoc,omitempty"`
 	Email      *string         `json:"email,omitempty"`
 	Photo      *string         `json:"photo,omitempty"`
-	Addresses  []AddressDTO    `json:"addresses,omitempty"`
-	Phones     []PhoneDTO      `json:"phones,omitempty"`
+	// Addresses y Phones reemplazan por completo los contactos de la persona al actualizar:
+	// los que traen ID se modifican, los nuevos se agregan y los que faltan se eliminan.
+	// Si se omiten, los contactos no cambian.
+	Addresses []AddressDTO `json:"addresses,omitempty"`
+	Phones    []PhoneDTO   `json:"phones,omitempty"`
+	// CustomFields son los valores de los campos personalizados, por clave.
+	CustomFields map[string]any `json:"customFields,omitempty" swaggertype:"object"`
 }
 
 // ToDomain convierte el DTO PersonRequest a la entidad del dominio domain.Person.
 // Realiza la conversión de tipos necesarios, como parsear la fecha de nacimiento.
+// De los campos personalizados solo se valida la forma; el servicio los valida
+// contra sus definiciones.
 func (pr *PersonRequest) ToDomain() (*domain.Person, error) {
 	var birthday *time.Time
 	if pr.Birthday != nil && *pr.Birthday != "" {
 		parsedBirthday, err := time.Parse("2006-01-02", *pr.Birthday)
 		if err != nil {
-			return nil, err
+			return nil, errors.New("invalid birthday format, use YYYY-MM-DD")
 		}
 		birthday = &parsedBirthday
 	}
 
+	var customFields domain.CustomFieldValues
+	for key, value := range pr.CustomFields {
+		if !domain.IsValidCustomFieldKey(key) {
+			return nil, fmt.Errorf("invalid custom field key %q", key)
+		}
+		switch value.(type) {
+		case nil, string, float64, bool:
+		default:
+			return nil, fmt.Errorf("custom field %q must be a text, number, boolean or null", key)
+		}
+		if customFields == nil {
+			customFields = make(domain.CustomFieldValues)
+		}
+		customFields[key] = value
+	}
+
 	var id uint
 	if pr.ID != nil {
 		id = *pr.ID
 	}
 
-	// Convertir DTOs de dirección a modelos de dominio
+	// Convertir DTOs de dirección a modelos de dominio. Una lista vacía se conserva
+	// para distinguir "eliminar todas" de "no cambiar" (nil) al actualizar.
 	var addresses []domain.Address
 	if pr.Addresses != nil {
+		addresses = make([]domain.Address, 0, len(pr.Addresses))
 		for _, addrDTO := range pr.Addresses {
-			addresses = append(addresses, domain.Address{
-				ID:      addrDTO.ID,
-				Address: addrDTO.Address,
-			})
+			address := addrDTO.ToDomain()
+			address.PersonID = 0 // La dirección se asocia a la persona que se guarda.
+			addresses = append(addresses, address)
 		}
 	}
 
 	// Convertir DTOs de teléfono a modelos de dominio
 	var phones []domain.Phone
 	if pr.Phones != nil {
+		phones = make([]domain.Phone, 0, len(pr.Phones))
 		for _, phoneDTO := range pr.Phones {
-			phones = append(phones, domain.Phone{
-				ID:    phoneDTO.ID,
-				Phone: phoneDTO.Phone,
-			})
+			phone := phoneDTO.ToDomain()
+			phone.PersonID = 0 // El teléfono se asocia a la persona que se guarda.
+			phones = append(phones, phone)
 		}
 	}
 
 	return &domain.Person{
-		ID:         id,
-		Name:       pr.Name,
-		MiddleName: pr.MiddleName,
-		LastName:   pr.LastName,
-		Sex:        pr.Sex,
-		Birthday:   birthday,
-		DocNumber:  pr.DocNumber,
-		TypeDoc:    pr.TypeDoc,
-		Email:      pr.Email,
-		Photo:      pr.Photo,
-		Addresses:  addresses,
-		Phones:     phones,
+		ID:           id,
+		Name:         pr.Name,
+		MiddleName:   pr.MiddleName,
+		LastName:     pr.LastName,
+		Sex:          pr.Sex,
+		Birthday:     birthday,
+		DocNumber:    pr.DocNumber,
+		TypeDoc:      pr.TypeDoc,
+		Email:        pr.Email,
+		Photo:        pr.Photo,
+		Addresses:    addresses,
+		Phones:       phones,
+		CustomFields: customFields,
 	}, nil
 }
 
 // PersonResponse es el DTO para enviar la información de una persona en las respuestas HTTP.
 type PersonResponse struct {
-	ID         uint            `json:"id"`
-	Name       string          `json:"name"`
-	MiddleName string          `json:"middleName"`
-	LastName   string          `json:"lastName"`
-	Sex        domain.Sex      `json:"sex"`
-	Birthday   string          `json:"birthday,omitempty"` // Se envía en formato "YYYY-MM-DD"
-	DocNumber  *string         `json:"docNumber,omitempty"`
-	TypeDoc    *domain.DocType `json:"typeDoc,omitempty"`
-	Email      *string         `json:"email,omitempty"`
-	Photo      *string         `json:"photo,omitempty"`
-	Addresses  []AddressDTO    `json:"addresses,omitempty"`
-	Phones     []PhoneDTO      `json:"phones,omitempty"`
+	ID           uint                     `json:"id"`
+	Name         string                   `json:"name"`
+	MiddleName   string                   `json:"middleName"`
+	LastName     string                   `json:"lastName"`
+	Sex          domain.Sex               `json:"sex"`
+	Birthday     string                   `json:"birthday,omitempty"` // Se envía en formato "YYYY-MM-DD"
+	DocNumber    *string                  `json:"docNumber,omitempty"`
+	TypeDoc      *domain.DocType          `json:"typeDoc,omitempty"`
+	Email        *string                  `json:"email,omitempty"`
+	Photo        *string                  `json:"photo,omitempty"`
+	HouseholdID  *uint                    `json:"householdId,omitempty"`
+	Addresses    []AddressDTO             `json:"addresses,omitempty"`
+	Phones       []PhoneDTO               `json:"phones,omitempty"`
+	Tags         []string                 `json:"tags,omitempty"`
+	CustomFields domain.CustomFieldValues `json:"customFields,omitempty" swaggertype:"object"`
 }
 
 // NewPersonResponse es una función constructora que convierte una entidad
@@ -107,12 +137,10 @@ func NewPersonResponse(person *domain.Person) PersonResponse {
 	// Convertir modelos de dominio de dirección a DTOs
 	var addressDTOs []AddressDTO
 	if person.Addresses != nil {
-		for _, addr := range person.Addresses {
-			addressDTOs = append(addressDTOs, AddressDTO{
-				ID:       addr.ID,
-				PersonID: person.ID,
-				Address:  addr.Address,
-			})
+		for i := range person.Addresses {
+			addressDTO := NewAddressDTO(&person.Addresses[i])
+			addressDTO.PersonID = person.ID
+			addressDTOs = append(addressDTOs, addressDTO)
 		}
 	}
 
@@ -120,26 +148,47 @@ func NewPersonResponse(person *domain.Person) PersonResponse {
 	var phoneDTOs []PhoneDTO
 	if person.Phones != nil {
 		for _, phone := range person.Phones {
-			phoneDTOs = append(phoneDTOs, PhoneDTO{
-				ID:       phone.ID,
-				PersonID: person.ID,
-				Phone:    phone.Phone,
-			})
+			phone.PersonID = person.ID
+			phoneDTOs = append(phoneDTOs, NewPhoneDTO(&phone))
+		}
+	}
+
+	// Si la persona no tiene direcciones o teléfonos propios, se usan los de su hogar.
+	if household := person.Household; household != nil {
+		if len(addressDTOs) == 0 {
+			addressDTOs = householdAddressDTOs(household.Addresses)
 		}
+		if len(phoneDTOs) == 0 {
+			phoneDTOs = householdPhoneDTOs(household.Phones)
+		}
+	}
+
+	var tags []string
+	for _, tag := range person.Tags {
+		tags = append(tags, tag.Name)
 	}
 
 	return PersonResponse{
-		ID:         person.ID,
-		Name:       person.Name,
-		MiddleName: person.MiddleName,
-		LastName:   person.LastName,
-		Sex:        person.Sex,
-		Birthday:   birthdayStr,
-		DocNumber:  person.DocNumber,
-		TypeDoc:    person.TypeDoc,
-		Email:      person.Email,
-		Photo:      person.Photo,
-		Addresses:  addressDTOs,
-		Phones:     phoneDTOs,
+		ID:           person.ID,
+		Name:         person.Name,
+		MiddleName:   person.MiddleName,
+		LastName:     person.LastName,
+		Sex:          person.Sex,
+		Birthday:     birthdayStr,
+		DocNumber:    person.DocNumber,
+		TypeDoc:      person.TypeDoc,
+		Email:        person.Email,
+		Photo:        person.Photo,
+		HouseholdID:  person.HouseholdID,
+		Addresses:    addressDTOs,
+		Phones:       phoneDTOs,
+		Tags:         tags,
+		CustomFields: person.CustomFields,
 	}
 }
+
+// ReorderRequest es el nuevo orden de las direcciones o teléfonos de una persona:
+// todos sus IDs, cada uno una sola vez.
+type ReorderRequest struct {
+	IDs []uint `json:"ids" example:"3,1,2"`
+}
@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/csv"
 	"errors"
+	"io"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/domain"
 	"github.com/riada2/internal/core/ports"
 )
 
@@ -18,7 +23,7 @@ func NewPersonHandler(personService ports.PersonService) *PersonHandler {
 
 // CreateOrUpdatePersonForUser godoc
 // @Summary Create or update own person information
-// @Description Create or update person information for the authenticated user.
+// @Description Create or update person information for the authenticated user. On update, addresses and phones, when sent, replace the stored ones as a whole: entries with an id are updated, new ones are added and missing ones are removed, all in one transaction.
 // @Tags Person
 // @Accept json
 // @Produce json
@@ -26,6 +31,8 @@ func NewPersonHandler(personService ports.PersonService) *PersonHandler {
 // @Success 200 {object} handlers.PersonResponse
 // @Failure 400 {object} ErrorResponse "Bad Request"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not Found"
 // @Failure 409 {object} ErrorResponse "Conflict - Document already exists"
 // @Failure 500 {object} ErrorResponse "Internal Server Error"
 // @Security ApiKeyAuth
@@ -38,29 +45,121 @@ func (h *PersonHandler) CreateOrUpdatePersonForUser(c *fiber.Ctx) error {
 
 	person, err := req.ToDomain()
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid birthday format, use YYYY-MM-DD"})
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
 	}
 
-	userID, ok := c.Locals("userID").(float64)
+	actor, ok := actorFromContext(c)
 	if !ok {
 		// This should ideally not happen if the auth middleware is working correctly.
 		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
 	}
 
-	userIDUint := uint(userID)
+	userIDUint := actor.UserID
 	person.UserID = &userIDUint
 
-	updatedPerson, err := h.personService.CreateOrUpdatePersonForUser(person)
+	updatedPerson, err := h.personService.CreateOrUpdatePersonForUser(person, actor)
 	if err != nil {
-		if errors.Is(err, ports.ErrPersonDocumentExists) {
-			return c.Status(fiber.StatusConflict).JSON(ErrorResponse{Error: err.Error()})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+		return c.Status(personErrorStatus(err)).JSON(newErrorResponse(err))
 	}
 
 	return c.Status(fiber.StatusOK).JSON(NewPersonResponse(updatedPerson))
 }
 
+// personErrorStatus traduce los errores del servicio de personas a códigos HTTP.
+func personErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrPersonNotFound), errors.Is(err, ports.ErrAddressNotFound), errors.Is(err, ports.ErrPhoneNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrPersonDocumentExists), errors.Is(err, ports.ErrPhoneExists):
+		return fiber.StatusConflict
+	case errors.Is(err, ports.ErrInvalidCustomField), errors.Is(err, ports.ErrInvalidAddress), errors.Is(err, ports.ErrInvalidPhone),
+		errors.Is(err, ports.ErrInvalidPerson), errors.Is(err, ports.ErrContactLimitReached):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
+// GetPerson godoc
+// @Summary Get a person
+// @Description Returns a person record with its addresses and phones. Available to the linked user, to users with an active delegation for the person and to admins.
+// @Tags Person
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {object} handlers.PersonResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id} [get]
+func (h *PersonHandler) GetPerson(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	person, err := h.personService.GetPerson(id, actor)
+	if err != nil {
+		return c.Status(personErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewPersonResponse(person))
+}
+
+// UpdatePerson godoc
+// @Summary Update a person
+// @Description Replaces the personal data of an existing person. When sent, addresses and phones replace the stored ones as a whole: entries with an id are updated, new ones are added and missing ones are removed, all in one transaction. Available to the linked user, to users with an active delegation for the person and to admins; the change is recorded in the history with the user who made it.
+// @Tags Person
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param person body handlers.PersonRequest true "Person information"
+// @Success 200 {object} handlers.PersonResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 409 {object} ErrorResponse "Conflict - Document already exists"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id} [put]
+func (h *PersonHandler) UpdatePerson(c *fiber.Ctx) error {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	var req PersonRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+	person, err := req.ToDomain()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+	person.ID = id
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	person, err = h.personService.UpdatePerson(person, actor)
+	if err != nil {
+		return c.Status(personErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+
+	return c.JSON(NewPersonResponse(person))
+}
+
 // CreatePersonByAdmin godoc
 // @Summary Create a new person record (Admin)
 // @Description Create a new person record, not necessarily linked to a user.
@@ -84,24 +183,21 @@ func (h *PersonHandler) CreatePersonByAdmin(c *fiber.Ctx) error {
 
 	person, err := req.ToDomain()
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid birthday format, use YYYY-MM-DD"})
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
 	}
 
 	// Asignar el ID del administrador que está creando el registro.
-	userID, ok := c.Locals("userID").(float64)
+	actor, ok := actorFromContext(c)
 	if !ok {
 		// No debería ocurrir si el middleware de autenticación funciona.
 		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
 	}
-	userIDUint := uint(userID)
+	userIDUint := actor.UserID
 	person.UserID = &userIDUint
 
-	createdPerson, err := h.personService.CreatePerson(person)
+	createdPerson, err := h.personService.CreatePerson(person, actor)
 	if err != nil {
-		if errors.Is(err, ports.ErrPersonDocumentExists) {
-			return c.Status(fiber.StatusConflict).JSON(ErrorResponse{Error: err.Error()})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+		return c.Status(personErrorStatus(err)).JSON(newErrorResponse(err))
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(NewPersonResponse(createdPerson))
@@ -131,10 +227,18 @@ func (h *PersonHandler) DeletePerson(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
 	}
 
-	if err := h.personService.DeletePerson(uint(id)); err != nil {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.personService.DeletePerson(uint(id), actor); err != nil {
 		if errors.Is(err, ports.ErrPersonNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{Error: err.Error()})
 		}
+		if errors.Is(err, ports.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
 	}
 
@@ -143,10 +247,14 @@ func (h *PersonHandler) DeletePerson(c *fiber.Ctx) error {
 
 // SearchPersons godoc
 // @Summary Search persons
-// @Description Search for persons by a single search term. The search is performed on the full name and document number.
+// @Description Search for persons by a single search term. The search is performed on the full name, the document number and, when the term looks like a phone, the phone numbers in any format. Results can be narrowed to the current members of a group and to persons having every given tag.
 // @Tags Person
 // @Produce json
-// @Param q query string false "Search term"
+// @Param q query string false "Search term: name, document number or phone in any format"
+// @Param groupId query int false "Only current members of this group"
+// @Param tag query []string false "Only persons with all these tags (repeat or comma-separate)" collectionFormat(multi)
+// @Param cf.key query string false "Only persons whose custom field <key> has this value, e.g. cf.occupation=teacher"
+// @Param ubigeo query string false "Only persons with an address in this department (2 digits), province (4) or district (6)"
 // @Success 200 {array} handlers.PersonResponse
 // @Failure 400 {object} ErrorResponse "Bad Request"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
@@ -154,10 +262,16 @@ func (h *PersonHandler) DeletePerson(c *fiber.Ctx) error {
 // @Security ApiKeyAuth
 // @Router /protected/person/search [get]
 func (h *PersonHandler) SearchPersons(c *fiber.Ctx) error {
-	searchTerm := c.Query("q")
+	filter, err := personFilterFromQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid groupId"})
+	}
 
-	persons, err := h.personService.SearchPersons(searchTerm)
+	persons, err := h.personService.SearchPersons(filter)
 	if err != nil {
+		if errors.Is(err, ports.ErrInvalidCustomField) || errors.Is(err, ports.ErrInvalidAddress) {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
 	}
 
@@ -169,3 +283,135 @@ func (h *PersonHandler) SearchPersons(c *fiber.Ctx) error {
 
 	return c.JSON(responseDTOs)
 }
+
+// ExportPersons godoc
+// @Summary Export persons as CSV (Admin)
+// @Description Exports every person matching the filters as a CSV file. Accepts the same filters as the search, without its result limit.
+// @Tags Person
+// @Produce text/csv
+// @Param q query string false "Search term: name, document number or phone in any format"
+// @Param groupId query int false "Only current members of this group"
+// @Param tag query []string false "Only persons with all these tags (repeat or comma-separate)" collectionFormat(multi)
+// @Param cf.key query string false "Only persons whose custom field <key> has this value, e.g. cf.occupation=teacher"
+// @Param ubigeo query string false "Only persons with an address in this department (2 digits), province (4) or district (6)"
+// @Success 200 {file} file "CSV file"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/export [get]
+func (h *PersonHandler) ExportPersons(c *fiber.Ctx) error {
+	filter, err := personFilterFromQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid groupId"})
+	}
+
+	persons, err := h.personService.ExportPersons(filter)
+	if err != nil {
+		if errors.Is(err, ports.ErrInvalidCustomField) || errors.Is(err, ports.ErrInvalidAddress) {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	var buf bytes.Buffer
+	if err := writePersonsCSV(&buf, persons); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: "failed to write CSV"})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="persons.csv"`)
+	return c.Send(buf.Bytes())
+}
+
+// personFilterFromQuery arma el filtro de búsqueda a partir de los parámetros q, groupId, tag, cf.<clave> y ubigeo.
+// Las etiquetas pueden repetirse (?tag=a&tag=b) o separarse por comas (?tag=a,b).
+func personFilterFromQuery(c *fiber.Ctx) (ports.PersonFilter, error) {
+	filter := ports.PersonFilter{Term: c.Query("q"), Ubigeo: c.Query("ubigeo")}
+
+	groupID, err := optionalUintQuery(c, "groupId")
+	if err != nil {
+		return filter, err
+	}
+	filter.GroupID = groupID
+
+	for _, value := range c.Context().QueryArgs().PeekMulti("tag") {
+		filter.Tags = append(filter.Tags, strings.Split(string(value), ",")...)
+	}
+
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		if name, ok := strings.CutPrefix(string(key), "cf."); ok {
+			if filter.CustomFields == nil {
+				filter.CustomFields = make(map[string]string)
+			}
+			filter.CustomFields[name] = string(value)
+		}
+	})
+	return filter, nil
+}
+
+// writePersonsCSV escribe una fila por persona. Las direcciones, teléfonos y
+// etiquetas se separan con "; " y, como en la API, se usan los del hogar si la
+// persona no tiene propios.
+func writePersonsCSV(w io.Writer, persons []domain.Person) error {
+	writer := csv.NewWriter(w)
+	header := []string{"id", "name", "middleName", "lastName", "sex", "birthday", "typeDoc", "docNumber", "email", "householdId", "addresses", "ubigeos", "phones", "tags"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for i := range persons {
+		p := NewPersonResponse(&persons[i])
+
+		var typeDoc, householdID string
+		if p.TypeDoc != nil {
+			typeDoc = string(*p.TypeDoc)
+		}
+		if p.HouseholdID != nil {
+			householdID = strconv.FormatUint(uint64(*p.HouseholdID), 10)
+		}
+		addresses := make([]string, len(p.Addresses))
+		var ubigeos []string
+		for j, address := range p.Addresses {
+			addresses[j] = address.Address
+			if address.Ubigeo != "" {
+				ubigeos = append(ubigeos, address.Ubigeo)
+			}
+		}
+		phones := make([]string, len(p.Phones))
+		for j, phone := range p.Phones {
+			phones[j] = phone.Phone
+		}
+
+		record := []string{
+			strconv.FormatUint(uint64(p.ID), 10),
+			p.Name,
+			p.MiddleName,
+			p.LastName,
+			string(p.Sex),
+			p.Birthday,
+			typeDoc,
+			valueOrEmpty(p.DocNumber),
+			valueOrEmpty(p.Email),
+			householdID,
+			strings.Join(addresses, "; "),
+			strings.Join(ubigeos, "; "),
+			strings.Join(phones, "; "),
+			strings.Join(p.Tags, "; "),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -1,8 +1,66 @@
 package handlers
 
+import "github.com/riada2/internal/core/domain"
+
 // PhoneDTO es el DTO para la información del teléfono.
+// En las respuestas, HouseholdID indica que el teléfono es el compartido del hogar.
+// El número se acepta en cualquier formato y se devuelve en E.164 (por ejemplo "+51999888777").
 type PhoneDTO struct {
-	ID       uint   `json:"id,omitempty"`
-	PersonID uint   `json:"personId,omitempty"`
-	Phone    string `json:"phone" binding:"required"`
+	ID          uint   `json:"id,omitempty"`
+	PersonID    uint   `json:"personId,omitempty"`
+	HouseholdID *uint  `json:"householdId,omitempty"`
+	Phone       string `json:"phone" binding:"required" example:"999 888 777"`
+	Type        string `json:"type,omitempty" enums:"mobile,home,work,whatsapp" example:"mobile"`
+	IsPrimary   bool   `json:"isPrimary"`
+	SortOrder   int    `json:"sortOrder"` // Solo lectura; se cambia al reordenar
+}
+
+// ToDomain convierte el DTO en un teléfono del dominio.
+func (d PhoneDTO) ToDomain() domain.Phone {
+	return domain.Phone{
+		ID:        d.ID,
+		PersonID:  d.PersonID,
+		Phone:     d.Phone,
+		Type:      domain.PhoneType(d.Type),
+		IsPrimary: d.IsPrimary,
+	}
+}
+
+// NewPhoneDTO construye el DTO de respuesta de un teléfono.
+func NewPhoneDTO(p *domain.Phone) PhoneDTO {
+	return PhoneDTO{
+		ID:        p.ID,
+		PersonID:  p.PersonID,
+		Phone:     p.Phone,
+		Type:      string(p.Type),
+		IsPrimary: p.IsPrimary,
+		SortOrder: p.SortOrder,
+	}
+}
+
+func newPhoneDTOs(phones []domain.Phone) []PhoneDTO {
+	dtos := make([]PhoneDTO, len(phones))
+	for i := range phones {
+		dtos[i] = NewPhoneDTO(&phones[i])
+	}
+	return dtos
+}
+
+// PhoneMigrationResponse es el resultado de la normalización de los teléfonos guardados.
+type PhoneMigrationResponse struct {
+	DryRun     bool `json:"dryRun"`
+	Scanned    int  `json:"scanned"`
+	Normalized int  `json:"normalized"`
+	Invalid    int  `json:"invalid"`
+	Duplicates int  `json:"duplicates"`
+}
+
+func NewPhoneMigrationResponse(report *domain.PhoneMigrationReport) PhoneMigrationResponse {
+	return PhoneMigrationResponse{
+		DryRun:     report.DryRun,
+		Scanned:    report.Scanned,
+		Normalized: report.Normalized,
+		Invalid:    report.Invalid,
+		Duplicates: report.Duplicates,
+	}
 }
@@ -5,7 +5,6 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/riada2/internal/core/domain"
 	"github.com/riada2/internal/core/ports"
 )
 
@@ -23,29 +22,34 @@ func (h *PhoneHandler) CreateOrUpdatePhone(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
 	}
 
-	phone := &domain.Phone{
-		ID:       req.ID,
-		PersonID: req.PersonID,
-		Phone:    req.Phone,
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
 	}
 
-	println("phone", phone.PersonID)
-	savedPhone, err := h.phoneService.CreateOrUpdatePhone(phone)
+	phone := req.ToDomain()
+	savedPhone, err := h.phoneService.CreateOrUpdatePhone(&phone, actor)
 
 	if err != nil {
 		if errors.Is(err, ports.ErrPersonNotFound) {
 			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "la persona especificada no existe"})
 		}
+		if errors.Is(err, ports.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		}
+		if errors.Is(err, ports.ErrInvalidPhone) {
+			return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		}
+		if errors.Is(err, ports.ErrPhoneExists) || errors.Is(err, ports.ErrContactLimitReached) {
+			return c.Status(fiber.StatusConflict).JSON(newErrorResponse(err))
+		}
+		if errors.Is(err, ports.ErrPhoneNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{Error: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
 	}
 
-	responseDTO := PhoneDTO{
-		ID:       savedPhone.ID,
-		PersonID: savedPhone.PersonID,
-		Phone:    savedPhone.Phone,
-	}
-
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": responseDTO})
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": NewPhoneDTO(savedPhone)})
 }
 
 func (h *PhoneHandler) DeletePhone(c *fiber.Ctx) error {
@@ -59,15 +63,283 @@ func (h *PhoneHandler) DeletePhone(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid phone ID format"})
 	}
 
-	userID, ok := c.Locals("userID").(float64)
+	actor, ok := actorFromContext(c)
 	if !ok {
 		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
 	}
-	userIDUint := uint(userID)
 
-	if err := h.phoneService.DeletePhoneForUser(uint(id), userIDUint); err != nil {
+	if err := h.phoneService.DeletePhoneForUser(uint(id), actor); err != nil {
+		if errors.Is(err, ports.ErrPhoneNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{Error: err.Error()})
+		}
+		if errors.Is(err, ports.ErrInvalidPerson) {
+			return c.Status(fiber.StatusConflict).JSON(newErrorResponse(err))
+		}
+		if errors.Is(err, ports.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// NormalizePhones godoc
+// @Summary Normalize stored phones to E.164
+// @Description Converts every stored phone to E.164 using the default country code, fills in the missing phone types and marks a primary phone for persons without one. Invalid numbers and numbers that repeat another phone of the same person are left unchanged and reported. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param dryRun query bool false "Only report what would change"
+// @Success 200 {object} handlers.PhoneMigrationResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/phones/normalize [post]
+func (h *PhoneHandler) NormalizePhones(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	report, err := h.phoneService.NormalizePhones(c.QueryBool("dryRun"), actor)
+	if err != nil {
+		if errors.Is(err, ports.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewPhoneMigrationResponse(report))
+}
+
+// phoneErrorStatus traduce los errores de las rutas anidadas de teléfonos a códigos HTTP.
+func phoneErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrPersonNotFound), errors.Is(err, ports.ErrPhoneNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrInvalidPhone):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ports.ErrContactLimitReached), errors.Is(err, ports.ErrInvalidPerson), errors.Is(err, ports.ErrPhoneExists):
+		return fiber.StatusConflict
+	}
+	return fiber.StatusInternalServerError
+}
+
+// ListPersonPhones godoc
+// @Summary List the phones of a person
+// @Description Returns the person's own phones in their sort order. Only the owner or an admin can list them.
+// @Tags Person
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {array} handlers.PhoneDTO
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/phones [get]
+func (h *PhoneHandler) ListPersonPhones(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID"})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	phones, err := h.phoneService.ListPhones(personID, actor)
+	if err != nil {
+		return c.Status(phoneErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.JSON(newPhoneDTOs(phones))
+}
+
+// GetPersonPhone godoc
+// @Summary Get a phone of a person
+// @Description Returns one of the person's own phones. Only the owner or an admin can read it.
+// @Tags Person
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param phoneId path int true "Phone ID"
+// @Success 200 {object} handlers.PhoneDTO
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person or phone not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/phones/{phoneId} [get]
+func (h *PhoneHandler) GetPersonPhone(c *fiber.Ctx) error {
+	personID, phoneID, err := parsePersonContactParams(c, "phoneId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	phone, err := h.phoneService.GetPhone(personID, phoneID, actor)
+	if err != nil {
+		return c.Status(phoneErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.JSON(NewPhoneDTO(phone))
+}
+
+// CreatePersonPhone godoc
+// @Summary Add a phone to a person
+// @Description Adds a phone at the end of the person's phones. Only the owner or an admin can add it.
+// @Tags Person
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param phone body handlers.PhoneDTO true "Phone"
+// @Success 201 {object} handlers.PhoneDTO
+// @Failure 400 {object} ErrorResponse "Invalid phone"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 409 {object} ErrorResponse "Phone limit reached or duplicate phone"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/phones [post]
+func (h *PhoneHandler) CreatePersonPhone(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID"})
+	}
+	var req PhoneDTO
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	phone := req.ToDomain()
+	phone.ID, phone.PersonID = 0, personID
+	saved, err := h.phoneService.CreateOrUpdatePhone(&phone, actor)
+	if err != nil {
+		return c.Status(phoneErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.Status(fiber.StatusCreated).JSON(NewPhoneDTO(saved))
+}
+
+// UpdatePersonPhone godoc
+// @Summary Update a phone of a person
+// @Description Replaces one of the person's own phones; its sort order is kept. Only the owner or an admin can update it.
+// @Tags Person
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param phoneId path int true "Phone ID"
+// @Param phone body handlers.PhoneDTO true "Phone"
+// @Success 200 {object} handlers.PhoneDTO
+// @Failure 400 {object} ErrorResponse "Invalid phone"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person or phone not found"
+// @Failure 409 {object} ErrorResponse "Phone limit reached or duplicate phone"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/phones/{phoneId} [put]
+func (h *PhoneHandler) UpdatePersonPhone(c *fiber.Ctx) error {
+	personID, phoneID, err := parsePersonContactParams(c, "phoneId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+	var req PhoneDTO
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	phone := req.ToDomain()
+	phone.ID, phone.PersonID = phoneID, personID
+	saved, err := h.phoneService.CreateOrUpdatePhone(&phone, actor)
+	if err != nil {
+		return c.Status(phoneErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.JSON(NewPhoneDTO(saved))
+}
+
+// DeletePersonPhone godoc
+// @Summary Delete a phone of a person
+// @Description Deletes one of the person's own phones. Only the owner or an admin can delete it.
+// @Tags Person
+// @Param id path int true "Person ID"
+// @Param phoneId path int true "Phone ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person or phone not found"
+// @Failure 409 {object} ErrorResponse "The phone is required"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/phones/{phoneId} [delete]
+func (h *PhoneHandler) DeletePersonPhone(c *fiber.Ctx) error {
+	personID, phoneID, err := parsePersonContactParams(c, "phoneId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	// Se comprueba primero que el teléfono sea de la persona de la ruta.
+	if _, err := h.phoneService.GetPhone(personID, phoneID, actor); err != nil {
+		return c.Status(phoneErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	if err := h.phoneService.DeletePhoneForUser(phoneID, actor); err != nil {
+		return c.Status(phoneErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ReorderPersonPhones godoc
+// @Summary Reorder the phones of a person
+// @Description Sets the sort order of the person's phones. The list must contain every phone ID of the person exactly once. Only the owner or an admin can reorder them.
+// @Tags Person
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param order body handlers.ReorderRequest true "Phone IDs in the new order"
+// @Success 200 {array} handlers.PhoneDTO
+// @Failure 400 {object} ErrorResponse "Invalid order"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/phones/order [put]
+func (h *PhoneHandler) ReorderPersonPhones(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID"})
+	}
+	var req ReorderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	phones, err := h.phoneService.ReorderPhones(personID, req.IDs, actor)
+	if err != nil {
+		return c.Status(phoneErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.JSON(newPhoneDTOs(phones))
+}
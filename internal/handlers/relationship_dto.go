@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// RelationshipRequest es el DTO para crear una relación desde la persona de la ruta.
+// RelatedPersonID es el <Type> de esa persona. Si Reciprocal no se envía, se crea
+// también la relación inversa.
+type RelationshipRequest struct {
+	RelatedPersonID uint                    `json:"relatedPersonId" example:"2"`
+	Type            domain.RelationshipType `json:"type" example:"spouse"`
+	Since           *string                 `json:"since,omitempty" example:"2010-05-20"` // Formato "YYYY-MM-DD"
+	Reciprocal      *bool                   `json:"reciprocal,omitempty"`
+}
+
+// ToDomain convierte el DTO a domain.Relationship para la persona indicada.
+func (rr *RelationshipRequest) ToDomain(personID uint) (*domain.Relationship, error) {
+	var since *time.Time
+	if rr.Since != nil && *rr.Since != "" {
+		parsed, err := time.Parse("2006-01-02", *rr.Since)
+		if err != nil {
+			return nil, err
+		}
+		since = &parsed
+	}
+
+	return &domain.Relationship{
+		PersonID:        personID,
+		RelatedPersonID: rr.RelatedPersonID,
+		Type:            rr.Type,
+		Since:           since,
+	}, nil
+}
+
+// RelationshipResponse es el DTO de una relación.
+type RelationshipResponse struct {
+	ID              uint                    `json:"id"`
+	PersonID        uint                    `json:"personId"`
+	RelatedPersonID uint                    `json:"relatedPersonId"`
+	Type            domain.RelationshipType `json:"type" example:"parent"`
+	Since           string                  `json:"since,omitempty"`
+}
+
+func NewRelationshipResponse(relationship *domain.Relationship) RelationshipResponse {
+	var since string
+	if relationship.Since != nil {
+		since = relationship.Since.Format("2006-01-02")
+	}
+	return RelationshipResponse{
+		ID:              relationship.ID,
+		PersonID:        relationship.PersonID,
+		RelatedPersonID: relationship.RelatedPersonID,
+		Type:            relationship.Type,
+		Since:           since,
+	}
+}
+
+// PersonSummaryResponse es una versión reducida de PersonResponse, sin contactos,
+// para listados y árboles.
+type PersonSummaryResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	MiddleName string     `json:"middleName"`
+	LastName   string     `json:"lastName"`
+	Sex        domain.Sex `json:"sex"`
+	Birthday   string     `json:"birthday,omitempty"`
+}
+
+func NewPersonSummaryResponse(person *domain.Person) PersonSummaryResponse {
+	var birthday string
+	if person.Birthday != nil {
+		birthday = person.Birthday.Format("2006-01-02")
+	}
+	return PersonSummaryResponse{
+		ID:         person.ID,
+		Name:       person.Name,
+		MiddleName: person.MiddleName,
+		LastName:   person.LastName,
+		Sex:        person.Sex,
+		Birthday:   birthday,
+	}
+}
+
+// HouseholdMemberResponse es una persona del entorno familiar y su relación.
+type HouseholdMemberResponse struct {
+	Relation domain.RelationshipType `json:"relation" example:"child"`
+	Person   PersonSummaryResponse   `json:"person"`
+}
+
+// FamilyTreeNodeResponse es el DTO del árbol genealógico.
+type FamilyTreeNodeResponse struct {
+	Person   PersonSummaryResponse    `json:"person"`
+	Spouse   *PersonSummaryResponse   `json:"spouse,omitempty"`
+	Parents  []FamilyTreeNodeResponse `json:"parents,omitempty"`
+	Children []FamilyTreeNodeResponse `json:"children,omitempty"`
+}
+
+func NewFamilyTreeNodeResponse(node *domain.FamilyTreeNode) FamilyTreeNodeResponse {
+	response := FamilyTreeNodeResponse{Person: NewPersonSummaryResponse(&node.Person)}
+	if node.Spouse != nil {
+		spouse := NewPersonSummaryResponse(node.Spouse)
+		response.Spouse = &spouse
+	}
+	for i := range node.Parents {
+		response.Parents = append(response.Parents, NewFamilyTreeNodeResponse(&node.Parents[i]))
+	}
+	for i := range node.Children {
+		response.Children = append(response.Children, NewFamilyTreeNodeResponse(&node.Children[i]))
+	}
+	return response
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/ports"
+)
+
+type RelationshipHandler struct {
+	relationshipService ports.RelationshipService
+}
+
+func NewRelationshipHandler(relationshipService ports.RelationshipService) *RelationshipHandler {
+	return &RelationshipHandler{relationshipService: relationshipService}
+}
+
+// relationshipErrorStatus traduce los errores del servicio de relaciones a códigos HTTP.
+func relationshipErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrPersonNotFound), errors.Is(err, ports.ErrRelationshipNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrRelationshipExists):
+		return fiber.StatusConflict
+	case errors.Is(err, ports.ErrInvalidRelationship):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
+// AddRelationship godoc
+// @Summary Add a relationship to a person
+// @Description Links the person in the path with another person. relatedPersonId is the <type> of the person (e.g. type=parent means relatedPersonId is their parent). The inverse relationship is created too unless reciprocal is false. Owner, delegate or admin of both persons only.
+// @Tags Relationships
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param relationship body handlers.RelationshipRequest true "Relationship"
+// @Success 201 {object} handlers.RelationshipResponse
+// @Failure 400 {object} ErrorResponse "Invalid relationship"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 409 {object} ErrorResponse "Relationship already exists"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/relationships [post]
+func (h *RelationshipHandler) AddRelationship(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	var req RelationshipRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	relationship, err := req.ToDomain(personID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid since format, use YYYY-MM-DD"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	reciprocal := req.Reciprocal == nil || *req.Reciprocal
+	created, err := h.relationshipService.AddRelationship(relationship, reciprocal, actor)
+	if err != nil {
+		return c.Status(relationshipErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewRelationshipResponse(created))
+}
+
+// GetRelationships godoc
+// @Summary List the relationships of a person
+// @Description Returns every relationship the person takes part in, in either direction. Owner, delegate or admin only.
+// @Tags Relationships
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {array} handlers.RelationshipResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/relationships [get]
+func (h *RelationshipHandler) GetRelationships(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	relationships, err := h.relationshipService.GetRelationships(personID, actor)
+	if err != nil {
+		return c.Status(relationshipErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	responseDTOs := make([]RelationshipResponse, len(relationships))
+	for i := range relationships {
+		responseDTOs[i] = NewRelationshipResponse(&relationships[i])
+	}
+	return c.JSON(responseDTOs)
+}
+
+// RemoveRelationship godoc
+// @Summary Remove a relationship
+// @Description Deletes the relationship and its inverse, if any. Owner, delegate or admin only.
+// @Tags Relationships
+// @Param id path int true "Person ID"
+// @Param relationshipId path int true "Relationship ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Relationship not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/relationships/{relationshipId} [delete]
+func (h *RelationshipHandler) RemoveRelationship(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+	relationshipID, err := parseIDParam(c, "relationshipId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid relationship ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	if err := h.relationshipService.RemoveRelationship(personID, relationshipID, actor); err != nil {
+		return c.Status(relationshipErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetHousehold godoc
+// @Summary Get the household of a person
+// @Description Returns the immediate family of the person (spouse, parents, children, siblings, guardians and wards) with their relation to them. Owner, delegate or admin only.
+// @Tags Relationships
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {array} handlers.HouseholdMemberResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/household [get]
+func (h *RelationshipHandler) GetHousehold(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	members, err := h.relationshipService.GetHousehold(personID, actor)
+	if err != nil {
+		return c.Status(relationshipErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	responseDTOs := make([]HouseholdMemberResponse, len(members))
+	for i := range members {
+		responseDTOs[i] = HouseholdMemberResponse{
+			Relation: members[i].Relation,
+			Person:   NewPersonSummaryResponse(&members[i].Person),
+		}
+	}
+	return c.JSON(responseDTOs)
+}
+
+// ExportFamilyTree godoc
+// @Summary Export the family tree of a person
+// @Description Returns the ancestors and descendants of the person as a nested tree, up to the given depth. Owner, delegate or admin only.
+// @Tags Relationships
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param depth query int false "Generations in each direction (default 3, max 10)"
+// @Success 200 {object} handlers.FamilyTreeNodeResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/family-tree [get]
+func (h *RelationshipHandler) ExportFamilyTree(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	tree, err := h.relationshipService.GetFamilyTree(personID, c.QueryInt("depth"), actor)
+	if err != nil {
+		return c.Status(relationshipErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewFamilyTreeNodeResponse(tree))
+}
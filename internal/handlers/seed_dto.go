@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// SeedRequest describe la carga de datos de demostración.
+type SeedRequest struct {
+	Seed     int64  `json:"seed" example:"1"`        // La misma semilla genera las mismas personas
+	Persons  int    `json:"persons" example:"200"`   // Entre 1 y 10000
+	Accounts int    `json:"accounts" example:"20"`   // Las primeras personas con cuenta de usuario
+	Password string `json:"password" example:"demo"` // Contraseña de esas cuentas
+}
+
+func (r *SeedRequest) ToOptions() ports.SeedOptions {
+	return ports.SeedOptions{Seed: r.Seed, Persons: r.Persons, Accounts: r.Accounts, Password: r.Password}
+}
+
+// SeedResponse es el resultado de una carga de datos de demostración.
+type SeedResponse struct {
+	Seed     int64    `json:"seed"`
+	Persons  int      `json:"persons"`
+	Created  int      `json:"created"`
+	Existing int      `json:"existing"`
+	Accounts int      `json:"accounts"`
+	Failed   int      `json:"failed"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+func NewSeedResponse(report *domain.SeedReport) SeedResponse {
+	return SeedResponse{
+		Seed:     report.Seed,
+		Persons:  report.Persons,
+		Created:  report.Created,
+		Existing: report.Existing,
+		Accounts: report.Accounts,
+		Failed:   report.Failed,
+		Errors:   report.Errors,
+	}
+}
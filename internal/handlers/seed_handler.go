@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/ports"
+)
+
+type SeedHandler struct {
+	seedService ports.SeedService
+}
+
+func NewSeedHandler(seedService ports.SeedService) *SeedHandler {
+	return &SeedHandler{seedService: seedService}
+}
+
+// Seed godoc
+// @Summary Load demo data (development only)
+// @Description Generates Peruvian demo persons with DNI, address, phones and birthday, and optionally linked user accounts. The same seed always generates the same persons, so repeating a load only adds the missing ones. Only available when SEED_ENDPOINT_ENABLED is true. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param options body handlers.SeedRequest true "Seed, volume and accounts"
+// @Success 200 {object} handlers.SeedResponse
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/seed [post]
+func (h *SeedHandler) Seed(c *fiber.Ctx) error {
+	var req SeedRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	report, err := h.seedService.Seed(req.ToOptions(), actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrForbidden):
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		case errors.Is(err, ports.ErrInvalidSeedOptions):
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+	return c.JSON(NewSeedResponse(report))
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+)
+
+// SettingResponse es una clave de configuración con su valor vigente y su valor por defecto.
+type SettingResponse struct {
+	Key          string     `json:"key" example:"contacts.max_phones"`
+	Value        string     `json:"value" example:"3"`
+	DefaultValue string     `json:"defaultValue" example:"2"`
+	Description  string     `json:"description"`
+	UpdatedBy    *uint      `json:"updatedBy,omitempty"`
+	UpdatedAt    *time.Time `json:"updatedAt,omitempty"`
+}
+
+func NewSettingResponse(info *domain.SettingInfo) SettingResponse {
+	return SettingResponse{
+		Key:          info.Key,
+		Value:        info.Value,
+		DefaultValue: info.DefaultValue,
+		Description:  info.Description,
+		UpdatedBy:    info.UpdatedBy,
+		UpdatedAt:    info.UpdatedAt,
+	}
+}
+
+// UpdateSettingsRequest asigna valores a las claves de configuración. Se aceptan textos,
+// números, booleanos y listas de textos, por ejemplo:
+// {"contacts.max_phones": 3, "person.required_fields": ["lastName", "phone"]}.
+type UpdateSettingsRequest map[string]any
+
+// ToValues convierte los valores a texto, que es como se guarda la configuración.
+func (r UpdateSettingsRequest) ToValues() (map[string]string, error) {
+	values := make(map[string]string, len(r))
+	for key, raw := range r {
+		switch value := raw.(type) {
+		case string:
+			values[key] = value
+		case float64:
+			values[key] = strconv.FormatFloat(value, 'f', -1, 64)
+		case bool:
+			values[key] = strconv.FormatBool(value)
+		case []any:
+			items := make([]string, len(value))
+			for i, item := range value {
+				text, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s: list items must be strings", key)
+				}
+				items[i] = text
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			return nil, fmt.Errorf("%s: unsupported value", key)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type SettingHandler struct {
+	settingsService ports.SettingsService
+}
+
+func NewSettingHandler(settingsService ports.SettingsService) *SettingHandler {
+	return &SettingHandler{settingsService: settingsService}
+}
+
+// settingErrorStatus traduce los errores del servicio de configuración a códigos HTTP.
+func settingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ports.ErrInvalidSetting):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
+
+// ListSettings godoc
+// @Summary List organization settings
+// @Description Returns every runtime setting (contact limits, primary phone rule and required person fields) with its current and default value. Admin only.
+// @Tags Admin
+// @Produce json
+// @Success 200 {array} handlers.SettingResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/settings [get]
+func (h *SettingHandler) ListSettings(c *fiber.Ctx) error {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	infos, err := h.settingsService.ListSettings(actor)
+	if err != nil {
+		return c.Status(settingErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.JSON(newSettingResponses(infos))
+}
+
+// UpdateSettings godoc
+// @Summary Update organization settings
+// @Description Sets the given settings. Changes apply immediately; if any value is invalid, nothing is saved. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param settings body handlers.UpdateSettingsRequest true "Values by setting key"
+// @Success 200 {array} handlers.SettingResponse
+// @Failure 400 {object} ErrorResponse "Unknown setting or invalid value"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/admin/settings [put]
+func (h *SettingHandler) UpdateSettings(c *fiber.Ctx) error {
+	var req UpdateSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+	values, err := req.ToValues()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	infos, err := h.settingsService.UpdateSettings(values, actor)
+	if err != nil {
+		return c.Status(settingErrorStatus(err)).JSON(newErrorResponse(err))
+	}
+	return c.JSON(newSettingResponses(infos))
+}
+
+func newSettingResponses(infos []domain.SettingInfo) []SettingResponse {
+	responseDTOs := make([]SettingResponse, len(infos))
+	for i := range infos {
+		responseDTOs[i] = NewSettingResponse(&infos[i])
+	}
+	return responseDTOs
+}
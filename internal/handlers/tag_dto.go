@@ -0,0 +1,22 @@
+package handlers
+
+import "github.com/riada2/internal/core/domain"
+
+// PersonTagsRequest es el DTO para reemplazar las etiquetas de una persona.
+type PersonTagsRequest struct {
+	Tags []string `json:"tags" example:"jóvenes,voluntario"`
+}
+
+// TagResponse es el DTO de una etiqueta.
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func NewTagResponses(tags []domain.Tag) []TagResponse {
+	responses := make([]TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = TagResponse{ID: tag.ID, Name: tag.Name}
+	}
+	return responses
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/ports"
+)
+
+type TagHandler struct {
+	tagService ports.TagService
+}
+
+func NewTagHandler(tagService ports.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+// ListTags godoc
+// @Summary List tags
+// @Description Returns every tag in use, ordered by name.
+// @Tags Tags
+// @Produce json
+// @Success 200 {array} handlers.TagResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/tags [get]
+func (h *TagHandler) ListTags(c *fiber.Ctx) error {
+	tags, err := h.tagService.ListTags()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: "failed to list tags"})
+	}
+	return c.JSON(NewTagResponses(tags))
+}
+
+// SetPersonTags godoc
+// @Summary Set the tags of a person
+// @Description Replaces the tags of the person. Tags are free-form; they are stored in lower case and created on first use. Send an empty list to remove all tags. Admin only.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param tags body handlers.PersonTagsRequest true "Tags"
+// @Success 200 {array} handlers.TagResponse
+// @Failure 400 {object} ErrorResponse "Invalid tag"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Person not found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security ApiKeyAuth
+// @Router /protected/person/{id}/tags [put]
+func (h *TagHandler) SetPersonTags(c *fiber.Ctx) error {
+	personID, err := parseIDParam(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "invalid person ID format"})
+	}
+
+	var req PersonTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	tags, err := h.tagService.SetPersonTags(personID, req.Tags, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrPersonNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{Error: err.Error()})
+		case errors.Is(err, ports.ErrForbidden):
+			return c.Status(fiber.StatusForbidden).JSON(ErrorResponse{Error: err.Error()})
+		case errors.Is(err, ports.ErrInvalidTag):
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(NewTagResponses(tags))
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type UbigeoHandler struct {
+	catalog ports.UbigeoCatalog
+}
+
+func NewUbigeoHandler(catalog ports.UbigeoCatalog) *UbigeoHandler {
+	return &UbigeoHandler{catalog: catalog}
+}
+
+// UbigeoAreaResponse es un departamento, provincia o distrito del catálogo.
+type UbigeoAreaResponse struct {
+	Code string `json:"code" example:"150113"`
+	Name string `json:"name" example:"Jesús María"`
+}
+
+// UbigeoResponse es un distrito con su provincia y departamento.
+type UbigeoResponse struct {
+	Code       string `json:"code" example:"150113"`
+	Department string `json:"department" example:"Lima"`
+	Province   string `json:"province" example:"Lima"`
+	District   string `json:"district" example:"Jesús María"`
+}
+
+func newUbigeoAreaResponses(areas []domain.UbigeoArea) []UbigeoAreaResponse {
+	responses := make([]UbigeoAreaResponse, len(areas))
+	for i, area := range areas {
+		responses[i] = UbigeoAreaResponse{Code: area.Code, Name: area.Name}
+	}
+	return responses
+}
+
+// ListDepartments godoc
+// @Summary List the departments of Peru
+// @Description Returns the departments of the INEI ubigeo catalog, sorted by name. Use them to fill the first of the cascading selects.
+// @Tags Ubigeo
+// @Produce json
+// @Success 200 {array} handlers.UbigeoAreaResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Security ApiKeyAuth
+// @Router /protected/ubigeo/departments [get]
+func (h *UbigeoHandler) ListDepartments(c *fiber.Ctx) error {
+	return c.JSON(newUbigeoAreaResponses(h.catalog.Departments()))
+}
+
+// ListProvinces godoc
+// @Summary List the provinces of a department
+// @Description Returns the provinces of the department, sorted by name.
+// @Tags Ubigeo
+// @Produce json
+// @Param code path string true "Department code (2 digits)"
+// @Success 200 {array} handlers.UbigeoAreaResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Department not found"
+// @Security ApiKeyAuth
+// @Router /protected/ubigeo/departments/{code}/provinces [get]
+func (h *UbigeoHandler) ListProvinces(c *fiber.Ctx) error {
+	provinces, err := h.catalog.Provinces(c.Params("code"))
+	if err != nil {
+		return c.Status(ubigeoErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+	return c.JSON(newUbigeoAreaResponses(provinces))
+}
+
+// ListDistricts godoc
+// @Summary List the districts of a province
+// @Description Returns the districts of the province, sorted by name. The code of each district is its ubigeo.
+// @Tags Ubigeo
+// @Produce json
+// @Param code path string true "Province code (4 digits)"
+// @Success 200 {array} handlers.UbigeoAreaResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Province not found"
+// @Security ApiKeyAuth
+// @Router /protected/ubigeo/provinces/{code}/districts [get]
+func (h *UbigeoHandler) ListDistricts(c *fiber.Ctx) error {
+	districts, err := h.catalog.Districts(c.Params("code"))
+	if err != nil {
+		return c.Status(ubigeoErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+	return c.JSON(newUbigeoAreaResponses(districts))
+}
+
+// GetUbigeo godoc
+// @Summary Get a district by its ubigeo
+// @Description Returns the district with its province and department.
+// @Tags Ubigeo
+// @Produce json
+// @Param code path string true "Ubigeo (6 digits)"
+// @Success 200 {object} handlers.UbigeoResponse
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Ubigeo not found"
+// @Security ApiKeyAuth
+// @Router /protected/ubigeo/{code} [get]
+func (h *UbigeoHandler) GetUbigeo(c *fiber.Ctx) error {
+	location, err := h.catalog.Find(c.Params("code"))
+	if err != nil {
+		return c.Status(ubigeoErrorStatus(err)).JSON(ErrorResponse{Error: err.Error()})
+	}
+	return c.JSON(UbigeoResponse{
+		Code:       location.Code,
+		Department: location.Department,
+		Province:   location.Province,
+		District:   location.District,
+	})
+}
+
+func ubigeoErrorStatus(err error) int {
+	if errors.Is(err, ports.ErrUbigeoNotFound) {
+		return fiber.StatusNotFound
+	}
+	return fiber.StatusInternalServerError
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -41,8 +42,23 @@ type ProfileResponse struct {
 }
 
 // ErrorResponse define una estructura estándar para los errores.
+// Code y Field solo se informan en los errores de validación estructurados.
 type ErrorResponse struct {
 	Error string `json:"error"`
+	Code  string `json:"code,omitempty" example:"phone_invalid_length"`
+	Field string `json:"field,omitempty" example:"phone"`
+}
+
+// newErrorResponse construye la respuesta de error, con el código y el campo si err
+// es un ports.ValidationError.
+func newErrorResponse(err error) ErrorResponse {
+	response := ErrorResponse{Error: err.Error()}
+	var validationErr *ports.ValidationError
+	if errors.As(err, &validationErr) {
+		response.Code = validationErr.Code
+		response.Field = validationErr.Field
+	}
+	return response
 }
 
 // Register godoc
@@ -62,7 +78,12 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Error: "cannot parse JSON"})
 	}
 
-	user, err := h.userService.Register(req.Username, req.Password)
+	actor, ok := actorFromContext(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{Error: "user ID not found in context"})
+	}
+
+	user, err := h.userService.Register(req.Username, req.Password, actor)
 	if err != nil {
 		return c.Status(fiber.StatusConflict).JSON(ErrorResponse{Error: err.Error()})
 	}
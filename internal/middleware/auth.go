@@ -11,39 +11,55 @@ import (
 // AuthRequired es un middleware para verificar el token JWT.
 func AuthRequired(secret string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		authHeader := c.Get("Authorization")
-		if authHeader == "" {
+		if c.Get("Authorization") == "" {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "missing or malformed JWT"})
 		}
+		return authenticate(c, secret)
+	}
+}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "missing or malformed JWT"})
+// AuthOptional identifica al usuario si la petición trae un token, para las rutas
+// públicas que muestran más datos a los usuarios autenticados. Sin token la petición
+// sigue como anónima; un token inválido se rechaza igual que en AuthRequired.
+func AuthOptional(secret string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Get("Authorization") == "" {
+			return c.Next()
 		}
+		return authenticate(c, secret)
+	}
+}
 
-		tokenString := parts[1]
-
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fiber.NewError(fiber.StatusUnauthorized, "unexpected signing method")
-			}
-			return []byte(secret), nil
-		})
+// authenticate verifica el token JWT de la cabecera Authorization y guarda el usuario
+// y su rol en el contexto.
+func authenticate(c *fiber.Ctx, secret string) error {
+	parts := strings.Split(c.Get("Authorization"), " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "missing or malformed JWT"})
+	}
 
-		if err != nil || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid or expired JWT"})
-		}
+	tokenString := parts[1]
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid JWT claims"})
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fiber.NewError(fiber.StatusUnauthorized, "unexpected signing method")
 		}
+		return []byte(secret), nil
+	})
 
-		c.Locals("userID", claims["sub"])
-		c.Locals("userRole", claims["role"])
+	if err != nil || !token.Valid {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid or expired JWT"})
+	}
 
-		return c.Next()
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid JWT claims"})
 	}
+
+	c.Locals("userID", claims["sub"])
+	c.Locals("userRole", claims["role"])
+
+	return c.Next()
 }
 
 // RoleRequired es un middleware para verificar el rol del usuario.
@@ -0,0 +1,242 @@
+// Package migrations aplica los cambios versionados del esquema de la base de datos.
+//
+// Cada versión es un par de archivos SQL por dialecto, en postgres/ y sqlite/:
+// NNNN_nombre.up.sql aplica el cambio y NNNN_nombre.down.sql lo revierte. Los archivos
+// se incluyen en el binario y las versiones aplicadas se registran en la tabla
+// schema_migrations. Una versión nunca se edita una vez publicada: los cambios
+// posteriores van en una versión nueva.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// advisoryLockKey identifica el bloqueo de PostgreSQL que impide que dos instancias
+// migren a la vez.
+const advisoryLockKey = 2_024_061_501
+
+// Migration es una versión del esquema.
+type Migration struct {
+	Version int
+	Name    string
+	up      string
+	down    string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Status indica si una migración está aplicada y desde cuándo.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrator aplica y revierte las migraciones del dialecto de la conexión.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New carga las migraciones del dialecto de db ("postgres" o "sqlite").
+func New(db *gorm.DB) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	migrations, err := load(dialect)
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return nil, fmt.Errorf("no migrations for the %s dialect", dialect)
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// load lee las migraciones del directorio del dialecto, ordenadas por versión.
+func load(dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for the %s dialect", dialect)
+	}
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		base, direction, ok := strings.Cut(strings.TrimSuffix(entry.Name(), ".sql"), ".")
+		number, name, hasName := strings.Cut(base, "_")
+		version, err := strconv.Atoi(number)
+		if !ok || !hasName || err != nil || version <= 0 || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name %s, use NNNN_name.up.sql or NNNN_name.down.sql", entry.Name())
+		}
+		content, err := files.ReadFile(path.Join(dialect, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration := byVersion[version]
+		if migration == nil {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, name)
+		}
+		if direction == "up" {
+			migration.up = string(content)
+		} else {
+			migration.down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == "" || migration.down == "" {
+			return nil, fmt.Errorf("migration %s needs both an up and a down file", migration)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up aplica, en orden, las migraciones pendientes y devuelve las aplicadas. En PostgreSQL
+// cada una se aplica en su propia transacción y, si una falla, las anteriores quedan
+// aplicadas; en SQLite todas comparten la transacción del bloqueo y se deshacen juntas.
+func (m *Migrator) Up() (applied []Migration, err error) {
+	err = m.locked(func(conn *gorm.DB) error {
+		done, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.up).Error; err != nil {
+					return err
+				}
+				return tx.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+					migration.Version, migration.Name, time.Now().UTC()).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %s failed: %w", migration, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down revierte las últimas steps migraciones aplicadas, de la más reciente a la más
+// antigua, y devuelve las revertidas.
+func (m *Migrator) Down(steps int) (reverted []Migration, err error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("the number of migrations to revert must be positive, got %d", steps)
+	}
+	err = m.locked(func(conn *gorm.DB) error {
+		done, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.down).Error; err != nil {
+					return err
+				}
+				return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("reverting migration %s failed: %w", migration, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status devuelve todas las migraciones conocidas con la fecha en que se aplicaron.
+func (m *Migrator) Status() ([]Status, error) {
+	var statuses []Status
+	err := m.locked(func(conn *gorm.DB) error {
+		done, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			status := Status{Migration: migration}
+			if appliedAt, ok := done[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// locked ejecuta fn con el bloqueo de migraciones tomado, sobre una sola conexión, para
+// que dos instancias que arrancan a la vez no apliquen la misma migración. PostgreSQL usa
+// un bloqueo consultivo de sesión. En SQLite, fn corre dentro de una transacción, que
+// toma el bloqueo de escritura de la base (ver repository.OpenSQLite) y deja a las demás
+// instancias esperando.
+func (m *Migrator) locked(fn func(conn *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		if m.db.Dialector.Name() == "sqlite" {
+			return conn.Transaction(func(tx *gorm.DB) error {
+				if err := createMigrationsTable(tx); err != nil {
+					return err
+				}
+				return fn(tx)
+			})
+		}
+
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", advisoryLockKey).Error; err != nil {
+			return fmt.Errorf("could not take the migrations lock: %w", err)
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", advisoryLockKey)
+		if err := createMigrationsTable(conn); err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+func createMigrationsTable(db *gorm.DB) error {
+	err := db.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version bigint PRIMARY KEY, name text NOT NULL, applied_at timestamp NOT NULL)").Error
+	if err != nil {
+		return fmt.Errorf("could not create the schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// appliedVersions devuelve la fecha de aplicación de cada versión registrada.
+func appliedVersions(db *gorm.DB) (map[int]time.Time, error) {
+	var rows []struct {
+		Version   int
+		AppliedAt time.Time
+	}
+	if err := db.Raw("SELECT version, applied_at FROM schema_migrations").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("could not read the applied migrations: %w", err)
+	}
+	done := make(map[int]time.Time, len(rows))
+	for _, row := range rows {
+		done[row.Version] = row.AppliedAt
+	}
+	return done, nil
+}
@@ -0,0 +1,237 @@
+package migrations
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openTestDB(t *testing.T, path string) *gorm.DB {
+	t.Helper()
+	db, err := repository.OpenSQLite(path, &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("OpenSQLite: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func newMigrator(t *testing.T, db *gorm.DB) *Migrator {
+	t.Helper()
+	migrator, err := New(db)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return migrator
+}
+
+func TestEveryDialectHasTheSameVersions(t *testing.T) {
+	postgres, err := load("postgres")
+	if err != nil {
+		t.Fatalf("load postgres: %v", err)
+	}
+	sqlite, err := load("sqlite")
+	if err != nil {
+		t.Fatalf("load sqlite: %v", err)
+	}
+	if len(postgres) != len(sqlite) {
+		t.Fatalf("postgres has %d migrations and sqlite %d", len(postgres), len(sqlite))
+	}
+	for i := range postgres {
+		if postgres[i].String() != sqlite[i].String() {
+			t.Fatalf("migration %d differs: postgres %s, sqlite %s", i, postgres[i], sqlite[i])
+		}
+	}
+}
+
+func TestUpDownAndStatus(t *testing.T) {
+	db := openTestDB(t, filepath.Join(t.TempDir(), "riada.db"))
+	migrator := newMigrator(t, db)
+
+	applied, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(applied) != len(migrator.migrations) {
+		t.Fatalf("Up applied %d of %d migrations", len(applied), len(migrator.migrations))
+	}
+	if !db.Migrator().HasTable("people") {
+		t.Fatal("the baseline should create the people table")
+	}
+	if again, err := migrator.Up(); err != nil || len(again) != 0 {
+		t.Fatalf("a second Up should apply nothing, got %v, %v", again, err)
+	}
+
+	statuses, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt == nil || time.Since(*status.AppliedAt) > time.Minute {
+			t.Fatalf("migration %s should be applied just now, got %v", status.Migration, status.AppliedAt)
+		}
+	}
+
+	reverted, err := migrator.Down(len(migrator.migrations))
+	if err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	if len(reverted) != len(migrator.migrations) || reverted[0].Version != migrator.migrations[len(migrator.migrations)-1].Version {
+		t.Fatalf("Down should revert every migration from the newest, got %v", reverted)
+	}
+	if db.Migrator().HasTable("people") {
+		t.Fatal("reverting the baseline should drop the people table")
+	}
+	statuses, err = migrator.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt != nil {
+			t.Fatalf("migration %s should be pending", status.Migration)
+		}
+	}
+	if _, err := migrator.Down(0); err == nil {
+		t.Fatal("Down(0) should fail")
+	}
+}
+
+// TestBaselineMatchesModels comprueba que el esquema migrado tenga todas las tablas,
+// columnas e índices que declaran los modelos.
+func TestBaselineMatchesModels(t *testing.T) {
+	db := openTestDB(t, filepath.Join(t.TempDir(), "riada.db"))
+	if _, err := newMigrator(t, db).Up(); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	checkSchemaMatchesModels(t, db)
+}
+
+// TestUpFromAutoMigrateSchema parte de una base creada con AutoMigrate antes de las
+// migraciones, con documentos repetidos, y comprueba que llegue al esquema actual sin
+// perder sus datos.
+func TestUpFromAutoMigrateSchema(t *testing.T) {
+	db := openTestDB(t, filepath.Join(t.TempDir(), "riada.db"))
+	for _, statement := range []string{
+		`CREATE TABLE "users" ("id" integer PRIMARY KEY AUTOINCREMENT,"created_at" datetime,"updated_at" datetime,"deleted_at" datetime,"username" text NOT NULL,"password_hash" text NOT NULL,"role" varchar(10) NOT NULL DEFAULT 'user',"person_id" integer)`,
+		`CREATE UNIQUE INDEX "idx_users_username" ON "users" ("username")`,
+		`CREATE TABLE "people" ("id" integer PRIMARY KEY AUTOINCREMENT,"user_id" integer,"name" text,"middle_name" text,"last_name" text,"sex" text,"birthday" datetime,"doc_number" text,"type_doc" text,"email" text,"photo" text,"created_at" datetime,"updated_at" datetime)`,
+		`CREATE TABLE "addresses" ("id" integer PRIMARY KEY AUTOINCREMENT,"person_id" integer,"address" text,"created_at" datetime,"updated_at" datetime)`,
+		`CREATE TABLE "phones" ("id" integer PRIMARY KEY AUTOINCREMENT,"person_id" integer,"phone" text,"created_at" datetime,"updated_at" datetime)`,
+		`INSERT INTO "people" ("id", "name", "doc_number", "type_doc") VALUES (1, 'Ana', '12345678', 'DNI'), (2, 'Ana María', '12345678', 'DNI'), (3, 'Luis', '87654321', 'DNI')`,
+		`INSERT INTO "addresses" ("person_id", "address") VALUES (1, 'Av. Brasil 1234')`,
+		`INSERT INTO "phones" ("person_id", "phone") VALUES (1, '999888777')`,
+	} {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("could not create the AutoMigrate schema: %v", err)
+		}
+	}
+
+	migrator := newMigrator(t, db)
+	applied, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(applied) != len(migrator.migrations) {
+		t.Fatalf("Up applied %d of %d migrations", len(applied), len(migrator.migrations))
+	}
+	checkSchemaMatchesModels(t, db)
+
+	var documents []struct {
+		ID        uint
+		DocNumber *string
+	}
+	if err := db.Raw(`SELECT "id", "doc_number" FROM "people" ORDER BY "id"`).Scan(&documents).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(documents) != 3 || documents[0].DocNumber == nil || documents[1].DocNumber != nil || documents[2].DocNumber == nil {
+		t.Fatalf("only the repeated document of the newer person should be cleared, got %+v", documents)
+	}
+	var address domain.Address
+	if err := db.First(&address).Error; err != nil || address.Address != "Av. Brasil 1234" || address.Country != "PE" {
+		t.Fatalf("the existing address should be kept with the default country, got %+v, %v", address, err)
+	}
+	var phones int64
+	if err := db.Table("phones").Count(&phones).Error; err != nil || phones != 1 {
+		t.Fatalf("the existing phone should be kept, got %d, %v", phones, err)
+	}
+}
+
+// checkSchemaMatchesModels comprueba que la base tenga todas las tablas, columnas e índices
+// que declaran los modelos.
+func checkSchemaMatchesModels(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	models := []interface{}{&domain.User{}, &domain.Person{}, &domain.Address{}, &domain.Phone{}, &domain.EntityVersion{},
+		&domain.AuditEntry{}, &domain.Relationship{}, &domain.Household{}, &domain.HouseholdAddress{}, &domain.HouseholdPhone{},
+		&domain.Group{}, &domain.GroupMembership{}, &domain.Tag{}, &domain.CustomFieldDefinition{}, &domain.Event{},
+		&domain.Attendance{}, &domain.JobState{}, &domain.Note{}, &domain.Setting{}, &domain.Delegation{}}
+	migrator := db.Migrator()
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatalf("parse %T: %v", model, err)
+		}
+		table := stmt.Schema.Table
+		if !migrator.HasTable(table) {
+			t.Errorf("table %s is missing", table)
+			continue
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !migrator.HasColumn(model, field.DBName) {
+				t.Errorf("column %s.%s is missing", table, field.DBName)
+			}
+		}
+		for _, index := range stmt.Schema.ParseIndexes() {
+			if !migrator.HasIndex(model, index.Name) {
+				t.Errorf("index %s on %s is missing", index.Name, table)
+			}
+		}
+		for _, rel := range stmt.Schema.Relationships.Many2Many {
+			if !migrator.HasTable(rel.JoinTable.Table) {
+				t.Errorf("join table %s is missing", rel.JoinTable.Table)
+			}
+		}
+	}
+}
+
+func TestConcurrentUp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "riada.db")
+	const instances = 4
+	migrators := make([]*Migrator, instances)
+	for i := range migrators {
+		migrators[i] = newMigrator(t, openTestDB(t, path))
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan int, instances)
+	for _, migrator := range migrators {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			applied, err := migrator.Up()
+			if err != nil {
+				t.Errorf("Up: %v", err)
+			}
+			results <- len(applied)
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	total := 0
+	for applied := range results {
+		total += applied
+	}
+	if want := len(migrators[0].migrations); total != want {
+		t.Fatalf("the instances applied %d migrations in total, want %d", total, want)
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "phones", "addresses", "people", "users";
@@ -0,0 +1,19 @@
+-- Esquema inicial, igual al que creaba AutoMigrate antes de las migraciones versionadas.
+-- Usa IF NOT EXISTS para que una base creada con AutoMigrate quede registrada en esta
+-- versión sin cambios; las versiones siguientes la llevan al esquema actual.
+
+CREATE TABLE IF NOT EXISTS "users" ("id" bigserial,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,"username" text NOT NULL,"password_hash" text NOT NULL,"role" varchar(10) NOT NULL DEFAULT 'user',"person_id" bigint,PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_username" ON "users" ("username");
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "people" ("id" bigserial,"user_id" bigint,"name" text,"middle_name" text,"last_name" text,"sex" text,"birthday" timestamptz,"doc_number" text,"type_doc" text,"email" text,"photo" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_users_person') THEN
+		ALTER TABLE "people" ADD CONSTRAINT "fk_users_person" FOREIGN KEY ("user_id") REFERENCES "users"("id");
+	END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS "addresses" ("id" bigserial,"person_id" bigint,"address" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+
+CREATE TABLE IF NOT EXISTS "phones" ("id" bigserial,"person_id" bigint,"phone" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "idx_people_document";
+DROP INDEX IF EXISTS "idx_people_household_id";
+ALTER TABLE "people" DROP COLUMN IF EXISTS "household_id", DROP COLUMN IF EXISTS "custom_fields";
+
+DROP INDEX IF EXISTS "idx_addresses_ubigeo";
+ALTER TABLE "addresses" DROP COLUMN IF EXISTS "street", DROP COLUMN IF EXISTS "number", DROP COLUMN IF EXISTS "reference",
+	DROP COLUMN IF EXISTS "district", DROP COLUMN IF EXISTS "province", DROP COLUMN IF EXISTS "department",
+	DROP COLUMN IF EXISTS "ubigeo", DROP COLUMN IF EXISTS "country", DROP COLUMN IF EXISTS "postal_code",
+	DROP COLUMN IF EXISTS "latitude", DROP COLUMN IF EXISTS "longitude", DROP COLUMN IF EXISTS "sort_order";
+
+ALTER TABLE "phones" DROP COLUMN IF EXISTS "type", DROP COLUMN IF EXISTS "is_primary", DROP COLUMN IF EXISTS "sort_order";
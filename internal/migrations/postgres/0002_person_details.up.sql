@@ -0,0 +1,32 @@
+-- Columnas nuevas de personas, direcciones y teléfonos. Usa IF NOT EXISTS porque una
+-- base creada con AutoMigrate durante el desarrollo ya puede tenerlas.
+ALTER TABLE "people" ADD COLUMN IF NOT EXISTS "household_id" bigint;
+ALTER TABLE "people" ADD COLUMN IF NOT EXISTS "custom_fields" jsonb;
+CREATE INDEX IF NOT EXISTS "idx_people_household_id" ON "people" ("household_id");
+
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "street" text;
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "number" varchar(20);
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "reference" text;
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "district" text;
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "province" text;
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "department" text;
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "ubigeo" varchar(6);
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "country" varchar(2) DEFAULT 'PE';
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "postal_code" varchar(10);
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "latitude" decimal;
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "longitude" decimal;
+ALTER TABLE "addresses" ADD COLUMN IF NOT EXISTS "sort_order" bigint;
+CREATE INDEX IF NOT EXISTS "idx_addresses_ubigeo" ON "addresses" ("ubigeo");
+
+ALTER TABLE "phones" ADD COLUMN IF NOT EXISTS "type" varchar(20);
+ALTER TABLE "phones" ADD COLUMN IF NOT EXISTS "is_primary" boolean;
+ALTER TABLE "phones" ADD COLUMN IF NOT EXISTS "sort_order" bigint;
+
+-- Antes no se validaba que el documento fuera único. Se conserva en la persona registrada
+-- primero y se borra de las demás, que quedan sin documento para que se revisen.
+UPDATE "people" SET "doc_number" = NULL
+WHERE "doc_number" <> '' AND EXISTS (
+	SELECT 1 FROM "people" AS "first"
+	WHERE "first"."doc_number" = "people"."doc_number" AND "first"."type_doc" = "people"."type_doc" AND "first"."id" < "people"."id"
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_people_document" ON "people" ("doc_number","type_doc") WHERE doc_number <> '';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "delegations", "settings", "notes", "job_states", "attendances", "events", "custom_field_definitions",
+	"person_tags", "tags", "group_memberships", "groups", "household_phones", "household_addresses", "households",
+	"relationships", "audit_entries", "entity_versions";
@@ -0,0 +1,61 @@
+-- Tablas del historial, la auditoría, las familias, los grupos, las etiquetas, los campos
+-- personalizados, los eventos, las notas, la configuración y las delegaciones.
+
+CREATE TABLE "entity_versions" ("id" bigserial,"entity_type" varchar(20),"entity_id" bigint,"person_id" bigint,"version" bigint,"action" varchar(20),"changed_by" bigint,"changes" text,"snapshot" text,"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX "idx_entity_versions_person_id" ON "entity_versions" ("person_id");
+CREATE INDEX "idx_versions_entity" ON "entity_versions" ("entity_type","entity_id");
+
+CREATE TABLE "audit_entries" ("id" bigserial,"actor_id" bigint,"actor_role" varchar(10),"action" varchar(50),"entity_type" varchar(30),"entity_id" bigint,"changes" text,"request_id" varchar(64),"ip" varchar(64),"outcome" varchar(10),"error" text,"prev_hash" varchar(64),"hash" varchar(64),"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX "idx_audit_entries_hash" ON "audit_entries" ("hash");
+CREATE INDEX "idx_audit_entries_request_id" ON "audit_entries" ("request_id");
+CREATE INDEX "idx_audit_entity" ON "audit_entries" ("entity_type","entity_id");
+CREATE INDEX "idx_audit_entries_action" ON "audit_entries" ("action");
+CREATE INDEX "idx_audit_entries_actor_id" ON "audit_entries" ("actor_id");
+
+CREATE TABLE "relationships" ("id" bigserial,"person_id" bigint,"related_person_id" bigint,"type" varchar(20),"since" timestamptz,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX "idx_relationships_related_person_id" ON "relationships" ("related_person_id");
+CREATE UNIQUE INDEX "idx_relationships_pair" ON "relationships" ("person_id","related_person_id","type");
+
+CREATE TABLE "households" ("id" bigserial,"name" text,"head_person_id" bigint,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+
+CREATE TABLE "household_addresses" ("id" bigserial,"household_id" bigint,"address" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX "idx_household_addresses_household_id" ON "household_addresses" ("household_id");
+
+CREATE TABLE "household_phones" ("id" bigserial,"household_id" bigint,"phone" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX "idx_household_phones_household_id" ON "household_phones" ("household_id");
+
+CREATE TABLE "groups" ("id" bigserial,"name" text,"type" varchar(20),"description" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX "idx_groups_name" ON "groups" ("name");
+
+CREATE TABLE "group_memberships" ("id" bigserial,"group_id" bigint,"person_id" bigint,"role" varchar(10),"start_date" timestamptz,"end_date" timestamptz,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX "idx_group_memberships_person_id" ON "group_memberships" ("person_id");
+CREATE UNIQUE INDEX "idx_group_memberships_pair" ON "group_memberships" ("group_id","person_id");
+
+CREATE TABLE "tags" ("id" bigserial,"name" varchar(50),PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX "idx_tags_name" ON "tags" ("name");
+
+CREATE TABLE "person_tags" ("person_id" bigint,"tag_id" bigint,PRIMARY KEY ("person_id","tag_id"));
+
+CREATE TABLE "custom_field_definitions" ("id" bigserial,"key" varchar(50),"label" text,"type" varchar(10),"required" boolean,"options" text,"min" decimal,"max" decimal,"pattern" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX "idx_custom_field_definitions_key" ON "custom_field_definitions" ("key");
+
+CREATE TABLE "events" ("id" bigserial,"name" text,"description" text,"place" text,"starts_at" timestamptz,"ends_at" timestamptz,"recurrence" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+
+CREATE TABLE "attendances" ("id" bigserial,"event_id" bigint,"person_id" bigint,"date" date,"method" varchar(10),"checked_in_by" bigint,"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX "idx_attendances_date" ON "attendances" ("date");
+CREATE INDEX "idx_attendances_person_id" ON "attendances" ("person_id");
+CREATE UNIQUE INDEX "idx_attendances_occurrence" ON "attendances" ("event_id","person_id","date");
+
+CREATE TABLE "job_states" ("name" varchar(100),"last_run_at" timestamptz,"last_success_at" timestamptz,"last_error" text,"next_run_at" timestamptz,"runs" bigint,"updated_at" timestamptz,PRIMARY KEY ("name"));
+
+CREATE TABLE "notes" ("id" bigserial,"person_id" bigint,"author_id" bigint,"type" varchar(20),"visibility" varchar(20),"restricted_to" varchar(10),"content" text,"date" timestamptz,"due_date" date,"assignee_id" bigint,"completed_at" timestamptz,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX "idx_notes_assignee_id" ON "notes" ("assignee_id");
+CREATE INDEX "idx_notes_author_id" ON "notes" ("author_id");
+CREATE INDEX "idx_notes_person_id" ON "notes" ("person_id");
+
+CREATE TABLE "settings" ("key" varchar(100),"value" text,"updated_by" bigint,"updated_at" timestamptz,PRIMARY KEY ("key"));
+
+CREATE TABLE "delegations" ("id" bigserial,"user_id" bigint,"person_id" bigint,"status" varchar(10),"reason" text,"requested_by" bigint,"decided_by" bigint,"decided_at" timestamptz,"revoked_by" bigint,"revoked_at" timestamptz,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX "idx_delegations_status" ON "delegations" ("status");
+CREATE INDEX "idx_delegations_person_id" ON "delegations" ("person_id");
+CREATE INDEX "idx_delegations_user_person" ON "delegations" ("user_id","person_id");
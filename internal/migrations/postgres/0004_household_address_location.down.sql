@@ -0,0 +1,12 @@
+ALTER TABLE "household_addresses"
+	DROP COLUMN IF EXISTS "street",
+	DROP COLUMN IF EXISTS "number",
+	DROP COLUMN IF EXISTS "reference",
+	DROP COLUMN IF EXISTS "district",
+	DROP COLUMN IF EXISTS "province",
+	DROP COLUMN IF EXISTS "department",
+	DROP COLUMN IF EXISTS "ubigeo",
+	DROP COLUMN IF EXISTS "country",
+	DROP COLUMN IF EXISTS "postal_code",
+	DROP COLUMN IF EXISTS "latitude",
+	DROP COLUMN IF EXISTS "longitude";
@@ -0,0 +1,13 @@
+-- Las direcciones del hogar guardan la misma ubicación estructurada que las de las personas.
+ALTER TABLE "household_addresses"
+	ADD COLUMN IF NOT EXISTS "street" text,
+	ADD COLUMN IF NOT EXISTS "number" varchar(20),
+	ADD COLUMN IF NOT EXISTS "reference" text,
+	ADD COLUMN IF NOT EXISTS "district" text,
+	ADD COLUMN IF NOT EXISTS "province" text,
+	ADD COLUMN IF NOT EXISTS "department" text,
+	ADD COLUMN IF NOT EXISTS "ubigeo" varchar(6),
+	ADD COLUMN IF NOT EXISTS "country" varchar(2) DEFAULT 'PE',
+	ADD COLUMN IF NOT EXISTS "postal_code" varchar(10),
+	ADD COLUMN IF NOT EXISTS "latitude" decimal,
+	ADD COLUMN IF NOT EXISTS "longitude" decimal;
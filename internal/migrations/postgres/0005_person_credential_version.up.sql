@@ -0,0 +1,3 @@
+-- Versión de la credencial del carné: al revocarla se incrementa y las credenciales
+-- emitidas con la versión anterior dejan de valer.
+ALTER TABLE "people" ADD COLUMN IF NOT EXISTS "credential_version" bigint NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "idx_audit_entries_prev_hash";
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "phones";
+DROP TABLE IF EXISTS "addresses";
+DROP TABLE IF EXISTS "people";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,13 @@
+-- Esquema inicial, igual al que creaba AutoMigrate antes de las migraciones versionadas.
+-- Usa IF NOT EXISTS para que una base creada con AutoMigrate quede registrada en esta
+-- versión sin cambios; las versiones siguientes la llevan al esquema actual.
+
+CREATE TABLE IF NOT EXISTS "users" ("id" integer PRIMARY KEY AUTOINCREMENT,"created_at" datetime,"updated_at" datetime,"deleted_at" datetime,"username" text NOT NULL,"password_hash" text NOT NULL,"role" varchar(10) NOT NULL DEFAULT 'user',"person_id" integer);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_username" ON "users" ("username");
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "people" ("id" integer PRIMARY KEY AUTOINCREMENT,"user_id" integer,"name" text,"middle_name" text,"last_name" text,"sex" text,"birthday" datetime,"doc_number" text,"type_doc" text,"email" text,"photo" text,"created_at" datetime,"updated_at" datetime,CONSTRAINT "fk_users_person" FOREIGN KEY ("user_id") REFERENCES "users"("id"));
+
+CREATE TABLE IF NOT EXISTS "addresses" ("id" integer PRIMARY KEY AUTOINCREMENT,"person_id" integer,"address" text,"created_at" datetime,"updated_at" datetime);
+
+CREATE TABLE IF NOT EXISTS "phones" ("id" integer PRIMARY KEY AUTOINCREMENT,"person_id" integer,"phone" text,"created_at" datetime,"updated_at" datetime);
@@ -0,0 +1,22 @@
+DROP INDEX IF EXISTS "idx_people_document";
+DROP INDEX IF EXISTS "idx_people_household_id";
+ALTER TABLE "people" DROP COLUMN "household_id";
+ALTER TABLE "people" DROP COLUMN "custom_fields";
+
+DROP INDEX IF EXISTS "idx_addresses_ubigeo";
+ALTER TABLE "addresses" DROP COLUMN "street";
+ALTER TABLE "addresses" DROP COLUMN "number";
+ALTER TABLE "addresses" DROP COLUMN "reference";
+ALTER TABLE "addresses" DROP COLUMN "district";
+ALTER TABLE "addresses" DROP COLUMN "province";
+ALTER TABLE "addresses" DROP COLUMN "department";
+ALTER TABLE "addresses" DROP COLUMN "ubigeo";
+ALTER TABLE "addresses" DROP COLUMN "country";
+ALTER TABLE "addresses" DROP COLUMN "postal_code";
+ALTER TABLE "addresses" DROP COLUMN "latitude";
+ALTER TABLE "addresses" DROP COLUMN "longitude";
+ALTER TABLE "addresses" DROP COLUMN "sort_order";
+
+ALTER TABLE "phones" DROP COLUMN "type";
+ALTER TABLE "phones" DROP COLUMN "is_primary";
+ALTER TABLE "phones" DROP COLUMN "sort_order";
@@ -0,0 +1,33 @@
+-- Columnas nuevas de personas, direcciones y teléfonos. SQLite no admite ADD COLUMN IF
+-- NOT EXISTS: si una base ya tiene alguna de estas columnas, la migración falla y no se
+-- aplica a medias.
+ALTER TABLE "people" ADD COLUMN "household_id" integer;
+ALTER TABLE "people" ADD COLUMN "custom_fields" jsonb;
+CREATE INDEX IF NOT EXISTS "idx_people_household_id" ON "people" ("household_id");
+
+ALTER TABLE "addresses" ADD COLUMN "street" text;
+ALTER TABLE "addresses" ADD COLUMN "number" varchar(20);
+ALTER TABLE "addresses" ADD COLUMN "reference" text;
+ALTER TABLE "addresses" ADD COLUMN "district" text;
+ALTER TABLE "addresses" ADD COLUMN "province" text;
+ALTER TABLE "addresses" ADD COLUMN "department" text;
+ALTER TABLE "addresses" ADD COLUMN "ubigeo" varchar(6);
+ALTER TABLE "addresses" ADD COLUMN "country" varchar(2) DEFAULT 'PE';
+ALTER TABLE "addresses" ADD COLUMN "postal_code" varchar(10);
+ALTER TABLE "addresses" ADD COLUMN "latitude" real;
+ALTER TABLE "addresses" ADD COLUMN "longitude" real;
+ALTER TABLE "addresses" ADD COLUMN "sort_order" integer;
+CREATE INDEX IF NOT EXISTS "idx_addresses_ubigeo" ON "addresses" ("ubigeo");
+
+ALTER TABLE "phones" ADD COLUMN "type" varchar(20);
+ALTER TABLE "phones" ADD COLUMN "is_primary" numeric;
+ALTER TABLE "phones" ADD COLUMN "sort_order" integer;
+
+-- Antes no se validaba que el documento fuera único. Se conserva en la persona registrada
+-- primero y se borra de las demás, que quedan sin documento para que se revisen.
+UPDATE "people" SET "doc_number" = NULL
+WHERE "doc_number" <> '' AND EXISTS (
+	SELECT 1 FROM "people" AS "first"
+	WHERE "first"."doc_number" = "people"."doc_number" AND "first"."type_doc" = "people"."type_doc" AND "first"."id" < "people"."id"
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_people_document" ON "people" ("doc_number","type_doc") WHERE doc_number <> '';
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS "delegations";
+DROP TABLE IF EXISTS "settings";
+DROP TABLE IF EXISTS "notes";
+DROP TABLE IF EXISTS "job_states";
+DROP TABLE IF EXISTS "attendances";
+DROP TABLE IF EXISTS "events";
+DROP TABLE IF EXISTS "custom_field_definitions";
+DROP TABLE IF EXISTS "person_tags";
+DROP TABLE IF EXISTS "tags";
+DROP TABLE IF EXISTS "group_memberships";
+DROP TABLE IF EXISTS "groups";
+DROP TABLE IF EXISTS "household_phones";
+DROP TABLE IF EXISTS "household_addresses";
+DROP TABLE IF EXISTS "households";
+DROP TABLE IF EXISTS "relationships";
+DROP TABLE IF EXISTS "audit_entries";
+DROP TABLE IF EXISTS "entity_versions";
@@ -0,0 +1,61 @@
+-- Tablas del historial, la auditoría, las familias, los grupos, las etiquetas, los campos
+-- personalizados, los eventos, las notas, la configuración y las delegaciones.
+
+CREATE TABLE "entity_versions" ("id" integer PRIMARY KEY AUTOINCREMENT,"entity_type" varchar(20),"entity_id" integer,"person_id" integer,"version" integer,"action" varchar(20),"changed_by" integer,"changes" text,"snapshot" text,"created_at" datetime);
+CREATE INDEX "idx_entity_versions_person_id" ON "entity_versions" ("person_id");
+CREATE INDEX "idx_versions_entity" ON "entity_versions" ("entity_type","entity_id");
+
+CREATE TABLE "audit_entries" ("id" integer PRIMARY KEY AUTOINCREMENT,"actor_id" integer,"actor_role" varchar(10),"action" varchar(50),"entity_type" varchar(30),"entity_id" integer,"changes" text,"request_id" varchar(64),"ip" varchar(64),"outcome" varchar(10),"error" text,"prev_hash" varchar(64),"hash" varchar(64),"created_at" datetime);
+CREATE UNIQUE INDEX "idx_audit_entries_hash" ON "audit_entries" ("hash");
+CREATE INDEX "idx_audit_entries_request_id" ON "audit_entries" ("request_id");
+CREATE INDEX "idx_audit_entity" ON "audit_entries" ("entity_type","entity_id");
+CREATE INDEX "idx_audit_entries_action" ON "audit_entries" ("action");
+CREATE INDEX "idx_audit_entries_actor_id" ON "audit_entries" ("actor_id");
+
+CREATE TABLE "relationships" ("id" integer PRIMARY KEY AUTOINCREMENT,"person_id" integer,"related_person_id" integer,"type" varchar(20),"since" datetime,"created_at" datetime,"updated_at" datetime);
+CREATE INDEX "idx_relationships_related_person_id" ON "relationships" ("related_person_id");
+CREATE UNIQUE INDEX "idx_relationships_pair" ON "relationships" ("person_id","related_person_id","type");
+
+CREATE TABLE "households" ("id" integer PRIMARY KEY AUTOINCREMENT,"name" text,"head_person_id" integer,"created_at" datetime,"updated_at" datetime);
+
+CREATE TABLE "household_addresses" ("id" integer PRIMARY KEY AUTOINCREMENT,"household_id" integer,"address" text,"created_at" datetime,"updated_at" datetime);
+CREATE INDEX "idx_household_addresses_household_id" ON "household_addresses" ("household_id");
+
+CREATE TABLE "household_phones" ("id" integer PRIMARY KEY AUTOINCREMENT,"household_id" integer,"phone" text,"created_at" datetime,"updated_at" datetime);
+CREATE INDEX "idx_household_phones_household_id" ON "household_phones" ("household_id");
+
+CREATE TABLE "groups" ("id" integer PRIMARY KEY AUTOINCREMENT,"name" text,"type" varchar(20),"description" text,"created_at" datetime,"updated_at" datetime);
+CREATE UNIQUE INDEX "idx_groups_name" ON "groups" ("name");
+
+CREATE TABLE "group_memberships" ("id" integer PRIMARY KEY AUTOINCREMENT,"group_id" integer,"person_id" integer,"role" varchar(10),"start_date" datetime,"end_date" datetime,"created_at" datetime,"updated_at" datetime);
+CREATE INDEX "idx_group_memberships_person_id" ON "group_memberships" ("person_id");
+CREATE UNIQUE INDEX "idx_group_memberships_pair" ON "group_memberships" ("group_id","person_id");
+
+CREATE TABLE "tags" ("id" integer PRIMARY KEY AUTOINCREMENT,"name" varchar(50));
+CREATE UNIQUE INDEX "idx_tags_name" ON "tags" ("name");
+
+CREATE TABLE "person_tags" ("person_id" integer,"tag_id" integer,PRIMARY KEY ("person_id","tag_id"));
+
+CREATE TABLE "custom_field_definitions" ("id" integer PRIMARY KEY AUTOINCREMENT,"key" varchar(50),"label" text,"type" varchar(10),"required" numeric,"options" text,"min" real,"max" real,"pattern" text,"created_at" datetime,"updated_at" datetime);
+CREATE UNIQUE INDEX "idx_custom_field_definitions_key" ON "custom_field_definitions" ("key");
+
+CREATE TABLE "events" ("id" integer PRIMARY KEY AUTOINCREMENT,"name" text,"description" text,"place" text,"starts_at" datetime,"ends_at" datetime,"recurrence" text,"created_at" datetime,"updated_at" datetime);
+
+CREATE TABLE "attendances" ("id" integer PRIMARY KEY AUTOINCREMENT,"event_id" integer,"person_id" integer,"date" date,"method" varchar(10),"checked_in_by" integer,"created_at" datetime);
+CREATE INDEX "idx_attendances_date" ON "attendances" ("date");
+CREATE INDEX "idx_attendances_person_id" ON "attendances" ("person_id");
+CREATE UNIQUE INDEX "idx_attendances_occurrence" ON "attendances" ("event_id","person_id","date");
+
+CREATE TABLE "job_states" ("name" varchar(100),"last_run_at" datetime,"last_success_at" datetime,"last_error" text,"next_run_at" datetime,"runs" integer,"updated_at" datetime,PRIMARY KEY ("name"));
+
+CREATE TABLE "notes" ("id" integer PRIMARY KEY AUTOINCREMENT,"person_id" integer,"author_id" integer,"type" varchar(20),"visibility" varchar(20),"restricted_to" varchar(10),"content" text,"date" datetime,"due_date" date,"assignee_id" integer,"completed_at" datetime,"created_at" datetime,"updated_at" datetime);
+CREATE INDEX "idx_notes_assignee_id" ON "notes" ("assignee_id");
+CREATE INDEX "idx_notes_author_id" ON "notes" ("author_id");
+CREATE INDEX "idx_notes_person_id" ON "notes" ("person_id");
+
+CREATE TABLE "settings" ("key" varchar(100),"value" text,"updated_by" integer,"updated_at" datetime,PRIMARY KEY ("key"));
+
+CREATE TABLE "delegations" ("id" integer PRIMARY KEY AUTOINCREMENT,"user_id" integer,"person_id" integer,"status" varchar(10),"reason" text,"requested_by" integer,"decided_by" integer,"decided_at" datetime,"revoked_by" integer,"revoked_at" datetime,"created_at" datetime,"updated_at" datetime);
+CREATE INDEX "idx_delegations_status" ON "delegations" ("status");
+CREATE INDEX "idx_delegations_person_id" ON "delegations" ("person_id");
+CREATE INDEX "idx_delegations_user_person" ON "delegations" ("user_id","person_id");
@@ -0,0 +1,11 @@
+ALTER TABLE "household_addresses" DROP COLUMN "street";
+ALTER TABLE "household_addresses" DROP COLUMN "number";
+ALTER TABLE "household_addresses" DROP COLUMN "reference";
+ALTER TABLE "household_addresses" DROP COLUMN "district";
+ALTER TABLE "household_addresses" DROP COLUMN "province";
+ALTER TABLE "household_addresses" DROP COLUMN "department";
+ALTER TABLE "household_addresses" DROP COLUMN "ubigeo";
+ALTER TABLE "household_addresses" DROP COLUMN "country";
+ALTER TABLE "household_addresses" DROP COLUMN "postal_code";
+ALTER TABLE "household_addresses" DROP COLUMN "latitude";
+ALTER TABLE "household_addresses" DROP COLUMN "longitude";
@@ -0,0 +1,12 @@
+-- Las direcciones del hogar guardan la misma ubicación estructurada que las de las personas.
+ALTER TABLE "household_addresses" ADD COLUMN "street" text;
+ALTER TABLE "household_addresses" ADD COLUMN "number" varchar(20);
+ALTER TABLE "household_addresses" ADD COLUMN "reference" text;
+ALTER TABLE "household_addresses" ADD COLUMN "district" text;
+ALTER TABLE "household_addresses" ADD COLUMN "province" text;
+ALTER TABLE "household_addresses" ADD COLUMN "department" text;
+ALTER TABLE "household_addresses" ADD COLUMN "ubigeo" varchar(6);
+ALTER TABLE "household_addresses" ADD COLUMN "country" varchar(2) DEFAULT 'PE';
+ALTER TABLE "household_addresses" ADD COLUMN "postal_code" varchar(10);
+ALTER TABLE "household_addresses" ADD COLUMN "latitude" real;
+ALTER TABLE "household_addresses" ADD COLUMN "longitude" real;
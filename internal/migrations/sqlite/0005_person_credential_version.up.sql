@@ -0,0 +1,3 @@
+-- Versión de la credencial del carné: al revocarla se incrementa y las credenciales
+-- emitidas con la versión anterior dejan de valer.
+ALTER TABLE "people" ADD COLUMN "credential_version" integer NOT NULL DEFAULT 0;
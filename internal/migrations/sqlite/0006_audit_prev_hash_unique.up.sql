@@ -0,0 +1,5 @@
+-- Cada entrada de la auditoría enlaza con una sola anterior: si dos procesos agregan
+-- una entrada a la vez sobre la misma, la clave única rechaza a uno y este reintenta
+-- sobre la nueva última. Falla si la cadena ya tiene una bifurcación; se detecta con
+-- GET /api/v1/protected/admin/audit/verify.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_audit_entries_prev_hash" ON "audit_entries" ("prev_hash");
@@ -0,0 +1,22 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type logNotifier struct{}
+
+// NewLogNotifier crea un canal que solo escribe las notificaciones en el log. Pensado para desarrollo.
+func NewLogNotifier() ports.Notifier {
+	return &logNotifier{}
+}
+
+func (n *logNotifier) Notify(_ context.Context, notification domain.Notification) error {
+	log.Printf("notification to [%s]: %s\n%s", strings.Join(notification.Recipients, ", "), notification.Subject, notification.Body)
+	return nil
+}
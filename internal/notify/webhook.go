@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier crea un canal que envía cada notificación como JSON, por POST, a la URL indicada.
+func NewWebhookNotifier(url string) ports.Notifier {
+	return &webhookNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// webhookPayload es el cuerpo que recibe el webhook.
+type webhookPayload struct {
+	Recipients []string  `json:"recipients"`
+	Subject    string    `json:"subject"`
+	Body       string    `json:"body"`
+	SentAt     time.Time `json:"sentAt"`
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	payload, err := json.Marshal(webhookPayload{
+		Recipients: notification.Recipients,
+		Subject:    notification.Subject,
+		Body:       notification.Body,
+		SentAt:     time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
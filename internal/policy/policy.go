@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// relation es el vínculo del actor con la persona dueña del recurso.
+type relation int
+
+const (
+	stranger relation = iota
+	owner
+	delegate
+)
+
+type actions map[ports.PolicyAction]bool
+
+var (
+	readOnly    = actions{ports.ActionRead: true}
+	readWrite   = actions{ports.ActionRead: true, ports.ActionUpdate: true}
+	fullContact = actions{ports.ActionRead: true, ports.ActionCreate: true, ports.ActionUpdate: true, ports.ActionDelete: true}
+)
+
+// grants son las acciones permitidas según el vínculo y el tipo de recurso.
+// Los administradores pueden todo; eliminar una persona es solo para ellos.
+// El dueño puede crear su propio registro; el delegado gestiona el de otro
+// que ya existe y, como el dueño, todos sus contactos y relaciones, y ve su
+// asistencia, su credencial y sus notas. Solo el dueño decide quién recibe
+// la gestión de su registro.
+var grants = map[relation]map[domain.VersionEntity]actions{
+	owner: {
+		domain.PersonEntity:        {ports.ActionRead: true, ports.ActionCreate: true, ports.ActionUpdate: true},
+		domain.AddressEntity:       fullContact,
+		domain.PhoneEntity:         fullContact,
+		ports.RelationshipResource: fullContact,
+		ports.AttendanceResource:   readOnly,
+		ports.CredentialResource:   readOnly,
+		ports.NoteResource:         readOnly,
+		ports.DelegationResource:   readWrite,
+	},
+	delegate: {
+		domain.PersonEntity:        readWrite,
+		domain.AddressEntity:       fullContact,
+		domain.PhoneEntity:         fullContact,
+		ports.RelationshipResource: fullContact,
+		ports.AttendanceResource:   readOnly,
+		ports.CredentialResource:   readOnly,
+		ports.NoteResource:         readOnly,
+	},
+}
+
+type personPolicy struct {
+	delegations ports.DelegationChecker
+}
+
+// New crea la política de acceso a los datos de las personas. Sin delegations
+// (nil) nadie tiene acceso delegado.
+func New(delegations ports.DelegationChecker) ports.Policy {
+	return &personPolicy{delegations: delegations}
+}
+
+func (p *personPolicy) Authorize(actor domain.Actor, action ports.PolicyAction, resource ports.PolicyResource) error {
+	if actor.UserID == 0 || resource.Person == nil {
+		return ports.ErrForbidden
+	}
+	if actor.IsAdmin() {
+		return nil
+	}
+	rel, err := p.relation(actor, resource.Person)
+	if err != nil {
+		return err
+	}
+	if !grants[rel][resource.Type][action] {
+		return ports.ErrForbidden
+	}
+	return nil
+}
+
+func (p *personPolicy) relation(actor domain.Actor, person *domain.Person) (relation, error) {
+	if person.UserID != nil && *person.UserID == actor.UserID {
+		return owner, nil
+	}
+	// Un registro que aún no existe no puede estar delegado.
+	if p.delegations == nil || person.ID == 0 {
+		return stranger, nil
+	}
+	delegated, err := p.delegations.HasDelegation(actor.UserID, person.ID)
+	if err != nil {
+		return stranger, err
+	}
+	if delegated {
+		return delegate, nil
+	}
+	return stranger, nil
+}
@@ -0,0 +1,121 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type fakeDelegations map[[2]uint]bool
+
+func (f fakeDelegations) HasDelegation(userID, personID uint) (bool, error) {
+	return f[[2]uint{userID, personID}], nil
+}
+
+type failingDelegations struct{}
+
+func (failingDelegations) HasDelegation(uint, uint) (bool, error) {
+	return false, errDelegations
+}
+
+var errDelegations = errors.New("delegations unavailable")
+
+func TestAuthorize(t *testing.T) {
+	ownerID, delegateID, strangerID := uint(10), uint(20), uint(30)
+	person := &domain.Person{ID: 1, UserID: &ownerID}
+	unlinked := &domain.Person{ID: 2}
+	newOwn := &domain.Person{UserID: &ownerID}
+	newOther := &domain.Person{UserID: &strangerID}
+
+	admin := domain.Actor{UserID: 1, Role: domain.AdminRole}
+	owner := domain.Actor{UserID: ownerID, Role: domain.UserRole}
+	delegate := domain.Actor{UserID: delegateID, Role: domain.UserRole}
+	stranger := domain.Actor{UserID: strangerID, Role: domain.UserRole}
+	anonymous := domain.Actor{}
+
+	policy := New(fakeDelegations{{delegateID, person.ID}: true})
+
+	tests := []struct {
+		name     string
+		actor    domain.Actor
+		action   ports.PolicyAction
+		resource ports.PolicyResource
+		allowed  bool
+	}{
+		{"admin reads any person", admin, ports.ActionRead, ports.PolicyResource{Type: domain.PersonEntity, Person: unlinked}, true},
+		{"admin creates a person for another user", admin, ports.ActionCreate, ports.PolicyResource{Type: domain.PersonEntity, Person: newOther}, true},
+		{"admin deletes a person", admin, ports.ActionDelete, ports.PolicyResource{Type: domain.PersonEntity, Person: person}, true},
+		{"admin deletes a phone", admin, ports.ActionDelete, ports.PolicyResource{Type: domain.PhoneEntity, Person: unlinked}, true},
+
+		{"owner reads own person", owner, ports.ActionRead, ports.PolicyResource{Type: domain.PersonEntity, Person: person}, true},
+		{"owner creates own person", owner, ports.ActionCreate, ports.PolicyResource{Type: domain.PersonEntity, Person: newOwn}, true},
+		{"owner updates own person", owner, ports.ActionUpdate, ports.PolicyResource{Type: domain.PersonEntity, Person: person}, true},
+		{"owner cannot delete own person", owner, ports.ActionDelete, ports.PolicyResource{Type: domain.PersonEntity, Person: person}, false},
+		{"owner creates own address", owner, ports.ActionCreate, ports.PolicyResource{Type: domain.AddressEntity, Person: person}, true},
+		{"owner deletes own address", owner, ports.ActionDelete, ports.PolicyResource{Type: domain.AddressEntity, Person: person}, true},
+		{"owner updates own phone", owner, ports.ActionUpdate, ports.PolicyResource{Type: domain.PhoneEntity, Person: person}, true},
+		{"owner deletes own phone", owner, ports.ActionDelete, ports.PolicyResource{Type: domain.PhoneEntity, Person: person}, true},
+		{"owner cannot update unlinked person", owner, ports.ActionUpdate, ports.PolicyResource{Type: domain.PersonEntity, Person: unlinked}, false},
+
+		{"delegate reads person", delegate, ports.ActionRead, ports.PolicyResource{Type: domain.PersonEntity, Person: person}, true},
+		{"delegate updates person", delegate, ports.ActionUpdate, ports.PolicyResource{Type: domain.PersonEntity, Person: person}, true},
+		{"delegate cannot delete person", delegate, ports.ActionDelete, ports.PolicyResource{Type: domain.PersonEntity, Person: person}, false},
+		{"delegate creates address", delegate, ports.ActionCreate, ports.PolicyResource{Type: domain.AddressEntity, Person: person}, true},
+		{"delegate deletes phone", delegate, ports.ActionDelete, ports.PolicyResource{Type: domain.PhoneEntity, Person: person}, true},
+		{"delegate has no access to other persons", delegate, ports.ActionRead, ports.PolicyResource{Type: domain.PersonEntity, Person: unlinked}, false},
+		{"delegate cannot create persons", delegate, ports.ActionCreate, ports.PolicyResource{Type: domain.PersonEntity, Person: newOther}, false},
+
+		{"owner decides on delegations", owner, ports.ActionUpdate, ports.PolicyResource{Type: ports.DelegationResource, Person: person}, true},
+		{"owner reads own notes", owner, ports.ActionRead, ports.PolicyResource{Type: ports.NoteResource, Person: person}, true},
+		{"delegate adds a relationship", delegate, ports.ActionCreate, ports.PolicyResource{Type: ports.RelationshipResource, Person: person}, true},
+		{"delegate reads attendance", delegate, ports.ActionRead, ports.PolicyResource{Type: ports.AttendanceResource, Person: person}, true},
+		{"delegate reads credential", delegate, ports.ActionRead, ports.PolicyResource{Type: ports.CredentialResource, Person: person}, true},
+		{"delegate reads notes", delegate, ports.ActionRead, ports.PolicyResource{Type: ports.NoteResource, Person: person}, true},
+		{"delegate cannot decide on delegations", delegate, ports.ActionUpdate, ports.PolicyResource{Type: ports.DelegationResource, Person: person}, false},
+		{"stranger cannot read notes", stranger, ports.ActionRead, ports.PolicyResource{Type: ports.NoteResource, Person: person}, false},
+
+		{"stranger cannot read person", stranger, ports.ActionRead, ports.PolicyResource{Type: domain.PersonEntity, Person: person}, false},
+		{"stranger cannot update address", stranger, ports.ActionUpdate, ports.PolicyResource{Type: domain.AddressEntity, Person: person}, false},
+		{"stranger cannot create phone", stranger, ports.ActionCreate, ports.PolicyResource{Type: domain.PhoneEntity, Person: person}, false},
+
+		{"anonymous is always denied", anonymous, ports.ActionRead, ports.PolicyResource{Type: domain.PersonEntity, Person: unlinked}, false},
+		{"missing person is denied", admin, ports.ActionRead, ports.PolicyResource{Type: domain.PersonEntity}, false},
+		{"unknown resource type is denied", owner, ports.ActionRead, ports.PolicyResource{Type: "payment", Person: person}, false},
+		{"unknown action is denied", owner, "archive", ports.PolicyResource{Type: domain.PersonEntity, Person: person}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Authorize(tt.actor, tt.action, tt.resource)
+			if tt.allowed && err != nil {
+				t.Fatalf("expected access, got %v", err)
+			}
+			if !tt.allowed && !errors.Is(err, ports.ErrForbidden) {
+				t.Fatalf("expected ErrForbidden, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthorizeWithoutDelegations(t *testing.T) {
+	ownerID := uint(10)
+	person := &domain.Person{ID: 1, UserID: &ownerID}
+	policy := New(nil)
+
+	if err := policy.Authorize(domain.Actor{UserID: ownerID}, ports.ActionUpdate, ports.PolicyResource{Type: domain.PhoneEntity, Person: person}); err != nil {
+		t.Fatalf("owner should keep access without delegations, got %v", err)
+	}
+	if err := policy.Authorize(domain.Actor{UserID: 20}, ports.ActionRead, ports.PolicyResource{Type: domain.PersonEntity, Person: person}); !errors.Is(err, ports.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestAuthorizeDelegationError(t *testing.T) {
+	person := &domain.Person{ID: 1}
+	err := New(failingDelegations{}).Authorize(domain.Actor{UserID: 20}, ports.ActionRead, ports.PolicyResource{Type: domain.PersonEntity, Person: person})
+	if !errors.Is(err, errDelegations) {
+		t.Fatalf("expected the delegation error, got %v", err)
+	}
+}
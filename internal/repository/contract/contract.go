@@ -0,0 +1,660 @@
+// Package contract reúne las pruebas que debe pasar cualquier adaptador de los puertos
+// de repositorio, sea de GORM o en memoria. Cada adaptador las ejecuta desde sus propias
+// pruebas con Run.
+package contract
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// Repositories son los adaptadores que se comprueban, todos sobre el mismo almacenamiento.
+type Repositories struct {
+	Persons      ports.PersonRepository
+	Addresses    ports.AddressRepository
+	Phones       ports.PhoneRepository
+	Users        ports.UserRepository
+	Versions     ports.VersionRepository
+	CustomFields ports.CustomFieldRepository
+	Households   ports.HouseholdRepository
+	Audit        ports.AuditRepository
+	JobStates    ports.JobStateRepository
+	UnitOfWork   ports.UnitOfWork
+}
+
+// Run ejecuta la suite completa. setup debe devolver repositorios sin datos en cada llamada.
+func Run(t *testing.T, setup func(t *testing.T) Repositories) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repos Repositories)
+	}{
+		{"PersonSaveAndFind", testPersonSaveAndFind},
+		{"PersonNestedContacts", testPersonNestedContacts},
+		{"PersonDocumentIsUnique", testPersonDocumentIsUnique},
+		{"PersonLookups", testPersonLookups},
+		{"PersonSearch", testPersonSearch},
+		{"PersonSearchByCustomFields", testPersonSearchByCustomFields},
+		{"PersonSearchByUbigeo", testPersonSearchByUbigeo},
+		{"CustomFieldRemoveValues", testCustomFieldRemoveValues},
+		{"CustomFieldListValues", testCustomFieldListValues},
+		{"PersonDelete", testPersonDelete},
+		{"PersonRevokeCredential", testPersonRevokeCredential},
+		{"AddressCRUD", testAddressCRUD},
+		{"AddressOrderAndFilters", testAddressOrderAndFilters},
+		{"PhoneCRUD", testPhoneCRUD},
+		{"PhoneOrder", testPhoneOrder},
+		{"UserSaveAndFind", testUserSaveAndFind},
+		{"UserUsernameIsUnique", testUserUsernameIsUnique},
+		{"Versions", testVersions},
+		{"HouseholdSearch", testHouseholdSearch},
+		{"AuditPrevHashIsUnique", testAuditPrevHashIsUnique},
+		{"JobStateClaim", testJobStateClaim},
+		{"UnitOfWorkCommits", testUnitOfWorkCommits},
+		{"UnitOfWorkRollsBack", testUnitOfWorkRollsBack},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, setup(t))
+		})
+	}
+}
+
+// testAuditPrevHashIsUnique comprueba que la cadena de auditoría no se bifurca: dos
+// entradas no pueden enlazar con la misma anterior.
+func testAuditPrevHashIsUnique(t *testing.T, repos Repositories) {
+	first := &domain.AuditEntry{Action: domain.AuditSettingsUpdate, Outcome: domain.AuditSuccess, Hash: "a"}
+	mustSave(t, repos.Audit.Append(first))
+	mustSave(t, repos.Audit.Append(&domain.AuditEntry{Action: domain.AuditSettingsUpdate, Outcome: domain.AuditSuccess, PrevHash: "a", Hash: "b"}))
+
+	fork := &domain.AuditEntry{Action: domain.AuditSettingsUpdate, Outcome: domain.AuditSuccess, PrevHash: "a", Hash: "c"}
+	if err := repos.Audit.Append(fork); !errors.Is(err, ports.ErrConflict) {
+		t.Fatalf("Append of a second entry after %q = %v, want ErrConflict", first.Hash, err)
+	}
+	last, err := repos.Audit.Last()
+	if err != nil || last == nil || last.Hash != "b" {
+		t.Fatalf("Last = %+v, %v; want the entry %q", last, err, "b")
+	}
+}
+
+// testJobStateClaim comprueba que cada ejecución prevista de una tarea se reserva una sola vez.
+func testJobStateClaim(t *testing.T, repos Repositories) {
+	scheduledAt := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+	next := scheduledAt.AddDate(0, 0, 1)
+
+	claimed, err := repos.JobStates.Claim("reminders", scheduledAt, next)
+	if err != nil || !claimed {
+		t.Fatalf("first Claim = %v, %v; want true", claimed, err)
+	}
+	if claimed, err := repos.JobStates.Claim("reminders", scheduledAt, next); err != nil || claimed {
+		t.Fatalf("second Claim of the same run = %v, %v; want false", claimed, err)
+	}
+	state, err := repos.JobStates.Find("reminders")
+	if err != nil || state.NextRunAt == nil || !state.NextRunAt.Equal(next) {
+		t.Fatalf("Find = %+v, %v; want the next run at %v", state, err, next)
+	}
+	if claimed, err := repos.JobStates.Claim("reminders", next, next.AddDate(0, 0, 1)); err != nil || !claimed {
+		t.Fatalf("Claim of the next run = %v, %v; want true", claimed, err)
+	}
+}
+
+func testPersonSaveAndFind(t *testing.T, repos Repositories) {
+	person := &domain.Person{Name: "Ana", LastName: "Quispe", Sex: domain.Female,
+		CustomFields: domain.CustomFieldValues{"ministry": "choir"}}
+	mustSave(t, repos.Persons.Save(person))
+	if person.ID == 0 || person.CreatedAt.IsZero() || person.UpdatedAt.IsZero() {
+		t.Fatalf("Save should assign the ID and timestamps, got %+v", person)
+	}
+
+	found, err := repos.Persons.FindByID(person.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if found.FullName() != "Ana Quispe" || found.Sex != domain.Female || found.CustomFields["ministry"] != "choir" {
+		t.Fatalf("unexpected person %+v", found)
+	}
+
+	found.Name = "Ana María"
+	mustSave(t, repos.Persons.Save(found))
+	updated, err := repos.Persons.FindByID(person.ID)
+	if err != nil {
+		t.Fatalf("FindByID after update: %v", err)
+	}
+	if updated.Name != "Ana María" || !updated.CreatedAt.Equal(found.CreatedAt) {
+		t.Fatalf("update not persisted: %+v", updated)
+	}
+
+	if _, err := repos.Persons.FindByID(person.ID + 1000); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func testPersonNestedContacts(t *testing.T, repos Repositories) {
+	person := &domain.Person{
+		Name:      "Luis",
+		Addresses: []domain.Address{{Address: "Jr. Dos 2", SortOrder: 1}, {Address: "Av. Uno 1", SortOrder: 0}},
+		Phones:    []domain.Phone{{Phone: "+51999000001", IsPrimary: true}, {Phone: "+51999000002", SortOrder: 1}},
+	}
+	mustSave(t, repos.Persons.Save(person))
+	for _, address := range person.Addresses {
+		if address.ID == 0 || address.PersonID != person.ID {
+			t.Fatalf("nested address not saved: %+v", address)
+		}
+	}
+	for _, phone := range person.Phones {
+		if phone.ID == 0 || phone.PersonID != person.ID {
+			t.Fatalf("nested phone not saved: %+v", phone)
+		}
+	}
+
+	found, err := repos.Persons.FindByID(person.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if len(found.Addresses) != 2 || found.Addresses[0].Address != "Av. Uno 1" || found.Addresses[1].Address != "Jr. Dos 2" {
+		t.Fatalf("addresses should come in their sort order, got %+v", found.Addresses)
+	}
+	if len(found.Phones) != 2 || !found.Phones[0].IsPrimary || found.Phones[1].Phone != "+51999000002" {
+		t.Fatalf("phones should come in their sort order, got %+v", found.Phones)
+	}
+}
+
+func testPersonDocumentIsUnique(t *testing.T, repos Repositories) {
+	dni, ce := domain.DNI, domain.CE
+	number, empty := "12345678", ""
+
+	first := &domain.Person{Name: "Uno", TypeDoc: &dni, DocNumber: &number}
+	mustSave(t, repos.Persons.Save(first))
+
+	if err := repos.Persons.Save(&domain.Person{Name: "Dos", TypeDoc: &dni, DocNumber: &number}); !errors.Is(err, ports.ErrConflict) {
+		t.Fatalf("a repeated document should be a conflict, got %v", err)
+	}
+	mustSave(t, repos.Persons.Save(&domain.Person{Name: "Tres", TypeDoc: &ce, DocNumber: &number}))
+	mustSave(t, repos.Persons.Save(&domain.Person{Name: "Cuatro", TypeDoc: &dni, DocNumber: &empty}))
+	mustSave(t, repos.Persons.Save(&domain.Person{Name: "Cinco", TypeDoc: &dni, DocNumber: &empty}))
+	mustSave(t, repos.Persons.Save(&domain.Person{Name: "Seis"}))
+
+	first.Name = "Uno bis"
+	mustSave(t, repos.Persons.Save(first))
+}
+
+func testPersonLookups(t *testing.T, repos Repositories) {
+	user := &domain.User{Username: "rosa", PasswordHash: "hash", Role: domain.UserRole}
+	mustSave(t, repos.Users.Save(user))
+	userID := user.ID
+	dni, number := domain.DNI, "87654321"
+	birthday := time.Date(1990, time.May, 3, 0, 0, 0, 0, time.UTC)
+	linked := &domain.Person{Name: "Rosa", UserID: &userID, TypeDoc: &dni, DocNumber: &number, Birthday: &birthday,
+		Phones: []domain.Phone{{Phone: "+51999000003"}}}
+	mustSave(t, repos.Persons.Save(linked))
+	mustSave(t, repos.Persons.Save(&domain.Person{Name: "Sin cumpleaños"}))
+
+	byUser, err := repos.Persons.FindByUserID(userID)
+	if err != nil || byUser.ID != linked.ID || len(byUser.Phones) != 1 {
+		t.Fatalf("FindByUserID = %+v, %v", byUser, err)
+	}
+	if _, err := repos.Persons.FindByUserID(userID + 1); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	byDocument, err := repos.Persons.FindByDocument(dni, number)
+	if err != nil || byDocument.ID != linked.ID {
+		t.Fatalf("FindByDocument = %+v, %v", byDocument, err)
+	}
+	if _, err := repos.Persons.FindByDocument(domain.CE, number); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	withBirthday, err := repos.Persons.ListWithBirthday()
+	if err != nil || len(withBirthday) != 1 || withBirthday[0].ID != linked.ID {
+		t.Fatalf("ListWithBirthday = %+v, %v", withBirthday, err)
+	}
+}
+
+func testPersonSearch(t *testing.T, repos Repositories) {
+	number := "11112222"
+	dni := domain.DNI
+	ana := &domain.Person{Name: "Ana", MiddleName: "Lucía", LastName: "Quispe", Phones: []domain.Phone{{Phone: "+51987654321"}}}
+	juan := &domain.Person{Name: "Juan", LastName: "Pérez", TypeDoc: &dni, DocNumber: &number}
+	mara := &domain.Person{Name: "Mara", LastName: "Quispe"}
+	for _, person := range []*domain.Person{ana, juan, mara} {
+		mustSave(t, repos.Persons.Save(person))
+	}
+
+	// SQLite solo ignora las mayúsculas del ASCII; los acentos se buscan tal como se guardaron.
+	assertSearch(t, repos, "all, newest first", ports.PersonFilter{}, mara.ID, juan.ID, ana.ID)
+	assertSearch(t, repos, "name ignores case", ports.PersonFilter{Term: "quispe"}, mara.ID, ana.ID)
+	assertSearch(t, repos, "full name", ports.PersonFilter{Term: "ana lucía"}, ana.ID)
+	assertSearch(t, repos, "document", ports.PersonFilter{Term: number}, juan.ID)
+	assertSearch(t, repos, "phone digits", ports.PersonFilter{Term: "987654", Phone: "987654"}, ana.ID)
+	assertSearch(t, repos, "limit", ports.PersonFilter{Limit: 2}, mara.ID, juan.ID)
+
+	persons, err := repos.Persons.Search(ports.PersonFilter{Term: "Ana"})
+	if err != nil || len(persons) != 1 || len(persons[0].Phones) != 1 {
+		t.Fatalf("search results should include the contacts, got %+v, %v", persons, err)
+	}
+}
+
+func testPersonSearchByCustomFields(t *testing.T, repos Repositories) {
+	choir := &domain.Person{Name: "Ana", CustomFields: domain.CustomFieldValues{"ministry": "choir", "children": 2, "baptized": true}}
+	ushers := &domain.Person{Name: "Luis", CustomFields: domain.CustomFieldValues{"ministry": "ushers", "children": 0, "baptized": false}}
+	without := &domain.Person{Name: "Rosa"}
+	for _, person := range []*domain.Person{choir, ushers, without} {
+		mustSave(t, repos.Persons.Save(person))
+	}
+
+	// Los valores se comparan como texto, sea cual sea su tipo en JSON.
+	assertSearch(t, repos, "text", ports.PersonFilter{CustomFields: map[string]string{"ministry": "choir"}}, choir.ID)
+	assertSearch(t, repos, "number", ports.PersonFilter{CustomFields: map[string]string{"children": "0"}}, ushers.ID)
+	assertSearch(t, repos, "boolean", ports.PersonFilter{CustomFields: map[string]string{"baptized": "true"}}, choir.ID)
+	assertSearch(t, repos, "all fields must match",
+		ports.PersonFilter{CustomFields: map[string]string{"ministry": "choir", "baptized": "false"}})
+	assertSearch(t, repos, "missing field", ports.PersonFilter{CustomFields: map[string]string{"occupation": "teacher"}})
+}
+
+func testPersonSearchByUbigeo(t *testing.T, repos Repositories) {
+	person := newPerson(t, repos)
+	mustSave(t, repos.Addresses.Save(&domain.Address{PersonID: person.ID, Address: "Av. Brasil 1234", Ubigeo: "150113", Country: domain.PeruCountryCode}))
+
+	assertSearch(t, repos, "department", ports.PersonFilter{Ubigeo: "15"}, person.ID)
+	assertSearch(t, repos, "province", ports.PersonFilter{Ubigeo: "1501"}, person.ID)
+	assertSearch(t, repos, "district", ports.PersonFilter{Ubigeo: "150113"}, person.ID)
+	assertSearch(t, repos, "another district", ports.PersonFilter{Ubigeo: "150114"})
+	for _, wildcard := range []string{"%", "15%", "1_0113"} {
+		if _, err := repos.Persons.Search(ports.PersonFilter{Ubigeo: wildcard}); err == nil {
+			t.Errorf("Search with ubigeo %q should fail", wildcard)
+		}
+	}
+}
+
+func testCustomFieldRemoveValues(t *testing.T, repos Repositories) {
+	ana := &domain.Person{Name: "Ana", CustomFields: domain.CustomFieldValues{"ministry": "choir", "occupation": "teacher"}}
+	luis := &domain.Person{Name: "Luis", CustomFields: domain.CustomFieldValues{"occupation": "nurse"}}
+	rosa := &domain.Person{Name: "Rosa"}
+	for _, person := range []*domain.Person{ana, luis, rosa} {
+		mustSave(t, repos.Persons.Save(person))
+	}
+
+	if err := repos.CustomFields.RemoveValues("ministry"); err != nil {
+		t.Fatalf("RemoveValues: %v", err)
+	}
+	for _, want := range []*domain.Person{
+		{ID: ana.ID, CustomFields: domain.CustomFieldValues{"occupation": "teacher"}},
+		{ID: luis.ID, CustomFields: domain.CustomFieldValues{"occupation": "nurse"}},
+		{ID: rosa.ID},
+	} {
+		found, err := repos.Persons.FindByID(want.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if len(found.CustomFields) != len(want.CustomFields) || found.CustomFields["occupation"] != want.CustomFields["occupation"] {
+			t.Fatalf("person %d: got custom fields %v, want %v", want.ID, found.CustomFields, want.CustomFields)
+		}
+	}
+}
+
+func testCustomFieldListValues(t *testing.T, repos Repositories) {
+	ana := &domain.Person{Name: "Ana", CustomFields: domain.CustomFieldValues{"ministry": "choir", "children": 2.0}}
+	luis := &domain.Person{Name: "Luis", CustomFields: domain.CustomFieldValues{"children": 0.0}}
+	rosa := &domain.Person{Name: "Rosa"}
+	for _, person := range []*domain.Person{ana, luis, rosa} {
+		mustSave(t, repos.Persons.Save(person))
+	}
+
+	values, err := repos.CustomFields.ListValues("children")
+	if err != nil {
+		t.Fatalf("ListValues: %v", err)
+	}
+	if len(values) != 2 || values[ana.ID] != 2.0 || values[luis.ID] != 0.0 {
+		t.Fatalf("ListValues = %v, want the values of Ana and Luis", values)
+	}
+	missing, err := repos.CustomFields.CountMissing("ministry")
+	if err != nil || missing != 2 {
+		t.Fatalf("CountMissing = %d, %v; want Luis and Rosa", missing, err)
+	}
+}
+
+func testPersonRevokeCredential(t *testing.T, repos Repositories) {
+	person := &domain.Person{Name: "Ana"}
+	mustSave(t, repos.Persons.Save(person))
+	if err := repos.Persons.RevokeCredential(person.ID); err != nil {
+		t.Fatalf("RevokeCredential: %v", err)
+	}
+
+	// Guardar una copia leída antes de revocar no recupera la versión anterior.
+	person.Name = "Ana María"
+	mustSave(t, repos.Persons.Save(person))
+	found, err := repos.Persons.FindByID(person.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if found.CredentialVersion != 1 || found.Name != "Ana María" {
+		t.Fatalf("got version %d and name %q, want 1 and the new name", found.CredentialVersion, found.Name)
+	}
+	if err := repos.Persons.RevokeCredential(person.ID + 100); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("RevokeCredential of a missing person: expected ErrNotFound, got %v", err)
+	}
+}
+
+func testPersonDelete(t *testing.T, repos Repositories) {
+	person := &domain.Person{Name: "Temporal"}
+	mustSave(t, repos.Persons.Save(person))
+	if err := repos.Persons.Delete(person.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repos.Persons.FindByID(person.ID); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func testAddressCRUD(t *testing.T, repos Repositories) {
+	person := newPerson(t, repos)
+	lat, lon := -12.0464, -77.0428
+	address := &domain.Address{PersonID: person.ID, Address: "Av. Uno 1", Ubigeo: "150101", Country: domain.PeruCountryCode,
+		Latitude: &lat, Longitude: &lon}
+	mustSave(t, repos.Addresses.Save(address))
+	if address.ID == 0 || address.CreatedAt.IsZero() {
+		t.Fatalf("Save should assign the ID and timestamps, got %+v", address)
+	}
+
+	found, err := repos.Addresses.FindByID(address.ID)
+	if err != nil || found.Address != "Av. Uno 1" || found.Point() == nil {
+		t.Fatalf("FindByID = %+v, %v", found, err)
+	}
+	found.Reference = "Frente al parque"
+	mustSave(t, repos.Addresses.Save(found))
+	if updated, err := repos.Addresses.FindByID(address.ID); err != nil || updated.Reference != "Frente al parque" {
+		t.Fatalf("update not persisted: %+v, %v", updated, err)
+	}
+
+	if err := repos.Addresses.Delete(address.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repos.Addresses.FindByID(address.ID); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func testAddressOrderAndFilters(t *testing.T, repos Repositories) {
+	person := newPerson(t, repos)
+	lat, lon := -12.0464, -77.0428
+	structured := &domain.Address{PersonID: person.ID, Address: "Av. Uno 1", Ubigeo: "150101", Country: domain.PeruCountryCode,
+		Latitude: &lat, Longitude: &lon, SortOrder: 0}
+	loose := &domain.Address{PersonID: person.ID, Address: "Jr. Dos 2", Country: domain.PeruCountryCode, SortOrder: 1}
+	abroad := &domain.Address{PersonID: person.ID, Address: "Calle 3", Country: "CL", SortOrder: 2}
+	for _, address := range []*domain.Address{structured, loose, abroad} {
+		mustSave(t, repos.Addresses.Save(address))
+	}
+
+	if count, err := repos.Addresses.CountByPersonID(person.ID); err != nil || count != 3 {
+		t.Fatalf("CountByPersonID = %d, %v", count, err)
+	}
+	if err := repos.Addresses.Reorder([]uint{abroad.ID, structured.ID, loose.ID}); err != nil {
+		t.Fatalf("Reorder: %v", err)
+	}
+	addresses, err := repos.Addresses.FindByPersonID(person.ID)
+	if err != nil {
+		t.Fatalf("FindByPersonID: %v", err)
+	}
+	assertOrder(t, "addresses", []uint{abroad.ID, structured.ID, loose.ID}, addresses, func(a domain.Address) uint { return a.ID })
+
+	unstructured, err := repos.Addresses.FindUnstructured()
+	if err != nil {
+		t.Fatalf("FindUnstructured: %v", err)
+	}
+	assertOrder(t, "unstructured", []uint{loose.ID}, unstructured, func(a domain.Address) uint { return a.ID })
+
+	withoutCoordinates, err := repos.Addresses.FindWithoutCoordinates()
+	if err != nil {
+		t.Fatalf("FindWithoutCoordinates: %v", err)
+	}
+	assertOrder(t, "without coordinates", []uint{loose.ID, abroad.ID}, withoutCoordinates, func(a domain.Address) uint { return a.ID })
+}
+
+func testPhoneCRUD(t *testing.T, repos Repositories) {
+	person := newPerson(t, repos)
+	phone := &domain.Phone{PersonID: person.ID, Phone: "+51999000010", Type: domain.MobilePhone}
+	mustSave(t, repos.Phones.Save(phone))
+	if phone.ID == 0 || phone.CreatedAt.IsZero() {
+		t.Fatalf("Save should assign the ID and timestamps, got %+v", phone)
+	}
+
+	found, err := repos.Phones.FindByID(phone.ID)
+	if err != nil || found.Phone != "+51999000010" || found.Type != domain.MobilePhone {
+		t.Fatalf("FindByID = %+v, %v", found, err)
+	}
+	found.Type = domain.WhatsAppPhone
+	mustSave(t, repos.Phones.Save(found))
+	if updated, err := repos.Phones.FindByID(phone.ID); err != nil || updated.Type != domain.WhatsAppPhone {
+		t.Fatalf("update not persisted: %+v, %v", updated, err)
+	}
+
+	if err := repos.Phones.Delete(phone.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repos.Phones.FindByID(phone.ID); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func testPhoneOrder(t *testing.T, repos Repositories) {
+	ana, luis := newPerson(t, repos), newPerson(t, repos)
+	first := &domain.Phone{PersonID: ana.ID, Phone: "+51999000011", SortOrder: 0}
+	second := &domain.Phone{PersonID: ana.ID, Phone: "+51999000012", SortOrder: 1}
+	other := &domain.Phone{PersonID: luis.ID, Phone: "+51999000013"}
+	for _, phone := range []*domain.Phone{first, second, other} {
+		mustSave(t, repos.Phones.Save(phone))
+	}
+
+	if count, err := repos.Phones.CountByPersonID(ana.ID); err != nil || count != 2 {
+		t.Fatalf("CountByPersonID = %d, %v", count, err)
+	}
+	if err := repos.Phones.Reorder([]uint{second.ID, first.ID}); err != nil {
+		t.Fatalf("Reorder: %v", err)
+	}
+	phones, err := repos.Phones.FindByPersonID(ana.ID)
+	if err != nil {
+		t.Fatalf("FindByPersonID: %v", err)
+	}
+	assertOrder(t, "phones", []uint{second.ID, first.ID}, phones, func(p domain.Phone) uint { return p.ID })
+
+	all, err := repos.Phones.FindAll()
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	assertOrder(t, "all phones", []uint{first.ID, second.ID, other.ID}, all, func(p domain.Phone) uint { return p.ID })
+}
+
+func testUserSaveAndFind(t *testing.T, repos Repositories) {
+	user := &domain.User{Username: "ana", PasswordHash: "hash", Role: domain.UserRole}
+	mustSave(t, repos.Users.Save(user))
+	if user.ID == 0 || user.CreatedAt.IsZero() {
+		t.Fatalf("Save should assign the ID and timestamps, got %+v", user)
+	}
+
+	byName, err := repos.Users.FindByUsername("ana")
+	if err != nil || byName.ID != user.ID || byName.Role != domain.UserRole {
+		t.Fatalf("FindByUsername = %+v, %v", byName, err)
+	}
+	byName.Role = domain.AdminRole
+	mustSave(t, repos.Users.Save(byName))
+	if byID, err := repos.Users.FindByID(user.ID); err != nil || byID.Role != domain.AdminRole {
+		t.Fatalf("FindByID = %+v, %v", byID, err)
+	}
+
+	if _, err := repos.Users.FindByUsername("nadie"); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := repos.Users.FindByID(user.ID + 1000); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if users, err := repos.Users.FindAll(); err != nil || len(users) != 1 {
+		t.Fatalf("FindAll = %+v, %v", users, err)
+	}
+}
+
+func testUserUsernameIsUnique(t *testing.T, repos Repositories) {
+	mustSave(t, repos.Users.Save(&domain.User{Username: "luis", PasswordHash: "hash", Role: domain.UserRole}))
+	err := repos.Users.Save(&domain.User{Username: "luis", PasswordHash: "other", Role: domain.UserRole})
+	if !errors.Is(err, ports.ErrConflict) {
+		t.Fatalf("a repeated username should be a conflict, got %v", err)
+	}
+}
+
+func testVersions(t *testing.T, repos Repositories) {
+	person := newPerson(t, repos)
+	if latest, err := repos.Versions.LatestVersion(domain.PersonEntity, person.ID); err != nil || latest != 0 {
+		t.Fatalf("LatestVersion without versions = %d, %v", latest, err)
+	}
+
+	var saved []*domain.EntityVersion
+	for version := 1; version <= 2; version++ {
+		entry := &domain.EntityVersion{EntityType: domain.PersonEntity, EntityID: person.ID, PersonID: person.ID,
+			Version: version, Action: domain.VersionUpdated, Snapshot: map[string]*string{"name": &person.Name}}
+		mustSave(t, repos.Versions.Save(entry))
+		saved = append(saved, entry)
+	}
+
+	if latest, err := repos.Versions.LatestVersion(domain.PersonEntity, person.ID); err != nil || latest != 2 {
+		t.Fatalf("LatestVersion = %d, %v", latest, err)
+	}
+	found, err := repos.Versions.FindByID(saved[0].ID)
+	if err != nil || found.Version != 1 || found.Snapshot["name"] == nil || *found.Snapshot["name"] != person.Name {
+		t.Fatalf("FindByID = %+v, %v", found, err)
+	}
+	versions, err := repos.Versions.FindByPersonID(person.ID)
+	if err != nil {
+		t.Fatalf("FindByPersonID: %v", err)
+	}
+	assertOrder(t, "versions", []uint{saved[1].ID, saved[0].ID}, versions, func(v domain.EntityVersion) uint { return v.ID })
+	if _, err := repos.Versions.FindByID(saved[1].ID + 1000); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func testHouseholdSearch(t *testing.T, repos Repositories) {
+	quispe := &domain.Household{Name: "Familia Quispe"}
+	perez := &domain.Household{Name: "Casa Pérez"}
+	for _, household := range []*domain.Household{quispe, perez} {
+		mustSave(t, repos.Households.Save(household))
+	}
+	member := &domain.Person{Name: "Rosa", LastName: "Mamani"}
+	mustSave(t, repos.Persons.Save(member))
+	mustSave(t, repos.Households.SetHousehold([]uint{member.ID}, &perez.ID))
+	mustSave(t, repos.Households.SaveAddress(&domain.HouseholdAddress{HouseholdID: quispe.ID, Address: "Av. Los Álamos 123"}))
+
+	search := func(name, term string, want ...uint) []domain.Household {
+		t.Helper()
+		households, err := repos.Households.Search(term)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		assertOrder(t, name, want, households, func(h domain.Household) uint { return h.ID })
+		return households
+	}
+	search("all, newest first", "", perez.ID, quispe.ID)
+	search("name ignores case", "QUISPE", quispe.ID)
+	search("address", "los Álamos", quispe.ID)
+	if found := search("member name", "mamani", perez.ID); len(found[0].Members) != 1 {
+		t.Fatalf("search results should include the members, got %+v", found[0].Members)
+	}
+}
+
+func testUnitOfWorkCommits(t *testing.T, repos Repositories) {
+	var person domain.Person
+	err := repos.UnitOfWork.Do(func(tx ports.Repositories) error {
+		person = domain.Person{Name: "Confirmada", Phones: []domain.Phone{{Phone: "+51999000020"}}}
+		if err := tx.Persons.Save(&person); err != nil {
+			return err
+		}
+		if err := tx.Addresses.Save(&domain.Address{PersonID: person.ID, Address: "Av. Uno 1"}); err != nil {
+			return err
+		}
+		return tx.Versions.Save(&domain.EntityVersion{EntityType: domain.PersonEntity, EntityID: person.ID, PersonID: person.ID, Version: 1})
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	found, err := repos.Persons.FindByID(person.ID)
+	if err != nil || len(found.Phones) != 1 || len(found.Addresses) != 1 {
+		t.Fatalf("committed work should be visible, got %+v, %v", found, err)
+	}
+	if latest, err := repos.Versions.LatestVersion(domain.PersonEntity, person.ID); err != nil || latest != 1 {
+		t.Fatalf("LatestVersion = %d, %v", latest, err)
+	}
+}
+
+func testUnitOfWorkRollsBack(t *testing.T, repos Repositories) {
+	existing := newPerson(t, repos)
+	failure := errors.New("stop")
+	var created domain.Person
+	err := repos.UnitOfWork.Do(func(tx ports.Repositories) error {
+		created = domain.Person{Name: "Descartada", Phones: []domain.Phone{{Phone: "+51999000021"}}}
+		if err := tx.Persons.Save(&created); err != nil {
+			return err
+		}
+		renamed := *existing
+		renamed.Name = "Cambiada"
+		if err := tx.Persons.Save(&renamed); err != nil {
+			return err
+		}
+		if err := tx.Phones.Save(&domain.Phone{PersonID: existing.ID, Phone: "+51999000022"}); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Do should return the error of fn, got %v", err)
+	}
+
+	if _, err := repos.Persons.FindByID(created.ID); !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("rolled back person should not exist, got %v", err)
+	}
+	found, err := repos.Persons.FindByID(existing.ID)
+	if err != nil || found.Name != existing.Name || len(found.Phones) != 0 {
+		t.Fatalf("rolled back changes should not be visible, got %+v, %v", found, err)
+	}
+	if phones, err := repos.Phones.FindAll(); err != nil || len(phones) != 0 {
+		t.Fatalf("rolled back phones should not exist, got %+v, %v", phones, err)
+	}
+}
+
+func newPerson(t *testing.T, repos Repositories) *domain.Person {
+	t.Helper()
+	person := &domain.Person{Name: "Persona", LastName: "De prueba"}
+	mustSave(t, repos.Persons.Save(person))
+	return person
+}
+
+// assertSearch comprueba que la búsqueda devuelva exactamente las personas want, en ese orden.
+func assertSearch(t *testing.T, repos Repositories, name string, filter ports.PersonFilter, want ...uint) {
+	t.Helper()
+	persons, err := repos.Persons.Search(filter)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	assertOrder(t, name, want, persons, func(p domain.Person) uint { return p.ID })
+}
+
+func mustSave(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+}
+
+func assertOrder[V any](t *testing.T, name string, want []uint, values []V, id func(V) uint) {
+	t.Helper()
+	if len(values) != len(want) {
+		t.Fatalf("%s: got %d records, want %d", name, len(values), len(want))
+	}
+	for i, value := range values {
+		if id(value) != want[i] {
+			t.Fatalf("%s: record %d has ID %d, want %d", name, i, id(value), want[i])
+		}
+	}
+}
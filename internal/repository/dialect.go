@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Las pocas consultas cuya sintaxis cambia entre PostgreSQL y SQLite se arman con estas
+// funciones, que eligen según el driver de la conexión. El resto del SQL es común.
+
+// isSQLite indica si la conexión es a SQLite.
+func isSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// containsIgnoringCase devuelve la condición "expr coincide con el patrón LIKE ?" sin
+// distinguir mayúsculas. En SQLite, LIKE ya ignora mayúsculas, pero solo las del ASCII.
+func containsIgnoringCase(db *gorm.DB, expr string) string {
+	if isSQLite(db) {
+		return expr + " LIKE ?"
+	}
+	return expr + " ILIKE ?"
+}
+
+// jsonFieldEquals devuelve la condición "el valor de key en la columna JSON column,
+// como texto, es value". Los textos se comparan sin comillas y los números y booleanos
+// con su forma JSON, como hace ->> en PostgreSQL.
+func jsonFieldEquals(db *gorm.DB, column, key, value string) clause.Expr {
+	if isSQLite(db) {
+		path := jsonPath(key)
+		return gorm.Expr("CASE json_type("+column+", ?) WHEN 'text' THEN json_extract("+column+", ?) ELSE "+column+" -> ? END = ?",
+			path, path, path, value)
+	}
+	return gorm.Expr(column+" ->> ? = ?", key, value)
+}
+
+// jsonHasKey devuelve la condición "la columna JSON column tiene la clave key".
+func jsonHasKey(db *gorm.DB, column, key string) clause.Expr {
+	if isSQLite(db) {
+		return gorm.Expr("json_type("+column+", ?) IS NOT NULL", jsonPath(key))
+	}
+	return gorm.Expr("jsonb_exists("+column+", ?)", key)
+}
+
+// jsonWithoutKey devuelve la columna JSON column sin la clave key.
+func jsonWithoutKey(db *gorm.DB, column, key string) clause.Expr {
+	if isSQLite(db) {
+		return gorm.Expr("json_remove("+column+", ?)", jsonPath(key))
+	}
+	return gorm.Expr(column+" - ?", key)
+}
+
+// jsonPath es la ruta de SQLite para una clave de primer nivel. Las claves de los
+// campos personalizados solo tienen minúsculas, dígitos y guiones bajos.
+func jsonPath(key string) string {
+	return "$." + key
+}
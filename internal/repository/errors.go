@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// Códigos SQLSTATE de PostgreSQL para las restricciones de integridad.
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgNotNullViolation    = "23502"
+	pgCheckViolation      = "23514"
+	pgExclusionViolation  = "23P01"
+)
+
+// translateError convierte los errores de GORM y del driver en los errores de ports, para
+// que los servicios no dependan de la base de datos. El error original se conserva
+// envuelto; los que no son de integridad se devuelven sin cambios.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ports.ErrNotFound) || errors.Is(err, ports.ErrConflict) || errors.Is(err, ports.ErrConstraintViolation) {
+		return err
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ports.ErrNotFound
+	}
+	// Los dialectos con TranslateError activado ya traen el error genérico de GORM.
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("%w: %w", ports.ErrConflict, err)
+	}
+	if errors.Is(err, gorm.ErrForeignKeyViolated) || errors.Is(err, gorm.ErrCheckConstraintViolated) {
+		return fmt.Errorf("%w: %w", ports.ErrConstraintViolation, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			return fmt.Errorf("%w: %w", ports.ErrConflict, err)
+		case pgForeignKeyViolation, pgNotNullViolation, pgCheckViolation, pgExclusionViolation:
+			return fmt.Errorf("%w: %w", ports.ErrConstraintViolation, err)
+		}
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.ExtendedCode {
+		case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+			return fmt.Errorf("%w: %w", ports.ErrConflict, err)
+		case sqlite3.ErrConstraintForeignKey, sqlite3.ErrConstraintNotNull, sqlite3.ErrConstraintCheck:
+			return fmt.Errorf("%w: %w", ports.ErrConstraintViolation, err)
+		}
+	}
+	return err
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"sort"
+
 	"github.com/riada2/internal/core/domain"
 	"github.com/riada2/internal/core/ports"
 	"gorm.io/gorm"
@@ -8,24 +10,38 @@ import (
 
 type gormAddressRepository struct {
 	db *gorm.DB
+	// postGIS indica si la base de datos tiene PostGIS; si no, las distancias se calculan en Go.
+	postGIS bool
 }
 
 func NewGormAddressRepository(db *gorm.DB) ports.AddressRepository {
-	return &gormAddressRepository{db: db}
+	return &gormAddressRepository{db: db, postGIS: hasPostGIS(db)}
+}
+
+// hasPostGIS indica si la base de datos es PostgreSQL con la extensión PostGIS instalada.
+func hasPostGIS(db *gorm.DB) bool {
+	if db.Dialector.Name() != "postgres" {
+		return false
+	}
+	var count int64
+	if err := db.Raw("SELECT COUNT(*) FROM pg_extension WHERE extname = 'postgis'").Scan(&count).Error; err != nil {
+		return false
+	}
+	return count > 0
 }
 
 func (r *gormAddressRepository) Save(address *domain.Address) error {
-	return r.db.Save(address).Error
+	return translateError(r.db.Save(address).Error)
 }
 
 func (r *gormAddressRepository) Delete(id uint) error {
-	return r.db.Delete(&domain.Address{}, id).Error
+	return translateError(r.db.Delete(&domain.Address{}, id).Error)
 }
 
 func (r *gormAddressRepository) FindByID(id uint) (*domain.Address, error) {
 	var address domain.Address
 	if err := r.db.First(&address, id).Error; err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return &address, nil
 }
@@ -33,5 +49,144 @@ func (r *gormAddressRepository) FindByID(id uint) (*domain.Address, error) {
 func (r *gormAddressRepository) CountByPersonID(personID uint) (int64, error) {
 	var count int64
 	err := r.db.Model(&domain.Address{}).Where("person_id = ?", personID).Count(&count).Error
-	return count, err
+	return count, translateError(err)
+}
+
+func (r *gormAddressRepository) FindByPersonID(personID uint) ([]domain.Address, error) {
+	var addresses []domain.Address
+	err := r.db.Where("person_id = ?", personID).Order("sort_order, id").Find(&addresses).Error
+	return addresses, translateError(err)
+}
+
+func (r *gormAddressRepository) Reorder(ids []uint) error {
+	return reorder(r.db, &domain.Address{}, ids)
+}
+
+// reorder guarda, en una transacción, la posición de cada ID de la lista como su sort_order.
+func reorder(db *gorm.DB, model any, ids []uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(model).Where("id = ?", id).Update("sort_order", i).Error; err != nil {
+				return translateError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *gormAddressRepository) FindUnstructured() ([]domain.Address, error) {
+	var addresses []domain.Address
+	err := r.db.Where("(ubigeo IS NULL OR ubigeo = '') AND (country IS NULL OR country IN ('', ?))", domain.PeruCountryCode).
+		Order("id").Find(&addresses).Error
+	return addresses, translateError(err)
+}
+
+func (r *gormAddressRepository) FindWithoutCoordinates() ([]domain.Address, error) {
+	var addresses []domain.Address
+	err := r.db.Where("latitude IS NULL OR longitude IS NULL").Order("id").Find(&addresses).Error
+	return addresses, translateError(err)
+}
+
+// nearbyRow es una dirección con su distancia al centro de la búsqueda.
+type nearbyRow struct {
+	domain.Address `gorm:"embedded"`
+	DistanceKm     float64
+}
+
+func (r *gormAddressRepository) FindNearby(query ports.NearbyQuery) ([]domain.NearbyPerson, error) {
+	var rows []nearbyRow
+	var err error
+	if r.postGIS {
+		rows, err = r.nearbyPostGIS(query)
+	} else {
+		rows, err = r.nearbyHaversine(query)
+	}
+	if err != nil || len(rows) == 0 {
+		return nil, translateError(err)
+	}
+
+	personIDs := make([]uint, len(rows))
+	for i, row := range rows {
+		personIDs[i] = row.PersonID
+	}
+	var persons []domain.Person
+	if err := r.db.Where("id IN ?", personIDs).Find(&persons).Error; err != nil {
+		return nil, translateError(err)
+	}
+	byID := make(map[uint]domain.Person, len(persons))
+	for _, person := range persons {
+		byID[person.ID] = person
+	}
+
+	nearby := make([]domain.NearbyPerson, 0, len(rows))
+	for _, row := range rows {
+		if person, ok := byID[row.PersonID]; ok {
+			nearby = append(nearby, domain.NearbyPerson{Person: person, Address: row.Address, DistanceKm: row.DistanceKm})
+		}
+	}
+	return nearby, nil
+}
+
+// nearbyPostGIS calcula las distancias sobre el esferoide con PostGIS y se queda con la
+// dirección más cercana de cada persona.
+func (r *gormAddressRepository) nearbyPostGIS(query ports.NearbyQuery) ([]nearbyRow, error) {
+	const point = "geography(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326))"
+	const center = "geography(ST_SetSRID(ST_MakePoint(@lon, @lat), 4326))"
+	sql := "SELECT * FROM (" +
+		"SELECT DISTINCT ON (person_id) addresses.*, ST_Distance(" + point + ", " + center + ") / 1000 AS distance_km" +
+		" FROM addresses WHERE latitude IS NOT NULL AND longitude IS NOT NULL AND person_id <> @exclude"
+	if query.RadiusKm > 0 {
+		sql += " AND ST_DWithin(" + point + ", " + center + ", @radius)"
+	}
+	sql += " ORDER BY person_id, distance_km) nearest ORDER BY distance_km, person_id LIMIT @limit"
+
+	var rows []nearbyRow
+	err := r.db.Raw(sql, map[string]any{
+		"lat":     query.Center.Latitude,
+		"lon":     query.Center.Longitude,
+		"radius":  query.RadiusKm * 1000,
+		"exclude": query.ExcludePersonID,
+		"limit":   query.Limit,
+	}).Scan(&rows).Error
+	return rows, translateError(err)
+}
+
+// nearbyHaversine descarta en la base de datos las direcciones fuera del rectángulo que
+// contiene el radio y calcula la distancia exacta con la fórmula del haversine.
+func (r *gormAddressRepository) nearbyHaversine(query ports.NearbyQuery) ([]nearbyRow, error) {
+	db := r.db.Where("latitude IS NOT NULL AND longitude IS NOT NULL AND person_id <> ?", query.ExcludePersonID)
+	if query.RadiusKm > 0 {
+		minLat, maxLat, minLon, maxLon := query.Center.BoundingBox(query.RadiusKm)
+		db = db.Where("latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", minLat, maxLat, minLon, maxLon)
+	}
+	var addresses []domain.Address
+	if err := db.Find(&addresses).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	nearest := make(map[uint]nearbyRow)
+	for _, address := range addresses {
+		distance := query.Center.DistanceKm(*address.Point())
+		if query.RadiusKm > 0 && distance > query.RadiusKm {
+			continue
+		}
+		if current, ok := nearest[address.PersonID]; !ok || distance < current.DistanceKm {
+			nearest[address.PersonID] = nearbyRow{Address: address, DistanceKm: distance}
+		}
+	}
+
+	rows := make([]nearbyRow, 0, len(nearest))
+	for _, row := range nearest {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].DistanceKm != rows[j].DistanceKm {
+			return rows[i].DistanceKm < rows[j].DistanceKm
+		}
+		return rows[i].PersonID < rows[j].PersonID
+	})
+	if query.Limit > 0 && len(rows) > query.Limit {
+		rows = rows[:query.Limit]
+	}
+	return rows, nil
 }
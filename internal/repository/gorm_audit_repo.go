@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type gormAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAuditRepository(db *gorm.DB) ports.AuditRepository {
+	return &gormAuditRepository{db: db}
+}
+
+// auditChainLock es la clave del bloqueo consultivo de PostgreSQL que serializa la cadena.
+const auditChainLock = 0x61756469 // "audi"
+
+// LockChain toma en PostgreSQL un bloqueo consultivo que se libera al terminar la
+// transacción. En SQLite no hace falta: las transacciones toman el bloqueo de escritura
+// al empezar (_txlock=immediate).
+func (r *gormAuditRepository) LockChain() error {
+	if isSQLite(r.db) {
+		return nil
+	}
+	return translateError(r.db.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLock).Error)
+}
+
+func (r *gormAuditRepository) Append(entry *domain.AuditEntry) error {
+	return translateError(r.db.Create(entry).Error)
+}
+
+func (r *gormAuditRepository) Last() (*domain.AuditEntry, error) {
+	// Find en lugar de First: una auditoría vacía no es un error.
+	var entries []domain.AuditEntry
+	if err := r.db.Order("id DESC").Limit(1).Find(&entries).Error; err != nil {
+		return nil, translateError(err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+func (r *gormAuditRepository) Search(filter ports.AuditFilter) ([]domain.AuditEntry, int64, error) {
+	query := r.db.Model(&domain.AuditEntry{})
+
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	var entries []domain.AuditEntry
+	if err := query.Order("id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&entries).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+	return entries, total, nil
+}
+
+func (r *gormAuditRepository) ListAfter(afterID uint, limit int) ([]domain.AuditEntry, error) {
+	var entries []domain.AuditEntry
+	if err := r.db.Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&entries).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/riada2/internal/migrations"
+	"github.com/riada2/internal/repository/contract"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var testGormConfig = &gorm.Config{
+	Logger:                                   logger.Default.LogMode(logger.Silent),
+	DisableForeignKeyConstraintWhenMigrating: true,
+}
+
+// TestPostgresContract ejecuta la suite de contrato sobre PostgreSQL. Necesita una base de
+// datos de pruebas en TEST_DATABASE_DSN; sus tablas se vacían antes de cada prueba.
+func TestPostgresContract(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), testGormConfig)
+	if err != nil {
+		t.Fatalf("could not connect to the test database: %v", err)
+	}
+	migrate(t, db)
+
+	contract.Run(t, func(t *testing.T) contract.Repositories {
+		err := db.Exec("TRUNCATE users, people, addresses, phones, entity_versions, households, household_addresses," +
+			" household_phones, tags, person_tags, custom_field_definitions, job_states, audit_entries RESTART IDENTITY CASCADE").Error
+		if err != nil {
+			t.Fatalf("could not clean the test database: %v", err)
+		}
+		return gormRepositories(db)
+	})
+}
+
+// TestSQLiteContract ejecuta la suite de contrato sobre un archivo SQLite nuevo en cada prueba.
+func TestSQLiteContract(t *testing.T) {
+	contract.Run(t, func(t *testing.T) contract.Repositories {
+		db, err := OpenSQLite(filepath.Join(t.TempDir(), "contract.db"), testGormConfig)
+		if err != nil {
+			t.Fatalf("OpenSQLite: %v", err)
+		}
+		t.Cleanup(func() {
+			if sqlDB, err := db.DB(); err == nil {
+				sqlDB.Close()
+			}
+		})
+		migrate(t, db)
+		return gormRepositories(db)
+	})
+}
+
+// migrate crea el esquema con las migraciones, igual que al iniciar la aplicación.
+func migrate(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	migrator, err := migrations.New(db)
+	if err != nil {
+		t.Fatalf("migrations.New: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("could not migrate the test database: %v", err)
+	}
+}
+
+func gormRepositories(db *gorm.DB) contract.Repositories {
+	return contract.Repositories{
+		Persons:      NewGormPersonRepository(db),
+		Addresses:    NewGormAddressRepository(db),
+		Phones:       NewGormPhoneRepository(db),
+		Users:        NewGormUserRepository(db),
+		Versions:     NewGormVersionRepository(db),
+		CustomFields: NewGormCustomFieldRepository(db),
+		Households:   NewGormHouseholdRepository(db),
+		Audit:        NewGormAuditRepository(db),
+		JobStates:    NewGormJobStateRepository(db),
+		UnitOfWork:   NewGormUnitOfWork(db),
+	}
+}
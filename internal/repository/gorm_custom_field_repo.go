@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type gormCustomFieldRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCustomFieldRepository(db *gorm.DB) ports.CustomFieldRepository {
+	return &gormCustomFieldRepository{db: db}
+}
+
+func (r *gormCustomFieldRepository) Save(definition *domain.CustomFieldDefinition) error {
+	return translateError(r.db.Save(definition).Error)
+}
+
+func (r *gormCustomFieldRepository) Delete(id uint) error {
+	return translateError(r.db.Delete(&domain.CustomFieldDefinition{}, id).Error)
+}
+
+func (r *gormCustomFieldRepository) FindByID(id uint) (*domain.CustomFieldDefinition, error) {
+	var definition domain.CustomFieldDefinition
+	if err := r.db.First(&definition, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &definition, nil
+}
+
+func (r *gormCustomFieldRepository) FindByKey(key string) (*domain.CustomFieldDefinition, error) {
+	var definition domain.CustomFieldDefinition
+	if err := r.db.Where("key = ?", key).First(&definition).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &definition, nil
+}
+
+func (r *gormCustomFieldRepository) List() ([]domain.CustomFieldDefinition, error) {
+	var definitions []domain.CustomFieldDefinition
+	if err := r.db.Order("key").Find(&definitions).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return definitions, nil
+}
+
+func (r *gormCustomFieldRepository) RemoveValues(key string) error {
+	err := r.db.Model(&domain.Person{}).
+		Where(jsonHasKey(r.db, "custom_fields", key)).
+		Update("custom_fields", jsonWithoutKey(r.db, "custom_fields", key)).Error
+	return translateError(err)
+}
+
+func (r *gormCustomFieldRepository) ListValues(key string) (map[uint]any, error) {
+	var persons []domain.Person
+	err := r.db.Select("id", "custom_fields").Where(jsonHasKey(r.db, "custom_fields", key)).Find(&persons).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	values := make(map[uint]any, len(persons))
+	for _, person := range persons {
+		values[person.ID] = person.CustomFields[key]
+	}
+	return values, nil
+}
+
+func (r *gormCustomFieldRepository) CountMissing(key string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Person{}).
+		Where("custom_fields IS NULL OR NOT (?)", jsonHasKey(r.db, "custom_fields", key)).
+		Count(&count).Error
+	return count, translateError(err)
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type gormDelegationRepository struct {
+	db *gorm.DB
+}
+
+func NewGormDelegationRepository(db *gorm.DB) ports.DelegationRepository {
+	return &gormDelegationRepository{db: db}
+}
+
+func (r *gormDelegationRepository) Save(delegation *domain.Delegation) error {
+	return translateError(r.db.Omit("Person").Save(delegation).Error)
+}
+
+func (r *gormDelegationRepository) FindByID(id uint) (*domain.Delegation, error) {
+	var delegation domain.Delegation
+	if err := r.db.Preload("Person").First(&delegation, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &delegation, nil
+}
+
+func (r *gormDelegationRepository) FindOpen(userID, personID uint) (*domain.Delegation, error) {
+	var delegation domain.Delegation
+	err := r.db.Where("user_id = ? AND person_id = ? AND status IN ?", userID, personID,
+		[]domain.DelegationStatus{domain.DelegationPending, domain.DelegationActive}).First(&delegation).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &delegation, nil
+}
+
+func (r *gormDelegationRepository) Search(filter ports.DelegationFilter) ([]domain.Delegation, error) {
+	query := r.db.Preload("Person")
+	if filter.UserID != nil && filter.PersonIDs != nil {
+		// Las del usuario y las que afectan a sus personas.
+		query = query.Where("user_id = ? OR person_id IN ?", *filter.UserID, filter.PersonIDs)
+	} else {
+		if filter.UserID != nil {
+			query = query.Where("user_id = ?", *filter.UserID)
+		}
+		if filter.PersonIDs != nil {
+			query = query.Where("person_id IN ?", filter.PersonIDs)
+		}
+	}
+	if filter.PersonID != nil {
+		query = query.Where("person_id = ?", *filter.PersonID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var delegations []domain.Delegation
+	if err := query.Order("created_at DESC, id DESC").Find(&delegations).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return delegations, nil
+}
+
+func (r *gormDelegationRepository) HasDelegation(userID, personID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.Delegation{}).
+		Where("user_id = ? AND person_id = ? AND status = ?", userID, personID, domain.DelegationActive).
+		Count(&count).Error
+	return count > 0, translateError(err)
+}
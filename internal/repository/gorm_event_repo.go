@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormEventRepository struct {
+	db *gorm.DB
+}
+
+func NewGormEventRepository(db *gorm.DB) ports.EventRepository {
+	return &gormEventRepository{db: db}
+}
+
+func (r *gormEventRepository) Save(event *domain.Event) error {
+	return translateError(r.db.Save(event).Error)
+}
+
+func (r *gormEventRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ?", id).Delete(&domain.Attendance{}).Error; err != nil {
+			return translateError(err)
+		}
+		return translateError(tx.Delete(&domain.Event{}, id).Error)
+	})
+}
+
+func (r *gormEventRepository) FindByID(id uint) (*domain.Event, error) {
+	var event domain.Event
+	if err := r.db.First(&event, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &event, nil
+}
+
+func (r *gormEventRepository) List() ([]domain.Event, error) {
+	var events []domain.Event
+	if err := r.db.Order("starts_at, id").Find(&events).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return events, nil
+}
+
+type gormAttendanceRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAttendanceRepository(db *gorm.DB) ports.AttendanceRepository {
+	return &gormAttendanceRepository{db: db}
+}
+
+func (r *gormAttendanceRepository) Save(attendance *domain.Attendance) error {
+	return translateError(r.db.Omit(clause.Associations).Save(attendance).Error)
+}
+
+func (r *gormAttendanceRepository) Delete(id uint) error {
+	return translateError(r.db.Delete(&domain.Attendance{}, id).Error)
+}
+
+func (r *gormAttendanceRepository) FindByID(id uint) (*domain.Attendance, error) {
+	var attendance domain.Attendance
+	if err := r.db.First(&attendance, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &attendance, nil
+}
+
+func (r *gormAttendanceRepository) Find(eventID, personID uint, date time.Time) (*domain.Attendance, error) {
+	var attendance domain.Attendance
+	err := r.db.Where("event_id = ? AND person_id = ? AND date = ?", eventID, personID, domain.AttendanceDate(date)).
+		First(&attendance).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &attendance, nil
+}
+
+func (r *gormAttendanceRepository) ListByPerson(personID uint, from, to time.Time) ([]domain.Attendance, error) {
+	var attendances []domain.Attendance
+	err := r.inPeriod(from, to).Preload("Event").
+		Where("person_id = ?", personID).
+		Order("date, event_id").
+		Find(&attendances).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return attendances, nil
+}
+
+func (r *gormAttendanceRepository) ListByEvent(eventID uint, from, to time.Time) ([]domain.Attendance, error) {
+	var attendances []domain.Attendance
+	err := r.inPeriod(from, to).Preload("Person").
+		Where("event_id = ?", eventID).
+		Order("date, person_id").
+		Find(&attendances).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return attendances, nil
+}
+
+func (r *gormAttendanceRepository) ListInPeriod(from, to time.Time) ([]domain.Attendance, error) {
+	var attendances []domain.Attendance
+	err := r.inPeriod(from, to).Preload("Event").Preload("Person").
+		Order("date, event_id, person_id").
+		Find(&attendances).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return attendances, nil
+}
+
+func (r *gormAttendanceRepository) FirstAttendances(from, to time.Time) ([]domain.Attendance, error) {
+	var attendances []domain.Attendance
+	first := r.db.Table("attendances AS earlier").
+		Select("MIN(earlier.date)").
+		Where("earlier.person_id = attendances.person_id")
+	err := r.inPeriod(from, to).Preload("Person").
+		Where("date = (?)", first).
+		Order("date, person_id, event_id").
+		Find(&attendances).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return attendances, nil
+}
+
+// inPeriod filtra las asistencias cuya fecha cae en [from, to).
+func (r *gormAttendanceRepository) inPeriod(from, to time.Time) *gorm.DB {
+	return r.db.Where("date >= ? AND date < ?", domain.AttendanceDate(from), domain.AttendanceDate(to))
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormGroupRepository struct {
+	db *gorm.DB
+}
+
+func NewGormGroupRepository(db *gorm.DB) ports.GroupRepository {
+	return &gormGroupRepository{db: db}
+}
+
+func (r *gormGroupRepository) Save(group *domain.Group) error {
+	return translateError(r.db.Omit(clause.Associations).Save(group).Error)
+}
+
+func (r *gormGroupRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", id).Delete(&domain.GroupMembership{}).Error; err != nil {
+			return translateError(err)
+		}
+		return translateError(tx.Delete(&domain.Group{}, id).Error)
+	})
+}
+
+func (r *gormGroupRepository) FindByID(id uint) (*domain.Group, error) {
+	var group domain.Group
+	err := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB { return db.Order("role, person_id") }).
+		Preload("Members.Person").
+		First(&group, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &group, nil
+}
+
+func (r *gormGroupRepository) FindByName(name string) (*domain.Group, error) {
+	var group domain.Group
+	if err := r.db.Where("name = ?", name).First(&group).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &group, nil
+}
+
+func (r *gormGroupRepository) List(groupType domain.GroupType) ([]domain.Group, error) {
+	var groups []domain.Group
+	query := r.db.Order("name")
+	if groupType != "" {
+		query = query.Where("type = ?", groupType)
+	}
+	if err := query.Find(&groups).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return groups, nil
+}
+
+func (r *gormGroupRepository) SaveMembership(membership *domain.GroupMembership) error {
+	return translateError(r.db.Omit("Person").Save(membership).Error)
+}
+
+func (r *gormGroupRepository) FindMembership(groupID, personID uint) (*domain.GroupMembership, error) {
+	var membership domain.GroupMembership
+	if err := r.db.Where("group_id = ? AND person_id = ?", groupID, personID).First(&membership).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &membership, nil
+}
+
+func (r *gormGroupRepository) DeleteMemberships(groupID uint, personIDs []uint) (int64, error) {
+	if len(personIDs) == 0 {
+		return 0, nil
+	}
+	result := r.db.Where("group_id = ? AND person_id IN ?", groupID, personIDs).Delete(&domain.GroupMembership{})
+	return result.RowsAffected, translateError(result.Error)
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormHouseholdRepository struct {
+	db *gorm.DB
+}
+
+func NewGormHouseholdRepository(db *gorm.DB) ports.HouseholdRepository {
+	return &gormHouseholdRepository{db: db}
+}
+
+func (r *gormHouseholdRepository) Save(household *domain.Household) error {
+	return translateError(r.db.Omit(clause.Associations).Save(household).Error)
+}
+
+func (r *gormHouseholdRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Person{}).Where("household_id = ?", id).Update("household_id", nil).Error; err != nil {
+			return translateError(err)
+		}
+		if err := tx.Where("household_id = ?", id).Delete(&domain.HouseholdAddress{}).Error; err != nil {
+			return translateError(err)
+		}
+		if err := tx.Where("household_id = ?", id).Delete(&domain.HouseholdPhone{}).Error; err != nil {
+			return translateError(err)
+		}
+		return translateError(tx.Delete(&domain.Household{}, id).Error)
+	})
+}
+
+func (r *gormHouseholdRepository) FindByID(id uint) (*domain.Household, error) {
+	var household domain.Household
+	err := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Addresses").Preload("Phones").
+		First(&household, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &household, nil
+}
+
+func (r *gormHouseholdRepository) Search(searchTerm string) ([]domain.Household, error) {
+	var households []domain.Household
+	query := r.db.Preload("Members").Preload("Addresses").Preload("Phones")
+
+	if searchTerm != "" {
+		likeTerm := "%" + searchTerm + "%"
+		// Igual que la búsqueda de personas, sin distinguir mayúsculas.
+		query = query.Where(
+			containsIgnoringCase(r.db, "households.name")+
+				" OR EXISTS (SELECT 1 FROM people p WHERE p.household_id = households.id AND "+
+				containsIgnoringCase(r.db, "p.name || ' ' || p.middle_name || ' ' || p.last_name")+")"+
+				" OR EXISTS (SELECT 1 FROM household_addresses a WHERE a.household_id = households.id AND "+
+				containsIgnoringCase(r.db, "a.address")+")",
+			likeTerm, likeTerm, likeTerm)
+	}
+
+	if err := query.Order("id DESC").Limit(300).Find(&households).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return households, nil
+}
+
+func (r *gormHouseholdRepository) SetHousehold(personIDs []uint, householdID *uint) error {
+	if len(personIDs) == 0 {
+		return nil
+	}
+	return translateError(r.db.Model(&domain.Person{}).Where("id IN ?", personIDs).Update("household_id", householdID).Error)
+}
+
+func (r *gormHouseholdRepository) SaveAddress(address *domain.HouseholdAddress) error {
+	return translateError(r.db.Save(address).Error)
+}
+
+func (r *gormHouseholdRepository) DeleteAddress(id uint) error {
+	return translateError(r.db.Delete(&domain.HouseholdAddress{}, id).Error)
+}
+
+func (r *gormHouseholdRepository) FindAddressByID(id uint) (*domain.HouseholdAddress, error) {
+	var address domain.HouseholdAddress
+	if err := r.db.First(&address, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &address, nil
+}
+
+func (r *gormHouseholdRepository) SavePhone(phone *domain.HouseholdPhone) error {
+	return translateError(r.db.Save(phone).Error)
+}
+
+func (r *gormHouseholdRepository) DeletePhone(id uint) error {
+	return translateError(r.db.Delete(&domain.HouseholdPhone{}, id).Error)
+}
+
+func (r *gormHouseholdRepository) FindPhoneByID(id uint) (*domain.HouseholdPhone, error) {
+	var phone domain.HouseholdPhone
+	if err := r.db.First(&phone, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &phone, nil
+}
+
+func (r *gormHouseholdRepository) FindUngroupedAddresses() ([]domain.Address, error) {
+	var addresses []domain.Address
+	err := r.db.Joins("JOIN people ON people.id = addresses.person_id").
+		Where("people.household_id IS NULL").
+		Order("addresses.id").
+		Find(&addresses).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return addresses, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormJobStateRepository struct {
+	db *gorm.DB
+}
+
+func NewGormJobStateRepository(db *gorm.DB) ports.JobStateRepository {
+	return &gormJobStateRepository{db: db}
+}
+
+func (r *gormJobStateRepository) Find(name string) (*domain.JobState, error) {
+	var state domain.JobState
+	if err := r.db.Where("name = ?", name).First(&state).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &state, nil
+}
+
+func (r *gormJobStateRepository) Save(state *domain.JobState) error {
+	return translateError(r.db.Save(state).Error)
+}
+
+func (r *gormJobStateRepository) Claim(name string, scheduledAt, next time.Time) (bool, error) {
+	// La fila se crea la primera vez; si ya existe, la inserción no hace nada.
+	err := r.db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(&domain.JobState{Name: name}).Error
+	if err != nil {
+		return false, translateError(err)
+	}
+	// La actualización condicional es atómica: de varias instancias, solo una cambia la fila.
+	result := r.db.Model(&domain.JobState{}).
+		Where("name = ? AND (next_run_at IS NULL OR next_run_at <= ?)", name, scheduledAt).
+		Update("next_run_at", next)
+	if result.Error != nil {
+		return false, translateError(result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormJobStateRepository) List() ([]domain.JobState, error) {
+	var states []domain.JobState
+	if err := r.db.Order("name").Find(&states).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return states, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type gormNoteRepository struct {
+	db *gorm.DB
+}
+
+func NewGormNoteRepository(db *gorm.DB) ports.NoteRepository {
+	return &gormNoteRepository{db: db}
+}
+
+func (r *gormNoteRepository) Save(note *domain.Note) error {
+	return translateError(r.db.Save(note).Error)
+}
+
+func (r *gormNoteRepository) Delete(id uint) error {
+	return translateError(r.db.Delete(&domain.Note{}, id).Error)
+}
+
+func (r *gormNoteRepository) FindByID(id uint) (*domain.Note, error) {
+	var note domain.Note
+	if err := r.db.First(&note, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &note, nil
+}
+
+func (r *gormNoteRepository) FindByPerson(personID uint) ([]domain.Note, error) {
+	var notes []domain.Note
+	if err := r.db.Where("person_id = ?", personID).Order("date DESC, id DESC").Find(&notes).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return notes, nil
+}
+
+func (r *gormNoteRepository) FindAssigned(userID uint, openOnly bool) ([]domain.Note, error) {
+	var notes []domain.Note
+	query := r.db.Where("assignee_id = ? AND due_date IS NOT NULL", userID)
+	if openOnly {
+		query = query.Where("completed_at IS NULL")
+	}
+	if err := query.Order("due_date, id").Find(&notes).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return notes, nil
+}
@@ -1,6 +1,11 @@
 package repository
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/riada2/internal/core/domain"
 	"github.com/riada2/internal/core/ports"
 	"gorm.io/gorm"
@@ -17,42 +22,114 @@ func NewGormPersonRepository(db *gorm.DB) ports.PersonRepository {
 func (r *gormPersonRepository) Save(person *domain.Person) error {
 	// Save actualiza el registro si tiene una clave primaria, o crea uno nuevo si no la tiene.
 	// println("Saving person:", person.ID, person.Name, person.MiddleName)
-	return r.db.Save(person).Error
+	// El hogar y las etiquetas se gestionan desde sus propios repositorios;
+	// aquí solo se guarda HouseholdID. La versión de la credencial solo cambia al revocarla.
+	return translateError(r.db.Omit("Household", "Tags", "CredentialVersion").Save(person).Error)
+}
+
+func (r *gormPersonRepository) RevokeCredential(id uint) error {
+	result := r.db.Model(&domain.Person{}).Where("id = ?", id).
+		UpdateColumn("credential_version", gorm.Expr("credential_version + 1"))
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ports.ErrNotFound
+	}
+	return nil
+}
+
+// withContacts precarga los contactos de la persona, los de su hogar y sus etiquetas.
+func (r *gormPersonRepository) withContacts() *gorm.DB {
+	return r.db.Preload("Addresses", bySortOrder).Preload("Phones", bySortOrder).Preload("Household.Addresses").Preload("Household.Phones").Preload("Tags")
+}
+
+// bySortOrder ordena las direcciones y teléfonos precargados según el orden elegido por la persona.
+func bySortOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order, id")
 }
 
 func (r *gormPersonRepository) Delete(id uint) error {
-	return r.db.Delete(&domain.Person{}, id).Error
+	return translateError(r.db.Delete(&domain.Person{}, id).Error)
 }
 
 func (r *gormPersonRepository) FindByID(id uint) (*domain.Person, error) {
 	var person domain.Person
-	if err := r.db.Preload("Addresses").Preload("Phones").First(&person, id).Error; err != nil {
-		return nil, err
+	if err := r.withContacts().First(&person, id).Error; err != nil {
+		return nil, translateError(err)
 	}
 	return &person, nil
 }
 
 func (r *gormPersonRepository) FindByUserID(userID uint) (*domain.Person, error) {
 	var person domain.Person
-	if err := r.db.Preload("Addresses").Preload("Phones").Where("user_id = ?", userID).First(&person).Error; err != nil {
-		return nil, err
+	if err := r.withContacts().Where("user_id = ?", userID).First(&person).Error; err != nil {
+		return nil, translateError(err)
 	}
 	return &person, nil
 }
 
-func (r *gormPersonRepository) Search(searchTerm string) ([]domain.Person, error) {
+func (r *gormPersonRepository) Search(filter ports.PersonFilter) ([]domain.Person, error) {
 	var persons []domain.Person
-	query := r.db.Preload("Addresses").Preload("Phones")
+	query := r.withContacts()
 
-	if searchTerm != "" {
-		likeTerm := "%" + searchTerm + "%"
+	if filter.Term != "" {
+		likeTerm := "%" + filter.Term + "%"
 		// Busca en la concatenación de nombre, apellido paterno y materno, O en el número de documento.
-		// Esta sintaxis es para PostgreSQL.
-		query = query.Where("name || ' ' || middle_name || ' ' || last_name ILIKE ? OR doc_number = ?", likeTerm, searchTerm)
+		fullName := containsIgnoringCase(r.db, "name || ' ' || middle_name || ' ' || last_name")
+		if filter.Phone != "" {
+			// Los teléfonos se guardan en E.164, así que basta comparar los dígitos.
+			query = query.Where(fullName+" OR doc_number = ?"+
+				" OR id IN (SELECT person_id FROM phones WHERE phone LIKE ?)", likeTerm, filter.Term, "%"+filter.Phone+"%")
+		} else {
+			query = query.Where(fullName+" OR doc_number = ?", likeTerm, filter.Term)
+		}
+	}
+
+	if filter.GroupID != nil {
+		// Solo los miembros vigentes a la fecha actual.
+		now := time.Now()
+		query = query.Where("id IN (SELECT person_id FROM group_memberships WHERE group_id = ?"+
+			" AND (start_date IS NULL OR start_date <= ?) AND (end_date IS NULL OR end_date >= ?))", *filter.GroupID, now, now)
+	}
+
+	// La persona debe tener todas las etiquetas indicadas.
+	for _, tag := range filter.Tags {
+		query = query.Where("id IN (SELECT pt.person_id FROM person_tags pt JOIN tags t ON t.id = pt.tag_id WHERE t.name = ?)", tag)
 	}
 
-	if err := query.Order("id DESC").Limit(300).Find(&persons).Error; err != nil {
-		return nil, err
+	// Las claves se ordenan para que la consulta sea estable.
+	keys := make([]string, 0, len(filter.CustomFields))
+	for key := range filter.CustomFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		query = query.Where(jsonFieldEquals(r.db, "custom_fields", key, filter.CustomFields[key]))
+	}
+
+	if filter.Ubigeo != "" {
+		// El prefijo va en un patrón LIKE: solo se admiten dígitos para que % y _ no sean comodines.
+		if strings.Trim(filter.Ubigeo, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid ubigeo filter %q, use digits only", filter.Ubigeo)
+		}
+		query = query.Where("id IN (SELECT person_id FROM addresses WHERE ubigeo LIKE ?)", filter.Ubigeo+"%")
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if err := query.Order("id DESC").Find(&persons).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return persons, nil
+}
+
+func (r *gormPersonRepository) ListWithBirthday() ([]domain.Person, error) {
+	var persons []domain.Person
+	if err := r.db.Where("birthday IS NOT NULL").Order("id").Find(&persons).Error; err != nil {
+		return nil, translateError(err)
 	}
 	return persons, nil
 }
@@ -60,7 +137,7 @@ func (r *gormPersonRepository) Search(searchTerm string) ([]domain.Person, error
 func (r *gormPersonRepository) FindByDocument(docType domain.DocType, docNumber string) (*domain.Person, error) {
 	var person domain.Person
 	if err := r.db.Where("type_doc = ? AND doc_number = ?", docType, docNumber).First(&person).Error; err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return &person, nil
 }
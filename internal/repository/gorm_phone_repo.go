@@ -15,17 +15,17 @@ func NewGormPhoneRepository(db *gorm.DB) ports.PhoneRepository {
 }
 
 func (r *gormPhoneRepository) Save(phone *domain.Phone) error {
-	return r.db.Save(phone).Error
+	return translateError(r.db.Save(phone).Error)
 }
 
 func (r *gormPhoneRepository) Delete(id uint) error {
-	return r.db.Delete(&domain.Phone{}, id).Error
+	return translateError(r.db.Delete(&domain.Phone{}, id).Error)
 }
 
 func (r *gormPhoneRepository) FindByID(id uint) (*domain.Phone, error) {
 	var phone domain.Phone
 	if err := r.db.First(&phone, id).Error; err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return &phone, nil
 }
@@ -33,5 +33,21 @@ func (r *gormPhoneRepository) FindByID(id uint) (*domain.Phone, error) {
 func (r *gormPhoneRepository) CountByPersonID(personID uint) (int64, error) {
 	var count int64
 	err := r.db.Model(&domain.Phone{}).Where("person_id = ?", personID).Count(&count).Error
-	return count, err
+	return count, translateError(err)
+}
+
+func (r *gormPhoneRepository) FindByPersonID(personID uint) ([]domain.Phone, error) {
+	var phones []domain.Phone
+	err := r.db.Where("person_id = ?", personID).Order("sort_order, id").Find(&phones).Error
+	return phones, translateError(err)
+}
+
+func (r *gormPhoneRepository) Reorder(ids []uint) error {
+	return reorder(r.db, &domain.Phone{}, ids)
+}
+
+func (r *gormPhoneRepository) FindAll() ([]domain.Phone, error) {
+	var phones []domain.Phone
+	err := r.db.Order("person_id, id").Find(&phones).Error
+	return phones, translateError(err)
 }
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type gormRelationshipRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRelationshipRepository(db *gorm.DB) ports.RelationshipRepository {
+	return &gormRelationshipRepository{db: db}
+}
+
+func (r *gormRelationshipRepository) Save(relationship *domain.Relationship) error {
+	return translateError(r.db.Save(relationship).Error)
+}
+
+func (r *gormRelationshipRepository) Delete(id uint) error {
+	return translateError(r.db.Delete(&domain.Relationship{}, id).Error)
+}
+
+func (r *gormRelationshipRepository) FindByID(id uint) (*domain.Relationship, error) {
+	var relationship domain.Relationship
+	if err := r.db.First(&relationship, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &relationship, nil
+}
+
+func (r *gormRelationshipRepository) FindInvolving(personID uint) ([]domain.Relationship, error) {
+	var relationships []domain.Relationship
+	if err := r.db.Where("person_id = ? OR related_person_id = ?", personID, personID).Order("id").Find(&relationships).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return relationships, nil
+}
+
+func (r *gormRelationshipRepository) ListWithSince(relType domain.RelationshipType) ([]domain.Relationship, error) {
+	var relationships []domain.Relationship
+	if err := r.db.Where("type = ? AND since IS NOT NULL", relType).Order("id").Find(&relationships).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return relationships, nil
+}
+
+func (r *gormRelationshipRepository) Find(personID, relatedPersonID uint, relType domain.RelationshipType) (*domain.Relationship, error) {
+	var relationship domain.Relationship
+	err := r.db.Where("person_id = ? AND related_person_id = ? AND type = ?", personID, relatedPersonID, relType).
+		First(&relationship).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &relationship, nil
+}
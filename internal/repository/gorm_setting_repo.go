@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type gormSettingRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSettingRepository(db *gorm.DB) ports.SettingRepository {
+	return &gormSettingRepository{db: db}
+}
+
+func (r *gormSettingRepository) List() ([]domain.Setting, error) {
+	var settings []domain.Setting
+	if err := r.db.Order("key").Find(&settings).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return settings, nil
+}
+
+func (r *gormSettingRepository) SaveAll(settings []domain.Setting) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range settings {
+			if err := tx.Save(&settings[i]).Error; err != nil {
+				return translateError(err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormTagRepository struct {
+	db *gorm.DB
+}
+
+func NewGormTagRepository(db *gorm.DB) ports.TagRepository {
+	return &gormTagRepository{db: db}
+}
+
+func (r *gormTagRepository) List() ([]domain.Tag, error) {
+	var tags []domain.Tag
+	if err := r.db.Order("name").Find(&tags).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return tags, nil
+}
+
+func (r *gormTagRepository) FindOrCreate(names []string) ([]domain.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	tags := make([]domain.Tag, len(names))
+	for i, name := range names {
+		tags[i] = domain.Tag{Name: name}
+	}
+	// Las etiquetas existentes se ignoran y luego se leen con su ID.
+	if err := r.db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&tags).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	var found []domain.Tag
+	if err := r.db.Where("name IN ?", names).Order("name").Find(&found).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return found, nil
+}
+
+func (r *gormTagRepository) ReplacePersonTags(personID uint, tags []domain.Tag) error {
+	person := &domain.Person{ID: personID}
+	return translateError(r.db.Model(person).Association("Tags").Replace(tags))
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type gormUnitOfWork struct {
+	db *gorm.DB
+	// postGIS se detecta una sola vez, no en cada transacción.
+	postGIS bool
+}
+
+// NewGormUnitOfWork crea una unidad de trabajo que usa las transacciones de la base de datos.
+func NewGormUnitOfWork(db *gorm.DB) ports.UnitOfWork {
+	return &gormUnitOfWork{db: db, postGIS: hasPostGIS(db)}
+}
+
+func (u *gormUnitOfWork) Do(fn func(repos ports.Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(ports.Repositories{
+			Persons:       NewGormPersonRepository(tx),
+			Addresses:     &gormAddressRepository{db: tx, postGIS: u.postGIS},
+			Phones:        NewGormPhoneRepository(tx),
+			Versions:      NewGormVersionRepository(tx),
+			Households:    NewGormHouseholdRepository(tx),
+			Relationships: NewGormRelationshipRepository(tx),
+			Users:         NewGormUserRepository(tx),
+			Tags:          NewGormTagRepository(tx),
+			Groups:        NewGormGroupRepository(tx),
+			CustomFields:  NewGormCustomFieldRepository(tx),
+			Events:        NewGormEventRepository(tx),
+			Attendances:   NewGormAttendanceRepository(tx),
+			Notes:         NewGormNoteRepository(tx),
+			Delegations:   NewGormDelegationRepository(tx),
+			Settings:      NewGormSettingRepository(tx),
+			Audit:         NewGormAuditRepository(tx),
+		})
+	})
+}
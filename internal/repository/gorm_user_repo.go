@@ -16,13 +16,13 @@ func NewGormUserRepository(db *gorm.DB) ports.UserRepository {
 
 func (r *gormUserRepository) Save(user *domain.User) error {
 	// GORM's Save method maneja tanto la creación (si la PK es cero) como la actualización.
-	return r.db.Save(user).Error
+	return translateError(r.db.Save(user).Error)
 }
 
 func (r *gormUserRepository) FindByUsername(username string) (*domain.User, error) {
 	var user domain.User
 	if err := r.db.Where("username = ?", username).First(&user).Error; err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return &user, nil
 }
@@ -30,7 +30,7 @@ func (r *gormUserRepository) FindByUsername(username string) (*domain.User, erro
 func (r *gormUserRepository) FindByID(id uint) (*domain.User, error) {
 	var user domain.User
 	if err := r.db.First(&user, id).Error; err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return &user, nil
 }
@@ -38,7 +38,7 @@ func (r *gormUserRepository) FindByID(id uint) (*domain.User, error) {
 func (r *gormUserRepository) FindAll() ([]domain.User, error) {
 	var users []domain.User
 	if err := r.db.Find(&users).Error; err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return users, nil
 }
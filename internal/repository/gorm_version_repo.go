@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type gormVersionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormVersionRepository(db *gorm.DB) ports.VersionRepository {
+	return &gormVersionRepository{db: db}
+}
+
+func (r *gormVersionRepository) Save(version *domain.EntityVersion) error {
+	return translateError(r.db.Create(version).Error)
+}
+
+func (r *gormVersionRepository) FindByID(id uint) (*domain.EntityVersion, error) {
+	var version domain.EntityVersion
+	if err := r.db.First(&version, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &version, nil
+}
+
+func (r *gormVersionRepository) FindByPersonID(personID uint) ([]domain.EntityVersion, error) {
+	var versions []domain.EntityVersion
+	if err := r.db.Where("person_id = ?", personID).Order("created_at DESC, id DESC").Find(&versions).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return versions, nil
+}
+
+func (r *gormVersionRepository) LatestVersion(entityType domain.VersionEntity, entityID uint) (int, error) {
+	var latest int
+	err := r.db.Model(&domain.EntityVersion{}).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error
+	return latest, translateError(err)
+}
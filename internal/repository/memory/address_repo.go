@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"cmp"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type addressRepository struct {
+	store *Store
+}
+
+func NewAddressRepository(store *Store) ports.AddressRepository {
+	return &addressRepository{store: store}
+}
+
+func (r *addressRepository) Save(address *domain.Address) error {
+	return r.store.write(func(t *tables) error {
+		t.saveAddress(address)
+		return nil
+	})
+}
+
+func (t *tables) saveAddress(address *domain.Address) {
+	address.ID = t.nextID("addresses", address.ID)
+	stamp(&address.CreatedAt, &address.UpdatedAt)
+	t.addresses[address.ID] = *address
+}
+
+func (r *addressRepository) Delete(id uint) error {
+	return r.store.write(func(t *tables) error {
+		delete(t.addresses, id)
+		return nil
+	})
+}
+
+func (r *addressRepository) FindByID(id uint) (address *domain.Address, err error) {
+	err = r.store.read(func(t *tables) error {
+		address, err = find(t.addresses, id)
+		return err
+	})
+	return address, err
+}
+
+func (r *addressRepository) CountByPersonID(personID uint) (count int64, err error) {
+	err = r.store.read(func(t *tables) error {
+		count = int64(len(t.addressesOf(personID)))
+		return nil
+	})
+	return count, err
+}
+
+func (r *addressRepository) FindByPersonID(personID uint) (addresses []domain.Address, err error) {
+	err = r.store.read(func(t *tables) error {
+		addresses = t.addressesOf(personID)
+		return nil
+	})
+	return addresses, err
+}
+
+// addressesOf devuelve las direcciones de la persona en el orden que eligió.
+func (t *tables) addressesOf(personID uint) []domain.Address {
+	addresses := filter(t.addresses, func(a domain.Address) bool { return a.PersonID == personID })
+	byKeys(addresses, func(a, b domain.Address) int { return cmp.Compare(a.SortOrder, b.SortOrder) })
+	return addresses
+}
+
+func (r *addressRepository) Reorder(ids []uint) error {
+	return r.store.write(func(t *tables) error {
+		for i, id := range ids {
+			if address, ok := t.addresses[id]; ok {
+				address.SortOrder = i
+				t.addresses[id] = address
+			}
+		}
+		return nil
+	})
+}
+
+func (r *addressRepository) FindUnstructured() (addresses []domain.Address, err error) {
+	err = r.store.read(func(t *tables) error {
+		addresses = filter(t.addresses, func(a domain.Address) bool {
+			return a.Ubigeo == "" && (a.Country == "" || a.Country == domain.PeruCountryCode)
+		})
+		return nil
+	})
+	return addresses, err
+}
+
+func (r *addressRepository) FindWithoutCoordinates() (addresses []domain.Address, err error) {
+	err = r.store.read(func(t *tables) error {
+		addresses = filter(t.addresses, func(a domain.Address) bool { return a.Latitude == nil || a.Longitude == nil })
+		return nil
+	})
+	return addresses, err
+}
+
+// FindNearby calcula las distancias con la fórmula del haversine y se queda con la
+// dirección más cercana de cada persona, como el adaptador de GORM sin PostGIS.
+func (r *addressRepository) FindNearby(query ports.NearbyQuery) (nearby []domain.NearbyPerson, err error) {
+	err = r.store.read(func(t *tables) error {
+		nearest := make(map[uint]domain.NearbyPerson)
+		for _, address := range filter(t.addresses, nil) {
+			point := address.Point()
+			if point == nil || address.PersonID == query.ExcludePersonID {
+				continue
+			}
+			distance := query.Center.DistanceKm(*point)
+			if query.RadiusKm > 0 && distance > query.RadiusKm {
+				continue
+			}
+			person, ok := t.persons[address.PersonID]
+			if !ok {
+				continue
+			}
+			if current, ok := nearest[address.PersonID]; !ok || distance < current.DistanceKm {
+				nearest[address.PersonID] = domain.NearbyPerson{Person: person, Address: address, DistanceKm: distance}
+			}
+		}
+
+		for _, item := range nearest {
+			nearby = append(nearby, item)
+		}
+		byKeys(nearby,
+			func(a, b domain.NearbyPerson) int { return cmp.Compare(a.DistanceKm, b.DistanceKm) },
+			func(a, b domain.NearbyPerson) int { return cmp.Compare(a.Person.ID, b.Person.ID) })
+		if query.Limit > 0 && len(nearby) > query.Limit {
+			nearby = nearby[:query.Limit]
+		}
+		return nil
+	})
+	return nearby, err
+}
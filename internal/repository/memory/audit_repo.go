@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type auditRepository struct {
+	store *Store
+}
+
+func NewAuditRepository(store *Store) ports.AuditRepository {
+	return &auditRepository{store: store}
+}
+
+// LockChain no hace nada: la unidad de trabajo ya bloquea el almacén durante la transacción.
+func (r *auditRepository) LockChain() error {
+	return nil
+}
+
+func (r *auditRepository) Append(entry *domain.AuditEntry) error {
+	return r.store.write(func(t *tables) error {
+		for _, other := range t.audit {
+			if entry.Hash != "" && other.Hash == entry.Hash {
+				return fmt.Errorf("%w: audit hash %s already exists", ports.ErrConflict, entry.Hash)
+			}
+			// Como la clave única de prev_hash: cada entrada tiene una sola siguiente.
+			if other.PrevHash == entry.PrevHash {
+				return fmt.Errorf("%w: audit entry %d already follows %q", ports.ErrConflict, other.ID, entry.PrevHash)
+			}
+		}
+		entry.ID = t.nextID("audit", entry.ID)
+		stamp(&entry.CreatedAt, nil)
+		t.audit[entry.ID] = *entry
+		return nil
+	})
+}
+
+func (r *auditRepository) Last() (entry *domain.AuditEntry, err error) {
+	err = r.store.read(func(t *tables) error {
+		if entries := filter(t.audit, nil); len(entries) > 0 {
+			entry = &entries[len(entries)-1]
+		}
+		return nil
+	})
+	return entry, err
+}
+
+func (r *auditRepository) Search(f ports.AuditFilter) (entries []domain.AuditEntry, total int64, err error) {
+	err = r.store.read(func(t *tables) error {
+		entries = filter(t.audit, func(e domain.AuditEntry) bool {
+			return (f.ActorID == nil || (e.ActorID != nil && *e.ActorID == *f.ActorID)) &&
+				(f.Action == "" || e.Action == f.Action) &&
+				(f.EntityType == "" || e.EntityType == f.EntityType) &&
+				(f.EntityID == nil || (e.EntityID != nil && *e.EntityID == *f.EntityID)) &&
+				(f.Outcome == "" || e.Outcome == f.Outcome) &&
+				(f.RequestID == "" || e.RequestID == f.RequestID) &&
+				(f.From == nil || !e.CreatedAt.Before(*f.From)) &&
+				(f.To == nil || !e.CreatedAt.After(*f.To))
+		})
+		total = int64(len(entries))
+		slices.Reverse(entries)
+		entries = page(entries, f.Offset, f.Limit)
+		return nil
+	})
+	return entries, total, err
+}
+
+func (r *auditRepository) ListAfter(afterID uint, limit int) (entries []domain.AuditEntry, err error) {
+	err = r.store.read(func(t *tables) error {
+		entries = page(filter(t.audit, func(e domain.AuditEntry) bool { return e.ID > afterID }), 0, limit)
+		return nil
+	})
+	return entries, err
+}
+
+// page aplica el desplazamiento y el límite; un límite no positivo no limita.
+func page[V any](values []V, offset, limit int) []V {
+	if offset > 0 {
+		values = values[min(offset, len(values)):]
+	}
+	if limit > 0 && len(values) > limit {
+		values = values[:limit]
+	}
+	return values
+}
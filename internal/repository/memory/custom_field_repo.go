@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type customFieldRepository struct {
+	store *Store
+}
+
+func NewCustomFieldRepository(store *Store) ports.CustomFieldRepository {
+	return &customFieldRepository{store: store}
+}
+
+func (r *customFieldRepository) Save(definition *domain.CustomFieldDefinition) error {
+	return r.store.write(func(t *tables) error {
+		for id, other := range t.customFields {
+			if id != definition.ID && other.Key == definition.Key {
+				return fmt.Errorf("%w: custom field %q already exists", ports.ErrConflict, definition.Key)
+			}
+		}
+		definition.ID = t.nextID("customFields", definition.ID)
+		stamp(&definition.CreatedAt, &definition.UpdatedAt)
+		stored := *definition
+		stored.Options = slices.Clone(definition.Options)
+		t.customFields[definition.ID] = stored
+		return nil
+	})
+}
+
+func (r *customFieldRepository) Delete(id uint) error {
+	return r.store.write(func(t *tables) error {
+		delete(t.customFields, id)
+		return nil
+	})
+}
+
+func (r *customFieldRepository) FindByID(id uint) (definition *domain.CustomFieldDefinition, err error) {
+	err = r.store.read(func(t *tables) error {
+		definition, err = find(t.customFields, id)
+		return err
+	})
+	return definition, err
+}
+
+func (r *customFieldRepository) FindByKey(key string) (definition *domain.CustomFieldDefinition, err error) {
+	err = r.store.read(func(t *tables) error {
+		definition, err = first(t.customFields, func(d domain.CustomFieldDefinition) bool { return d.Key == key })
+		return err
+	})
+	return definition, err
+}
+
+func (r *customFieldRepository) List() (definitions []domain.CustomFieldDefinition, err error) {
+	err = r.store.read(func(t *tables) error {
+		definitions = filter(t.customFields, nil)
+		byKeys(definitions, func(a, b domain.CustomFieldDefinition) int { return strings.Compare(a.Key, b.Key) })
+		return nil
+	})
+	return definitions, err
+}
+
+func (r *customFieldRepository) RemoveValues(key string) error {
+	return r.store.write(func(t *tables) error {
+		for id, person := range t.persons {
+			if _, ok := person.CustomFields[key]; ok {
+				person.CustomFields = maps.Clone(person.CustomFields)
+				delete(person.CustomFields, key)
+				t.persons[id] = person
+			}
+		}
+		return nil
+	})
+}
+
+func (r *customFieldRepository) ListValues(key string) (values map[uint]any, err error) {
+	err = r.store.read(func(t *tables) error {
+		values = make(map[uint]any)
+		for id, person := range t.persons {
+			if value, ok := person.CustomFields[key]; ok {
+				values[id] = value
+			}
+		}
+		return nil
+	})
+	return values, err
+}
+
+func (r *customFieldRepository) CountMissing(key string) (count int64, err error) {
+	err = r.store.read(func(t *tables) error {
+		for _, person := range t.persons {
+			if _, ok := person.CustomFields[key]; !ok {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
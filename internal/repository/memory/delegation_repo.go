@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type delegationRepository struct {
+	store *Store
+}
+
+func NewDelegationRepository(store *Store) ports.DelegationRepository {
+	return &delegationRepository{store: store}
+}
+
+func (r *delegationRepository) Save(delegation *domain.Delegation) error {
+	return r.store.write(func(t *tables) error {
+		delegation.ID = t.nextID("delegations", delegation.ID)
+		stamp(&delegation.CreatedAt, &delegation.UpdatedAt)
+		stored := *delegation
+		stored.Person = nil
+		t.delegations[delegation.ID] = stored
+		return nil
+	})
+}
+
+func (r *delegationRepository) FindByID(id uint) (delegation *domain.Delegation, err error) {
+	err = r.store.read(func(t *tables) error {
+		if delegation, err = find(t.delegations, id); err != nil {
+			return err
+		}
+		t.withPerson(delegation)
+		return nil
+	})
+	return delegation, err
+}
+
+func (r *delegationRepository) FindOpen(userID, personID uint) (delegation *domain.Delegation, err error) {
+	err = r.store.read(func(t *tables) error {
+		delegation, err = first(t.delegations, func(d domain.Delegation) bool {
+			return d.UserID == userID && d.PersonID == personID && d.IsOpen()
+		})
+		return err
+	})
+	return delegation, err
+}
+
+func (r *delegationRepository) Search(f ports.DelegationFilter) (delegations []domain.Delegation, err error) {
+	err = r.store.read(func(t *tables) error {
+		delegations = filter(t.delegations, func(d domain.Delegation) bool {
+			byUser := f.UserID != nil && d.UserID == *f.UserID
+			byPersons := f.PersonIDs != nil && slices.Contains(f.PersonIDs, d.PersonID)
+			switch {
+			case f.UserID != nil && f.PersonIDs != nil:
+				// Las del usuario y las que afectan a sus personas.
+				if !byUser && !byPersons {
+					return false
+				}
+			case f.UserID != nil && !byUser, f.PersonIDs != nil && !byPersons:
+				return false
+			}
+			return (f.PersonID == nil || d.PersonID == *f.PersonID) && (f.Status == "" || d.Status == f.Status)
+		})
+		byKeys(delegations,
+			func(a, b domain.Delegation) int { return b.CreatedAt.Compare(a.CreatedAt) },
+			func(a, b domain.Delegation) int { return cmp.Compare(b.ID, a.ID) })
+		for i := range delegations {
+			t.withPerson(&delegations[i])
+		}
+		return nil
+	})
+	return delegations, err
+}
+
+func (r *delegationRepository) HasDelegation(userID, personID uint) (delegated bool, err error) {
+	err = r.store.read(func(t *tables) error {
+		_, err := first(t.delegations, func(d domain.Delegation) bool {
+			return d.UserID == userID && d.PersonID == personID && d.Status == domain.DelegationActive
+		})
+		delegated = err == nil
+		return nil
+	})
+	return delegated, err
+}
+
+func (t *tables) withPerson(delegation *domain.Delegation) {
+	delegation.Person = nil
+	if person, ok := t.persons[delegation.PersonID]; ok {
+		delegation.Person = &person
+	}
+}
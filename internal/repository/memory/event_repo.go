@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type eventRepository struct {
+	store *Store
+}
+
+func NewEventRepository(store *Store) ports.EventRepository {
+	return &eventRepository{store: store}
+}
+
+func (r *eventRepository) Save(event *domain.Event) error {
+	return r.store.write(func(t *tables) error {
+		event.ID = t.nextID("events", event.ID)
+		stamp(&event.CreatedAt, &event.UpdatedAt)
+		t.events[event.ID] = *event
+		return nil
+	})
+}
+
+func (r *eventRepository) Delete(id uint) error {
+	return r.store.write(func(t *tables) error {
+		for attendanceID, attendance := range t.attendances {
+			if attendance.EventID == id {
+				delete(t.attendances, attendanceID)
+			}
+		}
+		delete(t.events, id)
+		return nil
+	})
+}
+
+func (r *eventRepository) FindByID(id uint) (event *domain.Event, err error) {
+	err = r.store.read(func(t *tables) error {
+		event, err = find(t.events, id)
+		return err
+	})
+	return event, err
+}
+
+func (r *eventRepository) List() (events []domain.Event, err error) {
+	err = r.store.read(func(t *tables) error {
+		events = filter(t.events, nil)
+		byKeys(events, func(a, b domain.Event) int { return a.StartsAt.Compare(b.StartsAt) })
+		return nil
+	})
+	return events, err
+}
+
+type attendanceRepository struct {
+	store *Store
+}
+
+func NewAttendanceRepository(store *Store) ports.AttendanceRepository {
+	return &attendanceRepository{store: store}
+}
+
+func (r *attendanceRepository) Save(attendance *domain.Attendance) error {
+	return r.store.write(func(t *tables) error {
+		day := domain.AttendanceDate(attendance.Date)
+		for id, other := range t.attendances {
+			if id != attendance.ID && other.EventID == attendance.EventID && other.PersonID == attendance.PersonID && other.Date.Equal(day) {
+				return fmt.Errorf("%w: person %d already checked in to event %d on %s",
+					ports.ErrConflict, attendance.PersonID, attendance.EventID, day.Format(time.DateOnly))
+			}
+		}
+		attendance.ID = t.nextID("attendances", attendance.ID)
+		stamp(&attendance.CreatedAt, nil)
+		stored := *attendance
+		// La columna es de tipo fecha: se guarda solo el día.
+		stored.Date = day
+		stored.Event, stored.Person = domain.Event{}, domain.Person{}
+		t.attendances[attendance.ID] = stored
+		return nil
+	})
+}
+
+func (r *attendanceRepository) Delete(id uint) error {
+	return r.store.write(func(t *tables) error {
+		delete(t.attendances, id)
+		return nil
+	})
+}
+
+func (r *attendanceRepository) FindByID(id uint) (attendance *domain.Attendance, err error) {
+	err = r.store.read(func(t *tables) error {
+		attendance, err = find(t.attendances, id)
+		return err
+	})
+	return attendance, err
+}
+
+func (r *attendanceRepository) Find(eventID, personID uint, date time.Time) (attendance *domain.Attendance, err error) {
+	day := domain.AttendanceDate(date)
+	err = r.store.read(func(t *tables) error {
+		attendance, err = first(t.attendances, func(a domain.Attendance) bool {
+			return a.EventID == eventID && a.PersonID == personID && a.Date.Equal(day)
+		})
+		return err
+	})
+	return attendance, err
+}
+
+func (r *attendanceRepository) ListByPerson(personID uint, from, to time.Time) (attendances []domain.Attendance, err error) {
+	err = r.store.read(func(t *tables) error {
+		attendances = t.attendancesIn(from, to, func(a domain.Attendance) bool { return a.PersonID == personID })
+		byKeys(attendances, byDate, byEvent)
+		t.withAttendanceDetails(attendances, true, false)
+		return nil
+	})
+	return attendances, err
+}
+
+func (r *attendanceRepository) ListByEvent(eventID uint, from, to time.Time) (attendances []domain.Attendance, err error) {
+	err = r.store.read(func(t *tables) error {
+		attendances = t.attendancesIn(from, to, func(a domain.Attendance) bool { return a.EventID == eventID })
+		byKeys(attendances, byDate, byPerson)
+		t.withAttendanceDetails(attendances, false, true)
+		return nil
+	})
+	return attendances, err
+}
+
+func (r *attendanceRepository) ListInPeriod(from, to time.Time) (attendances []domain.Attendance, err error) {
+	err = r.store.read(func(t *tables) error {
+		attendances = t.attendancesIn(from, to, nil)
+		byKeys(attendances, byDate, byEvent, byPerson)
+		t.withAttendanceDetails(attendances, true, true)
+		return nil
+	})
+	return attendances, err
+}
+
+// FirstAttendances devuelve las asistencias del periodo que son las primeras de cada persona.
+func (r *attendanceRepository) FirstAttendances(from, to time.Time) (attendances []domain.Attendance, err error) {
+	err = r.store.read(func(t *tables) error {
+		firstDay := make(map[uint]time.Time)
+		for _, a := range t.attendances {
+			if day, ok := firstDay[a.PersonID]; !ok || a.Date.Before(day) {
+				firstDay[a.PersonID] = a.Date
+			}
+		}
+		attendances = t.attendancesIn(from, to, func(a domain.Attendance) bool { return a.Date.Equal(firstDay[a.PersonID]) })
+		byKeys(attendances, byDate, byPerson, byEvent)
+		t.withAttendanceDetails(attendances, false, true)
+		return nil
+	})
+	return attendances, err
+}
+
+// attendancesIn filtra las asistencias cuya fecha cae en [from, to).
+func (t *tables) attendancesIn(from, to time.Time, match func(domain.Attendance) bool) []domain.Attendance {
+	from, to = domain.AttendanceDate(from), domain.AttendanceDate(to)
+	return filter(t.attendances, func(a domain.Attendance) bool {
+		return !a.Date.Before(from) && a.Date.Before(to) && (match == nil || match(a))
+	})
+}
+
+func (t *tables) withAttendanceDetails(attendances []domain.Attendance, event, person bool) {
+	for i := range attendances {
+		if event {
+			attendances[i].Event = t.events[attendances[i].EventID]
+		}
+		if person {
+			attendances[i].Person = t.persons[attendances[i].PersonID]
+		}
+	}
+}
+
+func byDate(a, b domain.Attendance) int   { return a.Date.Compare(b.Date) }
+func byEvent(a, b domain.Attendance) int  { return cmp.Compare(a.EventID, b.EventID) }
+func byPerson(a, b domain.Attendance) int { return cmp.Compare(a.PersonID, b.PersonID) }
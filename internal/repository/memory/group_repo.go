@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type groupRepository struct {
+	store *Store
+}
+
+func NewGroupRepository(store *Store) ports.GroupRepository {
+	return &groupRepository{store: store}
+}
+
+func (r *groupRepository) Save(group *domain.Group) error {
+	return r.store.write(func(t *tables) error {
+		for id, other := range t.groups {
+			if id != group.ID && other.Name == group.Name {
+				return fmt.Errorf("%w: group %q already exists", ports.ErrConflict, group.Name)
+			}
+		}
+		group.ID = t.nextID("groups", group.ID)
+		stamp(&group.CreatedAt, &group.UpdatedAt)
+		stored := *group
+		stored.Members = nil
+		t.groups[group.ID] = stored
+		return nil
+	})
+}
+
+func (r *groupRepository) Delete(id uint) error {
+	return r.store.write(func(t *tables) error {
+		for membershipID, membership := range t.memberships {
+			if membership.GroupID == id {
+				delete(t.memberships, membershipID)
+			}
+		}
+		delete(t.groups, id)
+		return nil
+	})
+}
+
+func (r *groupRepository) FindByID(id uint) (group *domain.Group, err error) {
+	err = r.store.read(func(t *tables) error {
+		if group, err = find(t.groups, id); err != nil {
+			return err
+		}
+		group.Members = filter(t.memberships, func(m domain.GroupMembership) bool { return m.GroupID == id })
+		byKeys(group.Members,
+			func(a, b domain.GroupMembership) int { return strings.Compare(string(a.Role), string(b.Role)) },
+			func(a, b domain.GroupMembership) int { return cmp.Compare(a.PersonID, b.PersonID) })
+		for i := range group.Members {
+			group.Members[i].Person = t.persons[group.Members[i].PersonID]
+		}
+		return nil
+	})
+	return group, err
+}
+
+func (r *groupRepository) FindByName(name string) (group *domain.Group, err error) {
+	err = r.store.read(func(t *tables) error {
+		group, err = first(t.groups, func(g domain.Group) bool { return g.Name == name })
+		return err
+	})
+	return group, err
+}
+
+func (r *groupRepository) List(groupType domain.GroupType) (groups []domain.Group, err error) {
+	err = r.store.read(func(t *tables) error {
+		groups = filter(t.groups, func(g domain.Group) bool { return groupType == "" || g.Type == groupType })
+		byKeys(groups, func(a, b domain.Group) int { return strings.Compare(a.Name, b.Name) })
+		return nil
+	})
+	return groups, err
+}
+
+func (r *groupRepository) SaveMembership(membership *domain.GroupMembership) error {
+	return r.store.write(func(t *tables) error {
+		for id, other := range t.memberships {
+			if id != membership.ID && other.GroupID == membership.GroupID && other.PersonID == membership.PersonID {
+				return fmt.Errorf("%w: person %d is already a member of group %d", ports.ErrConflict, membership.PersonID, membership.GroupID)
+			}
+		}
+		membership.ID = t.nextID("memberships", membership.ID)
+		stamp(&membership.CreatedAt, &membership.UpdatedAt)
+		stored := *membership
+		stored.Person = domain.Person{}
+		t.memberships[membership.ID] = stored
+		return nil
+	})
+}
+
+func (r *groupRepository) FindMembership(groupID, personID uint) (membership *domain.GroupMembership, err error) {
+	err = r.store.read(func(t *tables) error {
+		membership, err = first(t.memberships, func(m domain.GroupMembership) bool {
+			return m.GroupID == groupID && m.PersonID == personID
+		})
+		return err
+	})
+	return membership, err
+}
+
+func (r *groupRepository) DeleteMemberships(groupID uint, personIDs []uint) (removed int64, err error) {
+	err = r.store.write(func(t *tables) error {
+		for id, membership := range t.memberships {
+			if membership.GroupID == groupID && slices.Contains(personIDs, membership.PersonID) {
+				delete(t.memberships, id)
+				removed++
+			}
+		}
+		return nil
+	})
+	return removed, err
+}
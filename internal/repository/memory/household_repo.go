@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type householdRepository struct {
+	store *Store
+}
+
+func NewHouseholdRepository(store *Store) ports.HouseholdRepository {
+	return &householdRepository{store: store}
+}
+
+func (r *householdRepository) Save(household *domain.Household) error {
+	return r.store.write(func(t *tables) error {
+		household.ID = t.nextID("households", household.ID)
+		stamp(&household.CreatedAt, &household.UpdatedAt)
+		stored := *household
+		stored.Members, stored.Addresses, stored.Phones = nil, nil, nil
+		t.households[household.ID] = stored
+		return nil
+	})
+}
+
+func (r *householdRepository) Delete(id uint) error {
+	return r.store.write(func(t *tables) error {
+		t.setHousehold(t.membersOf(id), nil)
+		for addressID, address := range t.householdAddresses {
+			if address.HouseholdID == id {
+				delete(t.householdAddresses, addressID)
+			}
+		}
+		for phoneID, phone := range t.householdPhones {
+			if phone.HouseholdID == id {
+				delete(t.householdPhones, phoneID)
+			}
+		}
+		delete(t.households, id)
+		return nil
+	})
+}
+
+func (r *householdRepository) FindByID(id uint) (household *domain.Household, err error) {
+	err = r.store.read(func(t *tables) error {
+		if household, err = find(t.households, id); err != nil {
+			return err
+		}
+		t.withMembers(household)
+		return nil
+	})
+	return household, err
+}
+
+func (r *householdRepository) Search(searchTerm string) (households []domain.Household, err error) {
+	err = r.store.read(func(t *tables) error {
+		term := strings.ToLower(searchTerm)
+		contains := func(s string) bool { return strings.Contains(strings.ToLower(s), term) }
+		households = filter(t.households, func(h domain.Household) bool {
+			if searchTerm == "" || contains(h.Name) {
+				return true
+			}
+			for _, member := range t.membersOf(h.ID) {
+				if contains(member.Name + " " + member.MiddleName + " " + member.LastName) {
+					return true
+				}
+			}
+			for _, address := range t.householdAddressesOf(h.ID) {
+				if contains(address.Address) {
+					return true
+				}
+			}
+			return false
+		})
+		slices.Reverse(households)
+		households = page(households, 0, 300)
+		for i := range households {
+			t.withMembers(&households[i])
+		}
+		return nil
+	})
+	return households, err
+}
+
+func (r *householdRepository) SetHousehold(personIDs []uint, householdID *uint) error {
+	return r.store.write(func(t *tables) error {
+		var persons []domain.Person
+		for _, id := range personIDs {
+			if person, ok := t.persons[id]; ok {
+				persons = append(persons, person)
+			}
+		}
+		t.setHousehold(persons, householdID)
+		return nil
+	})
+}
+
+func (t *tables) setHousehold(persons []domain.Person, householdID *uint) {
+	for _, person := range persons {
+		person.HouseholdID = householdID
+		t.persons[person.ID] = person
+	}
+}
+
+func (r *householdRepository) SaveAddress(address *domain.HouseholdAddress) error {
+	return r.store.write(func(t *tables) error {
+		address.ID = t.nextID("householdAddresses", address.ID)
+		stamp(&address.CreatedAt, &address.UpdatedAt)
+		t.householdAddresses[address.ID] = *address
+		return nil
+	})
+}
+
+func (r *householdRepository) DeleteAddress(id uint) error {
+	return r.store.write(func(t *tables) error {
+		delete(t.householdAddresses, id)
+		return nil
+	})
+}
+
+func (r *householdRepository) FindAddressByID(id uint) (address *domain.HouseholdAddress, err error) {
+	err = r.store.read(func(t *tables) error {
+		address, err = find(t.householdAddresses, id)
+		return err
+	})
+	return address, err
+}
+
+func (r *householdRepository) SavePhone(phone *domain.HouseholdPhone) error {
+	return r.store.write(func(t *tables) error {
+		phone.ID = t.nextID("householdPhones", phone.ID)
+		stamp(&phone.CreatedAt, &phone.UpdatedAt)
+		t.householdPhones[phone.ID] = *phone
+		return nil
+	})
+}
+
+func (r *householdRepository) DeletePhone(id uint) error {
+	return r.store.write(func(t *tables) error {
+		delete(t.householdPhones, id)
+		return nil
+	})
+}
+
+func (r *householdRepository) FindPhoneByID(id uint) (phone *domain.HouseholdPhone, err error) {
+	err = r.store.read(func(t *tables) error {
+		phone, err = find(t.householdPhones, id)
+		return err
+	})
+	return phone, err
+}
+
+func (r *householdRepository) FindUngroupedAddresses() (addresses []domain.Address, err error) {
+	err = r.store.read(func(t *tables) error {
+		addresses = filter(t.addresses, func(a domain.Address) bool {
+			person, ok := t.persons[a.PersonID]
+			return ok && person.HouseholdID == nil
+		})
+		return nil
+	})
+	return addresses, err
+}
+
+func (t *tables) withMembers(household *domain.Household) {
+	household.Members = t.membersOf(household.ID)
+	household.Addresses = t.householdAddressesOf(household.ID)
+	household.Phones = t.householdPhonesOf(household.ID)
+}
+
+func (t *tables) membersOf(householdID uint) []domain.Person {
+	return filter(t.persons, func(p domain.Person) bool { return p.HouseholdID != nil && *p.HouseholdID == householdID })
+}
+
+func (t *tables) householdAddressesOf(householdID uint) []domain.HouseholdAddress {
+	return filter(t.householdAddresses, func(a domain.HouseholdAddress) bool { return a.HouseholdID == householdID })
+}
+
+func (t *tables) householdPhonesOf(householdID uint) []domain.HouseholdPhone {
+	return filter(t.householdPhones, func(p domain.HouseholdPhone) bool { return p.HouseholdID == householdID })
+}
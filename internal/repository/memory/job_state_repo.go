@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type jobStateRepository struct {
+	store *Store
+}
+
+func NewJobStateRepository(store *Store) ports.JobStateRepository {
+	return &jobStateRepository{store: store}
+}
+
+func (r *jobStateRepository) Find(name string) (state *domain.JobState, err error) {
+	err = r.store.read(func(t *tables) error {
+		found, ok := t.jobStates[name]
+		if !ok {
+			return ports.ErrNotFound
+		}
+		state = &found
+		return nil
+	})
+	return state, err
+}
+
+func (r *jobStateRepository) Save(state *domain.JobState) error {
+	return r.store.write(func(t *tables) error {
+		stamp(nil, &state.UpdatedAt)
+		t.jobStates[state.Name] = *state
+		return nil
+	})
+}
+
+func (r *jobStateRepository) Claim(name string, scheduledAt, next time.Time) (claimed bool, err error) {
+	err = r.store.write(func(t *tables) error {
+		state, ok := t.jobStates[name]
+		if !ok {
+			state = domain.JobState{Name: name}
+		}
+		if state.NextRunAt != nil && state.NextRunAt.After(scheduledAt) {
+			return nil
+		}
+		state.NextRunAt = &next
+		stamp(nil, &state.UpdatedAt)
+		t.jobStates[name] = state
+		claimed = true
+		return nil
+	})
+	return claimed, err
+}
+
+func (r *jobStateRepository) List() (states []domain.JobState, err error) {
+	err = r.store.read(func(t *tables) error {
+		for _, name := range slices.Sorted(maps.Keys(t.jobStates)) {
+			states = append(states, t.jobStates[name])
+		}
+		return nil
+	})
+	return states, err
+}
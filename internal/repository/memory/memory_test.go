@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/repository/contract"
+)
+
+func TestContract(t *testing.T) {
+	contract.Run(t, func(t *testing.T) contract.Repositories {
+		store := NewStore()
+		return contract.Repositories{
+			Persons:      NewPersonRepository(store),
+			Addresses:    NewAddressRepository(store),
+			Phones:       NewPhoneRepository(store),
+			Users:        NewUserRepository(store),
+			Versions:     NewVersionRepository(store),
+			CustomFields: NewCustomFieldRepository(store),
+			Households:   NewHouseholdRepository(store),
+			Audit:        NewAuditRepository(store),
+			JobStates:    NewJobStateRepository(store),
+			UnitOfWork:   NewUnitOfWork(store),
+		}
+	})
+}
+
+func TestConcurrentSaves(t *testing.T) {
+	store := NewStore()
+	persons := NewPersonRepository(store)
+	uow := NewUnitOfWork(store)
+
+	const workers = 50
+	var wg sync.WaitGroup
+	ids := make(chan uint, 2*workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			person := &domain.Person{Name: fmt.Sprintf("Persona %d", i)}
+			if err := persons.Save(person); err != nil {
+				t.Errorf("Save: %v", err)
+			}
+			ids <- person.ID
+		}()
+		go func() {
+			defer wg.Done()
+			var person domain.Person
+			err := uow.Do(func(repos ports.Repositories) error {
+				person = domain.Person{Name: fmt.Sprintf("Transacción %d", i)}
+				return repos.Persons.Save(&person)
+			})
+			if err != nil {
+				t.Errorf("Do: %v", err)
+			}
+			ids <- person.ID
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[uint]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("ID %d assigned twice", id)
+		}
+		seen[id] = true
+	}
+	all, err := persons.Search(ports.PersonFilter{})
+	if err != nil || len(all) != 2*workers {
+		t.Fatalf("expected %d persons, got %d (%v)", 2*workers, len(all), err)
+	}
+}
+
+func TestConcurrentDuplicateDocuments(t *testing.T) {
+	persons := NewPersonRepository(NewStore())
+	dni, number := domain.DNI, "12345678"
+
+	const workers = 20
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	saved, conflicts := 0, 0
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := persons.Save(&domain.Person{Name: "Duplicada", TypeDoc: &dni, DocNumber: &number})
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				saved++
+			case errors.Is(err, ports.ErrConflict):
+				conflicts++
+			default:
+				t.Errorf("unexpected error %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if saved != 1 || conflicts != workers-1 {
+		t.Fatalf("expected one save and %d conflicts, got %d and %d", workers-1, saved, conflicts)
+	}
+}
+
+func TestReturnedRecordsAreCopies(t *testing.T) {
+	persons := NewPersonRepository(NewStore())
+	person := &domain.Person{Name: "Ana", CustomFields: domain.CustomFieldValues{"ministry": "choir"},
+		Phones: []domain.Phone{{Phone: "+51999000001"}}}
+	if err := persons.Save(person); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	person.Name = "Cambiada sin guardar"
+	person.CustomFields["ministry"] = "ushers"
+
+	found, err := persons.FindByID(person.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	found.Phones[0].Phone = "+51999999999"
+	found.CustomFields["extra"] = true
+
+	again, err := persons.FindByID(person.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if again.Name != "Ana" || again.CustomFields["ministry"] != "choir" || len(again.CustomFields) != 1 || again.Phones[0].Phone != "+51999000001" {
+		t.Fatalf("stored record changed through a returned copy: %+v", again)
+	}
+}
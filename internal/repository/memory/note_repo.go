@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"cmp"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type noteRepository struct {
+	store *Store
+}
+
+func NewNoteRepository(store *Store) ports.NoteRepository {
+	return &noteRepository{store: store}
+}
+
+func (r *noteRepository) Save(note *domain.Note) error {
+	return r.store.write(func(t *tables) error {
+		note.ID = t.nextID("notes", note.ID)
+		stamp(&note.CreatedAt, &note.UpdatedAt)
+		t.notes[note.ID] = *note
+		return nil
+	})
+}
+
+func (r *noteRepository) Delete(id uint) error {
+	return r.store.write(func(t *tables) error {
+		delete(t.notes, id)
+		return nil
+	})
+}
+
+func (r *noteRepository) FindByID(id uint) (note *domain.Note, err error) {
+	err = r.store.read(func(t *tables) error {
+		note, err = find(t.notes, id)
+		return err
+	})
+	return note, err
+}
+
+func (r *noteRepository) FindByPerson(personID uint) (notes []domain.Note, err error) {
+	err = r.store.read(func(t *tables) error {
+		notes = filter(t.notes, func(n domain.Note) bool { return n.PersonID == personID })
+		byKeys(notes,
+			func(a, b domain.Note) int { return b.Date.Compare(a.Date) },
+			func(a, b domain.Note) int { return cmp.Compare(b.ID, a.ID) })
+		return nil
+	})
+	return notes, err
+}
+
+func (r *noteRepository) FindAssigned(userID uint, openOnly bool) (notes []domain.Note, err error) {
+	err = r.store.read(func(t *tables) error {
+		notes = filter(t.notes, func(n domain.Note) bool {
+			return n.AssigneeID != nil && *n.AssigneeID == userID && n.DueDate != nil && (!openOnly || n.CompletedAt == nil)
+		})
+		byKeys(notes, func(a, b domain.Note) int { return compareOptionalTime(a.DueDate, b.DueDate) })
+		return nil
+	})
+	return notes, err
+}
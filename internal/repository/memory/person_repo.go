@@ -0,0 +1,230 @@
+package memory
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type personRepository struct {
+	store *Store
+}
+
+func NewPersonRepository(store *Store) ports.PersonRepository {
+	return &personRepository{store: store}
+}
+
+// Save guarda la persona y, como GORM, también sus direcciones y teléfonos anidados.
+// El hogar y las etiquetas se gestionan desde sus propios repositorios.
+func (r *personRepository) Save(person *domain.Person) error {
+	return r.store.write(func(t *tables) error {
+		if err := t.checkDocument(person); err != nil {
+			return err
+		}
+		person.ID = t.nextID("persons", person.ID)
+		stamp(&person.CreatedAt, &person.UpdatedAt)
+		stored := *person
+		stored.Addresses, stored.Phones, stored.Household, stored.Tags = nil, nil, nil, nil
+		stored.CustomFields = maps.Clone(person.CustomFields)
+		// Como en GORM, la versión de la credencial solo cambia al revocarla.
+		stored.CredentialVersion = t.persons[person.ID].CredentialVersion
+		t.persons[person.ID] = stored
+
+		for i := range person.Addresses {
+			person.Addresses[i].PersonID = person.ID
+			t.saveAddress(&person.Addresses[i])
+		}
+		for i := range person.Phones {
+			person.Phones[i].PersonID = person.ID
+			t.savePhone(&person.Phones[i])
+		}
+		return nil
+	})
+}
+
+// checkDocument aplica la clave única del documento; los registros sin número no cuentan.
+func (t *tables) checkDocument(person *domain.Person) error {
+	if person.TypeDoc == nil || person.DocNumber == nil || *person.DocNumber == "" {
+		return nil
+	}
+	for id, other := range t.persons {
+		if id != person.ID && other.TypeDoc != nil && other.DocNumber != nil &&
+			*other.TypeDoc == *person.TypeDoc && *other.DocNumber == *person.DocNumber {
+			return fmt.Errorf("%w: document %s %s is already registered", ports.ErrConflict, *person.TypeDoc, *person.DocNumber)
+		}
+	}
+	return nil
+}
+
+func (r *personRepository) RevokeCredential(id uint) error {
+	return r.store.write(func(t *tables) error {
+		person, ok := t.persons[id]
+		if !ok {
+			return ports.ErrNotFound
+		}
+		person.CredentialVersion++
+		t.persons[id] = person
+		return nil
+	})
+}
+
+func (r *personRepository) Delete(id uint) error {
+	return r.store.write(func(t *tables) error {
+		delete(t.persons, id)
+		delete(t.personTags, id)
+		return nil
+	})
+}
+
+func (r *personRepository) FindByID(id uint) (person *domain.Person, err error) {
+	err = r.store.read(func(t *tables) error {
+		if person, err = find(t.persons, id); err != nil {
+			return err
+		}
+		t.withContacts(person)
+		return nil
+	})
+	return person, err
+}
+
+func (r *personRepository) FindByUserID(userID uint) (person *domain.Person, err error) {
+	err = r.store.read(func(t *tables) error {
+		person, err = first(t.persons, func(p domain.Person) bool { return p.UserID != nil && *p.UserID == userID })
+		if err != nil {
+			return err
+		}
+		t.withContacts(person)
+		return nil
+	})
+	return person, err
+}
+
+func (r *personRepository) FindByDocument(docType domain.DocType, docNumber string) (person *domain.Person, err error) {
+	err = r.store.read(func(t *tables) error {
+		person, err = first(t.persons, func(p domain.Person) bool {
+			return p.TypeDoc != nil && p.DocNumber != nil && *p.TypeDoc == docType && *p.DocNumber == docNumber
+		})
+		return err
+	})
+	if person != nil {
+		person.CustomFields = maps.Clone(person.CustomFields)
+	}
+	return person, err
+}
+
+func (r *personRepository) ListWithBirthday() (persons []domain.Person, err error) {
+	err = r.store.read(func(t *tables) error {
+		persons = filter(t.persons, func(p domain.Person) bool { return p.Birthday != nil })
+		return nil
+	})
+	for i := range persons {
+		persons[i].CustomFields = maps.Clone(persons[i].CustomFields)
+	}
+	return persons, err
+}
+
+func (r *personRepository) Search(f ports.PersonFilter) (persons []domain.Person, err error) {
+	// Igual que el repositorio de GORM, que usa el prefijo en un patrón LIKE.
+	if strings.Trim(f.Ubigeo, "0123456789") != "" {
+		return nil, fmt.Errorf("invalid ubigeo filter %q, use digits only", f.Ubigeo)
+	}
+	err = r.store.read(func(t *tables) error {
+		now := time.Now()
+		term := strings.ToLower(f.Term)
+		keys := slices.Sorted(maps.Keys(f.CustomFields))
+		persons = filter(t.persons, func(p domain.Person) bool {
+			if f.Term != "" && !strings.Contains(strings.ToLower(p.Name+" "+p.MiddleName+" "+p.LastName), term) &&
+				(p.DocNumber == nil || *p.DocNumber != f.Term) &&
+				(f.Phone == "" || !t.hasPhoneLike(p.ID, f.Phone)) {
+				return false
+			}
+			if f.GroupID != nil && !t.isCurrentMember(*f.GroupID, p.ID, now) {
+				return false
+			}
+			for _, tag := range f.Tags {
+				if !t.hasTag(p.ID, tag) {
+					return false
+				}
+			}
+			for _, key := range keys {
+				value, ok := p.CustomFields[key]
+				if !ok || fmt.Sprint(value) != f.CustomFields[key] {
+					return false
+				}
+			}
+			if f.Ubigeo != "" && !t.hasUbigeo(p.ID, f.Ubigeo) {
+				return false
+			}
+			return true
+		})
+		slices.Reverse(persons)
+		if f.Limit > 0 && len(persons) > f.Limit {
+			persons = persons[:f.Limit]
+		}
+		for i := range persons {
+			t.withContacts(&persons[i])
+		}
+		return nil
+	})
+	return persons, err
+}
+
+// withContacts completa la persona con sus contactos, los de su hogar y sus etiquetas,
+// igual que la precarga del repositorio de GORM.
+func (t *tables) withContacts(person *domain.Person) {
+	person.CustomFields = maps.Clone(person.CustomFields)
+	person.Addresses = t.addressesOf(person.ID)
+	person.Phones = t.phonesOf(person.ID)
+	person.Household = nil
+	if person.HouseholdID != nil {
+		if household, ok := t.households[*person.HouseholdID]; ok {
+			household.Addresses = t.householdAddressesOf(household.ID)
+			household.Phones = t.householdPhonesOf(household.ID)
+			person.Household = &household
+		}
+	}
+	person.Tags = t.tagsOf(person.ID)
+}
+
+func (t *tables) hasPhoneLike(personID uint, digits string) bool {
+	for _, phone := range t.phones {
+		if phone.PersonID == personID && strings.Contains(phone.Phone, digits) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *tables) hasUbigeo(personID uint, prefix string) bool {
+	for _, address := range t.addresses {
+		if address.PersonID == personID && strings.HasPrefix(address.Ubigeo, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *tables) hasTag(personID uint, name string) bool {
+	for _, id := range t.personTags[personID] {
+		if t.tags[id].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isCurrentMember indica si la persona es miembro vigente del grupo en la fecha indicada.
+func (t *tables) isCurrentMember(groupID, personID uint, at time.Time) bool {
+	for _, m := range t.memberships {
+		if m.GroupID == groupID && m.PersonID == personID &&
+			(m.StartDate == nil || !m.StartDate.After(at)) && (m.EndDate == nil || !m.EndDate.Before(at)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"cmp"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type phoneRepository struct {
+	store *Store
+}
+
+func NewPhoneRepository(store *Store) ports.PhoneRepository {
+	return &phoneRepository{store: store}
+}
+
+func (r *phoneRepository) Save(phone *domain.Phone) error {
+	return r.store.write(func(t *tables) error {
+		t.savePhone(phone)
+		return nil
+	})
+}
+
+func (t *tables) savePhone(phone *domain.Phone) {
+	phone.ID = t.nextID("phones", phone.ID)
+	stamp(&phone.CreatedAt, &phone.UpdatedAt)
+	t.phones[phone.ID] = *phone
+}
+
+func (r *phoneRepository) Delete(id uint) error {
+	return r.store.write(func(t *tables) error {
+		delete(t.phones, id)
+		return nil
+	})
+}
+
+func (r *phoneRepository) FindByID(id uint) (phone *domain.Phone, err error) {
+	err = r.store.read(func(t *tables) error {
+		phone, err = find(t.phones, id)
+		return err
+	})
+	return phone, err
+}
+
+func (r *phoneRepository) CountByPersonID(personID uint) (count int64, err error) {
+	err = r.store.read(func(t *tables) error {
+		count = int64(len(t.phonesOf(personID)))
+		return nil
+	})
+	return count, err
+}
+
+func (r *phoneRepository) FindByPersonID(personID uint) (phones []domain.Phone, err error) {
+	err = r.store.read(func(t *tables) error {
+		phones = t.phonesOf(personID)
+		return nil
+	})
+	return phones, err
+}
+
+// phonesOf devuelve los teléfonos de la persona en el orden que eligió.
+func (t *tables) phonesOf(personID uint) []domain.Phone {
+	phones := filter(t.phones, func(p domain.Phone) bool { return p.PersonID == personID })
+	byKeys(phones, func(a, b domain.Phone) int { return cmp.Compare(a.SortOrder, b.SortOrder) })
+	return phones
+}
+
+func (r *phoneRepository) Reorder(ids []uint) error {
+	return r.store.write(func(t *tables) error {
+		for i, id := range ids {
+			if phone, ok := t.phones[id]; ok {
+				phone.SortOrder = i
+				t.phones[id] = phone
+			}
+		}
+		return nil
+	})
+}
+
+func (r *phoneRepository) FindAll() (phones []domain.Phone, err error) {
+	err = r.store.read(func(t *tables) error {
+		phones = filter(t.phones, nil)
+		byKeys(phones, func(a, b domain.Phone) int { return cmp.Compare(a.PersonID, b.PersonID) })
+		return nil
+	})
+	return phones, err
+}
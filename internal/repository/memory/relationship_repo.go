@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type relationshipRepository struct {
+	store *Store
+}
+
+func NewRelationshipRepository(store *Store) ports.RelationshipRepository {
+	return &relationshipRepository{store: store}
+}
+
+func (r *relationshipRepository) Save(relationship *domain.Relationship) error {
+	return r.store.write(func(t *tables) error {
+		for id, other := range t.relationships {
+			if id != relationship.ID && other.PersonID == relationship.PersonID &&
+				other.RelatedPersonID == relationship.RelatedPersonID && other.Type == relationship.Type {
+				return fmt.Errorf("%w: relationship %s between %d and %d already exists",
+					ports.ErrConflict, relationship.Type, relationship.PersonID, relationship.RelatedPersonID)
+			}
+		}
+		relationship.ID = t.nextID("relationships", relationship.ID)
+		stamp(&relationship.CreatedAt, &relationship.UpdatedAt)
+		t.relationships[relationship.ID] = *relationship
+		return nil
+	})
+}
+
+func (r *relationshipRepository) Delete(id uint) error {
+	return r.store.write(func(t *tables) error {
+		delete(t.relationships, id)
+		return nil
+	})
+}
+
+func (r *relationshipRepository) FindByID(id uint) (relationship *domain.Relationship, err error) {
+	err = r.store.read(func(t *tables) error {
+		relationship, err = find(t.relationships, id)
+		return err
+	})
+	return relationship, err
+}
+
+func (r *relationshipRepository) FindInvolving(personID uint) (relationships []domain.Relationship, err error) {
+	err = r.store.read(func(t *tables) error {
+		relationships = filter(t.relationships, func(rel domain.Relationship) bool {
+			return rel.PersonID == personID || rel.RelatedPersonID == personID
+		})
+		return nil
+	})
+	return relationships, err
+}
+
+func (r *relationshipRepository) ListWithSince(relType domain.RelationshipType) (relationships []domain.Relationship, err error) {
+	err = r.store.read(func(t *tables) error {
+		relationships = filter(t.relationships, func(rel domain.Relationship) bool {
+			return rel.Type == relType && rel.Since != nil
+		})
+		return nil
+	})
+	return relationships, err
+}
+
+func (r *relationshipRepository) Find(personID, relatedPersonID uint, relType domain.RelationshipType) (relationship *domain.Relationship, err error) {
+	err = r.store.read(func(t *tables) error {
+		relationship, err = first(t.relationships, func(rel domain.Relationship) bool {
+			return rel.PersonID == personID && rel.RelatedPersonID == relatedPersonID && rel.Type == relType
+		})
+		return err
+	})
+	return relationship, err
+}
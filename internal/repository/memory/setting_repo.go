@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type settingRepository struct {
+	store *Store
+}
+
+func NewSettingRepository(store *Store) ports.SettingRepository {
+	return &settingRepository{store: store}
+}
+
+func (r *settingRepository) List() (settings []domain.Setting, err error) {
+	err = r.store.read(func(t *tables) error {
+		for _, key := range slices.Sorted(maps.Keys(t.settings)) {
+			settings = append(settings, t.settings[key])
+		}
+		return nil
+	})
+	return settings, err
+}
+
+func (r *settingRepository) SaveAll(settings []domain.Setting) error {
+	return r.store.write(func(t *tables) error {
+		for i := range settings {
+			stamp(nil, &settings[i].UpdatedAt)
+			t.settings[settings[i].Key] = settings[i]
+		}
+		return nil
+	})
+}
@@ -0,0 +1,206 @@
+// Package memory implementa los puertos de repositorio en memoria. Sirve para las
+// pruebas de los servicios y para ejecutar el núcleo sin base de datos; se comporta
+// como los adaptadores de GORM, incluidas las claves únicas y los errores de ports.
+package memory
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// Store guarda las tablas que comparten todos los repositorios en memoria. Es seguro
+// para uso concurrente: las lecturas comparten el candado y las escrituras lo toman
+// en exclusiva. Los registros se guardan y se devuelven como copias.
+type Store struct {
+	mu     sync.RWMutex
+	tables *tables
+}
+
+type tables struct {
+	seq map[string]uint
+
+	persons            map[uint]domain.Person
+	addresses          map[uint]domain.Address
+	phones             map[uint]domain.Phone
+	personTags         map[uint][]uint
+	tags               map[uint]domain.Tag
+	users              map[uint]domain.User
+	versions           map[uint]domain.EntityVersion
+	audit              map[uint]domain.AuditEntry
+	customFields       map[uint]domain.CustomFieldDefinition
+	delegations        map[uint]domain.Delegation
+	events             map[uint]domain.Event
+	attendances        map[uint]domain.Attendance
+	groups             map[uint]domain.Group
+	memberships        map[uint]domain.GroupMembership
+	households         map[uint]domain.Household
+	householdAddresses map[uint]domain.HouseholdAddress
+	householdPhones    map[uint]domain.HouseholdPhone
+	notes              map[uint]domain.Note
+	relationships      map[uint]domain.Relationship
+	jobStates          map[string]domain.JobState
+	settings           map[string]domain.Setting
+}
+
+// NewStore crea un almacén vacío.
+func NewStore() *Store {
+	return &Store{tables: &tables{
+		seq:                map[string]uint{},
+		persons:            map[uint]domain.Person{},
+		addresses:          map[uint]domain.Address{},
+		phones:             map[uint]domain.Phone{},
+		personTags:         map[uint][]uint{},
+		tags:               map[uint]domain.Tag{},
+		users:              map[uint]domain.User{},
+		versions:           map[uint]domain.EntityVersion{},
+		audit:              map[uint]domain.AuditEntry{},
+		customFields:       map[uint]domain.CustomFieldDefinition{},
+		delegations:        map[uint]domain.Delegation{},
+		events:             map[uint]domain.Event{},
+		attendances:        map[uint]domain.Attendance{},
+		groups:             map[uint]domain.Group{},
+		memberships:        map[uint]domain.GroupMembership{},
+		households:         map[uint]domain.Household{},
+		householdAddresses: map[uint]domain.HouseholdAddress{},
+		householdPhones:    map[uint]domain.HouseholdPhone{},
+		notes:              map[uint]domain.Note{},
+		relationships:      map[uint]domain.Relationship{},
+		jobStates:          map[string]domain.JobState{},
+		settings:           map[string]domain.Setting{},
+	}}
+}
+
+func (s *Store) read(fn func(t *tables) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(s.tables)
+}
+
+func (s *Store) write(fn func(t *tables) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.tables)
+}
+
+// clone copia las tablas para una transacción. Los registros son valores, así que
+// basta con copiar los mapas; las listas de etiquetas se copian aparte.
+func (t *tables) clone() *tables {
+	tags := make(map[uint][]uint, len(t.personTags))
+	for id, ids := range t.personTags {
+		tags[id] = slices.Clone(ids)
+	}
+	return &tables{
+		seq:                maps.Clone(t.seq),
+		persons:            maps.Clone(t.persons),
+		addresses:          maps.Clone(t.addresses),
+		phones:             maps.Clone(t.phones),
+		personTags:         tags,
+		tags:               maps.Clone(t.tags),
+		users:              maps.Clone(t.users),
+		versions:           maps.Clone(t.versions),
+		audit:              maps.Clone(t.audit),
+		customFields:       maps.Clone(t.customFields),
+		delegations:        maps.Clone(t.delegations),
+		events:             maps.Clone(t.events),
+		attendances:        maps.Clone(t.attendances),
+		groups:             maps.Clone(t.groups),
+		memberships:        maps.Clone(t.memberships),
+		households:         maps.Clone(t.households),
+		householdAddresses: maps.Clone(t.householdAddresses),
+		householdPhones:    maps.Clone(t.householdPhones),
+		notes:              maps.Clone(t.notes),
+		relationships:      maps.Clone(t.relationships),
+		jobStates:          maps.Clone(t.jobStates),
+		settings:           maps.Clone(t.settings),
+	}
+}
+
+// nextID asigna el siguiente ID de la tabla, o reserva el indicado si ya trae uno.
+func (t *tables) nextID(table string, id uint) uint {
+	if id == 0 {
+		t.seq[table]++
+		return t.seq[table]
+	}
+	if id > t.seq[table] {
+		t.seq[table] = id
+	}
+	return id
+}
+
+// find devuelve una copia del registro o ports.ErrNotFound.
+func find[V any](table map[uint]V, id uint) (*V, error) {
+	value, ok := table[id]
+	if !ok {
+		return nil, ports.ErrNotFound
+	}
+	return &value, nil
+}
+
+// first devuelve el registro de menor ID que cumple match, como First en GORM.
+func first[V any](table map[uint]V, match func(V) bool) (*V, error) {
+	var found *V
+	var foundID uint
+	for id, value := range table {
+		if match(value) && (found == nil || id < foundID) {
+			value := value
+			found, foundID = &value, id
+		}
+	}
+	if found == nil {
+		return nil, ports.ErrNotFound
+	}
+	return found, nil
+}
+
+// filter devuelve los registros que cumplen match, ordenados por ID.
+func filter[V any](table map[uint]V, match func(V) bool) []V {
+	ids := slices.Sorted(maps.Keys(table))
+	var values []V
+	for _, id := range ids {
+		if value := table[id]; match == nil || match(value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// byKeys ordena los registros por varias claves, en orden.
+func byKeys[V any](values []V, keys ...func(a, b V) int) {
+	slices.SortStableFunc(values, func(a, b V) int {
+		for _, key := range keys {
+			if c := key(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+// compareOptionalTime ordena los nulos al final, como PostgreSQL en orden ascendente.
+func compareOptionalTime(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Compare(*b)
+}
+
+// stamp fija las marcas de tiempo como lo hace GORM al guardar.
+func stamp(createdAt, updatedAt *time.Time) {
+	now := time.Now()
+	if createdAt != nil && createdAt.IsZero() {
+		*createdAt = now
+	}
+	if updatedAt != nil {
+		*updatedAt = now
+	}
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type tagRepository struct {
+	store *Store
+}
+
+func NewTagRepository(store *Store) ports.TagRepository {
+	return &tagRepository{store: store}
+}
+
+func (r *tagRepository) List() (tags []domain.Tag, err error) {
+	err = r.store.read(func(t *tables) error {
+		tags = filter(t.tags, nil)
+		byKeys(tags, byName)
+		return nil
+	})
+	return tags, err
+}
+
+// FindOrCreate crea las etiquetas que faltan y devuelve todas las indicadas con su ID.
+func (r *tagRepository) FindOrCreate(names []string) (tags []domain.Tag, err error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	err = r.store.write(func(t *tables) error {
+		for _, name := range names {
+			if _, err := first(t.tags, func(tag domain.Tag) bool { return tag.Name == name }); err == nil {
+				continue
+			}
+			id := t.nextID("tags", 0)
+			t.tags[id] = domain.Tag{ID: id, Name: name}
+		}
+		tags = filter(t.tags, func(tag domain.Tag) bool { return slices.Contains(names, tag.Name) })
+		byKeys(tags, byName)
+		return nil
+	})
+	return tags, err
+}
+
+func (r *tagRepository) ReplacePersonTags(personID uint, tags []domain.Tag) error {
+	return r.store.write(func(t *tables) error {
+		ids := make([]uint, 0, len(tags))
+		for i := range tags {
+			if tags[i].ID == 0 {
+				tags[i].ID = t.nextID("tags", 0)
+				t.tags[tags[i].ID] = tags[i]
+			}
+			ids = append(ids, tags[i].ID)
+		}
+		t.personTags[personID] = ids
+		return nil
+	})
+}
+
+// tagsOf devuelve las etiquetas de la persona ordenadas por ID.
+func (t *tables) tagsOf(personID uint) []domain.Tag {
+	ids := slices.Sorted(slices.Values(t.personTags[personID]))
+	var tags []domain.Tag
+	for _, id := range ids {
+		if tag, ok := t.tags[id]; ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func byName(a, b domain.Tag) int { return strings.Compare(a.Name, b.Name) }
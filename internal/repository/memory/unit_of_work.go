@@ -0,0 +1,44 @@
+package memory
+
+import "github.com/riada2/internal/core/ports"
+
+type unitOfWork struct {
+	store *Store
+}
+
+// NewUnitOfWork crea una unidad de trabajo sobre el almacén. Cada Do trabaja sobre una
+// copia de las tablas y la publica solo si fn termina sin error; mientras tanto el
+// almacén queda bloqueado, así que fn solo debe usar los repositorios que recibe.
+func NewUnitOfWork(store *Store) ports.UnitOfWork {
+	return &unitOfWork{store: store}
+}
+
+func (u *unitOfWork) Do(fn func(repos ports.Repositories) error) error {
+	u.store.mu.Lock()
+	defer u.store.mu.Unlock()
+
+	tx := &Store{tables: u.store.tables.clone()}
+	err := fn(ports.Repositories{
+		Persons:       NewPersonRepository(tx),
+		Addresses:     NewAddressRepository(tx),
+		Phones:        NewPhoneRepository(tx),
+		Versions:      NewVersionRepository(tx),
+		Households:    NewHouseholdRepository(tx),
+		Relationships: NewRelationshipRepository(tx),
+		Users:         NewUserRepository(tx),
+		Tags:          NewTagRepository(tx),
+		Groups:        NewGroupRepository(tx),
+		CustomFields:  NewCustomFieldRepository(tx),
+		Events:        NewEventRepository(tx),
+		Attendances:   NewAttendanceRepository(tx),
+		Notes:         NewNoteRepository(tx),
+		Delegations:   NewDelegationRepository(tx),
+		Settings:      NewSettingRepository(tx),
+		Audit:         NewAuditRepository(tx),
+	})
+	if err != nil {
+		return err
+	}
+	u.store.tables = tx.tables
+	return nil
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type userRepository struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) ports.UserRepository {
+	return &userRepository{store: store}
+}
+
+func (r *userRepository) Save(user *domain.User) error {
+	return r.store.write(func(t *tables) error {
+		for id, other := range t.users {
+			if id != user.ID && other.Username == user.Username {
+				return fmt.Errorf("%w: username %q is taken", ports.ErrConflict, user.Username)
+			}
+		}
+		user.ID = t.nextID("users", user.ID)
+		stamp(&user.CreatedAt, &user.UpdatedAt)
+		stored := *user
+		stored.Person = domain.Person{}
+		t.users[user.ID] = stored
+		return nil
+	})
+}
+
+func (r *userRepository) FindByUsername(username string) (user *domain.User, err error) {
+	err = r.store.read(func(t *tables) error {
+		user, err = first(t.users, func(u domain.User) bool { return u.Username == username })
+		return err
+	})
+	return user, err
+}
+
+func (r *userRepository) FindByID(id uint) (user *domain.User, err error) {
+	err = r.store.read(func(t *tables) error {
+		user, err = find(t.users, id)
+		return err
+	})
+	return user, err
+}
+
+func (r *userRepository) FindAll() (users []domain.User, err error) {
+	err = r.store.read(func(t *tables) error {
+		users = filter(t.users, nil)
+		return nil
+	})
+	return users, err
+}
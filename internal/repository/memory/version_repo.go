@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type versionRepository struct {
+	store *Store
+}
+
+func NewVersionRepository(store *Store) ports.VersionRepository {
+	return &versionRepository{store: store}
+}
+
+// Save siempre inserta: las versiones no se modifican.
+func (r *versionRepository) Save(version *domain.EntityVersion) error {
+	return r.store.write(func(t *tables) error {
+		if _, ok := t.versions[version.ID]; ok {
+			return fmt.Errorf("%w: version %d already exists", ports.ErrConflict, version.ID)
+		}
+		version.ID = t.nextID("versions", version.ID)
+		stamp(&version.CreatedAt, nil)
+		t.versions[version.ID] = *version
+		return nil
+	})
+}
+
+func (r *versionRepository) FindByID(id uint) (version *domain.EntityVersion, err error) {
+	err = r.store.read(func(t *tables) error {
+		version, err = find(t.versions, id)
+		return err
+	})
+	return version, err
+}
+
+func (r *versionRepository) FindByPersonID(personID uint) (versions []domain.EntityVersion, err error) {
+	err = r.store.read(func(t *tables) error {
+		versions = filter(t.versions, func(v domain.EntityVersion) bool { return v.PersonID == personID })
+		byKeys(versions,
+			func(a, b domain.EntityVersion) int { return b.CreatedAt.Compare(a.CreatedAt) },
+			func(a, b domain.EntityVersion) int { return cmp.Compare(b.ID, a.ID) })
+		return nil
+	})
+	return versions, err
+}
+
+func (r *versionRepository) LatestVersion(entityType domain.VersionEntity, entityID uint) (latest int, err error) {
+	err = r.store.read(func(t *tables) error {
+		for _, version := range t.versions {
+			if version.EntityType == entityType && version.EntityID == entityID {
+				latest = max(latest, version.Version)
+			}
+		}
+		return nil
+	})
+	return latest, err
+}
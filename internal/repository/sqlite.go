@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteOptions son los parámetros de conexión de SQLite:
+//   - _foreign_keys: SQLite no aplica las claves foráneas si no se activan.
+//   - _journal_mode=WAL: las lecturas no esperan a las escrituras.
+//   - _busy_timeout: una escritura espera hasta 5 s a que termine otra en lugar de fallar.
+//   - _txlock=immediate: las transacciones toman el bloqueo de escritura al empezar,
+//     así dos transacciones no se bloquean mutuamente al pasar de leer a escribir.
+const sqliteOptions = "_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000&_txlock=immediate"
+
+// OpenSQLite abre (y crea, si no existe) la base de datos SQLite del archivo path.
+func OpenSQLite(path string, config *gorm.Config) (*gorm.DB, error) {
+	if path == "" {
+		return nil, errors.New("the SQLite database path is empty")
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create the SQLite database directory: %w", err)
+		}
+	}
+
+	db, err := gorm.Open(sqlite.Open("file:"+path+"?"+sqliteOptions), config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the SQLite database %s: %w", path, err)
+	}
+	return db, nil
+}
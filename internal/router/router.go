@@ -10,8 +10,33 @@ import (
 	"github.com/riada2/internal/middleware"
 )
 
+// Handlers agrupa todos los handlers HTTP que se registran en el router.
+type Handlers struct {
+	Auth         *handlers.AuthHandler
+	User         *handlers.UserHandler
+	Person       *handlers.PersonHandler
+	Address      *handlers.AddressHandler
+	Phone        *handlers.PhoneHandler
+	History      *handlers.HistoryHandler
+	Audit        *handlers.AuditHandler
+	Relationship *handlers.RelationshipHandler
+	Household    *handlers.HouseholdHandler
+	Group        *handlers.GroupHandler
+	Tag          *handlers.TagHandler
+	CustomField  *handlers.CustomFieldHandler
+	Event        *handlers.EventHandler
+	Attendance   *handlers.AttendanceHandler
+	Card         *handlers.CardHandler
+	Celebration  *handlers.CelebrationHandler
+	Note         *handlers.NoteHandler
+	Ubigeo       *handlers.UbigeoHandler
+	Setting      *handlers.SettingHandler
+	Delegation   *handlers.DelegationHandler
+	Seed         *handlers.SeedHandler
+}
+
 // SetupRoutes define todas las rutas de la aplicación.
-func SetupRoutes(app *fiber.App, authHandler *handlers.AuthHandler, userHandler *handlers.UserHandler, personHandler *handlers.PersonHandler, addressHandler *handlers.AddressHandler, phoneHandler *handlers.PhoneHandler, cfg *config.Config) {
+func SetupRoutes(app *fiber.App, h Handlers, cfg *config.Config) {
 	// Ruta para la documentación de Swagger
 	app.Get("/swagger/*", swagger.New())
 
@@ -21,14 +46,16 @@ func SetupRoutes(app *fiber.App, authHandler *handlers.AuthHandler, userHandler
 	// Rutas públicas
 	api := app.Group("/api")
 	v1 := api.Group("/v1")
-	v1.Post("/login", authHandler.Login)
+	v1.Post("/login", h.Auth.Login)
+	// Pública, pero a un usuario autenticado también le muestra a quién pertenece la credencial.
+	v1.Get("/credentials/verify", middleware.AuthOptional(cfg.JWTSecret), h.Card.VerifyCredential)
 
 	// Rutas protegidas
 	protected := v1.Group("/protected")
 	protected.Use(middleware.AuthRequired(cfg.JWTSecret))
 
 	// Ruta para cualquier usuario autenticado
-	protected.Get("/profile", userHandler.GetProfile)
+	protected.Get("/profile", h.User.GetProfile)
 
 	// Ruta solo para administradores
 	adminOnly := protected.Group("/admin")
@@ -36,33 +63,181 @@ func SetupRoutes(app *fiber.App, authHandler *handlers.AuthHandler, userHandler
 	adminOnly.Get("/", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{"message": "Welcome Admin!"})
 	})
-	adminOnly.Post("/register", userHandler.Register)
-	adminOnly.Get("/users", userHandler.GetAllUsers)
+	adminOnly.Post("/register", h.User.Register)
+	adminOnly.Get("/users", h.User.GetAllUsers)
+	adminOnly.Post("/history/:versionId/revert", h.History.RevertVersion)
+	adminOnly.Get("/audit", h.Audit.SearchAudit)
+	adminOnly.Get("/audit/verify", h.Audit.VerifyAudit)
+	adminOnly.Post("/households/migrate", h.Household.MigrateSharedAddresses)
+	adminOnly.Post("/addresses/migrate", h.Address.MigrateAddresses)
+	adminOnly.Post("/addresses/geocode", h.Address.GeocodeAddresses)
+	adminOnly.Post("/phones/normalize", h.Phone.NormalizePhones)
+	adminOnly.Get("/attendance/report", h.Attendance.GetPeriodReport)
+	adminOnly.Post("/cards", h.Card.GetCards)
+	adminOnly.Get("/settings", h.Setting.ListSettings)
+	adminOnly.Put("/settings", h.Setting.UpdateSettings)
+	adminOnly.Get("/delegations", h.Delegation.SearchDelegations)
+	adminOnly.Post("/delegations", h.Delegation.GrantDelegation)
+	adminOnly.Get("/jobs", h.Celebration.ListJobs)
+	adminOnly.Post("/jobs/:name/run", h.Celebration.RunJob)
+	// POST /admin/seed: Datos de demostración, solo si SEED_ENDPOINT_ENABLED lo habilita (desarrollo).
+	if cfg.SeedEndpointEnabled {
+		adminOnly.Post("/seed", h.Seed.Seed)
+	}
 
 	// --- Rutas para Person (unificadas) ---
 	personRoutes := protected.Group("/person")
 
 	// PUT /person: Un usuario autenticado crea o actualiza su propia información personal.
-	personRoutes.Put("/", personHandler.CreateOrUpdatePersonForUser)
+	personRoutes.Put("/", h.Person.CreateOrUpdatePersonForUser)
 
 	// GET /person/search: Búsqueda de personas (accesible para cualquier usuario autenticado).
-	personRoutes.Get("/search", personHandler.SearchPersons)
+	personRoutes.Get("/search", h.Person.SearchPersons)
+
+	// GET /person/export: Exportación en CSV con los mismos filtros de la búsqueda (solo administradores).
+	personRoutes.Get("/export", middleware.RoleRequired(domain.AdminRole), h.Person.ExportPersons)
+
+	// GET /person/nearby: Personas que viven cerca de un punto o de otra persona (solo administradores).
+	personRoutes.Get("/nearby", middleware.RoleRequired(domain.AdminRole), h.Address.FindNearby)
 
 	// POST /person: Un administrador crea un nuevo registro de persona.
-	personRoutes.Post("/", middleware.RoleRequired(domain.AdminRole), personHandler.CreatePersonByAdmin)
+	personRoutes.Post("/", middleware.RoleRequired(domain.AdminRole), h.Person.CreatePersonByAdmin)
+
+	// GET y PUT /person/:id: Consulta y edición de un registro (dueño, delegado o administrador).
+	personRoutes.Get("/:id", h.Person.GetPerson)
+	personRoutes.Put("/:id", h.Person.UpdatePerson)
+
+	// GET /person/:id/history: Historial de cambios (dueño del registro, delegado o administrador).
+	personRoutes.Get("/:id/history", h.History.GetPersonHistory)
+
+	// Relaciones familiares: la escritura la valida el servicio (dueño o administrador).
+	personRoutes.Get("/:id/relationships", h.Relationship.GetRelationships)
+	personRoutes.Post("/:id/relationships", h.Relationship.AddRelationship)
+	personRoutes.Delete("/:id/relationships/:relationshipId", h.Relationship.RemoveRelationship)
+	personRoutes.Get("/:id/household", h.Relationship.GetHousehold)
+	personRoutes.Get("/:id/family-tree", h.Relationship.ExportFamilyTree)
+
+	// Asistencia y credencial con QR de la persona (dueño del registro, delegado o
+	// administrador). Revocar la credencial es solo para administradores.
+	personRoutes.Get("/:id/attendance", h.Attendance.GetPersonReport)
+	personRoutes.Get("/:id/credential", h.Attendance.GetCredential)
+	personRoutes.Delete("/:id/credential", middleware.RoleRequired(domain.AdminRole), h.Card.RevokeCredential)
+
+	// GET /person/:id/card: Carné en PDF con QR (solo administradores).
+	personRoutes.Get("/:id/card", middleware.RoleRequired(domain.AdminRole), h.Card.GetCard)
+
+	// Notas de seguimiento y línea de tiempo: el servicio filtra las notas según su visibilidad.
+	personRoutes.Get("/:id/notes", h.Note.ListNotes)
+	personRoutes.Post("/:id/notes", middleware.RoleRequired(domain.AdminRole), h.Note.CreateNote)
+	personRoutes.Get("/:id/timeline", h.Note.GetTimeline)
+
+	// Direcciones y teléfonos propios de la persona (dueño del registro, delegado o administrador).
+	// Las rutas de orden se registran antes que las de un solo contacto.
+	personRoutes.Get("/:id/addresses", h.Address.ListPersonAddresses)
+	personRoutes.Post("/:id/addresses", h.Address.CreatePersonAddress)
+	personRoutes.Put("/:id/addresses/order", h.Address.ReorderPersonAddresses)
+	personRoutes.Get("/:id/addresses/:addressId", h.Address.GetPersonAddress)
+	personRoutes.Put("/:id/addresses/:addressId", h.Address.UpdatePersonAddress)
+	personRoutes.Delete("/:id/addresses/:addressId", h.Address.DeletePersonAddress)
+	personRoutes.Get("/:id/phones", h.Phone.ListPersonPhones)
+	personRoutes.Post("/:id/phones", h.Phone.CreatePersonPhone)
+	personRoutes.Put("/:id/phones/order", h.Phone.ReorderPersonPhones)
+	personRoutes.Get("/:id/phones/:phoneId", h.Phone.GetPersonPhone)
+	personRoutes.Put("/:id/phones/:phoneId", h.Phone.UpdatePersonPhone)
+	personRoutes.Delete("/:id/phones/:phoneId", h.Phone.DeletePersonPhone)
+
+	// PUT /person/:id/tags: Reemplaza las etiquetas de la persona (solo administradores).
+	personRoutes.Put("/:id/tags", middleware.RoleRequired(domain.AdminRole), h.Tag.SetPersonTags)
 
 	// DELETE /person/:id: Un administrador elimina un registro de persona.
-	personRoutes.Delete("/:id", middleware.RoleRequired(domain.AdminRole), personHandler.DeletePerson)
+	personRoutes.Delete("/:id", middleware.RoleRequired(domain.AdminRole), h.Person.DeletePerson)
 
 	// --- Rutas para Address ---
 	addressRoutes := protected.Group("/address")
-	addressRoutes.Post("/", addressHandler.CreateOrUpdateAddress) // Crear
-	addressRoutes.Put("/", addressHandler.CreateOrUpdateAddress)  // Actualizar (usando el mismo handler)
-	addressRoutes.Delete("/:id", addressHandler.DeleteAddress)    // Eliminar
+	addressRoutes.Post("/", h.Address.CreateOrUpdateAddress) // Crear
+	addressRoutes.Put("/", h.Address.CreateOrUpdateAddress)  // Actualizar (usando el mismo handler)
+	addressRoutes.Delete("/:id", h.Address.DeleteAddress)    // Eliminar
+
+	// --- Rutas para Ubigeo ---
+	// Catálogo de departamentos, provincias y distritos para las listas en cascada.
+	ubigeoRoutes := protected.Group("/ubigeo")
+	ubigeoRoutes.Get("/departments", h.Ubigeo.ListDepartments)
+	ubigeoRoutes.Get("/departments/:code/provinces", h.Ubigeo.ListProvinces)
+	ubigeoRoutes.Get("/provinces/:code/districts", h.Ubigeo.ListDistricts)
+	ubigeoRoutes.Get("/:code", h.Ubigeo.GetUbigeo)
+
+	// --- Rutas para Household ---
+	// La lectura está abierta a cualquier usuario autenticado; la escritura, solo a administradores.
+	householdRoutes := protected.Group("/households")
+	householdRoutes.Get("/", h.Household.SearchHouseholds)
+	householdRoutes.Get("/:id", h.Household.GetHousehold)
+	householdRoutes.Post("/", middleware.RoleRequired(domain.AdminRole), h.Household.CreateHousehold)
+	householdRoutes.Put("/:id", middleware.RoleRequired(domain.AdminRole), h.Household.UpdateHousehold)
+	householdRoutes.Delete("/:id", middleware.RoleRequired(domain.AdminRole), h.Household.DeleteHousehold)
+	householdRoutes.Post("/:id/members", middleware.RoleRequired(domain.AdminRole), h.Household.AddMembers)
+	householdRoutes.Delete("/:id/members/:personId", middleware.RoleRequired(domain.AdminRole), h.Household.RemoveMember)
+	householdRoutes.Post("/:id/addresses", middleware.RoleRequired(domain.AdminRole), h.Household.AddAddress)
+	householdRoutes.Delete("/:id/addresses/:addressId", middleware.RoleRequired(domain.AdminRole), h.Household.RemoveAddress)
+	householdRoutes.Post("/:id/phones", middleware.RoleRequired(domain.AdminRole), h.Household.AddPhone)
+	householdRoutes.Delete("/:id/phones/:phoneId", middleware.RoleRequired(domain.AdminRole), h.Household.RemovePhone)
+
+	// --- Rutas para Group ---
+	groupRoutes := protected.Group("/groups")
+	groupRoutes.Get("/", h.Group.ListGroups)
+	groupRoutes.Get("/:id", h.Group.GetGroup)
+	groupRoutes.Post("/", middleware.RoleRequired(domain.AdminRole), h.Group.CreateGroup)
+	groupRoutes.Put("/:id", middleware.RoleRequired(domain.AdminRole), h.Group.UpdateGroup)
+	groupRoutes.Delete("/:id", middleware.RoleRequired(domain.AdminRole), h.Group.DeleteGroup)
+	groupRoutes.Post("/:id/members", middleware.RoleRequired(domain.AdminRole), h.Group.AddMembers)
+	groupRoutes.Delete("/:id/members", middleware.RoleRequired(domain.AdminRole), h.Group.RemoveMembers)
+
+	// --- Rutas para Tag ---
+	protected.Get("/tags", h.Tag.ListTags)
+
+	// --- Rutas para CustomField ---
+	customFieldRoutes := protected.Group("/custom-fields")
+	customFieldRoutes.Get("/", h.CustomField.ListCustomFields)
+	customFieldRoutes.Post("/", middleware.RoleRequired(domain.AdminRole), h.CustomField.CreateCustomField)
+	customFieldRoutes.Put("/:id", middleware.RoleRequired(domain.AdminRole), h.CustomField.UpdateCustomField)
+	customFieldRoutes.Delete("/:id", middleware.RoleRequired(domain.AdminRole), h.CustomField.DeleteCustomField)
+
+	// --- Rutas para Event ---
+	// La consulta de eventos está abierta a cualquier usuario autenticado; la asistencia, solo a administradores.
+	eventRoutes := protected.Group("/events")
+	eventRoutes.Get("/", h.Event.ListEvents)
+	eventRoutes.Get("/:id", h.Event.GetEvent)
+	eventRoutes.Get("/:id/occurrences", h.Event.GetOccurrences)
+	eventRoutes.Post("/", middleware.RoleRequired(domain.AdminRole), h.Event.CreateEvent)
+	eventRoutes.Put("/:id", middleware.RoleRequired(domain.AdminRole), h.Event.UpdateEvent)
+	eventRoutes.Delete("/:id", middleware.RoleRequired(domain.AdminRole), h.Event.DeleteEvent)
+	eventRoutes.Post("/:id/check-in", middleware.RoleRequired(domain.AdminRole), h.Attendance.CheckIn)
+	eventRoutes.Get("/:id/attendance", middleware.RoleRequired(domain.AdminRole), h.Attendance.GetEventReport)
+	eventRoutes.Delete("/:id/attendance/:attendanceId", middleware.RoleRequired(domain.AdminRole), h.Attendance.RemoveAttendance)
+
+	// --- Rutas para Note ---
+	// La edición y el cierre de tareas los valida el servicio (autor o persona asignada).
+	noteRoutes := protected.Group("/notes")
+	noteRoutes.Get("/assigned", h.Note.ListAssignedNotes)
+	noteRoutes.Put("/:id", h.Note.UpdateNote)
+	noteRoutes.Delete("/:id", h.Note.DeleteNote)
+	noteRoutes.Post("/:id/complete", h.Note.CompleteNote)
+
+	// --- Rutas para Delegation ---
+	// Solicitudes para gestionar el registro de otra persona; el servicio valida quién decide.
+	delegationRoutes := protected.Group("/delegations")
+	delegationRoutes.Get("/", h.Delegation.ListDelegations)
+	delegationRoutes.Post("/", h.Delegation.RequestDelegation)
+	delegationRoutes.Get("/persons", h.Delegation.ListManagedPersons)
+	delegationRoutes.Post("/:id/accept", h.Delegation.AcceptDelegation)
+	delegationRoutes.Post("/:id/reject", h.Delegation.RejectDelegation)
+	delegationRoutes.Delete("/:id", h.Delegation.RevokeDelegation)
+
+	// --- Rutas para Celebration ---
+	protected.Get("/celebrations", h.Celebration.ListCelebrations)
 
 	// --- Rutas para Phone ---
 	phoneRoutes := protected.Group("/phone")
-	phoneRoutes.Post("/", phoneHandler.CreateOrUpdatePhone)
-	phoneRoutes.Put("/", phoneHandler.CreateOrUpdatePhone)
-	phoneRoutes.Delete("/:id", phoneHandler.DeletePhone)
+	phoneRoutes.Post("/", h.Phone.CreateOrUpdatePhone)
+	phoneRoutes.Put("/", h.Phone.CreateOrUpdatePhone)
+	phoneRoutes.Delete("/:id", h.Phone.DeletePhone)
 }
@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// JobFunc es el trabajo de una tarea. scheduledAt es la hora prevista de la ejecución,
+// que puede ser anterior a la actual si se recupera una ejecución perdida.
+type JobFunc func(ctx context.Context, scheduledAt time.Time) error
+
+type job struct {
+	name   string
+	hour   int
+	minute int
+	run    JobFunc
+	mu     sync.Mutex // Evita que la misma tarea se ejecute dos veces a la vez.
+}
+
+// Scheduler ejecuta tareas diarias dentro del proceso y guarda su estado en la base de
+// datos. Al arrancar, si la última ejecución prevista de una tarea que ya se había
+// ejecutado antes se perdió (por ejemplo, porque el servidor estaba apagado), la ejecuta
+// una vez. Con varias instancias sobre la misma base, cada ejecución prevista la reserva
+// una sola en la base de datos antes de ejecutarla.
+type Scheduler struct {
+	repo ports.JobStateRepository
+	loc  *time.Location
+	jobs map[string]*job
+}
+
+// New crea un planificador que cuenta las horas en loc, la zona horaria de la organización.
+func New(repo ports.JobStateRepository, loc *time.Location) *Scheduler {
+	return &Scheduler{repo: repo, loc: loc, jobs: make(map[string]*job)}
+}
+
+// Daily registra una tarea que se ejecuta cada día a la hora indicada ("HH:MM").
+// Las tareas se deben registrar antes de llamar a Start.
+func (s *Scheduler) Daily(name, at string, run JobFunc) error {
+	parsed, err := time.Parse("15:04", at)
+	if err != nil {
+		return fmt.Errorf("invalid time %q for job %s, use HH:MM", at, name)
+	}
+	if _, exists := s.jobs[name]; exists {
+		return fmt.Errorf("job %s is already registered", name)
+	}
+	s.jobs[name] = &job{name: name, hour: parsed.Hour(), minute: parsed.Minute(), run: run}
+	return nil
+}
+
+// Start lanza las tareas registradas. Se detienen cuando se cancela ctx.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, j := range s.jobs {
+		go s.loop(ctx, j)
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	state, err := s.state(j.name)
+	if err != nil {
+		log.Printf("scheduler: could not load the state of job %s: %v", j.name, err)
+	}
+
+	now := time.Now().In(s.loc)
+	if last := j.previous(now); state != nil && state.LastRunAt != nil && state.LastRunAt.Before(last) {
+		log.Printf("scheduler: job %s missed its run of %s, running it now", j.name, last.Format(time.RFC3339))
+		s.runScheduled(ctx, j, last)
+	} else if err := s.saveNextRun(j, j.next(now)); err != nil {
+		log.Printf("scheduler: could not save the state of job %s: %v", j.name, err)
+	}
+
+	for {
+		next := j.next(time.Now().In(s.loc))
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.runScheduled(ctx, j, next)
+		}
+	}
+}
+
+// Jobs devuelve el estado de cada tarea registrada, incluidas las que aún no se ejecutaron.
+func (s *Scheduler) Jobs() ([]domain.JobState, error) {
+	states, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool)
+	var result []domain.JobState
+	for _, state := range states {
+		if _, ok := s.jobs[state.Name]; ok {
+			known[state.Name] = true
+			result = append(result, state)
+		}
+	}
+	for name := range s.jobs {
+		if !known[name] {
+			result = append(result, domain.JobState{Name: name})
+		}
+	}
+	sort.Slice(result, func(i, k int) bool { return result[i].Name < result[k].Name })
+	return result, nil
+}
+
+// RunNow ejecuta la tarea inmediatamente.
+func (s *Scheduler) RunNow(ctx context.Context, name string) error {
+	j, ok := s.jobs[name]
+	if !ok {
+		return ports.ErrJobNotFound
+	}
+	return s.execute(ctx, j, time.Now().In(s.loc))
+}
+
+// runScheduled ejecuta la tarea prevista para scheduledAt si esta instancia la reserva
+// antes que las demás; si otra la reservó, no hace nada.
+func (s *Scheduler) runScheduled(ctx context.Context, j *job, scheduledAt time.Time) {
+	claimed, err := s.repo.Claim(j.name, scheduledAt, j.next(scheduledAt))
+	if err != nil {
+		log.Printf("scheduler: could not claim the run of job %s: %v", j.name, err)
+		return
+	}
+	if !claimed {
+		log.Printf("scheduler: the run of job %s of %s was claimed by another instance", j.name, scheduledAt.Format(time.RFC3339))
+		return
+	}
+	s.execute(ctx, j, scheduledAt)
+}
+
+// execute ejecuta la tarea y guarda el resultado en su estado.
+func (s *Scheduler) execute(ctx context.Context, j *job, scheduledAt time.Time) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	startedAt := time.Now()
+	runErr := j.run(ctx, scheduledAt)
+	if runErr != nil {
+		log.Printf("scheduler: job %s failed: %v", j.name, runErr)
+	}
+
+	state, err := s.state(j.name)
+	if err != nil {
+		log.Printf("scheduler: could not load the state of job %s: %v", j.name, err)
+		return errors.Join(runErr, err)
+	}
+	if state == nil {
+		state = &domain.JobState{Name: j.name}
+	}
+	state.LastRunAt = &startedAt
+	state.Runs++
+	if runErr != nil {
+		state.LastError = runErr.Error()
+	} else {
+		state.LastSuccessAt = &startedAt
+		state.LastError = ""
+	}
+	next := j.next(time.Now().In(s.loc))
+	state.NextRunAt = &next
+	if err := s.repo.Save(state); err != nil {
+		log.Printf("scheduler: could not save the state of job %s: %v", j.name, err)
+		return errors.Join(runErr, err)
+	}
+	return runErr
+}
+
+// state devuelve el estado guardado de la tarea, o nil si nunca se ejecutó.
+func (s *Scheduler) state(name string) (*domain.JobState, error) {
+	state, err := s.repo.Find(name)
+	if errors.Is(err, ports.ErrNotFound) {
+		return nil, nil
+	}
+	return state, err
+}
+
+func (s *Scheduler) saveNextRun(j *job, next time.Time) error {
+	state, err := s.state(j.name)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		state = &domain.JobState{Name: j.name}
+	}
+	state.NextRunAt = &next
+	return s.repo.Save(state)
+}
+
+// next devuelve la próxima hora prevista posterior a now.
+func (j *job) next(now time.Time) time.Time {
+	at := time.Date(now.Year(), now.Month(), now.Day(), j.hour, j.minute, 0, 0, now.Location())
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at
+}
+
+// previous devuelve la última hora prevista anterior o igual a now.
+func (j *job) previous(now time.Time) time.Time {
+	at := time.Date(now.Year(), now.Month(), now.Day(), j.hour, j.minute, 0, 0, now.Location())
+	if at.After(now) {
+		at = at.AddDate(0, 0, -1)
+	}
+	return at
+}
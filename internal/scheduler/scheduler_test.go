@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/repository/memory"
+)
+
+func TestNextAndPrevious(t *testing.T) {
+	lima := time.FixedZone("America/Lima", -5*60*60)
+	j := &job{name: "celebration_reminders", hour: 7, minute: 30}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		next     time.Time
+		previous time.Time
+	}{
+		{
+			name:     "before the hour",
+			now:      time.Date(2024, 6, 15, 6, 0, 0, 0, lima),
+			next:     time.Date(2024, 6, 15, 7, 30, 0, 0, lima),
+			previous: time.Date(2024, 6, 14, 7, 30, 0, 0, lima),
+		},
+		{
+			name:     "at the hour",
+			now:      time.Date(2024, 6, 15, 7, 30, 0, 0, lima),
+			next:     time.Date(2024, 6, 16, 7, 30, 0, 0, lima),
+			previous: time.Date(2024, 6, 15, 7, 30, 0, 0, lima),
+		},
+		{
+			name:     "after the hour",
+			now:      time.Date(2024, 6, 15, 20, 0, 0, 0, lima),
+			next:     time.Date(2024, 6, 16, 7, 30, 0, 0, lima),
+			previous: time.Date(2024, 6, 15, 7, 30, 0, 0, lima),
+		},
+		{
+			name:     "end of month",
+			now:      time.Date(2024, 2, 29, 23, 0, 0, 0, lima),
+			next:     time.Date(2024, 3, 1, 7, 30, 0, 0, lima),
+			previous: time.Date(2024, 2, 29, 7, 30, 0, 0, lima),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := j.next(tt.now); !got.Equal(tt.next) {
+				t.Errorf("next = %v, want %v", got, tt.next)
+			}
+			if got := j.previous(tt.now); !got.Equal(tt.previous) {
+				t.Errorf("previous = %v, want %v", got, tt.previous)
+			}
+		})
+	}
+}
+
+func TestStartRunsMissedRun(t *testing.T) {
+	repo := memory.NewJobStateRepository(memory.NewStore())
+	s := New(repo, time.UTC)
+	ran := make(chan time.Time, 1)
+	if err := s.Daily("celebration_reminders", "07:30", func(_ context.Context, scheduledAt time.Time) error {
+		ran <- scheduledAt
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// La última ejecución fue el día anterior: el servidor estuvo apagado a la hora prevista.
+	missed := s.jobs["celebration_reminders"].previous(time.Now().In(time.UTC))
+	lastRun := missed.AddDate(0, 0, -1)
+	if err := repo.Save(&domain.JobState{Name: "celebration_reminders", LastRunAt: &lastRun, NextRunAt: &missed}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+
+	select {
+	case scheduledAt := <-ran:
+		if !scheduledAt.Equal(missed) {
+			t.Fatalf("scheduledAt = %v, want the missed run %v", scheduledAt, missed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the missed run was not caught up on start")
+	}
+}
+
+func TestMissedRunIsClaimedOnce(t *testing.T) {
+	repo := memory.NewJobStateRepository(memory.NewStore())
+	scheduledAt := time.Date(2024, 6, 15, 7, 30, 0, 0, time.UTC)
+	if err := repo.Save(&domain.JobState{Name: "celebration_reminders", NextRunAt: &scheduledAt}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Dos instancias sobre la misma base recuperan la misma ejecución perdida.
+	runs := 0
+	for i := 0; i < 2; i++ {
+		s := New(repo, time.UTC)
+		if err := s.Daily("celebration_reminders", "07:30", func(context.Context, time.Time) error {
+			runs++
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		s.runScheduled(context.Background(), s.jobs["celebration_reminders"], scheduledAt)
+	}
+	if runs != 1 {
+		t.Fatalf("runs = %d, want 1", runs)
+	}
+}
@@ -1,52 +1,618 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/riada2/internal/core/domain"
 	"github.com/riada2/internal/core/ports"
-	"gorm.io/gorm"
 )
 
 type addressServiceImpl struct {
 	addressRepo ports.AddressRepository
 	personRepo  ports.PersonRepository
+	uow         ports.UnitOfWork
+	catalog     ports.UbigeoCatalog
+	geocoder    ports.Geocoder
+	settings    ports.SettingsService
+	policy      ports.Policy
+	audit       ports.AuditService
 }
 
-func NewAddressService(addressRepo ports.AddressRepository, personRepo ports.PersonRepository) ports.AddressService {
-	return &addressServiceImpl{addressRepo, personRepo}
+// Límites de la búsqueda de personas cercanas.
+const (
+	defaultNearbyLimit = 20
+	maxNearbyLimit     = 100
+	maxNearbyRadiusKm  = 500
+)
+
+func NewAddressService(addressRepo ports.AddressRepository, personRepo ports.PersonRepository, uow ports.UnitOfWork, catalog ports.UbigeoCatalog, geocoder ports.Geocoder, settings ports.SettingsService, policy ports.Policy, audit ports.AuditService) ports.AddressService {
+	return &addressServiceImpl{addressRepo, personRepo, uow, catalog, geocoder, settings, policy, audit}
 }
 
-func (s *addressServiceImpl) CreateOrUpdateAddress(address *domain.Address) (*domain.Address, error) {
+func (s *addressServiceImpl) CreateOrUpdateAddress(address *domain.Address, actor domain.Actor) (_ *domain.Address, err error) {
+	auditAction := domain.AuditAddressCreate
+	if address.ID != 0 {
+		auditAction = domain.AuditAddressUpdate
+	}
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, auditAction, string(domain.AddressEntity), address.ID, changes, err))
+	}()
 
-	// Validar que la persona (PersonID) a la que se asocia la dirección realmente exista.
-	_, err := s.personRepo.FindByID(address.PersonID)
+	// Validar que la persona (PersonID) a la que se asocia la dirección exista y que el actor pueda gestionarla.
+	policyAction := ports.ActionCreate
+	if address.ID != 0 {
+		policyAction = ports.ActionUpdate
+	}
+	person, err := findAuthorizedPerson(s.personRepo, s.policy, address.PersonID, domain.AddressEntity, policyAction, actor)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// Usamos un error específico para que el handler pueda interpretarlo.
-			return nil, ports.ErrPersonNotFound
+		return nil, err
+	}
+
+	if err := normalizeAddressFields(s.catalog, address); err != nil {
+		return nil, err
+	}
+	geocodeAddress(s.geocoder, address)
+
+	// Si es una actualización, guardamos el estado previo para el historial.
+	var before map[string]*string
+	action := domain.VersionCreated
+	if address.ID != 0 {
+		existingAddress, err := s.addressRepo.FindByID(address.ID)
+		if err != nil {
+			if errors.Is(err, ports.ErrNotFound) {
+				return nil, ports.ErrAddressNotFound
+			}
+			return nil, err
+		}
+		// La dirección no puede pasar a otra persona.
+		if existingAddress.PersonID != address.PersonID {
+			return nil, ports.ErrAddressNotFound
+		}
+		before = addressSnapshot(existingAddress)
+		action = domain.VersionUpdated
+		// El orden solo cambia al reordenar.
+		address.SortOrder = existingAddress.SortOrder
+	} else {
+		// Es una dirección nueva, verificar el límite configurado.
+		settings, err := currentSettings(s.settings)
+		if err != nil {
+			return nil, err
 		}
-		return nil, err // Otro error de base de datos.
+		count, err := s.addressRepo.CountByPersonID(address.PersonID)
+		if err != nil {
+			return nil, err
+		}
+		if !domain.AllowsMore(settings.MaxAddresses, count) {
+			return nil, &ports.ValidationError{Err: ports.ErrContactLimitReached, Field: "address", Code: ports.AddressLimitCode,
+				Message: fmt.Sprintf("a person cannot have more than %d addresses", settings.MaxAddresses)}
+		}
+		// Las direcciones nuevas van al final.
+		address.SortOrder = nextSortOrder(person.Addresses, func(a domain.Address) int { return a.SortOrder })
 	}
 
-	// Es una dirección nueva, verificar el límite. Una persona no puede tener más de 3 direcciones.
-	count, err := s.addressRepo.CountByPersonID(address.PersonID)
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Addresses.Save(address); err != nil {
+			return err
+		}
+		if _, err := recordVersion(repos.Versions, domain.AddressEntity, address.ID, address.PersonID, action, before, addressSnapshot(address), actor); err != nil {
+			return err
+		}
+		changes = diffSnapshots(before, addressSnapshot(address))
+		return s.audit.RecordIn(repos, newAuditEntry(actor, auditAction, string(domain.AddressEntity), address.ID, changes, nil))
+	})
 	if err != nil {
 		return nil, err
 	}
-	if count >= 2 {
-		return nil, errors.New("una persona no puede tener más de 2 direcciones")
+	return address, nil
+}
+
+func (s *addressServiceImpl) DeleteAddress(addressID uint, actor domain.Actor) (err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditAddressDelete, string(domain.AddressEntity), addressID, changes, err))
+	}()
+
+	address, err := s.addressRepo.FindByID(addressID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return ports.ErrAddressNotFound
+		}
+		return err
+	}
+
+	person, err := findAuthorizedPerson(s.personRepo, s.policy, address.PersonID, domain.AddressEntity, ports.ActionDelete, actor)
+	if err != nil {
+		return err
+	}
+	if err := s.checkRequiredAddress(person); err != nil {
+		return err
 	}
 
-	if err := s.addressRepo.Save(address); err != nil {
+	before := addressSnapshot(address)
+	changes = diffSnapshots(before, nil)
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Addresses.Delete(addressID); err != nil {
+			return err
+		}
+		if _, err := recordVersion(repos.Versions, domain.AddressEntity, address.ID, address.PersonID, domain.VersionDeleted, before, nil, actor); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditAddressDelete, string(domain.AddressEntity), addressID, changes, nil))
+	})
+}
+
+func (s *addressServiceImpl) ListAddresses(personID uint, actor domain.Actor) ([]domain.Address, error) {
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, domain.AddressEntity, ports.ActionRead, actor); err != nil {
 		return nil, err
 	}
+	return s.addressRepo.FindByPersonID(personID)
+}
 
+func (s *addressServiceImpl) GetAddress(personID, addressID uint, actor domain.Actor) (*domain.Address, error) {
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, domain.AddressEntity, ports.ActionRead, actor); err != nil {
+		return nil, err
+	}
+	address, err := s.addressRepo.FindByID(addressID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrAddressNotFound
+		}
+		return nil, err
+	}
+	if address.PersonID != personID {
+		return nil, ports.ErrAddressNotFound
+	}
 	return address, nil
 }
 
-func (s *addressServiceImpl) DeleteAddress(addressID uint) error {
-	// Find the person associated with the user
+func (s *addressServiceImpl) ReorderAddresses(personID uint, ids []uint, actor domain.Actor) (_ []domain.Address, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditAddressReorder, string(domain.PersonEntity), personID, changes, err))
+	}()
+
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, domain.AddressEntity, ports.ActionUpdate, actor); err != nil {
+		return nil, err
+	}
+	addresses, err := s.addressRepo.FindByPersonID(personID)
+	if err != nil {
+		return nil, err
+	}
+	current := make([]uint, len(addresses))
+	for i := range addresses {
+		current[i] = addresses[i].ID
+	}
+	if !sameIDs(current, ids) {
+		return nil, &ports.ValidationError{Err: ports.ErrInvalidAddress, Field: "ids", Code: ports.InvalidOrderCode,
+			Message: "the order must list every address of the person exactly once"}
+	}
 
-	return s.addressRepo.Delete(addressID)
+	changes = map[string]domain.FieldChange{"order": {Old: textValue(joinIDs(current)), New: textValue(joinIDs(ids))}}
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Addresses.Reorder(ids); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditAddressReorder, string(domain.PersonEntity), personID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.addressRepo.FindByPersonID(personID)
+}
+
+// sameIDs indica si ids contiene exactamente una vez cada ID de current, en cualquier orden.
+func sameIDs(current, ids []uint) bool {
+	if len(current) != len(ids) {
+		return false
+	}
+	pending := make(map[uint]bool, len(current))
+	for _, id := range current {
+		pending[id] = true
+	}
+	for _, id := range ids {
+		if !pending[id] {
+			return false
+		}
+		delete(pending, id)
+	}
+	return true
+}
+
+// nextSortOrder devuelve el orden que deja un contacto nuevo después de los existentes.
+func nextSortOrder[T any](contacts []T, sortOrder func(T) int) int {
+	next := 0
+	for _, contact := range contacts {
+		if order := sortOrder(contact); order >= next {
+			next = order + 1
+		}
+	}
+	return next
+}
+
+// checkRequiredAddress impide eliminar la última dirección de la persona si la
+// configuración la exige y el hogar no tiene una.
+func (s *addressServiceImpl) checkRequiredAddress(person *domain.Person) error {
+	settings, err := currentSettings(s.settings)
+	if err != nil || !settings.Requires(domain.PersonFieldAddress) {
+		return err
+	}
+	if len(person.Addresses) > 1 || (person.Household != nil && len(person.Household.Addresses) > 0) {
+		return nil
+	}
+	return &ports.ValidationError{Err: ports.ErrInvalidPerson, Field: domain.PersonFieldAddress, Code: ports.FieldRequiredCode,
+		Message: "the address is required and this is the last one of the person"}
+}
+
+func (s *addressServiceImpl) MigrateAddresses(dryRun bool, actor domain.Actor) (report *domain.AddressMigrationReport, err error) {
+	defer func() {
+		if dryRun {
+			return // La simulación no modifica datos, no se audita.
+		}
+		var changes map[string]domain.FieldChange
+		if report != nil {
+			changes = map[string]domain.FieldChange{
+				"structured": {New: textValue(strconv.Itoa(report.Structured))},
+				"ambiguous":  {New: textValue(strconv.Itoa(report.Ambiguous))},
+				"unmatched":  {New: textValue(strconv.Itoa(report.Unmatched))},
+			}
+		}
+		recordSummary(s.audit, newAuditEntry(actor, domain.AuditAddressMigrate, string(domain.AddressEntity), 0, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+
+	addresses, err := s.addressRepo.FindUnstructured()
+	if err != nil {
+		return nil, err
+	}
+
+	report = &domain.AddressMigrationReport{DryRun: dryRun}
+	for i := range addresses {
+		address := &addresses[i]
+		report.Scanned++
+		location, ambiguous := matchFreeTextAddress(s.catalog, address.Address)
+		switch {
+		case ambiguous:
+			report.Ambiguous++
+			continue
+		case location == nil:
+			report.Unmatched++
+			continue
+		}
+
+		report.Structured++
+		if dryRun {
+			continue
+		}
+		before := addressSnapshot(address)
+		address.Country = domain.PeruCountryCode
+		setAddressLocation(address, location)
+		if err := s.saveVersioned(address, before, actor); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func (s *addressServiceImpl) GeocodeAddresses(actor domain.Actor) (report *domain.GeocodeReport, err error) {
+	defer func() {
+		var changes map[string]domain.FieldChange
+		if report != nil {
+			changes = map[string]domain.FieldChange{
+				"geocoded": {New: textValue(strconv.Itoa(report.Geocoded))},
+				"notFound": {New: textValue(strconv.Itoa(report.NotFound))},
+			}
+		}
+		recordSummary(s.audit, newAuditEntry(actor, domain.AuditAddressGeocode, string(domain.AddressEntity), 0, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+
+	addresses, err := s.addressRepo.FindWithoutCoordinates()
+	if err != nil {
+		return nil, err
+	}
+
+	report = &domain.GeocodeReport{}
+	for i := range addresses {
+		address := &addresses[i]
+		report.Scanned++
+		before := addressSnapshot(address)
+		address.Latitude, address.Longitude = nil, nil
+		if !geocodeAddress(s.geocoder, address) {
+			report.NotFound++
+			continue
+		}
+		report.Geocoded++
+		if err := s.saveVersioned(address, before, actor); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// saveVersioned guarda una dirección modificada por un proceso masivo junto con su versión.
+func (s *addressServiceImpl) saveVersioned(address *domain.Address, before map[string]*string, actor domain.Actor) error {
+	_, err := versionedWrite(s.uow, func(repos ports.Repositories) (*domain.EntityVersion, error) {
+		if err := repos.Addresses.Save(address); err != nil {
+			return nil, err
+		}
+		return recordVersion(repos.Versions, domain.AddressEntity, address.ID, address.PersonID, domain.VersionUpdated, before, addressSnapshot(address), actor)
+	})
+	return err
+}
+
+func (s *addressServiceImpl) FindNearby(query ports.NearbyQuery, centerPersonID *uint, actor domain.Actor) ([]domain.NearbyPerson, error) {
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+
+	if centerPersonID != nil {
+		person, err := findPerson(s.personRepo, *centerPersonID)
+		if err != nil {
+			return nil, err
+		}
+		var center *domain.GeoPoint
+		for i := range person.Addresses {
+			if center = person.Addresses[i].Point(); center != nil {
+				break
+			}
+		}
+		if center == nil {
+			return nil, fmt.Errorf("%w: person %d has no geocoded address", ports.ErrInvalidNearbyQuery, person.ID)
+		}
+		query.Center = *center
+		query.ExcludePersonID = person.ID
+	}
+
+	if !query.Center.IsValid() {
+		return nil, fmt.Errorf("%w: latitude must be between -90 and 90 and longitude between -180 and 180", ports.ErrInvalidNearbyQuery)
+	}
+	if query.RadiusKm < 0 || query.RadiusKm > maxNearbyRadiusKm {
+		return nil, fmt.Errorf("%w: radius must be between 0 and %d km", ports.ErrInvalidNearbyQuery, maxNearbyRadiusKm)
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultNearbyLimit
+	}
+	if query.Limit > maxNearbyLimit {
+		query.Limit = maxNearbyLimit
+	}
+	return s.addressRepo.FindNearby(query)
+}
+
+// geocodeAddress completa las coordenadas de la dirección si no las tiene e indica si
+// la dirección queda geocodificada. Una dirección que no se reconoce se guarda sin
+// coordenadas: la geocodificación nunca impide guardar.
+func geocodeAddress(geocoder ports.Geocoder, a *domain.Address) bool {
+	if a.Point() != nil {
+		return true
+	}
+	point, err := geocoder.Geocode(context.Background(), a)
+	if err != nil {
+		if !errors.Is(err, ports.ErrGeocodeNotFound) {
+			log.Printf("geocoding: could not geocode address %d: %v", a.ID, err)
+		}
+		return false
+	}
+	a.Latitude, a.Longitude = &point.Latitude, &point.Longitude
+	return true
+}
+
+func invalidAddress(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ports.ErrInvalidAddress, fmt.Sprintf(format, args...))
+}
+
+// normalizeAddressFields limpia los campos de la dirección y, en Perú, valida el distrito
+// contra el catálogo de ubigeo y guarda los nombres oficiales. Si falta el texto libre,
+// se arma a partir de los campos estructurados.
+func normalizeAddressFields(catalog ports.UbigeoCatalog, a *domain.Address) error {
+	for _, field := range []*string{&a.Address, &a.Street, &a.Number, &a.Reference, &a.District, &a.Province, &a.Department, &a.Ubigeo, &a.PostalCode} {
+		*field = strings.TrimSpace(*field)
+	}
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+	if a.Country == "" {
+		a.Country = domain.PeruCountryCode
+	}
+	if len(a.Country) != 2 || !isLetters(a.Country) {
+		return invalidAddress("country must be an ISO 3166-1 alpha-2 code")
+	}
+
+	if a.Country == domain.PeruCountryCode {
+		location, err := resolveUbigeo(catalog, a)
+		if err != nil {
+			return err
+		}
+		if location != nil {
+			setAddressLocation(a, location)
+		}
+		if a.PostalCode != "" && (len(a.PostalCode) != 5 || !isDigits(a.PostalCode)) {
+			return invalidAddress("postal code %q must have 5 digits", a.PostalCode)
+		}
+	} else if a.Ubigeo != "" {
+		return invalidAddress("ubigeo only applies to addresses in Peru")
+	}
+
+	if a.Address == "" {
+		a.Address = a.FormatLine()
+	}
+	if a.Address == "" {
+		return invalidAddress("address or street is required")
+	}
+
+	if (a.Latitude == nil) != (a.Longitude == nil) {
+		return invalidAddress("latitude and longitude must be given together")
+	}
+	if point := a.Point(); point != nil && !point.IsValid() {
+		return invalidAddress("latitude must be between -90 and 90 and longitude between -180 and 180")
+	}
+	return nil
+}
+
+// resolveUbigeo busca el distrito de la dirección por su código o, si no lo tiene, por
+// el nombre del distrito (y de la provincia y el departamento, si se indican).
+// Devuelve nil si la dirección no indica ninguna ubicación, o si el catálogo es parcial
+// y no tiene el distrito: la dirección se guarda sin verificar, con los datos indicados.
+func resolveUbigeo(catalog ports.UbigeoCatalog, a *domain.Address) (*domain.Ubigeo, error) {
+	if a.Ubigeo != "" {
+		location, err := catalog.Find(a.Ubigeo)
+		if err != nil {
+			if !errors.Is(err, ports.ErrUbigeoNotFound) {
+				return nil, err
+			}
+			// Un código bien formado de un departamento conocido puede ser un distrito
+			// que el catálogo parcial no incluye.
+			if !catalog.Complete() && len(a.Ubigeo) == 6 && isDigits(a.Ubigeo) && hasDepartment(catalog, a.Ubigeo[:2]) {
+				return nil, nil
+			}
+			return nil, invalidAddress("unknown ubigeo %q", a.Ubigeo)
+		}
+		if !matchesLocation(location, a) {
+			return nil, invalidAddress("ubigeo %s is %s, %s, %s", location.Code, location.District, location.Province, location.Department)
+		}
+		return location, nil
+	}
+
+	if a.District == "" {
+		if a.Province != "" || a.Department != "" {
+			return nil, invalidAddress("district is required when province or department are given")
+		}
+		return nil, nil
+	}
+
+	var candidates []domain.Ubigeo
+	for _, location := range catalog.FindByDistrict(a.District) {
+		if matchesLocation(&location, a) {
+			candidates = append(candidates, location)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		if !catalog.Complete() {
+			return nil, nil
+		}
+		return nil, invalidAddress("unknown district %q", a.District)
+	case 1:
+		return &candidates[0], nil
+	}
+	return nil, invalidAddress("district %q is ambiguous, send its province or ubigeo", a.District)
+}
+
+func hasDepartment(catalog ports.UbigeoCatalog, code string) bool {
+	_, err := catalog.Provinces(code)
+	return err == nil
+}
+
+// matchesLocation indica si los nombres indicados en la dirección coinciden con el distrito.
+func matchesLocation(location *domain.Ubigeo, a *domain.Address) bool {
+	same := func(given, official string) bool {
+		return given == "" || domain.PlaceKey(given) == domain.PlaceKey(official)
+	}
+	return same(a.District, location.District) && same(a.Province, location.Province) && same(a.Department, location.Department)
+}
+
+func setAddressLocation(a *domain.Address, location *domain.Ubigeo) {
+	a.Ubigeo = location.Code
+	a.District = location.District
+	a.Province = location.Province
+	a.Department = location.Department
+}
+
+// matchFreeTextAddress busca el distrito en una dirección escrita como
+// "calle, distrito, provincia": cada parte tras la primera coma se compara con los
+// nombres de distrito del catálogo. Las demás partes sirven para elegir entre distritos
+// homónimos, y una parte que es la provincia de otro candidato (como "Lima" en
+// "Av. Brasil 1234, Jesús María, Lima") no cuenta como distrito.
+// Devuelve ambiguous si queda más de un candidato.
+func matchFreeTextAddress(catalog ports.UbigeoCatalog, text string) (location *domain.Ubigeo, ambiguous bool) {
+	segments := strings.Split(text, ",")
+	if len(segments) < 2 {
+		return nil, false
+	}
+
+	mentioned := make(map[string]bool)
+	var candidates []domain.Ubigeo
+	seen := make(map[string]bool)
+	for _, segment := range segments[1:] {
+		key := domain.PlaceKey(segment)
+		if key == "" {
+			continue
+		}
+		mentioned[key] = true
+		for _, candidate := range catalog.FindByDistrict(key) {
+			if !seen[candidate.Code] {
+				seen[candidate.Code] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	if len(candidates) > 1 {
+		var inRegion []domain.Ubigeo
+		for _, candidate := range candidates {
+			if mentioned[domain.PlaceKey(candidate.Province)] || mentioned[domain.PlaceKey(candidate.Department)] {
+				inRegion = append(inRegion, candidate)
+			}
+		}
+		if len(inRegion) > 0 {
+			candidates = inRegion
+		}
+	}
+
+	if len(candidates) > 1 {
+		var districts []domain.Ubigeo
+		for _, candidate := range candidates {
+			if !isRegionOfOther(candidate, candidates) {
+				districts = append(districts, candidate)
+			}
+		}
+		candidates = districts
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, false
+	case 1:
+		return &candidates[0], false
+	}
+	return nil, true
+}
+
+// isRegionOfOther indica si el nombre del distrito es la provincia o el departamento de otro candidato.
+func isRegionOfOther(candidate domain.Ubigeo, candidates []domain.Ubigeo) bool {
+	name := domain.PlaceKey(candidate.District)
+	for _, other := range candidates {
+		if other.Code != candidate.Code && (domain.PlaceKey(other.Province) == name || domain.PlaceKey(other.Department) == name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
 }
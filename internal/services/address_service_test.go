@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/policy"
+	"github.com/riada2/internal/repository/memory"
+	"github.com/riada2/internal/ubigeo"
+)
+
+// stubGeocoder reconoce las direcciones de points por su texto y falla con err en las
+// de failing, como un servicio de geocodificación caído.
+type stubGeocoder struct {
+	points  map[string]domain.GeoPoint
+	failing map[string]bool
+}
+
+func (g stubGeocoder) Geocode(_ context.Context, address *domain.Address) (*domain.GeoPoint, error) {
+	if g.failing[address.Address] {
+		return nil, errors.New("geocoding service unavailable")
+	}
+	if point, ok := g.points[address.Address]; ok {
+		return &point, nil
+	}
+	return nil, ports.ErrGeocodeNotFound
+}
+
+func TestGeocodeAddresses(t *testing.T) {
+	store := memory.NewStore()
+	addressRepo := memory.NewAddressRepository(store)
+	personRepo := memory.NewPersonRepository(store)
+	catalog, err := ubigeo.Load("")
+	if err != nil {
+		t.Fatalf("ubigeo.Load: %v", err)
+	}
+	geocoder := stubGeocoder{
+		points:  map[string]domain.GeoPoint{"Av. Brasil 1234": {Latitude: -12.0702, Longitude: -77.0501}},
+		failing: map[string]bool{"Jr. Caído 3": true},
+	}
+	addresses := NewAddressService(addressRepo, personRepo, memory.NewUnitOfWork(store), catalog, geocoder, newSettings(store),
+		policy.New(memory.NewDelegationRepository(store)), newAuditService(store))
+
+	person := addPerson(t, personRepo, &domain.Person{Name: "Ana", LastName: "Quispe"})
+	var saved []*domain.Address
+	for _, text := range []string{"Av. Brasil 1234", "Calle Falsa 123", "Jr. Caído 3"} {
+		address := &domain.Address{PersonID: person.ID, Address: text}
+		if err := addressRepo.Save(address); err != nil {
+			t.Fatalf("Save address: %v", err)
+		}
+		saved = append(saved, address)
+	}
+
+	report, err := addresses.GeocodeAddresses(admin)
+	if err != nil {
+		t.Fatalf("GeocodeAddresses: %v", err)
+	}
+	if *report != (domain.GeocodeReport{Scanned: 3, Geocoded: 1, NotFound: 2}) {
+		t.Fatalf("report = %+v, want 3 scanned, 1 geocoded and 2 not found", *report)
+	}
+	for i, want := range []bool{true, false, false} {
+		found, err := addressRepo.FindByID(saved[i].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (found.Point() != nil) != want {
+			t.Errorf("address %q geocoded = %v, want %v", found.Address, found.Point() != nil, want)
+		}
+	}
+
+	// Una caída del servicio no impide guardar la dirección; queda sin coordenadas.
+	luis := addPerson(t, personRepo, &domain.Person{Name: "Luis", LastName: "Quispe"})
+	address, err := addresses.CreateOrUpdateAddress(&domain.Address{PersonID: luis.ID, Address: "Jr. Caído 3"}, admin)
+	if err != nil {
+		t.Fatalf("CreateOrUpdateAddress with the geocoder down: %v", err)
+	}
+	if address.Point() != nil {
+		t.Errorf("the address should be saved without coordinates, got %+v", address.Point())
+	}
+}
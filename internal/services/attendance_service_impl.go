@@ -0,0 +1,369 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+const dateKeyLayout = "2006-01-02"
+
+type attendanceServiceImpl struct {
+	attendanceRepo ports.AttendanceRepository
+	eventRepo      ports.EventRepository
+	personRepo     ports.PersonRepository
+	signer         ports.CredentialSigner
+	loc            *time.Location
+	policy         ports.Policy
+	uow            ports.UnitOfWork
+	audit          ports.AuditService
+}
+
+// NewAttendanceService crea el servicio de asistencia. loc es la zona horaria de la
+// organización: los días de las asistencias y de los reportes se cuentan en ella.
+func NewAttendanceService(attendanceRepo ports.AttendanceRepository, eventRepo ports.EventRepository, personRepo ports.PersonRepository, signer ports.CredentialSigner, loc *time.Location, policy ports.Policy, uow ports.UnitOfWork, audit ports.AuditService) ports.AttendanceService {
+	return &attendanceServiceImpl{attendanceRepo, eventRepo, personRepo, signer, loc, policy, uow, audit}
+}
+
+func (s *attendanceServiceImpl) CheckIn(eventID uint, checkIn ports.CheckIn, actor domain.Actor) (_ *domain.Attendance, err error) {
+	attendance := &domain.Attendance{EventID: eventID}
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditAttendanceCheckIn, domain.AuditAttendanceEntity, attendance.ID, checkInChanges(attendance), err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	event, err := findEvent(s.eventRepo, eventID, s.loc)
+	if err != nil {
+		return nil, err
+	}
+	person, method, err := s.identify(checkIn)
+	if err != nil {
+		return nil, err
+	}
+
+	day := time.Now().In(s.loc)
+	if checkIn.Date != nil {
+		// Una asistencia futura inflaría las rachas y los reportes de primeras visitas.
+		if calendarDay(*checkIn.Date, s.loc).After(calendarDay(day, s.loc)) {
+			return nil, fmt.Errorf("%w: the date cannot be after today", ports.ErrInvalidCheckIn)
+		}
+		day = *checkIn.Date
+	}
+	occurs, err := event.OccursOn(day)
+	if err != nil {
+		return nil, err
+	}
+	if !occurs {
+		return nil, fmt.Errorf("%w: the event has no occurrence on %s", ports.ErrInvalidCheckIn, day.Format(dateKeyLayout))
+	}
+
+	attendance.PersonID = person.ID
+	attendance.Date = domain.AttendanceDate(day)
+	attendance.Method = method
+	if actor.UserID != 0 {
+		checkedInBy := actor.UserID
+		attendance.CheckedInBy = &checkedInBy
+	}
+
+	_, err = s.attendanceRepo.Find(eventID, person.ID, attendance.Date)
+	if err == nil {
+		return nil, ports.ErrAlreadyCheckedIn
+	}
+	if !errors.Is(err, ports.ErrNotFound) {
+		return nil, err
+	}
+
+	// Otro registro simultáneo de la misma asistencia choca con la clave única.
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Attendances.Save(attendance); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditAttendanceCheckIn, domain.AuditAttendanceEntity, attendance.ID, checkInChanges(attendance), nil))
+	})
+	if err != nil {
+		if errors.Is(err, ports.ErrConflict) {
+			return nil, ports.ErrAlreadyCheckedIn
+		}
+		return nil, err
+	}
+	attendance.Event = *event
+	attendance.Person = *person
+	return attendance, nil
+}
+
+func checkInChanges(attendance *domain.Attendance) map[string]domain.FieldChange {
+	return map[string]domain.FieldChange{
+		"eventId":  {New: textValue(strconv.FormatUint(uint64(attendance.EventID), 10))},
+		"personId": {New: textValue(strconv.FormatUint(uint64(attendance.PersonID), 10))},
+		"date":     {New: textValue(attendance.Date.Format(dateKeyLayout))},
+		"method":   {New: textValue(string(attendance.Method))},
+	}
+}
+
+// identify resuelve la persona de un registro de asistencia. Se debe indicar una sola vía.
+func (s *attendanceServiceImpl) identify(checkIn ports.CheckIn) (*domain.Person, domain.CheckInMethod, error) {
+	byDocument := checkIn.TypeDoc != nil || checkIn.DocNumber != nil
+	provided := 0
+	for _, given := range []bool{checkIn.PersonID != nil, byDocument, checkIn.QRToken != nil} {
+		if given {
+			provided++
+		}
+	}
+	if provided != 1 {
+		return nil, "", fmt.Errorf("%w: provide exactly one of personId, typeDoc with docNumber, or qrToken", ports.ErrInvalidCheckIn)
+	}
+
+	switch {
+	case checkIn.PersonID != nil:
+		person, err := findPerson(s.personRepo, *checkIn.PersonID)
+		return person, domain.CheckInByPersonID, err
+
+	case byDocument:
+		if checkIn.TypeDoc == nil || checkIn.DocNumber == nil || strings.TrimSpace(*checkIn.DocNumber) == "" {
+			return nil, "", fmt.Errorf("%w: typeDoc and docNumber are both required", ports.ErrInvalidCheckIn)
+		}
+		person, err := s.personRepo.FindByDocument(*checkIn.TypeDoc, strings.TrimSpace(*checkIn.DocNumber))
+		if err != nil {
+			if errors.Is(err, ports.ErrNotFound) {
+				return nil, "", ports.ErrPersonNotFound
+			}
+			return nil, "", err
+		}
+		return person, domain.CheckInByDocument, nil
+
+	default:
+		person, err := verifyCredential(s.personRepo, s.signer, *checkIn.QRToken)
+		return person, domain.CheckInByQR, err
+	}
+}
+
+func (s *attendanceServiceImpl) RemoveAttendance(eventID, attendanceID uint, actor domain.Actor) (err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditAttendanceDelete, domain.AuditAttendanceEntity, attendanceID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return ports.ErrForbidden
+	}
+	attendance, err := s.attendanceRepo.FindByID(attendanceID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return ports.ErrAttendanceNotFound
+		}
+		return err
+	}
+	if attendance.EventID != eventID {
+		return ports.ErrAttendanceNotFound
+	}
+
+	changes = map[string]domain.FieldChange{
+		"eventId":  {Old: textValue(strconv.FormatUint(uint64(attendance.EventID), 10))},
+		"personId": {Old: textValue(strconv.FormatUint(uint64(attendance.PersonID), 10))},
+		"date":     {Old: textValue(attendance.Date.Format(dateKeyLayout))},
+	}
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Attendances.Delete(attendanceID); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditAttendanceDelete, domain.AuditAttendanceEntity, attendanceID, changes, nil))
+	})
+}
+
+func (s *attendanceServiceImpl) GetCredential(personID uint, actor domain.Actor) (string, error) {
+	person, err := findAuthorizedPerson(s.personRepo, s.policy, personID, ports.CredentialResource, ports.ActionRead, actor)
+	if err != nil {
+		return "", err
+	}
+	return s.signer.Issue(person.ID, person.CredentialVersion)
+}
+
+func (s *attendanceServiceImpl) GetPersonReport(personID uint, from, to time.Time, actor domain.Actor) (*domain.PersonAttendanceReport, error) {
+	from, to = calendarDay(from, s.loc), calendarDay(to, s.loc)
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: the end of the range must be after its start", ports.ErrInvalidCheckIn)
+	}
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, ports.AttendanceResource, ports.ActionRead, actor); err != nil {
+		return nil, err
+	}
+
+	records, err := s.attendanceRepo.ListByPerson(personID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.PersonAttendanceReport{PersonID: personID, From: from, To: to, Total: len(records), Records: records}
+	byEvent := make(map[uint][]domain.Attendance)
+	var eventIDs []uint
+	for _, record := range records {
+		if _, ok := byEvent[record.EventID]; !ok {
+			eventIDs = append(eventIDs, record.EventID)
+		}
+		byEvent[record.EventID] = append(byEvent[record.EventID], record)
+	}
+
+	// Solo cuentan las ocurrencias que ya empezaron.
+	until := to
+	if now := time.Now(); now.Before(until) {
+		until = now
+	}
+	for _, eventID := range eventIDs {
+		attended := byEvent[eventID]
+		event := inZone(&attended[0].Event, s.loc)
+		occurrences, err := event.Occurrences(from, until)
+		if err != nil {
+			return nil, err
+		}
+
+		summary := domain.PersonEventAttendance{Event: *event, Attended: len(attended), Occurrences: len(occurrences)}
+		lastAttended := attended[len(attended)-1].Date
+		summary.LastAttended = &lastAttended
+		summary.CurrentStreak, summary.LongestStreak = attendanceStreaks(occurrences, attended)
+		report.Events = append(report.Events, summary)
+	}
+	return report, nil
+}
+
+// attendanceStreaks cuenta las ocurrencias consecutivas con asistencia: la racha que
+// termina en la última ocurrencia y la más larga.
+func attendanceStreaks(occurrences []time.Time, attended []domain.Attendance) (current, longest int) {
+	days := make(map[string]bool, len(attended))
+	for _, record := range attended {
+		days[record.Date.Format(dateKeyLayout)] = true
+	}
+	for _, occurrence := range occurrences {
+		if days[occurrence.Format(dateKeyLayout)] {
+			current++
+			if current > longest {
+				longest = current
+			}
+		} else {
+			current = 0
+		}
+	}
+	return current, longest
+}
+
+func (s *attendanceServiceImpl) GetEventReport(eventID uint, from, to time.Time) (*domain.EventAttendanceReport, error) {
+	from, to = calendarDay(from, s.loc), calendarDay(to, s.loc)
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: the end of the range must be after its start", ports.ErrInvalidCheckIn)
+	}
+	event, err := findEvent(s.eventRepo, eventID, s.loc)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.attendanceRepo.ListByEvent(eventID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	firsts, err := s.attendanceRepo.FirstAttendances(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Se listan las ocurrencias ya empezadas y cualquier otro día con asistencias registradas.
+	until := to
+	if now := time.Now(); now.Before(until) {
+		until = now
+	}
+	occurrences, err := event.Occurrences(from, until)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string]*domain.OccurrenceAttendance)
+	var dates []string
+	occurrenceAt := func(day time.Time) *domain.OccurrenceAttendance {
+		key := day.Format(dateKeyLayout)
+		if _, ok := byDate[key]; !ok {
+			byDate[key] = &domain.OccurrenceAttendance{Date: domain.AttendanceDate(day)}
+			dates = append(dates, key)
+		}
+		return byDate[key]
+	}
+	for _, occurrence := range occurrences {
+		occurrenceAt(occurrence)
+	}
+	for _, record := range records {
+		occurrence := occurrenceAt(record.Date)
+		occurrence.Attendees = append(occurrence.Attendees, record.Person)
+	}
+	for _, first := range firsts {
+		if first.EventID == eventID {
+			occurrence := occurrenceAt(first.Date)
+			occurrence.FirstTimers = append(occurrence.FirstTimers, first.Person)
+		}
+	}
+
+	sort.Strings(dates)
+	report := &domain.EventAttendanceReport{Event: *event}
+	for _, key := range dates {
+		report.Occurrences = append(report.Occurrences, *byDate[key])
+	}
+	return report, nil
+}
+
+func (s *attendanceServiceImpl) GetPeriodReport(from, to time.Time) (*domain.PeriodAttendanceReport, error) {
+	from, to = calendarDay(from, s.loc), calendarDay(to, s.loc)
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: the end of the range must be after its start", ports.ErrInvalidCheckIn)
+	}
+	records, err := s.attendanceRepo.ListInPeriod(from, to)
+	if err != nil {
+		return nil, err
+	}
+	firsts, err := s.attendanceRepo.FirstAttendances(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.PeriodAttendanceReport{From: from, To: to, TotalCheckIns: len(records)}
+	persons := make(map[uint]bool)
+	byEvent := make(map[uint]*domain.EventAttendanceSummary)
+	var eventIDs []uint
+	for _, record := range records {
+		persons[record.PersonID] = true
+		summary, ok := byEvent[record.EventID]
+		if !ok {
+			summary = &domain.EventAttendanceSummary{Event: *inZone(&record.Event, s.loc), Counts: make(map[string]int)}
+			byEvent[record.EventID] = summary
+			eventIDs = append(eventIDs, record.EventID)
+		}
+		summary.Counts[record.Date.Format(dateKeyLayout)]++
+	}
+	report.UniquePersons = len(persons)
+
+	sort.Slice(eventIDs, func(i, j int) bool { return eventIDs[i] < eventIDs[j] })
+	for _, eventID := range eventIDs {
+		report.Events = append(report.Events, *byEvent[eventID])
+	}
+
+	visitors := make(map[uint]bool)
+	for _, first := range firsts {
+		if !visitors[first.PersonID] {
+			visitors[first.PersonID] = true
+			report.FirstTimeVisitors = append(report.FirstTimeVisitors, first.Person)
+		}
+	}
+	return report, nil
+}
+
+// findPerson busca una persona y traduce la ausencia del registro a ErrPersonNotFound.
+func findPerson(personRepo ports.PersonRepository, id uint) (*domain.Person, error) {
+	person, err := personRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrPersonNotFound
+		}
+		return nil, err
+	}
+	return person, nil
+}
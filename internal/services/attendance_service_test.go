@@ -0,0 +1,37 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/credential"
+	"github.com/riada2/internal/policy"
+	"github.com/riada2/internal/repository/memory"
+)
+
+func TestCheckInRejectsFutureDates(t *testing.T) {
+	store := memory.NewStore()
+	eventRepo := memory.NewEventRepository(store)
+	personRepo := memory.NewPersonRepository(store)
+	attendances := NewAttendanceService(memory.NewAttendanceRepository(store), eventRepo, personRepo,
+		credential.NewJWTSigner("secret", time.Hour), time.UTC, policy.New(memory.NewDelegationRepository(store)),
+		memory.NewUnitOfWork(store), newAuditService(store))
+
+	event := &domain.Event{Name: "Culto diario", StartsAt: time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC), Recurrence: "FREQ=DAILY"}
+	if err := eventRepo.Save(event); err != nil {
+		t.Fatalf("Save event: %v", err)
+	}
+	person := addPerson(t, personRepo, &domain.Person{Name: "Ana", LastName: "Quispe"})
+
+	today := time.Now().UTC()
+	tomorrow := today.AddDate(0, 0, 1)
+	if _, err := attendances.CheckIn(event.ID, ports.CheckIn{PersonID: &person.ID, Date: &tomorrow}, admin); !errors.Is(err, ports.ErrInvalidCheckIn) {
+		t.Fatalf("CheckIn tomorrow: err = %v, want ErrInvalidCheckIn", err)
+	}
+	if _, err := attendances.CheckIn(event.ID, ports.CheckIn{PersonID: &person.ID, Date: &today}, admin); err != nil {
+		t.Fatalf("CheckIn today: %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+	auditVerifyBatchSize = 1000
+)
+
+type auditServiceImpl struct {
+	auditRepo ports.AuditRepository
+	uow       ports.UnitOfWork
+}
+
+func NewAuditService(auditRepo ports.AuditRepository, uow ports.UnitOfWork) ports.AuditService {
+	return &auditServiceImpl{auditRepo: auditRepo, uow: uow}
+}
+
+func (s *auditServiceImpl) Record(entry domain.AuditEntry) error {
+	err := s.uow.Do(func(repos ports.Repositories) error {
+		return s.RecordIn(repos, entry)
+	})
+	if err != nil {
+		log.Printf("audit: could not record %s: %v", entry.Action, err)
+		if !errors.Is(err, ports.ErrAuditUnavailable) {
+			err = fmt.Errorf("%w: %v", ports.ErrAuditUnavailable, err)
+		}
+	}
+	return err
+}
+
+func (s *auditServiceImpl) RecordIn(repos ports.Repositories, entry domain.AuditEntry) error {
+	// El bloqueo dura hasta el final de la transacción: ninguna otra entrada puede
+	// enlazar con la misma última, y la clave única de PrevHash lo garantiza igual.
+	if err := repos.Audit.LockChain(); err != nil {
+		return fmt.Errorf("%w: %v", ports.ErrAuditUnavailable, err)
+	}
+	last, err := repos.Audit.Last()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ports.ErrAuditUnavailable, err)
+	}
+	if last != nil {
+		entry.PrevHash = last.Hash
+	}
+
+	// Postgres guarda los timestamps con precisión de microsegundos; truncamos
+	// antes de calcular el hash para que la verificación sea reproducible.
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	entry.Hash = auditHash(&entry)
+	if err := repos.Audit.Append(&entry); err != nil {
+		return fmt.Errorf("%w: %v", ports.ErrAuditUnavailable, err)
+	}
+	return nil
+}
+
+// recordFailure registra una acción que falló, en su propia transacción: no cambió
+// datos, así que no hay nada con qué confirmarla. Las acciones que se aplican registran
+// su entrada con RecordIn, en la transacción de sus cambios. Un error de la auditoría
+// queda en el log; el llamador ya devuelve el error de la acción.
+func recordFailure(audit ports.AuditService, entry domain.AuditEntry) {
+	if entry.Outcome == domain.AuditFailure {
+		audit.Record(entry)
+	}
+}
+
+// recordSummary registra el resumen de un proceso masivo, que guarda cada registro en su
+// propia transacción con su versión. Cuando termina, los cambios ya están confirmados: si
+// la auditoría falla queda en el log, y el proceso no se informa como fallido.
+func recordSummary(audit ports.AuditService, entry domain.AuditEntry) {
+	audit.Record(entry)
+}
+
+// auditResult combina el resultado de una acción que no modifica datos, como emitir un
+// carné, con el de su registro en la auditoría: si no quedó registrada, la acción falla.
+func auditResult(err, auditErr error) error {
+	if err != nil {
+		return err
+	}
+	return auditErr
+}
+
+func (s *auditServiceImpl) Search(filter ports.AuditFilter) ([]domain.AuditEntry, int64, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageSize
+	}
+	if filter.Limit > maxAuditPageSize {
+		filter.Limit = maxAuditPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.auditRepo.Search(filter)
+}
+
+func (s *auditServiceImpl) Verify() (*ports.AuditVerification, error) {
+	result := &ports.AuditVerification{Valid: true}
+	var afterID uint
+	prevHash := ""
+
+	for {
+		entries, err := s.auditRepo.ListAfter(afterID, auditVerifyBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		for i := range entries {
+			entry := &entries[i]
+			result.Checked++
+
+			if entry.PrevHash != prevHash {
+				return broken(result, entry.ID, "previous hash does not match the preceding entry"), nil
+			}
+			if auditHash(entry) != entry.Hash {
+				return broken(result, entry.ID, "entry content does not match its hash"), nil
+			}
+
+			prevHash = entry.Hash
+			afterID = entry.ID
+		}
+	}
+
+	result.LastHash = prevHash
+	return result, nil
+}
+
+func broken(result *ports.AuditVerification, id uint, reason string) *ports.AuditVerification {
+	result.Valid = false
+	result.BrokenAt = &id
+	result.Reason = reason
+	return result
+}
+
+// auditHash calcula el hash SHA-256 de la entrada encadenado con PrevHash.
+// Se excluyen el ID (asignado por la base de datos) y el propio Hash.
+func auditHash(entry *domain.AuditEntry) string {
+	payload, _ := json.Marshal(struct {
+		ActorID    *uint                         `json:"actorId"`
+		ActorRole  domain.Role                   `json:"actorRole"`
+		Action     string                        `json:"action"`
+		EntityType string                        `json:"entityType"`
+		EntityID   *uint                         `json:"entityId"`
+		Changes    map[string]domain.FieldChange `json:"changes"`
+		RequestID  string                        `json:"requestId"`
+		IP         string                        `json:"ip"`
+		Outcome    domain.AuditOutcome           `json:"outcome"`
+		Error      string                        `json:"error"`
+		CreatedAt  string                        `json:"createdAt"`
+	}{
+		ActorID:    entry.ActorID,
+		ActorRole:  entry.ActorRole,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Changes:    entry.Changes,
+		RequestID:  entry.RequestID,
+		IP:         entry.IP,
+		Outcome:    entry.Outcome,
+		Error:      entry.Error,
+		CreatedAt:  entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+
+	sum := sha256.Sum256([]byte(entry.PrevHash + string(payload)))
+	return hex.EncodeToString(sum[:])
+}
+
+// newAuditEntry arma una entrada de auditoría con los datos del actor.
+// Si err no es nil la acción se registra como fallida.
+func newAuditEntry(actor domain.Actor, action, entityType string, entityID uint, changes map[string]domain.FieldChange, err error) domain.AuditEntry {
+	entry := domain.AuditEntry{
+		ActorRole:  actor.Role,
+		Action:     action,
+		EntityType: entityType,
+		Changes:    changes,
+		RequestID:  actor.RequestID,
+		IP:         actor.IP,
+		Outcome:    domain.AuditSuccess,
+	}
+	if actor.UserID != 0 {
+		actorID := actor.UserID
+		entry.ActorID = &actorID
+	}
+	if entityID != 0 {
+		entry.EntityID = &entityID
+	}
+	if len(entry.Changes) == 0 {
+		entry.Changes = nil
+	}
+	if err != nil {
+		entry.Outcome = domain.AuditFailure
+		entry.Error = err.Error()
+	}
+	return entry
+}
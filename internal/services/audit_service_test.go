@@ -0,0 +1,107 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/repository/memory"
+)
+
+// newAuditService crea el servicio de auditoría sobre el almacén en memoria.
+func newAuditService(store *memory.Store) ports.AuditService {
+	return NewAuditService(memory.NewAuditRepository(store), memory.NewUnitOfWork(store))
+}
+
+// failingAudit es un repositorio de auditoría que no puede escribir.
+type failingAudit struct {
+	ports.AuditRepository
+}
+
+func (failingAudit) LockChain() error {
+	return nil
+}
+
+func (failingAudit) Last() (*domain.AuditEntry, error) {
+	return nil, nil
+}
+
+func (failingAudit) Append(*domain.AuditEntry) error {
+	return errors.New("disk full")
+}
+
+// failingAuditUnitOfWork simula una caída de la auditoría dentro de la transacción.
+type failingAuditUnitOfWork struct {
+	ports.UnitOfWork
+}
+
+func (u failingAuditUnitOfWork) Do(fn func(repos ports.Repositories) error) error {
+	return u.UnitOfWork.Do(func(repos ports.Repositories) error {
+		repos.Audit = failingAudit{repos.Audit}
+		return fn(repos)
+	})
+}
+
+func TestAuditChainAcrossReplicas(t *testing.T) {
+	store := memory.NewStore()
+	// Dos servicios sobre el mismo almacén, como dos réplicas sobre la misma base.
+	replicas := []ports.AuditService{newAuditService(store), newAuditService(store)}
+
+	const perReplica = 20
+	var wg sync.WaitGroup
+	for _, audit := range replicas {
+		wg.Add(1)
+		go func(audit ports.AuditService) {
+			defer wg.Done()
+			for i := 0; i < perReplica; i++ {
+				if err := audit.Record(domain.AuditEntry{Action: domain.AuditSettingsUpdate}); err != nil {
+					t.Error(err)
+				}
+			}
+		}(audit)
+	}
+	wg.Wait()
+
+	result, err := replicas[0].Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || result.Checked != 2*perReplica {
+		t.Fatalf("verification = %+v, want a valid chain of %d entries", result, 2*perReplica)
+	}
+}
+
+func TestUnrecordedChangeIsNotSaved(t *testing.T) {
+	store := memory.NewStore()
+	uow := failingAuditUnitOfWork{memory.NewUnitOfWork(store)}
+	settings := NewSettingsService(memory.NewSettingRepository(store), uow, NewAuditService(memory.NewAuditRepository(store), uow))
+
+	_, err := settings.UpdateSettings(map[string]string{domain.SettingMaxPhones: "3"}, admin)
+	if !errors.Is(err, ports.ErrAuditUnavailable) {
+		t.Fatalf("err = %v, want ErrAuditUnavailable", err)
+	}
+	current, err := settings.Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.MaxPhones == 3 {
+		t.Fatal("a change that could not be audited should not be saved")
+	}
+}
+
+func TestLoginWithoutAuditLog(t *testing.T) {
+	store := memory.NewStore()
+	userRepo := memory.NewUserRepository(store)
+	if _, err := NewUserService(userRepo, memory.NewUnitOfWork(store), "secret", time.Hour, newAuditService(store)).Register("ana", "secret123", domain.Actor{}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	uow := failingAuditUnitOfWork{memory.NewUnitOfWork(store)}
+	users := NewUserService(userRepo, uow, "secret", time.Hour, NewAuditService(memory.NewAuditRepository(store), uow))
+	if token, _, err := users.Login("ana", "secret123", domain.Actor{}); err != nil || token == "" {
+		t.Fatalf("Login = %q, %v, want a token while the audit log is down", token, err)
+	}
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// findAuthorizedPerson devuelve la persona si existe y la política permite al actor
+// realizar la acción sobre el recurso de esa persona.
+func findAuthorizedPerson(personRepo ports.PersonRepository, policy ports.Policy, personID uint, entity domain.VersionEntity, action ports.PolicyAction, actor domain.Actor) (*domain.Person, error) {
+	person, err := findPerson(personRepo, personID)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.Authorize(actor, action, ports.PolicyResource{Type: entity, Person: person}); err != nil {
+		return nil, err
+	}
+	return person, nil
+}
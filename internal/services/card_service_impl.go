@@ -0,0 +1,172 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// maxCardsPerBatch limita el número de carnés que se generan en una sola hoja.
+const maxCardsPerBatch = 200
+
+type cardServiceImpl struct {
+	personRepo ports.PersonRepository
+	groupRepo  ports.GroupRepository
+	signer     ports.CredentialSigner
+	renderer   ports.CardRenderer
+	uow        ports.UnitOfWork
+	audit      ports.AuditService
+}
+
+func NewCardService(personRepo ports.PersonRepository, groupRepo ports.GroupRepository, signer ports.CredentialSigner, renderer ports.CardRenderer, uow ports.UnitOfWork, audit ports.AuditService) ports.CardService {
+	return &cardServiceImpl{personRepo, groupRepo, signer, renderer, uow, audit}
+}
+
+func (s *cardServiceImpl) GetCard(personID uint, actor domain.Actor) (_ []byte, err error) {
+	defer func() {
+		err = auditResult(err, s.audit.Record(newAuditEntry(actor, domain.AuditCardPrint, string(domain.PersonEntity), personID, nil, err)))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	person, err := findPerson(s.personRepo, personID)
+	if err != nil {
+		return nil, err
+	}
+	card, err := s.card(person)
+	if err != nil {
+		return nil, err
+	}
+	return s.renderer.RenderCard(card)
+}
+
+func (s *cardServiceImpl) GetCards(personIDs []uint, groupID *uint, actor domain.Actor) (_ []byte, err error) {
+	var printed []uint
+	defer func() {
+		changes := map[string]domain.FieldChange{"personIds": {New: textValue(joinIDs(printed))}}
+		err = auditResult(err, s.audit.Record(newAuditEntry(actor, domain.AuditCardPrint, string(domain.PersonEntity), 0, changes, err)))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+
+	var persons []*domain.Person
+	switch {
+	case groupID != nil && len(personIDs) > 0:
+		return nil, fmt.Errorf("%w: send either personIds or groupId, not both", ports.ErrInvalidCardBatch)
+
+	case groupID != nil:
+		group, err := s.groupRepo.FindByID(*groupID)
+		if err != nil {
+			if errors.Is(err, ports.ErrNotFound) {
+				return nil, ports.ErrGroupNotFound
+			}
+			return nil, err
+		}
+		now := time.Now()
+		for i := range group.Members {
+			if group.Members[i].IsActive(now) {
+				persons = append(persons, &group.Members[i].Person)
+			}
+		}
+
+	default:
+		seen := make(map[uint]bool)
+		for _, id := range personIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			person, err := findPerson(s.personRepo, id)
+			if err != nil {
+				return nil, err
+			}
+			persons = append(persons, person)
+		}
+	}
+
+	if len(persons) == 0 {
+		return nil, fmt.Errorf("%w: there are no persons to print", ports.ErrInvalidCardBatch)
+	}
+	if len(persons) > maxCardsPerBatch {
+		return nil, fmt.Errorf("%w: at most %d cards can be printed at once", ports.ErrInvalidCardBatch, maxCardsPerBatch)
+	}
+
+	cards := make([]domain.IDCard, len(persons))
+	for i, person := range persons {
+		if cards[i], err = s.card(person); err != nil {
+			return nil, err
+		}
+		printed = append(printed, person.ID)
+	}
+	return s.renderer.RenderSheet(cards)
+}
+
+func (s *cardServiceImpl) VerifyCredential(token string) (*domain.Person, error) {
+	return verifyCredential(s.personRepo, s.signer, token)
+}
+
+func (s *cardServiceImpl) RevokeCredential(personID uint, actor domain.Actor) (err error) {
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditCredentialRevoke, string(domain.PersonEntity), personID, nil, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return ports.ErrForbidden
+	}
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Persons.RevokeCredential(personID); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditCredentialRevoke, string(domain.PersonEntity), personID, nil, nil))
+	})
+	if errors.Is(err, ports.ErrNotFound) {
+		return ports.ErrPersonNotFound
+	}
+	return err
+}
+
+// verifyCredential devuelve la persona de una credencial firmada, no vencida y de la
+// versión vigente. Si la persona ya no existe, la credencial tampoco vale.
+func verifyCredential(personRepo ports.PersonRepository, signer ports.CredentialSigner, token string) (*domain.Person, error) {
+	personID, version, err := signer.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	person, err := findPerson(personRepo, personID)
+	if errors.Is(err, ports.ErrPersonNotFound) {
+		return nil, ports.ErrInvalidCredential
+	}
+	if err != nil {
+		return nil, err
+	}
+	if person.CredentialVersion != version {
+		return nil, fmt.Errorf("%w: the credential was revoked", ports.ErrInvalidCredential)
+	}
+	return person, nil
+}
+
+// card reúne los datos del carné de la persona y le emite una credencial.
+func (s *cardServiceImpl) card(person *domain.Person) (domain.IDCard, error) {
+	token, err := s.signer.Issue(person.ID, person.CredentialVersion)
+	if err != nil {
+		return domain.IDCard{}, err
+	}
+
+	card := domain.IDCard{PersonID: person.ID, FullName: person.FullName(), Token: token}
+	if person.DocNumber != nil && *person.DocNumber != "" {
+		card.Document = *person.DocNumber
+		if person.TypeDoc != nil {
+			card.Document = string(*person.TypeDoc) + " " + card.Document
+		}
+	}
+	if person.Photo != nil {
+		card.Photo = *person.Photo
+	}
+	return card, nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/credential"
+	"github.com/riada2/internal/repository/memory"
+)
+
+func TestCredentialRevocationAndExpiry(t *testing.T) {
+	store := memory.NewStore()
+	personRepo := memory.NewPersonRepository(store)
+	signer := credential.NewJWTSigner("secret", time.Hour)
+	cards := NewCardService(personRepo, memory.NewGroupRepository(store), signer, nil, memory.NewUnitOfWork(store), newAuditService(store))
+	ana := addPerson(t, personRepo, &domain.Person{Name: "Ana"})
+	luis := addPerson(t, personRepo, &domain.Person{Name: "Luis"})
+
+	lost, _ := signer.Issue(ana.ID, ana.CredentialVersion)
+	other, _ := signer.Issue(luis.ID, luis.CredentialVersion)
+	if person, err := cards.VerifyCredential(lost); err != nil || person.ID != ana.ID {
+		t.Fatalf("VerifyCredential = %v, %v", person, err)
+	}
+
+	if err := cards.RevokeCredential(ana.ID, owner); !errors.Is(err, ports.ErrForbidden) {
+		t.Fatalf("only admins revoke credentials, got %v", err)
+	}
+	if err := cards.RevokeCredential(ana.ID, admin); err != nil {
+		t.Fatalf("RevokeCredential: %v", err)
+	}
+	if _, err := cards.VerifyCredential(lost); !errors.Is(err, ports.ErrInvalidCredential) {
+		t.Errorf("a revoked credential: expected ErrInvalidCredential, got %v", err)
+	}
+	if _, err := cards.VerifyCredential(other); err != nil {
+		t.Errorf("revoking a card should not affect other persons: %v", err)
+	}
+	ana, _ = personRepo.FindByID(ana.ID)
+	reissued, _ := signer.Issue(ana.ID, ana.CredentialVersion)
+	if _, err := cards.VerifyCredential(reissued); err != nil {
+		t.Errorf("a credential issued after the revocation: %v", err)
+	}
+
+	expired, _ := credential.NewJWTSigner("secret", -time.Minute).Issue(luis.ID, luis.CredentialVersion)
+	if _, err := cards.VerifyCredential(expired); !errors.Is(err, ports.ErrInvalidCredential) {
+		t.Errorf("an expired credential: expected ErrInvalidCredential, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// maxCelebrationDays limita el periodo de la consulta de celebraciones a un año.
+const maxCelebrationDays = 366
+
+type celebrationServiceImpl struct {
+	personRepo       ports.PersonRepository
+	relationshipRepo ports.RelationshipRepository
+	notifier         ports.Notifier
+	recipients       []string
+	loc              *time.Location
+}
+
+// NewCelebrationService crea el servicio de cumpleaños y aniversarios. Los días se cuentan
+// en loc, la zona horaria de la organización, y los recordatorios se envían a recipients.
+func NewCelebrationService(personRepo ports.PersonRepository, relationshipRepo ports.RelationshipRepository, notifier ports.Notifier, recipients []string, loc *time.Location) ports.CelebrationService {
+	return &celebrationServiceImpl{personRepo, relationshipRepo, notifier, recipients, loc}
+}
+
+func (s *celebrationServiceImpl) ListCelebrations(filter ports.CelebrationFilter) ([]domain.Celebration, error) {
+	if filter.Kind != "" && !filter.Kind.IsValid() {
+		return nil, fmt.Errorf("%w: unknown kind %q", ports.ErrInvalidCelebrationFilter, filter.Kind)
+	}
+	from, to, err := s.period(filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.celebrations(from, to, filter.Kind)
+}
+
+// period devuelve el rango [from, to) de la consulta.
+func (s *celebrationServiceImpl) period(filter ports.CelebrationFilter) (time.Time, time.Time, error) {
+	if filter.From != nil && filter.To != nil {
+		from, to := calendarDay(*filter.From, s.loc), calendarDay(*filter.To, s.loc).AddDate(0, 0, 1)
+		if !from.Before(to) {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: to cannot be before from", ports.ErrInvalidCelebrationFilter)
+		}
+		if to.Sub(from) > maxCelebrationDays*24*time.Hour {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: the period cannot be longer than a year", ports.ErrInvalidCelebrationFilter)
+		}
+		return from, to, nil
+	}
+	if filter.From != nil || filter.To != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: send both from and to", ports.ErrInvalidCelebrationFilter)
+	}
+
+	today := calendarDay(time.Now().In(s.loc), s.loc)
+	switch filter.Period {
+	case "", "week":
+		// Semana de lunes a domingo.
+		monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		return monday, monday.AddDate(0, 0, 7), nil
+	case "month":
+		first := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, s.loc)
+		return first, first.AddDate(0, 1, 0), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("%w: unknown period %q, use week or month", ports.ErrInvalidCelebrationFilter, filter.Period)
+}
+
+// celebrations devuelve las celebraciones en [from, to), ordenadas por fecha y nombre.
+func (s *celebrationServiceImpl) celebrations(from, to time.Time, kind domain.CelebrationKind) ([]domain.Celebration, error) {
+	var celebrations []domain.Celebration
+
+	if kind == "" || kind == domain.BirthdayCelebration {
+		persons, err := s.personRepo.ListWithBirthday()
+		if err != nil {
+			return nil, err
+		}
+		for _, person := range persons {
+			for _, date := range anniversariesBetween(*person.Birthday, from, to, s.loc) {
+				celebrations = append(celebrations, domain.Celebration{
+					Kind:   domain.BirthdayCelebration,
+					Date:   date,
+					Years:  date.Year() - person.Birthday.UTC().Year(),
+					Person: person,
+				})
+			}
+		}
+	}
+
+	if kind == "" || kind == domain.AnniversaryCelebration {
+		relationships, err := s.relationshipRepo.ListWithSince(domain.SpouseRelationship)
+		if err != nil {
+			return nil, err
+		}
+		for _, relationship := range relationships {
+			// Cada matrimonio se guarda en ambos sentidos; se cuenta una sola vez.
+			if relationship.PersonID > relationship.RelatedPersonID {
+				continue
+			}
+			dates := anniversariesBetween(*relationship.Since, from, to, s.loc)
+			if len(dates) == 0 {
+				continue
+			}
+			// Al eliminar a una persona sus relaciones quedan guardadas; se omiten.
+			person, err := findPerson(s.personRepo, relationship.PersonID)
+			if errors.Is(err, ports.ErrPersonNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			spouse, err := findPerson(s.personRepo, relationship.RelatedPersonID)
+			if errors.Is(err, ports.ErrPersonNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			for _, date := range dates {
+				celebrations = append(celebrations, domain.Celebration{
+					Kind:   domain.AnniversaryCelebration,
+					Date:   date,
+					Years:  date.Year() - relationship.Since.UTC().Year(),
+					Person: *person,
+					Spouse: spouse,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(celebrations, func(i, j int) bool {
+		if !celebrations[i].Date.Equal(celebrations[j].Date) {
+			return celebrations[i].Date.Before(celebrations[j].Date)
+		}
+		return celebrations[i].Person.FullName() < celebrations[j].Person.FullName()
+	})
+	return celebrations, nil
+}
+
+// anniversariesBetween devuelve los aniversarios de date, desde el primero, que caen en [from, to).
+// Las fechas de nacimiento y de matrimonio se guardan a medianoche UTC, así que su día se lee en UTC.
+func anniversariesBetween(date, from, to time.Time, loc *time.Location) []time.Time {
+	date = date.UTC()
+	var dates []time.Time
+	for year := from.Year(); year <= to.Year(); year++ {
+		if year <= date.Year() {
+			continue
+		}
+		anniversary := domain.AnniversaryIn(date, year, loc)
+		if !anniversary.Before(from) && anniversary.Before(to) {
+			dates = append(dates, anniversary)
+		}
+	}
+	return dates
+}
+
+func (s *celebrationServiceImpl) SendReminders(ctx context.Context, day time.Time) (int, error) {
+	from := calendarDay(day.In(s.loc), s.loc)
+	celebrations, err := s.celebrations(from, from.AddDate(0, 0, 1), "")
+	if err != nil {
+		return 0, err
+	}
+	if len(celebrations) == 0 {
+		return 0, nil
+	}
+
+	var body strings.Builder
+	body.WriteString("Hoy se celebra:\n\n")
+	for _, c := range celebrations {
+		switch c.Kind {
+		case domain.BirthdayCelebration:
+			fmt.Fprintf(&body, "- Cumpleaños de %s (%d años)\n", c.Person.FullName(), c.Years)
+		case domain.AnniversaryCelebration:
+			fmt.Fprintf(&body, "- Aniversario de bodas de %s y %s (%d años)\n", c.Person.FullName(), c.Spouse.FullName(), c.Years)
+		}
+	}
+
+	notification := domain.Notification{
+		Recipients: s.recipients,
+		Subject:    "Cumpleaños y aniversarios del " + from.Format("02/01/2006"),
+		Body:       body.String(),
+	}
+	if err := s.notifier.Notify(ctx, notification); err != nil {
+		return 0, err
+	}
+	return len(celebrations), nil
+}
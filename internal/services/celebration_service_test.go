@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/repository/memory"
+)
+
+// recordingNotifier guarda las notificaciones enviadas.
+type recordingNotifier struct {
+	sent []domain.Notification
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, notification domain.Notification) error {
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestCelebrationsSkipDeletedSpouse(t *testing.T) {
+	store := memory.NewStore()
+	personRepo := memory.NewPersonRepository(store)
+	relationshipRepo := memory.NewRelationshipRepository(store)
+	notifier := &recordingNotifier{}
+	celebrations := NewCelebrationService(personRepo, relationshipRepo, notifier, []string{"secretaria@iglesia.pe"}, time.UTC)
+
+	day := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	birthday := time.Date(1980, 6, 15, 0, 0, 0, 0, time.UTC)
+	since := time.Date(2000, 6, 15, 0, 0, 0, 0, time.UTC)
+	ana := addPerson(t, personRepo, &domain.Person{Name: "Ana", LastName: "Quispe", Birthday: &birthday})
+	luis := addPerson(t, personRepo, &domain.Person{Name: "Luis", LastName: "Quispe"})
+	for _, relationship := range []domain.Relationship{
+		{PersonID: ana.ID, RelatedPersonID: luis.ID, Type: domain.SpouseRelationship, Since: &since},
+		{PersonID: luis.ID, RelatedPersonID: ana.ID, Type: domain.SpouseRelationship, Since: &since},
+	} {
+		if err := relationshipRepo.Save(&relationship); err != nil {
+			t.Fatalf("Save relationship: %v", err)
+		}
+	}
+	if err := personRepo.Delete(luis.ID); err != nil {
+		t.Fatalf("Delete person: %v", err)
+	}
+
+	result, err := celebrations.ListCelebrations(ports.CelebrationFilter{From: &day, To: &day})
+	if err != nil {
+		t.Fatalf("ListCelebrations: %v", err)
+	}
+	if len(result) != 1 || result[0].Kind != domain.BirthdayCelebration {
+		t.Fatalf("celebrations = %+v, want only the birthday", result)
+	}
+
+	sent, err := celebrations.SendReminders(context.Background(), day)
+	if err != nil {
+		t.Fatalf("SendReminders: %v", err)
+	}
+	if sent != 1 || len(notifier.sent) != 1 {
+		t.Fatalf("SendReminders = %d with %d notifications, want 1 and 1", sent, len(notifier.sent))
+	}
+}
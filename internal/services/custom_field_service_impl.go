@@ -0,0 +1,367 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type customFieldServiceImpl struct {
+	customFieldRepo ports.CustomFieldRepository
+	uow             ports.UnitOfWork
+	audit           ports.AuditService
+}
+
+func NewCustomFieldService(customFieldRepo ports.CustomFieldRepository, uow ports.UnitOfWork, audit ports.AuditService) ports.CustomFieldService {
+	return &customFieldServiceImpl{customFieldRepo, uow, audit}
+}
+
+func (s *customFieldServiceImpl) CreateDefinition(definition *domain.CustomFieldDefinition, actor domain.Actor) (_ *domain.CustomFieldDefinition, err error) {
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditCustomFieldCreate, domain.AuditCustomFieldEntity, definition.ID, customFieldChanges(nil, definition), err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if err := validateDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	_, err = s.customFieldRepo.FindByKey(definition.Key)
+	if err == nil {
+		return nil, ports.ErrCustomFieldExists
+	}
+	if !errors.Is(err, ports.ErrNotFound) {
+		return nil, err
+	}
+
+	definition.ID = 0
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.CustomFields.Save(definition); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditCustomFieldCreate, domain.AuditCustomFieldEntity, definition.ID, customFieldChanges(nil, definition), nil))
+	})
+	if err != nil {
+		if errors.Is(err, ports.ErrConflict) {
+			return nil, ports.ErrCustomFieldExists
+		}
+		return nil, err
+	}
+	return definition, nil
+}
+
+func (s *customFieldServiceImpl) UpdateDefinition(definition *domain.CustomFieldDefinition, actor domain.Actor) (_ *domain.CustomFieldDefinition, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditCustomFieldUpdate, domain.AuditCustomFieldEntity, definition.ID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	existing, err := s.findDefinition(definition.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// La clave identifica el valor guardado en cada persona; no se puede cambiar.
+	definition.Key = existing.Key
+	definition.CreatedAt = existing.CreatedAt
+	if err := validateDefinition(definition); err != nil {
+		return nil, err
+	}
+	if err := s.checkStoredValues(definition); err != nil {
+		return nil, err
+	}
+
+	changes = customFieldChanges(existing, definition)
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.CustomFields.Save(definition); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditCustomFieldUpdate, domain.AuditCustomFieldEntity, definition.ID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+func (s *customFieldServiceImpl) DeleteDefinition(id uint, actor domain.Actor) (err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditCustomFieldDelete, domain.AuditCustomFieldEntity, id, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return ports.ErrForbidden
+	}
+	definition, err := s.findDefinition(id)
+	if err != nil {
+		return err
+	}
+
+	changes = customFieldChanges(definition, nil)
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.CustomFields.RemoveValues(definition.Key); err != nil {
+			return err
+		}
+		if err := repos.CustomFields.Delete(id); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditCustomFieldDelete, domain.AuditCustomFieldEntity, id, changes, nil))
+	})
+}
+
+func (s *customFieldServiceImpl) ListDefinitions() ([]domain.CustomFieldDefinition, error) {
+	return s.customFieldRepo.List()
+}
+
+// checkStoredValues rechaza la nueva definición si los valores ya guardados en las
+// personas no la cumplen: un cambio de tipo, de opciones o de límites no puede dejar
+// datos que la propia definición rechazaría al editar la persona.
+func (s *customFieldServiceImpl) checkStoredValues(definition *domain.CustomFieldDefinition) error {
+	values, err := s.customFieldRepo.ListValues(definition.Key)
+	if err != nil {
+		return err
+	}
+	// Se informa la persona de menor ID para que el error sea siempre el mismo.
+	invalid, firstID := 0, uint(0)
+	var firstErr error
+	for personID, value := range values {
+		if _, err := customFieldValue(definition, value); err != nil {
+			invalid++
+			if firstErr == nil || personID < firstID {
+				firstID, firstErr = personID, err
+			}
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%w: %d persons have a value that does not fit the new definition (person %d %v)",
+			ports.ErrInvalidCustomField, invalid, firstID, firstErr)
+	}
+
+	if definition.Required {
+		missing, err := s.customFieldRepo.CountMissing(definition.Key)
+		if err != nil {
+			return err
+		}
+		if missing > 0 {
+			return fmt.Errorf("%w: %d persons have no value, the field cannot be required", ports.ErrInvalidCustomField, missing)
+		}
+	}
+	return nil
+}
+
+func (s *customFieldServiceImpl) findDefinition(id uint) (*domain.CustomFieldDefinition, error) {
+	definition, err := s.customFieldRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrCustomFieldNotFound
+		}
+		return nil, err
+	}
+	return definition, nil
+}
+
+// validateDefinition comprueba que las reglas de validación tengan sentido para el tipo del campo.
+func validateDefinition(d *domain.CustomFieldDefinition) error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ports.ErrInvalidCustomField, fmt.Sprintf(format, args...))
+	}
+
+	d.Key = strings.TrimSpace(d.Key)
+	d.Label = strings.TrimSpace(d.Label)
+	if !domain.IsValidCustomFieldKey(d.Key) {
+		return invalid("key %q must start with a lowercase letter and contain only lowercase letters, digits and underscores (max 50)", d.Key)
+	}
+	if d.Label == "" {
+		d.Label = d.Key
+	}
+	if !d.Type.IsValid() {
+		return invalid("unknown type %q", d.Type)
+	}
+
+	if d.Type == domain.EnumField {
+		seen := make(map[string]bool)
+		for _, option := range d.Options {
+			if option == "" || seen[option] {
+				return invalid("enum options must be unique and not empty")
+			}
+			seen[option] = true
+		}
+		if len(d.Options) == 0 {
+			return invalid("an enum field needs at least one option")
+		}
+	} else if len(d.Options) > 0 {
+		return invalid("options are only allowed on enum fields")
+	}
+
+	if d.Min != nil || d.Max != nil {
+		if d.Type != domain.NumberField && d.Type != domain.TextField {
+			return invalid("min and max are only allowed on number and text fields")
+		}
+		if d.Min != nil && d.Max != nil && *d.Min > *d.Max {
+			return invalid("min cannot be greater than max")
+		}
+	}
+
+	if d.Pattern != "" {
+		if d.Type != domain.TextField {
+			return invalid("pattern is only allowed on text fields")
+		}
+		if _, err := regexp.Compile(d.Pattern); err != nil {
+			return invalid("pattern is not a valid regular expression: %v", err)
+		}
+	}
+	return nil
+}
+
+// validateCustomFieldValues comprueba los valores de una persona contra las definiciones
+// vigentes y devuelve los valores normalizados. Los valores vacíos se descartan.
+func validateCustomFieldValues(definitions []domain.CustomFieldDefinition, values domain.CustomFieldValues) (domain.CustomFieldValues, error) {
+	byKey := make(map[string]*domain.CustomFieldDefinition, len(definitions))
+	for i := range definitions {
+		byKey[definitions[i].Key] = &definitions[i]
+	}
+
+	// Se recorren las claves en orden para que el primer error sea siempre el mismo.
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	normalized := make(domain.CustomFieldValues)
+	for _, key := range keys {
+		definition, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", ports.ErrInvalidCustomField, key)
+		}
+		value, err := customFieldValue(definition, values[key])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %v", ports.ErrInvalidCustomField, key, err)
+		}
+		if value != nil {
+			normalized[key] = value
+		}
+	}
+
+	for _, definition := range definitions {
+		if _, ok := normalized[definition.Key]; definition.Required && !ok {
+			return nil, fmt.Errorf("%w: %s is required", ports.ErrInvalidCustomField, definition.Key)
+		}
+	}
+
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+	return normalized, nil
+}
+
+// customFieldValue valida un valor según su definición. Devuelve nil si el valor está vacío.
+func customFieldValue(d *domain.CustomFieldDefinition, raw any) (any, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	if text, ok := raw.(string); ok && strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+
+	switch d.Type {
+	case domain.TextField:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("must be a text")
+		}
+		length := float64(utf8.RuneCountInString(text))
+		if d.Min != nil && length < *d.Min {
+			return nil, fmt.Errorf("must have at least %v characters", *d.Min)
+		}
+		if d.Max != nil && length > *d.Max {
+			return nil, fmt.Errorf("must have at most %v characters", *d.Max)
+		}
+		if d.Pattern != "" && !regexp.MustCompile(d.Pattern).MatchString(text) {
+			return nil, errors.New("does not match the expected format")
+		}
+		return text, nil
+
+	case domain.NumberField:
+		number, ok := raw.(float64)
+		if !ok {
+			return nil, errors.New("must be a number")
+		}
+		if d.Min != nil && number < *d.Min {
+			return nil, fmt.Errorf("must be at least %v", *d.Min)
+		}
+		if d.Max != nil && number > *d.Max {
+			return nil, fmt.Errorf("must be at most %v", *d.Max)
+		}
+		return number, nil
+
+	case domain.DateField:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("must be a date in YYYY-MM-DD format")
+		}
+		if _, err := time.Parse("2006-01-02", text); err != nil {
+			return nil, errors.New("must be a date in YYYY-MM-DD format")
+		}
+		return text, nil
+
+	case domain.EnumField:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("must be one of the options")
+		}
+		for _, option := range d.Options {
+			if option == text {
+				return text, nil
+			}
+		}
+		return nil, fmt.Errorf("must be one of: %s", strings.Join(d.Options, ", "))
+
+	case domain.BooleanField:
+		value, ok := raw.(bool)
+		if !ok {
+			return nil, errors.New("must be true or false")
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("has an unknown type %q", d.Type)
+}
+
+func customFieldChanges(before, after *domain.CustomFieldDefinition) map[string]domain.FieldChange {
+	snapshot := func(d *domain.CustomFieldDefinition) map[string]*string {
+		if d == nil {
+			return nil
+		}
+		values := map[string]*string{
+			"key":      textValue(d.Key),
+			"label":    textValue(d.Label),
+			"type":     textValue(string(d.Type)),
+			"required": textValue(fmt.Sprint(d.Required)),
+			"options":  textValue(strings.Join(d.Options, ",")),
+			"pattern":  textValue(d.Pattern),
+			"min":      nil,
+			"max":      nil,
+		}
+		if d.Min != nil {
+			values["min"] = textValue(fmt.Sprint(*d.Min))
+		}
+		if d.Max != nil {
+			values["max"] = textValue(fmt.Sprint(*d.Max))
+		}
+		return values
+	}
+	return diffSnapshots(snapshot(before), snapshot(after))
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/repository/memory"
+)
+
+func TestUpdateDefinitionChecksStoredValues(t *testing.T) {
+	store := memory.NewStore()
+	personRepo := memory.NewPersonRepository(store)
+	fields := NewCustomFieldService(memory.NewCustomFieldRepository(store), memory.NewUnitOfWork(store), newAuditService(store))
+	definition, err := fields.CreateDefinition(&domain.CustomFieldDefinition{Key: "ministry", Type: domain.EnumField, Options: []string{"choir", "ushers"}}, admin)
+	if err != nil {
+		t.Fatalf("CreateDefinition: %v", err)
+	}
+	addPerson(t, personRepo, &domain.Person{Name: "Ana", CustomFields: domain.CustomFieldValues{"ministry": "choir"}})
+	addPerson(t, personRepo, &domain.Person{Name: "Luis"})
+
+	update := func(d domain.CustomFieldDefinition) error {
+		d.ID = definition.ID
+		_, err := fields.UpdateDefinition(&d, admin)
+		return err
+	}
+	for name, d := range map[string]domain.CustomFieldDefinition{
+		"remove a used option": {Type: domain.EnumField, Options: []string{"ushers"}},
+		"change the type":      {Type: domain.NumberField},
+		"require a value":      {Type: domain.EnumField, Options: []string{"choir", "ushers"}, Required: true},
+	} {
+		if err := update(d); !errors.Is(err, ports.ErrInvalidCustomField) {
+			t.Errorf("%s: expected ErrInvalidCustomField, got %v", name, err)
+		}
+	}
+	if err := update(domain.CustomFieldDefinition{Type: domain.TextField, Label: "Ministerio"}); err != nil {
+		t.Errorf("a compatible change was rejected: %v", err)
+	}
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type delegationServiceImpl struct {
+	delegationRepo ports.DelegationRepository
+	personRepo     ports.PersonRepository
+	userRepo       ports.UserRepository
+	policy         ports.Policy
+	uow            ports.UnitOfWork
+	audit          ports.AuditService
+}
+
+func NewDelegationService(delegationRepo ports.DelegationRepository, personRepo ports.PersonRepository, userRepo ports.UserRepository, policy ports.Policy, uow ports.UnitOfWork, audit ports.AuditService) ports.DelegationService {
+	return &delegationServiceImpl{delegationRepo, personRepo, userRepo, policy, uow, audit}
+}
+
+func (s *delegationServiceImpl) GrantDelegation(userID, personID uint, reason string, actor domain.Actor) (delegation *domain.Delegation, err error) {
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditDelegationGrant, domain.AuditDelegationEntity, delegationID(delegation), delegationChanges(nil, delegation), err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, fmt.Errorf("%w: user %d does not exist", ports.ErrInvalidDelegation, userID)
+		}
+		return nil, err
+	}
+	person, err := findPerson(s.personRepo, personID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCanDelegate(userID, person); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	decidedBy := actor.UserID
+	delegation = &domain.Delegation{
+		UserID:      userID,
+		PersonID:    personID,
+		Status:      domain.DelegationActive,
+		Reason:      strings.TrimSpace(reason),
+		RequestedBy: actor.UserID,
+		DecidedBy:   &decidedBy,
+		DecidedAt:   &now,
+	}
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Delegations.Save(delegation); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditDelegationGrant, domain.AuditDelegationEntity, delegation.ID, delegationChanges(nil, delegation), nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	delegation.Person = person
+	return delegation, nil
+}
+
+func (s *delegationServiceImpl) RequestDelegation(personID uint, reason string, actor domain.Actor) (delegation *domain.Delegation, err error) {
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditDelegationRequest, domain.AuditDelegationEntity, delegationID(delegation), delegationChanges(nil, delegation), err))
+	}()
+
+	// La persona no se carga: la respuesta no debe revelar si existe ni cómo se llama
+	// hasta que se acepte la solicitud. Una solicitud sobre una persona inexistente
+	// queda pendiente sin que nadie pueda aceptarla.
+	own, err := s.personRepo.FindByUserID(actor.UserID)
+	if err != nil && !errors.Is(err, ports.ErrNotFound) {
+		return nil, err
+	}
+	if own != nil && own.ID == personID {
+		return nil, fmt.Errorf("%w: the user already owns this person record", ports.ErrInvalidDelegation)
+	}
+	if err := s.checkNoOpenDelegation(actor.UserID, personID); err != nil {
+		return nil, err
+	}
+
+	delegation = &domain.Delegation{
+		UserID:      actor.UserID,
+		PersonID:    personID,
+		Status:      domain.DelegationPending,
+		Reason:      strings.TrimSpace(reason),
+		RequestedBy: actor.UserID,
+	}
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Delegations.Save(delegation); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditDelegationRequest, domain.AuditDelegationEntity, delegation.ID, delegationChanges(nil, delegation), nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return delegation, nil
+}
+
+func (s *delegationServiceImpl) AcceptDelegation(id uint, actor domain.Actor) (*domain.Delegation, error) {
+	return s.decide(id, domain.DelegationActive, domain.AuditDelegationAccept, actor)
+}
+
+func (s *delegationServiceImpl) RejectDelegation(id uint, actor domain.Actor) (*domain.Delegation, error) {
+	return s.decide(id, domain.DelegationRejected, domain.AuditDelegationReject, actor)
+}
+
+// decide acepta o rechaza una solicitud pendiente. Solo el dueño de la persona o un
+// administrador deciden; el delegado nunca puede aprobar su propia solicitud.
+func (s *delegationServiceImpl) decide(id uint, status domain.DelegationStatus, auditAction string, actor domain.Actor) (_ *domain.Delegation, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, auditAction, domain.AuditDelegationEntity, id, changes, err))
+	}()
+
+	delegation, err := s.findDelegation(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if delegation.UserID == actor.UserID {
+		return nil, ports.ErrForbidden
+	}
+	if err := s.policy.Authorize(actor, ports.ActionUpdate, ports.PolicyResource{Type: ports.DelegationResource, Person: delegation.Person}); err != nil {
+		return nil, err
+	}
+	if delegation.Status != domain.DelegationPending {
+		return nil, fmt.Errorf("%w: the delegation is %s, only pending requests can be decided", ports.ErrInvalidDelegation, delegation.Status)
+	}
+
+	before := *delegation
+	now := time.Now()
+	decidedBy := actor.UserID
+	delegation.Status = status
+	delegation.DecidedBy = &decidedBy
+	delegation.DecidedAt = &now
+	changes = delegationChanges(&before, delegation)
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Delegations.Save(delegation); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, auditAction, domain.AuditDelegationEntity, id, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return delegation, nil
+}
+
+func (s *delegationServiceImpl) RevokeDelegation(id uint, actor domain.Actor) (_ *domain.Delegation, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditDelegationRevoke, domain.AuditDelegationEntity, id, changes, err))
+	}()
+
+	// Quien puede ver la delegación puede retirarla: el delegado renuncia, el dueño o un administrador la revocan.
+	delegation, err := s.findDelegation(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !delegation.IsOpen() {
+		return nil, fmt.Errorf("%w: the delegation is already %s", ports.ErrInvalidDelegation, delegation.Status)
+	}
+
+	before := *delegation
+	now := time.Now()
+	revokedBy := actor.UserID
+	delegation.Status = domain.DelegationRevoked
+	delegation.RevokedBy = &revokedBy
+	delegation.RevokedAt = &now
+	changes = delegationChanges(&before, delegation)
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Delegations.Save(delegation); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditDelegationRevoke, domain.AuditDelegationEntity, id, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hidePerson(delegation, actor), nil
+}
+
+func (s *delegationServiceImpl) ListDelegations(actor domain.Actor) ([]domain.Delegation, error) {
+	filter := ports.DelegationFilter{UserID: &actor.UserID}
+	own, err := s.personRepo.FindByUserID(actor.UserID)
+	if err != nil && !errors.Is(err, ports.ErrNotFound) {
+		return nil, err
+	}
+	if own != nil {
+		filter.PersonIDs = []uint{own.ID}
+	}
+	delegations, err := s.delegationRepo.Search(filter)
+	if err != nil {
+		return nil, err
+	}
+	for i := range delegations {
+		hidePerson(&delegations[i], actor)
+	}
+	return delegations, nil
+}
+
+func (s *delegationServiceImpl) SearchDelegations(filter ports.DelegationFilter, actor domain.Actor) ([]domain.Delegation, error) {
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %q", ports.ErrInvalidDelegation, filter.Status)
+	}
+	return s.delegationRepo.Search(filter)
+}
+
+func (s *delegationServiceImpl) ListManagedPersons(actor domain.Actor) ([]domain.Person, error) {
+	delegations, err := s.delegationRepo.Search(ports.DelegationFilter{UserID: &actor.UserID, Status: domain.DelegationActive})
+	if err != nil {
+		return nil, err
+	}
+	persons := make([]domain.Person, 0, len(delegations))
+	for _, delegation := range delegations {
+		// Se recarga la persona para devolverla con sus direcciones y teléfonos.
+		person, err := s.personRepo.FindByID(delegation.PersonID)
+		if err != nil {
+			if errors.Is(err, ports.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		persons = append(persons, *person)
+	}
+	return persons, nil
+}
+
+// checkCanDelegate comprueba que el usuario no sea el dueño de la persona ni tenga
+// ya una delegación pendiente o vigente sobre ella.
+func (s *delegationServiceImpl) checkCanDelegate(userID uint, person *domain.Person) error {
+	if person.UserID != nil && *person.UserID == userID {
+		return fmt.Errorf("%w: the user already owns this person record", ports.ErrInvalidDelegation)
+	}
+	return s.checkNoOpenDelegation(userID, person.ID)
+}
+
+// checkNoOpenDelegation comprueba que el usuario no tenga ya una delegación pendiente o
+// vigente sobre la persona.
+func (s *delegationServiceImpl) checkNoOpenDelegation(userID, personID uint) error {
+	_, err := s.delegationRepo.FindOpen(userID, personID)
+	if err == nil {
+		return ports.ErrDelegationExists
+	}
+	if !errors.Is(err, ports.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// findDelegation devuelve la delegación si el actor puede verla: el delegado, el dueño
+// de la persona o un administrador. Para el resto se informa como inexistente. La
+// persona es nil si la solicitud se hizo sobre una persona que no existe.
+func (s *delegationServiceImpl) findDelegation(id uint, actor domain.Actor) (*domain.Delegation, error) {
+	delegation, err := s.delegationRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrDelegationNotFound
+		}
+		return nil, err
+	}
+	if delegation.UserID == actor.UserID || actor.IsAdmin() {
+		return delegation, nil
+	}
+	err = s.policy.Authorize(actor, ports.ActionRead, ports.PolicyResource{Type: ports.DelegationResource, Person: delegation.Person})
+	if errors.Is(err, ports.ErrForbidden) {
+		return nil, ports.ErrDelegationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return delegation, nil
+}
+
+// hidePerson quita la persona de la delegación cuando el actor es el delegado y la
+// delegación no está vigente: hasta que se acepta, el delegado no sabe a quién pidió
+// gestionar ni si existe.
+func hidePerson(delegation *domain.Delegation, actor domain.Actor) *domain.Delegation {
+	if delegation.UserID == actor.UserID && delegation.Status != domain.DelegationActive {
+		delegation.Person = nil
+	}
+	return delegation
+}
+
+func delegationID(delegation *domain.Delegation) uint {
+	if delegation == nil {
+		return 0
+	}
+	return delegation.ID
+}
+
+// delegationChanges registra quién gestiona a quién y el estado de la delegación.
+func delegationChanges(before, after *domain.Delegation) map[string]domain.FieldChange {
+	snapshot := func(d *domain.Delegation) map[string]*string {
+		if d == nil {
+			return nil
+		}
+		return map[string]*string{
+			"userId":   textValue(strconv.FormatUint(uint64(d.UserID), 10)),
+			"personId": textValue(strconv.FormatUint(uint64(d.PersonID), 10)),
+			"status":   textValue(string(d.Status)),
+			"reason":   textValue(d.Reason),
+		}
+	}
+	return diffSnapshots(snapshot(before), snapshot(after))
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/policy"
+	"github.com/riada2/internal/repository/memory"
+)
+
+func TestRequestDelegationHidesThePerson(t *testing.T) {
+	store := memory.NewStore()
+	personRepo := memory.NewPersonRepository(store)
+	delegationRepo := memory.NewDelegationRepository(store)
+	delegations := NewDelegationService(delegationRepo, personRepo, memory.NewUserRepository(store),
+		policy.New(delegationRepo), memory.NewUnitOfWork(store), newAuditService(store))
+	child := addPerson(t, personRepo, &domain.Person{Name: "Ana", LastName: "Quispe", UserID: &owner.UserID})
+
+	// Una persona que existe y una que no reciben la misma respuesta, sin el nombre.
+	request, err := delegations.RequestDelegation(child.ID, "Soy su madre", delegate)
+	if err != nil || request.Status != domain.DelegationPending || request.Person != nil {
+		t.Fatalf("RequestDelegation = %+v, %v; want a pending request without the person", request, err)
+	}
+	missing, err := delegations.RequestDelegation(child.ID+100, "", delegate)
+	if err != nil || missing.Status != domain.DelegationPending || missing.Person != nil {
+		t.Fatalf("RequestDelegation for a missing person = %+v, %v", missing, err)
+	}
+	listed, err := delegations.ListDelegations(delegate)
+	if err != nil || len(listed) != 2 || listed[0].Person != nil || listed[1].Person != nil {
+		t.Fatalf("the delegate should not see the persons of pending requests, got %+v, %v", listed, err)
+	}
+
+	if _, err := delegations.AcceptDelegation(missing.ID, admin); !errors.Is(err, ports.ErrForbidden) {
+		t.Errorf("accepting a request for a missing person: expected ErrForbidden, got %v", err)
+	}
+	if _, err := delegations.RevokeDelegation(missing.ID, delegate); err != nil {
+		t.Errorf("the delegate should withdraw a request for a missing person: %v", err)
+	}
+
+	if _, err := delegations.AcceptDelegation(request.ID, owner); err != nil {
+		t.Fatalf("AcceptDelegation: %v", err)
+	}
+	listed, err = delegations.ListDelegations(delegate)
+	if err != nil {
+		t.Fatalf("ListDelegations: %v", err)
+	}
+	for _, delegation := range listed {
+		if delegation.ID == request.ID && (delegation.Person == nil || delegation.Person.FullName() != "Ana Quispe") {
+			t.Errorf("an accepted delegation should show the person, got %+v", delegation)
+		}
+	}
+}
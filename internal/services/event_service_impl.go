@@ -0,0 +1,205 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type eventServiceImpl struct {
+	eventRepo ports.EventRepository
+	loc       *time.Location
+	uow       ports.UnitOfWork
+	audit     ports.AuditService
+}
+
+// NewEventService crea el servicio de eventos. loc es la zona horaria de la organización,
+// que define el día y la hora de cada ocurrencia.
+func NewEventService(eventRepo ports.EventRepository, loc *time.Location, uow ports.UnitOfWork, audit ports.AuditService) ports.EventService {
+	return &eventServiceImpl{eventRepo, loc, uow, audit}
+}
+
+func (s *eventServiceImpl) CreateEvent(event *domain.Event, actor domain.Actor) (_ *domain.Event, err error) {
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditEventCreate, domain.AuditEventEntity, event.ID, eventChanges(nil, event), err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if err := validateEvent(event); err != nil {
+		return nil, err
+	}
+
+	event.ID = 0
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Events.Save(event); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditEventCreate, domain.AuditEventEntity, event.ID, eventChanges(nil, event), nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inZone(event, s.loc), nil
+}
+
+func (s *eventServiceImpl) UpdateEvent(event *domain.Event, actor domain.Actor) (_ *domain.Event, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditEventUpdate, domain.AuditEventEntity, event.ID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	existing, err := s.GetEvent(event.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateEvent(event); err != nil {
+		return nil, err
+	}
+
+	event.CreatedAt = existing.CreatedAt
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Events.Save(event); err != nil {
+			return err
+		}
+		changes = eventChanges(existing, event)
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditEventUpdate, domain.AuditEventEntity, event.ID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inZone(event, s.loc), nil
+}
+
+func (s *eventServiceImpl) DeleteEvent(id uint, actor domain.Actor) (err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditEventDelete, domain.AuditEventEntity, id, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return ports.ErrForbidden
+	}
+	event, err := s.GetEvent(id)
+	if err != nil {
+		return err
+	}
+
+	changes = eventChanges(event, nil)
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Events.Delete(id); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditEventDelete, domain.AuditEventEntity, id, changes, nil))
+	})
+}
+
+func (s *eventServiceImpl) GetEvent(id uint) (*domain.Event, error) {
+	return findEvent(s.eventRepo, id, s.loc)
+}
+
+func (s *eventServiceImpl) ListEvents() ([]domain.Event, error) {
+	events, err := s.eventRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		inZone(&events[i], s.loc)
+	}
+	return events, nil
+}
+
+func (s *eventServiceImpl) GetOccurrences(eventID uint, from, to time.Time) ([]time.Time, error) {
+	from, to = calendarDay(from, s.loc), calendarDay(to, s.loc)
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: the end of the range must be after its start", ports.ErrInvalidEvent)
+	}
+	event, err := s.GetEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+	return event.Occurrences(from, to)
+}
+
+// findEvent busca un evento y lo expresa en la zona horaria indicada.
+func findEvent(eventRepo ports.EventRepository, id uint, loc *time.Location) (*domain.Event, error) {
+	event, err := eventRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrEventNotFound
+		}
+		return nil, err
+	}
+	return inZone(event, loc), nil
+}
+
+// inZone expresa las fechas del evento en la zona horaria de la organización. La base de
+// datos puede devolverlas en UTC, y es la hora local la que define el día de cada ocurrencia.
+func inZone(event *domain.Event, loc *time.Location) *domain.Event {
+	event.StartsAt = event.StartsAt.In(loc)
+	if event.EndsAt != nil {
+		endsAt := event.EndsAt.In(loc)
+		event.EndsAt = &endsAt
+	}
+	return event
+}
+
+// calendarDay devuelve la medianoche, en la zona horaria indicada, del día calendario
+// de t (su año, mes y día, sin convertirlos de zona).
+func calendarDay(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// validateEvent normaliza el evento y guarda la regla de recurrencia en su forma canónica.
+func validateEvent(event *domain.Event) error {
+	event.Name = strings.TrimSpace(event.Name)
+	event.Place = strings.TrimSpace(event.Place)
+	event.Recurrence = strings.TrimSpace(event.Recurrence)
+	if event.Name == "" {
+		return fmt.Errorf("%w: name is required", ports.ErrInvalidEvent)
+	}
+	if event.StartsAt.IsZero() {
+		return fmt.Errorf("%w: start date is required", ports.ErrInvalidEvent)
+	}
+	if event.EndsAt != nil && event.EndsAt.Before(event.StartsAt) {
+		return fmt.Errorf("%w: the event cannot end before it starts", ports.ErrInvalidEvent)
+	}
+
+	if event.Recurrence != "" {
+		rule, err := domain.ParseRecurrence(event.Recurrence)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ports.ErrInvalidEvent, err)
+		}
+		event.Recurrence = rule.String()
+	}
+	return nil
+}
+
+func eventChanges(before, after *domain.Event) map[string]domain.FieldChange {
+	snapshot := func(e *domain.Event) map[string]*string {
+		if e == nil {
+			return nil
+		}
+		values := map[string]*string{
+			"name":        textValue(e.Name),
+			"description": textValue(e.Description),
+			"place":       textValue(e.Place),
+			"startsAt":    textValue(e.StartsAt.Format(time.RFC3339)),
+			"endsAt":      nil,
+			"recurrence":  textValue(e.Recurrence),
+		}
+		if e.EndsAt != nil {
+			values["endsAt"] = textValue(e.EndsAt.Format(time.RFC3339))
+		}
+		return values
+	}
+	return diffSnapshots(snapshot(before), snapshot(after))
+}
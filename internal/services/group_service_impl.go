@@ -0,0 +1,263 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type groupServiceImpl struct {
+	groupRepo  ports.GroupRepository
+	personRepo ports.PersonRepository
+	uow        ports.UnitOfWork
+	audit      ports.AuditService
+}
+
+func NewGroupService(groupRepo ports.GroupRepository, personRepo ports.PersonRepository, uow ports.UnitOfWork, audit ports.AuditService) ports.GroupService {
+	return &groupServiceImpl{groupRepo, personRepo, uow, audit}
+}
+
+func (s *groupServiceImpl) CreateGroup(group *domain.Group, actor domain.Actor) (_ *domain.Group, err error) {
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditGroupCreate, domain.AuditGroupEntity, group.ID, groupChanges(nil, group), err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if err := s.validate(group); err != nil {
+		return nil, err
+	}
+
+	group.ID = 0
+	group.Members = nil
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := saveGroup(repos.Groups, group); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditGroupCreate, domain.AuditGroupEntity, group.ID, groupChanges(nil, group), nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (s *groupServiceImpl) UpdateGroup(group *domain.Group, actor domain.Actor) (_ *domain.Group, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditGroupUpdate, domain.AuditGroupEntity, group.ID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	existing, err := s.GetGroup(group.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validate(group); err != nil {
+		return nil, err
+	}
+
+	updated := *existing
+	updated.Name = group.Name
+	updated.Type = group.Type
+	updated.Description = group.Description
+	changes = groupChanges(existing, &updated)
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := saveGroup(repos.Groups, &updated); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditGroupUpdate, domain.AuditGroupEntity, group.ID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// validate normaliza el nombre del grupo y comprueba el tipo y la unicidad del nombre.
+func (s *groupServiceImpl) validate(group *domain.Group) error {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.Name == "" {
+		return fmt.Errorf("%w: name is required", ports.ErrInvalidGroup)
+	}
+	if group.Type == "" {
+		group.Type = domain.OtherGroup
+	}
+	if !group.Type.IsValid() {
+		return fmt.Errorf("%w: unknown type %q", ports.ErrInvalidGroup, group.Type)
+	}
+
+	existing, err := s.groupRepo.FindByName(group.Name)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != group.ID {
+		return ports.ErrGroupExists
+	}
+	return nil
+}
+
+// saveGroup guarda el grupo; si otro con el mismo nombre se guardó entre la validación
+// y este punto, la clave única lo rechaza.
+func saveGroup(groupRepo ports.GroupRepository, group *domain.Group) error {
+	err := groupRepo.Save(group)
+	if errors.Is(err, ports.ErrConflict) {
+		return ports.ErrGroupExists
+	}
+	return err
+}
+
+func (s *groupServiceImpl) DeleteGroup(id uint, actor domain.Actor) (err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditGroupDelete, domain.AuditGroupEntity, id, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return ports.ErrForbidden
+	}
+	group, err := s.GetGroup(id)
+	if err != nil {
+		return err
+	}
+
+	changes = groupChanges(group, nil)
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Groups.Delete(id); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditGroupDelete, domain.AuditGroupEntity, id, changes, nil))
+	})
+}
+
+func (s *groupServiceImpl) GetGroup(id uint) (*domain.Group, error) {
+	group, err := s.groupRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrGroupNotFound
+		}
+		return nil, err
+	}
+	return group, nil
+}
+
+func (s *groupServiceImpl) ListGroups(groupType domain.GroupType) ([]domain.Group, error) {
+	return s.groupRepo.List(groupType)
+}
+
+func (s *groupServiceImpl) AddMembers(groupID uint, members []domain.GroupMembership, actor domain.Actor) (_ *domain.Group, err error) {
+	personIDs := make([]uint, len(members))
+	for i := range members {
+		personIDs[i] = members[i].PersonID
+	}
+	changes := map[string]domain.FieldChange{"personIds": {New: textValue(joinIDs(personIDs))}}
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditGroupMemberAdd, domain.AuditGroupEntity, groupID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("%w: members is required", ports.ErrInvalidGroup)
+	}
+	if _, err := s.GetGroup(groupID); err != nil {
+		return nil, err
+	}
+
+	// Se valida todo el lote antes de guardar para no dejarlo a medias.
+	for i := range members {
+		member := &members[i]
+		if member.Role == "" {
+			member.Role = domain.GroupMember
+		}
+		if !member.Role.IsValid() {
+			return nil, fmt.Errorf("%w: unknown role %q", ports.ErrInvalidGroup, member.Role)
+		}
+		if member.StartDate != nil && member.EndDate != nil && member.EndDate.Before(*member.StartDate) {
+			return nil, fmt.Errorf("%w: end date of person %d is before its start date", ports.ErrInvalidGroup, member.PersonID)
+		}
+		if _, err := s.personRepo.FindByID(member.PersonID); err != nil {
+			if errors.Is(err, ports.ErrNotFound) {
+				return nil, fmt.Errorf("%w: %d", ports.ErrPersonNotFound, member.PersonID)
+			}
+			return nil, err
+		}
+	}
+
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		for i := range members {
+			membership, err := repos.Groups.FindMembership(groupID, members[i].PersonID)
+			if err != nil {
+				if !errors.Is(err, ports.ErrNotFound) {
+					return err
+				}
+				membership = &domain.GroupMembership{GroupID: groupID, PersonID: members[i].PersonID}
+			}
+			membership.Role = members[i].Role
+			membership.StartDate = members[i].StartDate
+			membership.EndDate = members[i].EndDate
+			if err := repos.Groups.SaveMembership(membership); err != nil {
+				return err
+			}
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditGroupMemberAdd, domain.AuditGroupEntity, groupID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetGroup(groupID)
+}
+
+func (s *groupServiceImpl) RemoveMembers(groupID uint, personIDs []uint, actor domain.Actor) (removed int, err error) {
+	changes := map[string]domain.FieldChange{"personIds": {Old: textValue(joinIDs(personIDs))}}
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditGroupMemberRemove, domain.AuditGroupEntity, groupID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return 0, ports.ErrForbidden
+	}
+	if len(personIDs) == 0 {
+		return 0, fmt.Errorf("%w: personIds is required", ports.ErrInvalidGroup)
+	}
+	if _, err := s.GetGroup(groupID); err != nil {
+		return 0, err
+	}
+
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		count, err := repos.Groups.DeleteMemberships(groupID, personIDs)
+		if err != nil {
+			return err
+		}
+		removed = int(count)
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditGroupMemberRemove, domain.AuditGroupEntity, groupID, changes, nil))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+func groupChanges(before, after *domain.Group) map[string]domain.FieldChange {
+	snapshot := func(g *domain.Group) map[string]*string {
+		if g == nil {
+			return nil
+		}
+		return map[string]*string{
+			"name":        textValue(g.Name),
+			"type":        textValue(string(g.Type)),
+			"description": textValue(g.Description),
+		}
+	}
+	return diffSnapshots(snapshot(before), snapshot(after))
+}
@@ -0,0 +1,485 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type historyServiceImpl struct {
+	versionRepo ports.VersionRepository
+	personRepo  ports.PersonRepository
+	addressRepo ports.AddressRepository
+	phoneRepo   ports.PhoneRepository
+	uow         ports.UnitOfWork
+	policy      ports.Policy
+	audit       ports.AuditService
+}
+
+func NewHistoryService(versionRepo ports.VersionRepository, personRepo ports.PersonRepository, addressRepo ports.AddressRepository, phoneRepo ports.PhoneRepository, uow ports.UnitOfWork, policy ports.Policy, audit ports.AuditService) ports.HistoryService {
+	return &historyServiceImpl{versionRepo, personRepo, addressRepo, phoneRepo, uow, policy, audit}
+}
+
+func (s *historyServiceImpl) GetPersonHistory(personID uint, actor domain.Actor) ([]domain.EntityVersion, error) {
+	// Quien puede leer el registro (dueño, delegado o administrador) ve su historial,
+	// con el usuario que hizo cada cambio.
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, domain.PersonEntity, ports.ActionRead, actor); err != nil {
+		return nil, err
+	}
+
+	return s.versionRepo.FindByPersonID(personID)
+}
+
+func (s *historyServiceImpl) RevertToVersion(versionID uint, actor domain.Actor) (reverted *domain.EntityVersion, err error) {
+	defer func() {
+		// Si la reversión falla se audita contra la versión solicitada.
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditVersionRevert, "version", versionID, nil, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+
+	target, err := s.versionRepo.FindByID(versionID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrVersionNotFound
+		}
+		return nil, err
+	}
+
+	switch target.EntityType {
+	case domain.PersonEntity:
+		return s.revertPerson(target, actor)
+	case domain.AddressEntity:
+		return s.revertAddress(target, actor)
+	case domain.PhoneEntity:
+		return s.revertPhone(target, actor)
+	default:
+		return nil, fmt.Errorf("unsupported entity type %q", target.EntityType)
+	}
+}
+
+func (s *historyServiceImpl) revertPerson(target *domain.EntityVersion, actor domain.Actor) (*domain.EntityVersion, error) {
+	person, err := s.personRepo.FindByID(target.EntityID)
+	exists := err == nil
+	if err != nil && !errors.Is(err, ports.ErrNotFound) {
+		return nil, err
+	}
+
+	var before map[string]*string
+	if exists {
+		before = personSnapshot(person)
+	}
+
+	// Revertir a una eliminación equivale a volver a eliminar el registro.
+	if target.Snapshot == nil {
+		if !exists {
+			return nil, errors.New("the person is already deleted")
+		}
+		return s.revert(actor, func(repos ports.Repositories) (*domain.EntityVersion, error) {
+			if err := repos.Persons.Delete(person.ID); err != nil {
+				return nil, err
+			}
+			return recordVersion(repos.Versions, domain.PersonEntity, person.ID, person.ID, domain.VersionReverted, before, nil, actor)
+		})
+	}
+
+	if !exists {
+		person = &domain.Person{ID: target.EntityID}
+	}
+	if err := applyPersonSnapshot(person, target.Snapshot); err != nil {
+		return nil, err
+	}
+	if err := checkDocumentUniqueness(s.personRepo, person); err != nil {
+		return nil, err
+	}
+	// Las direcciones y teléfonos se versionan por separado; no se tocan aquí.
+	person.Addresses = nil
+	person.Phones = nil
+	return s.revert(actor, func(repos ports.Repositories) (*domain.EntityVersion, error) {
+		if err := savePerson(repos.Persons, person); err != nil {
+			return nil, err
+		}
+		return recordVersion(repos.Versions, domain.PersonEntity, person.ID, person.ID, domain.VersionReverted, before, personSnapshot(person), actor)
+	})
+}
+
+func (s *historyServiceImpl) revertAddress(target *domain.EntityVersion, actor domain.Actor) (*domain.EntityVersion, error) {
+	address, err := s.addressRepo.FindByID(target.EntityID)
+	exists := err == nil
+	if err != nil && !errors.Is(err, ports.ErrNotFound) {
+		return nil, err
+	}
+
+	var before map[string]*string
+	if exists {
+		before = addressSnapshot(address)
+	}
+
+	if target.Snapshot == nil {
+		if !exists {
+			return nil, errors.New("the address is already deleted")
+		}
+		return s.revert(actor, func(repos ports.Repositories) (*domain.EntityVersion, error) {
+			if err := repos.Addresses.Delete(address.ID); err != nil {
+				return nil, err
+			}
+			return recordVersion(repos.Versions, domain.AddressEntity, address.ID, target.PersonID, domain.VersionReverted, before, nil, actor)
+		})
+	}
+
+	if !exists {
+		address = &domain.Address{ID: target.EntityID}
+	}
+	if err := applyAddressSnapshot(address, target.Snapshot); err != nil {
+		return nil, err
+	}
+	return s.revert(actor, func(repos ports.Repositories) (*domain.EntityVersion, error) {
+		if err := repos.Addresses.Save(address); err != nil {
+			return nil, err
+		}
+		return recordVersion(repos.Versions, domain.AddressEntity, address.ID, address.PersonID, domain.VersionReverted, before, addressSnapshot(address), actor)
+	})
+}
+
+func (s *historyServiceImpl) revertPhone(target *domain.EntityVersion, actor domain.Actor) (*domain.EntityVersion, error) {
+	phone, err := s.phoneRepo.FindByID(target.EntityID)
+	exists := err == nil
+	if err != nil && !errors.Is(err, ports.ErrNotFound) {
+		return nil, err
+	}
+
+	var before map[string]*string
+	if exists {
+		before = phoneSnapshot(phone)
+	}
+
+	if target.Snapshot == nil {
+		if !exists {
+			return nil, errors.New("the phone is already deleted")
+		}
+		return s.revert(actor, func(repos ports.Repositories) (*domain.EntityVersion, error) {
+			if err := repos.Phones.Delete(phone.ID); err != nil {
+				return nil, err
+			}
+			return recordVersion(repos.Versions, domain.PhoneEntity, phone.ID, target.PersonID, domain.VersionReverted, before, nil, actor)
+		})
+	}
+
+	if !exists {
+		phone = &domain.Phone{ID: target.EntityID}
+	}
+	if err := applyPhoneSnapshot(phone, target.Snapshot); err != nil {
+		return nil, err
+	}
+	return s.revert(actor, func(repos ports.Repositories) (*domain.EntityVersion, error) {
+		if err := repos.Phones.Save(phone); err != nil {
+			return nil, err
+		}
+		return recordVersion(repos.Versions, domain.PhoneEntity, phone.ID, phone.PersonID, domain.VersionReverted, before, phoneSnapshot(phone), actor)
+	})
+}
+
+// revert ejecuta fn, que aplica una reversión y registra su versión, en la misma
+// transacción que la entrada de auditoría de la reversión.
+func (s *historyServiceImpl) revert(actor domain.Actor, fn func(repos ports.Repositories) (*domain.EntityVersion, error)) (*domain.EntityVersion, error) {
+	return versionedWrite(s.uow, func(repos ports.Repositories) (*domain.EntityVersion, error) {
+		reverted, err := fn(repos)
+		if err != nil {
+			return nil, err
+		}
+		entry := newAuditEntry(actor, domain.AuditVersionRevert, string(reverted.EntityType), reverted.EntityID, reverted.Changes, nil)
+		return reverted, s.audit.RecordIn(repos, entry)
+	})
+}
+
+// versionedWrite ejecuta fn, que modifica una entidad y registra su versión, en una sola
+// transacción: el cambio no queda guardado sin su versión ni la versión sin el cambio.
+func versionedWrite(uow ports.UnitOfWork, fn func(repos ports.Repositories) (*domain.EntityVersion, error)) (version *domain.EntityVersion, err error) {
+	err = uow.Do(func(repos ports.Repositories) error {
+		version, err = fn(repos)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// recordVersion guarda una nueva versión de la entidad con los campos que cambiaron
+// entre before y after. Las actualizaciones sin cambios reales no generan versión.
+func recordVersion(repo ports.VersionRepository, entityType domain.VersionEntity, entityID, personID uint, action domain.VersionAction, before, after map[string]*string, actor domain.Actor) (*domain.EntityVersion, error) {
+	changes := diffSnapshots(before, after)
+	if action == domain.VersionUpdated && len(changes) == 0 {
+		return nil, nil
+	}
+
+	latest, err := repo.LatestVersion(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	version := &domain.EntityVersion{
+		EntityType: entityType,
+		EntityID:   entityID,
+		PersonID:   personID,
+		Version:    latest + 1,
+		Action:     action,
+		Changes:    changes,
+		Snapshot:   after,
+	}
+	if actor.UserID != 0 {
+		changedBy := actor.UserID
+		version.ChangedBy = &changedBy
+	}
+
+	if err := repo.Save(version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// diffSnapshots compara dos snapshots y devuelve solo los campos modificados.
+func diffSnapshots(before, after map[string]*string) map[string]domain.FieldChange {
+	changes := make(map[string]domain.FieldChange)
+	for field, newValue := range after {
+		oldValue := before[field]
+		if !sameValue(oldValue, newValue) {
+			changes[field] = domain.FieldChange{Old: oldValue, New: newValue}
+		}
+	}
+	for field, oldValue := range before {
+		if _, ok := after[field]; !ok && oldValue != nil {
+			changes[field] = domain.FieldChange{Old: oldValue}
+		}
+	}
+	return changes
+}
+
+func sameValue(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// --- Snapshots ---
+// Cada entidad versionada se representa como un mapa campo -> valor en texto.
+// Los nombres de campo coinciden con los del JSON de la API.
+
+func personSnapshot(p *domain.Person) map[string]*string {
+	snapshot := map[string]*string{
+		"name":         textValue(p.Name),
+		"middleName":   textValue(p.MiddleName),
+		"lastName":     textValue(p.LastName),
+		"sex":          textValue(string(p.Sex)),
+		"docNumber":    optionalText(p.DocNumber),
+		"email":        optionalText(p.Email),
+		"photo":        optionalText(p.Photo),
+		"birthday":     nil,
+		"typeDoc":      nil,
+		"userId":       nil,
+		"householdId":  nil,
+		"customFields": nil,
+	}
+	if p.Birthday != nil {
+		snapshot["birthday"] = textValue(p.Birthday.Format("2006-01-02"))
+	}
+	if p.TypeDoc != nil {
+		snapshot["typeDoc"] = textValue(string(*p.TypeDoc))
+	}
+	if p.UserID != nil {
+		snapshot["userId"] = textValue(strconv.FormatUint(uint64(*p.UserID), 10))
+	}
+	if p.HouseholdID != nil {
+		snapshot["householdId"] = textValue(strconv.FormatUint(uint64(*p.HouseholdID), 10))
+	}
+	if len(p.CustomFields) > 0 {
+		// json.Marshal ordena las claves, así el texto es estable entre versiones.
+		customFields, _ := json.Marshal(p.CustomFields)
+		snapshot["customFields"] = textValue(string(customFields))
+	}
+	return snapshot
+}
+
+func applyPersonSnapshot(p *domain.Person, snapshot map[string]*string) error {
+	p.Name = valueOf(snapshot["name"])
+	p.MiddleName = valueOf(snapshot["middleName"])
+	p.LastName = valueOf(snapshot["lastName"])
+	p.Sex = domain.Sex(valueOf(snapshot["sex"]))
+	p.DocNumber = copyText(snapshot["docNumber"])
+	p.Email = copyText(snapshot["email"])
+	p.Photo = copyText(snapshot["photo"])
+
+	p.Birthday = nil
+	if v := snapshot["birthday"]; v != nil {
+		birthday, err := time.Parse("2006-01-02", *v)
+		if err != nil {
+			return fmt.Errorf("invalid birthday in snapshot: %w", err)
+		}
+		p.Birthday = &birthday
+	}
+
+	p.TypeDoc = nil
+	if v := snapshot["typeDoc"]; v != nil {
+		typeDoc := domain.DocType(*v)
+		p.TypeDoc = &typeDoc
+	}
+
+	p.UserID = nil
+	if v := snapshot["userId"]; v != nil {
+		userID, err := parseUintText(*v)
+		if err != nil {
+			return fmt.Errorf("invalid userId in snapshot: %w", err)
+		}
+		p.UserID = &userID
+	}
+
+	p.HouseholdID = nil
+	if v := snapshot["householdId"]; v != nil {
+		householdID, err := parseUintText(*v)
+		if err != nil {
+			return fmt.Errorf("invalid householdId in snapshot: %w", err)
+		}
+		p.HouseholdID = &householdID
+	}
+
+	p.CustomFields = nil
+	if v := snapshot["customFields"]; v != nil {
+		if err := json.Unmarshal([]byte(*v), &p.CustomFields); err != nil {
+			return fmt.Errorf("invalid customFields in snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+func addressSnapshot(a *domain.Address) map[string]*string {
+	return map[string]*string{
+		"personId":   textValue(strconv.FormatUint(uint64(a.PersonID), 10)),
+		"address":    textValue(a.Address),
+		"street":     textValue(a.Street),
+		"number":     textValue(a.Number),
+		"reference":  textValue(a.Reference),
+		"district":   textValue(a.District),
+		"province":   textValue(a.Province),
+		"department": textValue(a.Department),
+		"ubigeo":     textValue(a.Ubigeo),
+		"country":    textValue(a.Country),
+		"postalCode": textValue(a.PostalCode),
+		"latitude":   optionalFloat(a.Latitude),
+		"longitude":  optionalFloat(a.Longitude),
+	}
+}
+
+func optionalFloat(f *float64) *string {
+	if f == nil {
+		return nil
+	}
+	return textValue(strconv.FormatFloat(*f, 'f', -1, 64))
+}
+
+func parseOptionalFloat(s *string) (*float64, error) {
+	if s == nil {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(*s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func applyAddressSnapshot(a *domain.Address, snapshot map[string]*string) error {
+	personID, err := parseUintText(valueOf(snapshot["personId"]))
+	if err != nil {
+		return fmt.Errorf("invalid personId in snapshot: %w", err)
+	}
+	a.PersonID = personID
+	a.Address = valueOf(snapshot["address"])
+	a.Street = valueOf(snapshot["street"])
+	a.Number = valueOf(snapshot["number"])
+	a.Reference = valueOf(snapshot["reference"])
+	a.District = valueOf(snapshot["district"])
+	a.Province = valueOf(snapshot["province"])
+	a.Department = valueOf(snapshot["department"])
+	a.Ubigeo = valueOf(snapshot["ubigeo"])
+	a.PostalCode = valueOf(snapshot["postalCode"])
+	if a.Latitude, err = parseOptionalFloat(snapshot["latitude"]); err != nil {
+		return fmt.Errorf("invalid latitude in snapshot: %w", err)
+	}
+	if a.Longitude, err = parseOptionalFloat(snapshot["longitude"]); err != nil {
+		return fmt.Errorf("invalid longitude in snapshot: %w", err)
+	}
+	// Las versiones anteriores a las direcciones estructuradas no guardan el país.
+	a.Country = valueOf(snapshot["country"])
+	if a.Country == "" {
+		a.Country = domain.PeruCountryCode
+	}
+	return nil
+}
+
+func phoneSnapshot(p *domain.Phone) map[string]*string {
+	return map[string]*string{
+		"personId":  textValue(strconv.FormatUint(uint64(p.PersonID), 10)),
+		"phone":     textValue(p.Phone),
+		"type":      textValue(string(p.Type)),
+		"isPrimary": textValue(strconv.FormatBool(p.IsPrimary)),
+	}
+}
+
+func applyPhoneSnapshot(p *domain.Phone, snapshot map[string]*string) error {
+	personID, err := parseUintText(valueOf(snapshot["personId"]))
+	if err != nil {
+		return fmt.Errorf("invalid personId in snapshot: %w", err)
+	}
+	p.PersonID = personID
+	p.Phone = valueOf(snapshot["phone"])
+	p.Type = domain.PhoneType(valueOf(snapshot["type"]))
+	// Las versiones anteriores a la normalización no guardan isPrimary.
+	p.IsPrimary = valueOf(snapshot["isPrimary"]) == "true"
+	return nil
+}
+
+// textValue devuelve nil para cadenas vacías para que "" y "sin valor" se comparen igual.
+func textValue(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalText(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	return textValue(*s)
+}
+
+func copyText(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func parseUintText(s string) (uint, error) {
+	v, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(v), nil
+}
@@ -0,0 +1,577 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type householdServiceImpl struct {
+	householdRepo ports.HouseholdRepository
+	personRepo    ports.PersonRepository
+	uow           ports.UnitOfWork
+	audit         ports.AuditService
+}
+
+// NewHouseholdService crea el servicio de hogares. Los cambios de membresía se escriben
+// en una transacción junto con el historial de las personas.
+func NewHouseholdService(householdRepo ports.HouseholdRepository, personRepo ports.PersonRepository, uow ports.UnitOfWork, audit ports.AuditService) ports.HouseholdService {
+	return &householdServiceImpl{householdRepo, personRepo, uow, audit}
+}
+
+func (s *householdServiceImpl) CreateHousehold(household *domain.Household, actor domain.Actor) (_ *domain.Household, err error) {
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditHouseholdCreate, domain.AuditHouseholdEntity, household.ID, householdChanges(nil, household), err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if strings.TrimSpace(household.Name) == "" {
+		return nil, fmt.Errorf("%w: name is required", ports.ErrInvalidHousehold)
+	}
+
+	// El jefe de hogar se agrega como primer miembro.
+	var head *domain.Person
+	if household.HeadPersonID != nil {
+		if head, err = s.findPerson(*household.HeadPersonID); err != nil {
+			return nil, err
+		}
+		if head.HouseholdID != nil {
+			return nil, fmt.Errorf("%w: person %d already belongs to household %d", ports.ErrInvalidHousehold, head.ID, *head.HouseholdID)
+		}
+	}
+
+	household.ID = 0
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Households.Save(household); err != nil {
+			return err
+		}
+		if head != nil {
+			if err := setMembership(repos, head, &household.ID, actor); err != nil {
+				return err
+			}
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditHouseholdCreate, domain.AuditHouseholdEntity, household.ID, householdChanges(nil, household), nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetHousehold(household.ID)
+}
+
+func (s *householdServiceImpl) UpdateHousehold(household *domain.Household, actor domain.Actor) (_ *domain.Household, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditHouseholdUpdate, domain.AuditHouseholdEntity, household.ID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if strings.TrimSpace(household.Name) == "" {
+		return nil, fmt.Errorf("%w: name is required", ports.ErrInvalidHousehold)
+	}
+
+	existing, err := s.GetHousehold(household.ID)
+	if err != nil {
+		return nil, err
+	}
+	if household.HeadPersonID != nil && !isMember(existing, *household.HeadPersonID) {
+		return nil, fmt.Errorf("%w: the head of household must be a member", ports.ErrInvalidHousehold)
+	}
+
+	updated := *existing
+	updated.Name = household.Name
+	updated.HeadPersonID = household.HeadPersonID
+	changes = householdChanges(existing, &updated)
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Households.Save(&updated); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditHouseholdUpdate, domain.AuditHouseholdEntity, household.ID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (s *householdServiceImpl) DeleteHousehold(id uint, actor domain.Actor) (err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditHouseholdDelete, domain.AuditHouseholdEntity, id, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return ports.ErrForbidden
+	}
+	household, err := s.GetHousehold(id)
+	if err != nil {
+		return err
+	}
+
+	changes = householdChanges(household, nil)
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Households.Delete(id); err != nil {
+			return err
+		}
+		// El repositorio ya desvinculó a los miembros; se registra en su historial.
+		for i := range household.Members {
+			member := &household.Members[i]
+			before := personSnapshot(member)
+			member.HouseholdID = nil
+			if _, err := recordVersion(repos.Versions, domain.PersonEntity, member.ID, member.ID, domain.VersionUpdated, before, personSnapshot(member), actor); err != nil {
+				return err
+			}
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditHouseholdDelete, domain.AuditHouseholdEntity, id, changes, nil))
+	})
+}
+
+func (s *householdServiceImpl) GetHousehold(id uint) (*domain.Household, error) {
+	household, err := s.householdRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrHouseholdNotFound
+		}
+		return nil, err
+	}
+	return household, nil
+}
+
+func (s *householdServiceImpl) SearchHouseholds(searchTerm string) ([]domain.Household, error) {
+	return s.householdRepo.Search(strings.TrimSpace(searchTerm))
+}
+
+func (s *householdServiceImpl) AddMembers(householdID uint, personIDs []uint, actor domain.Actor) (_ *domain.Household, err error) {
+	changes := map[string]domain.FieldChange{"personIds": {New: textValue(joinIDs(personIDs))}}
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditHouseholdMemberAdd, domain.AuditHouseholdEntity, householdID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if len(personIDs) == 0 {
+		return nil, fmt.Errorf("%w: personIds is required", ports.ErrInvalidHousehold)
+	}
+	if _, err := s.GetHousehold(householdID); err != nil {
+		return nil, err
+	}
+
+	// Se valida a todas las personas antes de modificar a ninguna.
+	persons := make([]*domain.Person, 0, len(personIDs))
+	for _, id := range personIDs {
+		person, err := s.findPerson(id)
+		if err != nil {
+			return nil, err
+		}
+		if person.HouseholdID != nil && *person.HouseholdID != householdID {
+			return nil, fmt.Errorf("%w: person %d already belongs to household %d", ports.ErrInvalidHousehold, id, *person.HouseholdID)
+		}
+		persons = append(persons, person)
+	}
+
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		for _, person := range persons {
+			if person.HouseholdID != nil {
+				continue // Ya es miembro.
+			}
+			if err := setMembership(repos, person, &householdID, actor); err != nil {
+				return err
+			}
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditHouseholdMemberAdd, domain.AuditHouseholdEntity, householdID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetHousehold(householdID)
+}
+
+func (s *householdServiceImpl) RemoveMember(householdID, personID uint, actor domain.Actor) (err error) {
+	changes := map[string]domain.FieldChange{"personIds": {Old: textValue(joinIDs([]uint{personID}))}}
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditHouseholdMemberRemove, domain.AuditHouseholdEntity, householdID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return ports.ErrForbidden
+	}
+	household, err := s.GetHousehold(householdID)
+	if err != nil {
+		return err
+	}
+	person, err := s.findPerson(personID)
+	if err != nil {
+		return err
+	}
+	if person.HouseholdID == nil || *person.HouseholdID != householdID {
+		return ports.ErrPersonNotFound
+	}
+
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := setMembership(repos, person, nil, actor); err != nil {
+			return err
+		}
+		// Si se retira al jefe de hogar, el hogar queda sin jefe.
+		if household.HeadPersonID != nil && *household.HeadPersonID == personID {
+			household.HeadPersonID = nil
+			if err := repos.Households.Save(household); err != nil {
+				return err
+			}
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditHouseholdMemberRemove, domain.AuditHouseholdEntity, householdID, changes, nil))
+	})
+}
+
+func (s *householdServiceImpl) AddAddress(householdID uint, address string, actor domain.Actor) (_ *domain.HouseholdAddress, err error) {
+	created := &domain.HouseholdAddress{HouseholdID: householdID, Address: strings.TrimSpace(address)}
+	changes := map[string]domain.FieldChange{"address": {New: textValue(created.Address)}}
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditHouseholdContactAdd, domain.AuditHouseholdEntity, householdID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if created.Address == "" {
+		return nil, fmt.Errorf("%w: address is required", ports.ErrInvalidHousehold)
+	}
+	if _, err := s.GetHousehold(householdID); err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Households.SaveAddress(created); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditHouseholdContactAdd, domain.AuditHouseholdEntity, householdID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *householdServiceImpl) RemoveAddress(householdID, addressID uint, actor domain.Actor) (err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		err = auditResult(err, s.audit.Record(newAuditEntry(actor, domain.AuditHouseholdContactRemove, domain.AuditHouseholdEntity, householdID, changes, err)))
+	}()
+
+	if !actor.IsAdmin() {
+		return ports.ErrForbidden
+	}
+	address, err := s.householdRepo.FindAddressByID(addressID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return ports.ErrHouseholdContactNotFound
+		}
+		return err
+	}
+	if address.HouseholdID != householdID {
+		return ports.ErrHouseholdContactNotFound
+	}
+
+	changes = map[string]domain.FieldChange{"address": {Old: textValue(address.Address)}}
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Households.DeleteAddress(addressID); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditHouseholdContactRemove, domain.AuditHouseholdEntity, householdID, changes, nil))
+	})
+}
+
+func (s *householdServiceImpl) AddPhone(householdID uint, phone string, actor domain.Actor) (_ *domain.HouseholdPhone, err error) {
+	created := &domain.HouseholdPhone{HouseholdID: householdID, Phone: strings.TrimSpace(phone)}
+	changes := map[string]domain.FieldChange{"phone": {New: textValue(created.Phone)}}
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditHouseholdContactAdd, domain.AuditHouseholdEntity, householdID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if created.Phone == "" {
+		return nil, fmt.Errorf("%w: phone is required", ports.ErrInvalidHousehold)
+	}
+	if _, err := s.GetHousehold(householdID); err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Households.SavePhone(created); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditHouseholdContactAdd, domain.AuditHouseholdEntity, householdID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *householdServiceImpl) RemovePhone(householdID, phoneID uint, actor domain.Actor) (err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		err = auditResult(err, s.audit.Record(newAuditEntry(actor, domain.AuditHouseholdContactRemove, domain.AuditHouseholdEntity, householdID, changes, err)))
+	}()
+
+	if !actor.IsAdmin() {
+		return ports.ErrForbidden
+	}
+	phone, err := s.householdRepo.FindPhoneByID(phoneID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return ports.ErrHouseholdContactNotFound
+		}
+		return err
+	}
+	if phone.HouseholdID != householdID {
+		return ports.ErrHouseholdContactNotFound
+	}
+
+	changes = map[string]domain.FieldChange{"phone": {Old: textValue(phone.Phone)}}
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Households.DeletePhone(phoneID); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditHouseholdContactRemove, domain.AuditHouseholdEntity, householdID, changes, nil))
+	})
+}
+
+// addressGroup son las direcciones iguales (tras normalizar) de personas distintas.
+type addressGroup struct {
+	key       string
+	addresses []domain.Address
+	personIDs []uint
+}
+
+func (s *householdServiceImpl) MigrateSharedAddresses(dryRun bool, actor domain.Actor) (report *domain.HouseholdMigrationReport, err error) {
+	defer func() {
+		if dryRun {
+			return // La simulación no modifica datos, no se audita.
+		}
+		var changes map[string]domain.FieldChange
+		if report != nil {
+			changes = map[string]domain.FieldChange{
+				"householdsCreated": {New: textValue(strconv.Itoa(report.HouseholdsCreated))},
+				"personsGrouped":    {New: textValue(strconv.Itoa(report.PersonsGrouped))},
+				"addressesMerged":   {New: textValue(strconv.Itoa(report.AddressesMerged))},
+			}
+		}
+		recordSummary(s.audit, newAuditEntry(actor, domain.AuditHouseholdMigrate, domain.AuditHouseholdEntity, 0, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+
+	addresses, err := s.householdRepo.FindUngroupedAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	report = &domain.HouseholdMigrationReport{DryRun: dryRun}
+	for _, group := range groupSharedAddresses(addresses) {
+		report.HouseholdsCreated++
+		report.PersonsGrouped += len(group.personIDs)
+		report.AddressesMerged += len(group.addresses)
+		if dryRun {
+			continue
+		}
+		if err := s.migrateGroup(group, actor); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// migrateGroup crea el hogar de un grupo, con el primer miembro como jefe de hogar,
+// y reemplaza las direcciones individuales por una sola dirección compartida, con su
+// ubicación estructurada. Cada grupo se migra en una transacción: si falla, las
+// personas conservan sus direcciones y una nueva ejecución las vuelve a agrupar.
+func (s *householdServiceImpl) migrateGroup(group addressGroup, actor domain.Actor) error {
+	persons := make([]*domain.Person, 0, len(group.personIDs))
+	for _, personID := range group.personIDs {
+		person, err := s.findPerson(personID)
+		if err != nil {
+			return err
+		}
+		persons = append(persons, person)
+	}
+	head := persons[0]
+
+	household := &domain.Household{Name: "Familia " + head.LastName, HeadPersonID: &head.ID}
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Households.Save(household); err != nil {
+			return err
+		}
+		shared := domain.NewHouseholdAddress(household.ID, sharedAddressSource(group.addresses))
+		shared.Address = strings.TrimSpace(shared.Address)
+		if err := repos.Households.SaveAddress(&shared); err != nil {
+			return err
+		}
+
+		for i := range group.addresses {
+			address := &group.addresses[i]
+			if err := repos.Addresses.Delete(address.ID); err != nil {
+				return err
+			}
+			if _, err := recordVersion(repos.Versions, domain.AddressEntity, address.ID, address.PersonID, domain.VersionDeleted, addressSnapshot(address), nil, actor); err != nil {
+				return err
+			}
+		}
+
+		for _, person := range persons {
+			if err := setMembership(repos, person, &household.ID, actor); err != nil {
+				return err
+			}
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditHouseholdCreate, domain.AuditHouseholdEntity, household.ID, householdChanges(nil, household), nil))
+	})
+}
+
+// sharedAddressSource elige la dirección del grupo que se copia al hogar: la más
+// completa, para no perder el distrito ni las coordenadas que tenga alguna de ellas.
+func sharedAddressSource(addresses []domain.Address) *domain.Address {
+	best, bestScore := &addresses[0], -1
+	for i := range addresses {
+		a := &addresses[i]
+		score := 0
+		for _, field := range []string{a.Street, a.Number, a.Reference, a.Ubigeo, a.District, a.PostalCode} {
+			if field != "" {
+				score++
+			}
+		}
+		if a.Point() != nil {
+			score += 2
+		}
+		if score > bestScore {
+			best, bestScore = a, score
+		}
+	}
+	return best
+}
+
+// groupSharedAddresses agrupa las direcciones por su texto normalizado y devuelve
+// solo los grupos con al menos dos personas distintas, en orden determinista.
+func groupSharedAddresses(addresses []domain.Address) []addressGroup {
+	byKey := make(map[string]*addressGroup)
+	var keys []string
+	for _, address := range addresses {
+		key := normalizeAddress(address.Address)
+		if key == "" {
+			continue
+		}
+		group, ok := byKey[key]
+		if !ok {
+			group = &addressGroup{key: key}
+			byKey[key] = group
+			keys = append(keys, key)
+		}
+		group.addresses = append(group.addresses, address)
+		if !containsID(group.personIDs, address.PersonID) {
+			group.personIDs = append(group.personIDs, address.PersonID)
+		}
+	}
+
+	// Una persona con la misma dirección en dos grupos solo entra en el primero.
+	assigned := make(map[uint]bool)
+	sort.Strings(keys)
+	var groups []addressGroup
+	for _, key := range keys {
+		group := byKey[key]
+		if len(group.personIDs) < 2 {
+			continue
+		}
+		conflict := false
+		for _, id := range group.personIDs {
+			conflict = conflict || assigned[id]
+		}
+		if conflict {
+			continue
+		}
+		for _, id := range group.personIDs {
+			assigned[id] = true
+		}
+		sort.Slice(group.personIDs, func(i, j int) bool { return group.personIDs[i] < group.personIDs[j] })
+		groups = append(groups, *group)
+	}
+	return groups
+}
+
+// normalizeAddress compara direcciones sin distinguir mayúsculas ni espacios.
+func normalizeAddress(address string) string {
+	return strings.ToLower(strings.Join(strings.Fields(address), " "))
+}
+
+// setMembership cambia el hogar de la persona y lo registra en su historial, con los
+// repositorios de la transacción en curso.
+func setMembership(repos ports.Repositories, person *domain.Person, householdID *uint, actor domain.Actor) error {
+	before := personSnapshot(person)
+	if err := repos.Households.SetHousehold([]uint{person.ID}, householdID); err != nil {
+		return err
+	}
+	person.HouseholdID = householdID
+	_, err := recordVersion(repos.Versions, domain.PersonEntity, person.ID, person.ID, domain.VersionUpdated, before, personSnapshot(person), actor)
+	return err
+}
+
+func (s *householdServiceImpl) findPerson(id uint) (*domain.Person, error) {
+	person, err := s.personRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrPersonNotFound
+		}
+		return nil, err
+	}
+	return person, nil
+}
+
+func householdChanges(before, after *domain.Household) map[string]domain.FieldChange {
+	snapshot := func(h *domain.Household) map[string]*string {
+		if h == nil {
+			return nil
+		}
+		values := map[string]*string{"name": textValue(h.Name), "headPersonId": nil}
+		if h.HeadPersonID != nil {
+			values["headPersonId"] = textValue(strconv.FormatUint(uint64(*h.HeadPersonID), 10))
+		}
+		return values
+	}
+	return diffSnapshots(snapshot(before), snapshot(after))
+}
+
+func isMember(household *domain.Household, personID uint) bool {
+	for _, member := range household.Members {
+		if member.ID == personID {
+			return true
+		}
+	}
+	return false
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func joinIDs(ids []uint) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/repository/memory"
+)
+
+// failingVersions simula un error al guardar el historial, a mitad de la transacción.
+type failingVersions struct {
+	ports.VersionRepository
+}
+
+func (failingVersions) Save(*domain.EntityVersion) error {
+	return errors.New("disk full")
+}
+
+type failingVersionsUnitOfWork struct {
+	ports.UnitOfWork
+}
+
+func (u failingVersionsUnitOfWork) Do(fn func(repos ports.Repositories) error) error {
+	return u.UnitOfWork.Do(func(repos ports.Repositories) error {
+		repos.Versions = failingVersions{repos.Versions}
+		return fn(repos)
+	})
+}
+
+// householdFixture arma el servicio de hogares sobre los repositorios en memoria.
+type householdFixture struct {
+	households ports.HouseholdService
+	store      *memory.Store
+	personRepo ports.PersonRepository
+}
+
+func newHouseholdFixture() *householdFixture {
+	store := memory.NewStore()
+	personRepo := memory.NewPersonRepository(store)
+	return &householdFixture{
+		households: NewHouseholdService(memory.NewHouseholdRepository(store), personRepo, memory.NewUnitOfWork(store), newAuditService(store)),
+		store:      store,
+		personRepo: personRepo,
+	}
+}
+
+// createNeighbors crea dos personas con la misma dirección, una de ellas geocodificada.
+func createNeighbors(t *testing.T, f *householdFixture) (*domain.Person, *domain.Person) {
+	t.Helper()
+	lat, lng := -12.0776, -77.0469
+	quispe := addPerson(t, f.personRepo, &domain.Person{Name: "Rosa", LastName: "Quispe", Addresses: []domain.Address{
+		{Address: "Av. Brasil 1234, Jesús María", Street: "Av. Brasil", Number: "1234", Ubigeo: "150113", District: "Jesús María", Latitude: &lat, Longitude: &lng},
+	}})
+	mamani := addPerson(t, f.personRepo, &domain.Person{Name: "Juan", LastName: "Mamani", Addresses: []domain.Address{
+		{Address: "Av. Brasil 1234, Jesús María", Street: "Av. Brasil", Number: "1234", Ubigeo: "150113", District: "Jesús María"},
+	}})
+	return quispe, mamani
+}
+
+func TestMigrateSharedAddressesKeepsTheLocation(t *testing.T) {
+	f := newHouseholdFixture()
+	quispe, mamani := createNeighbors(t, f)
+
+	report, err := f.households.MigrateSharedAddresses(false, admin)
+	if err != nil {
+		t.Fatalf("MigrateSharedAddresses: %v", err)
+	}
+	if report.HouseholdsCreated != 1 || report.PersonsGrouped != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	person, err := f.personRepo.FindByID(mamani.ID)
+	if err != nil || person.HouseholdID == nil || len(person.Addresses) != 0 {
+		t.Fatalf("person should be grouped without its own address, got %+v, %v", person, err)
+	}
+	household, err := f.households.GetHousehold(*person.HouseholdID)
+	if err != nil {
+		t.Fatalf("GetHousehold: %v", err)
+	}
+	if household.HeadPersonID == nil || *household.HeadPersonID != quispe.ID || len(household.Addresses) != 1 {
+		t.Fatalf("household = %+v", household)
+	}
+	shared := household.Addresses[0]
+	if shared.Street != "Av. Brasil" || shared.Number != "1234" || shared.Ubigeo != "150113" || shared.District != "Jesús María" ||
+		shared.Latitude == nil || shared.Longitude == nil {
+		t.Fatalf("the shared address lost its location: %+v", shared)
+	}
+}
+
+func TestMigrateSharedAddressesRollsBackAGroup(t *testing.T) {
+	f := newHouseholdFixture()
+	quispe, mamani := createNeighbors(t, f)
+	households := NewHouseholdService(memory.NewHouseholdRepository(f.store), f.personRepo,
+		failingVersionsUnitOfWork{memory.NewUnitOfWork(f.store)}, newAuditService(f.store))
+
+	if _, err := households.MigrateSharedAddresses(false, admin); err == nil {
+		t.Fatal("the migration should fail")
+	}
+	for _, id := range []uint{quispe.ID, mamani.ID} {
+		person, err := f.personRepo.FindByID(id)
+		if err != nil || person.HouseholdID != nil || len(person.Addresses) != 1 {
+			t.Fatalf("a failed group should leave the person untouched, got %+v, %v", person, err)
+		}
+	}
+	if found, err := f.households.SearchHouseholds(""); err != nil || len(found) != 0 {
+		t.Fatalf("a failed group should not create a household, got %+v, %v", found, err)
+	}
+
+	// Una nueva ejecución agrupa a las mismas personas.
+	if report, err := f.households.MigrateSharedAddresses(false, admin); err != nil || report.HouseholdsCreated != 1 {
+		t.Fatalf("rerun = %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,309 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+type noteServiceImpl struct {
+	noteRepo    ports.NoteRepository
+	personRepo  ports.PersonRepository
+	userRepo    ports.UserRepository
+	versionRepo ports.VersionRepository
+	policy      ports.Policy
+	uow         ports.UnitOfWork
+	audit       ports.AuditService
+}
+
+func NewNoteService(noteRepo ports.NoteRepository, personRepo ports.PersonRepository, userRepo ports.UserRepository, versionRepo ports.VersionRepository, policy ports.Policy, uow ports.UnitOfWork, audit ports.AuditService) ports.NoteService {
+	return &noteServiceImpl{noteRepo, personRepo, userRepo, versionRepo, policy, uow, audit}
+}
+
+func (s *noteServiceImpl) CreateNote(note *domain.Note, actor domain.Actor) (_ *domain.Note, err error) {
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditNoteCreate, domain.AuditNoteEntity, note.ID, noteChanges(nil, note), err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	if _, err := findPerson(s.personRepo, note.PersonID); err != nil {
+		return nil, err
+	}
+
+	note.ID = 0
+	note.AuthorID = actor.UserID
+	note.CompletedAt = nil
+	if note.Date.IsZero() {
+		note.Date = time.Now()
+	}
+	if err := s.validateNote(note); err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Notes.Save(note); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditNoteCreate, domain.AuditNoteEntity, note.ID, noteChanges(nil, note), nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+func (s *noteServiceImpl) UpdateNote(note *domain.Note, actor domain.Actor) (_ *domain.Note, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditNoteUpdate, domain.AuditNoteEntity, note.ID, changes, err))
+	}()
+
+	existing, err := s.findNote(note.ID, actor)
+	if err != nil {
+		return nil, err
+	}
+	// Solo el autor puede cambiar lo que escribió.
+	if existing.AuthorID != actor.UserID {
+		return nil, ports.ErrForbidden
+	}
+
+	// La persona, el autor y el estado de la tarea no se cambian al editar.
+	note.PersonID = existing.PersonID
+	note.AuthorID = existing.AuthorID
+	note.CompletedAt = existing.CompletedAt
+	note.CreatedAt = existing.CreatedAt
+	if note.Date.IsZero() {
+		note.Date = existing.Date
+	}
+	if err := s.validateNote(note); err != nil {
+		return nil, err
+	}
+
+	changes = noteChanges(existing, note)
+	if err := s.saveNote(note, newAuditEntry(actor, domain.AuditNoteUpdate, domain.AuditNoteEntity, note.ID, changes, nil)); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+func (s *noteServiceImpl) DeleteNote(id uint, actor domain.Actor) (err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditNoteDelete, domain.AuditNoteEntity, id, changes, err))
+	}()
+
+	note, err := s.findNote(id, actor)
+	if err != nil {
+		return err
+	}
+	if note.AuthorID != actor.UserID && !actor.IsAdmin() {
+		return ports.ErrForbidden
+	}
+
+	changes = noteChanges(note, nil)
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Notes.Delete(id); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditNoteDelete, domain.AuditNoteEntity, id, changes, nil))
+	})
+}
+
+func (s *noteServiceImpl) CompleteNote(id uint, actor domain.Actor) (_ *domain.Note, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditNoteComplete, domain.AuditNoteEntity, id, changes, err))
+	}()
+
+	note, err := s.findNote(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	isAssignee := note.AssigneeID != nil && *note.AssigneeID == actor.UserID
+	if note.AuthorID != actor.UserID && !isAssignee {
+		return nil, ports.ErrForbidden
+	}
+	if note.DueDate == nil {
+		return nil, fmt.Errorf("%w: the note is not a follow-up task", ports.ErrInvalidNote)
+	}
+	if note.CompletedAt != nil {
+		return note, nil
+	}
+
+	before := *note
+	now := time.Now()
+	note.CompletedAt = &now
+	changes = noteChanges(&before, note)
+	if err := s.saveNote(note, newAuditEntry(actor, domain.AuditNoteComplete, domain.AuditNoteEntity, id, changes, nil)); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// saveNote guarda una nota existente junto con la entrada de auditoría del cambio.
+func (s *noteServiceImpl) saveNote(note *domain.Note, entry domain.AuditEntry) error {
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Notes.Save(note); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, entry)
+	})
+}
+
+func (s *noteServiceImpl) ListNotes(personID uint, actor domain.Actor) ([]domain.Note, error) {
+	if _, err := s.findVisiblePerson(personID, actor); err != nil {
+		return nil, err
+	}
+	return s.readableNotes(personID, actor)
+}
+
+func (s *noteServiceImpl) ListAssignedNotes(actor domain.Actor, openOnly bool) ([]domain.Note, error) {
+	return s.noteRepo.FindAssigned(actor.UserID, openOnly)
+}
+
+func (s *noteServiceImpl) GetTimeline(personID uint, actor domain.Actor) ([]domain.TimelineEntry, error) {
+	if _, err := s.findVisiblePerson(personID, actor); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.readableNotes(personID, actor)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.versionRepo.FindByPersonID(personID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := make([]domain.TimelineEntry, 0, len(notes)+len(versions))
+	for i := range notes {
+		timeline = append(timeline, domain.TimelineEntry{At: notes[i].Date, Note: &notes[i]})
+	}
+	for i := range versions {
+		timeline = append(timeline, domain.TimelineEntry{At: versions[i].CreatedAt, Version: &versions[i]})
+	}
+	sort.SliceStable(timeline, func(i, j int) bool { return timeline[i].At.After(timeline[j].At) })
+	return timeline, nil
+}
+
+// findVisiblePerson devuelve la persona si la política permite al actor ver sus notas.
+func (s *noteServiceImpl) findVisiblePerson(personID uint, actor domain.Actor) (*domain.Person, error) {
+	return findAuthorizedPerson(s.personRepo, s.policy, personID, ports.NoteResource, ports.ActionRead, actor)
+}
+
+// readableNotes devuelve las notas de la persona que el actor puede leer.
+func (s *noteServiceImpl) readableNotes(personID uint, actor domain.Actor) ([]domain.Note, error) {
+	notes, err := s.noteRepo.FindByPerson(personID)
+	if err != nil {
+		return nil, err
+	}
+	readable := make([]domain.Note, 0, len(notes))
+	for _, note := range notes {
+		if note.CanRead(actor) {
+			readable = append(readable, note)
+		}
+	}
+	return readable, nil
+}
+
+// findNote devuelve la nota si existe y el actor puede leerla. Una nota que no puede
+// leer se informa como inexistente para no revelar que existe.
+func (s *noteServiceImpl) findNote(id uint, actor domain.Actor) (*domain.Note, error) {
+	note, err := s.noteRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrNoteNotFound
+		}
+		return nil, err
+	}
+	if !note.CanRead(actor) {
+		return nil, ports.ErrNoteNotFound
+	}
+	return note, nil
+}
+
+// validateNote normaliza la nota y comprueba tipo, visibilidad y la tarea asociada.
+func (s *noteServiceImpl) validateNote(note *domain.Note) error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ports.ErrInvalidNote, fmt.Sprintf(format, args...))
+	}
+
+	note.Content = strings.TrimSpace(note.Content)
+	if note.Content == "" {
+		return invalid("content is required")
+	}
+	if note.Type == "" {
+		note.Type = domain.OtherNote
+	}
+	if !note.Type.IsValid() {
+		return invalid("unknown type %q", note.Type)
+	}
+	if note.Visibility == "" {
+		note.Visibility = domain.RestrictedNote
+	}
+	if !note.Visibility.IsValid() {
+		return invalid("unknown visibility %q", note.Visibility)
+	}
+
+	if note.Visibility == domain.RestrictedNote {
+		if note.RestrictedTo == "" {
+			note.RestrictedTo = domain.AdminRole
+		}
+		if note.RestrictedTo != domain.AdminRole && note.RestrictedTo != domain.UserRole {
+			return invalid("unknown role %q", note.RestrictedTo)
+		}
+	} else {
+		note.RestrictedTo = ""
+	}
+
+	if note.AssigneeID != nil && note.DueDate == nil {
+		return invalid("an assigned note needs a due date")
+	}
+	if note.AssigneeID != nil {
+		if _, err := s.userRepo.FindByID(*note.AssigneeID); err != nil {
+			if errors.Is(err, ports.ErrNotFound) {
+				return invalid("assignee %d does not exist", *note.AssigneeID)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// noteChanges registra los metadatos de la nota. El contenido nunca se audita para no
+// copiar información confidencial al registro de auditoría.
+func noteChanges(before, after *domain.Note) map[string]domain.FieldChange {
+	snapshot := func(n *domain.Note) map[string]*string {
+		if n == nil {
+			return nil
+		}
+		values := map[string]*string{
+			"personId":     textValue(fmt.Sprint(n.PersonID)),
+			"type":         textValue(string(n.Type)),
+			"visibility":   textValue(string(n.Visibility)),
+			"restrictedTo": textValue(string(n.RestrictedTo)),
+			"date":         textValue(n.Date.Format(time.RFC3339)),
+			"dueDate":      nil,
+			"assigneeId":   nil,
+			"completedAt":  nil,
+		}
+		if n.DueDate != nil {
+			values["dueDate"] = textValue(n.DueDate.Format(dateKeyLayout))
+		}
+		if n.AssigneeID != nil {
+			values["assigneeId"] = textValue(fmt.Sprint(*n.AssigneeID))
+		}
+		if n.CompletedAt != nil {
+			values["completedAt"] = textValue(n.CompletedAt.Format(time.RFC3339))
+		}
+		return values
+	}
+	return diffSnapshots(snapshot(before), snapshot(after))
+}
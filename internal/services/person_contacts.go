@@ -0,0 +1,119 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// replaceAddresses deja las direcciones propias de la persona iguales a incoming:
+// agrega las que no tienen ID, actualiza las que cambiaron y elimina las que no vienen.
+// Cada cambio queda en el historial. Debe ejecutarse dentro de una unidad de trabajo.
+func replaceAddresses(repos ports.Repositories, personID uint, current, incoming []domain.Address, actor domain.Actor) error {
+	existing := make(map[uint]*domain.Address, len(current))
+	for i := range current {
+		existing[current[i].ID] = &current[i]
+	}
+
+	kept := make(map[uint]bool, len(incoming))
+	for i := range incoming {
+		address := &incoming[i]
+		address.PersonID = personID
+		var before map[string]*string
+		action := domain.VersionCreated
+		if address.ID != 0 {
+			old, ok := existing[address.ID]
+			if !ok {
+				return fmt.Errorf("%w: address %d does not belong to the person", ports.ErrAddressNotFound, address.ID)
+			}
+			if kept[address.ID] {
+				return &ports.ValidationError{Err: ports.ErrInvalidAddress, Field: "addresses", Code: ports.InvalidOrderCode,
+					Message: fmt.Sprintf("the address %d is repeated", address.ID)}
+			}
+			kept[address.ID] = true
+			before = addressSnapshot(old)
+			action = domain.VersionUpdated
+			// Sin cambios ni en los datos ni en el orden, no se vuelve a guardar.
+			if old.SortOrder == address.SortOrder && len(diffSnapshots(before, addressSnapshot(address))) == 0 {
+				*address = *old
+				continue
+			}
+			address.CreatedAt = old.CreatedAt
+		}
+		if err := repos.Addresses.Save(address); err != nil {
+			return err
+		}
+		if _, err := recordVersion(repos.Versions, domain.AddressEntity, address.ID, personID, action, before, addressSnapshot(address), actor); err != nil {
+			return err
+		}
+	}
+
+	for i := range current {
+		address := &current[i]
+		if kept[address.ID] {
+			continue
+		}
+		if err := repos.Addresses.Delete(address.ID); err != nil {
+			return err
+		}
+		if _, err := recordVersion(repos.Versions, domain.AddressEntity, address.ID, personID, domain.VersionDeleted, addressSnapshot(address), nil, actor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replacePhones hace lo mismo que replaceAddresses con los teléfonos de la persona.
+func replacePhones(repos ports.Repositories, personID uint, current, incoming []domain.Phone, actor domain.Actor) error {
+	existing := make(map[uint]*domain.Phone, len(current))
+	for i := range current {
+		existing[current[i].ID] = &current[i]
+	}
+
+	kept := make(map[uint]bool, len(incoming))
+	for i := range incoming {
+		phone := &incoming[i]
+		phone.PersonID = personID
+		var before map[string]*string
+		action := domain.VersionCreated
+		if phone.ID != 0 {
+			old, ok := existing[phone.ID]
+			if !ok {
+				return fmt.Errorf("%w: phone %d does not belong to the person", ports.ErrPhoneNotFound, phone.ID)
+			}
+			if kept[phone.ID] {
+				return &ports.ValidationError{Err: ports.ErrInvalidPhone, Field: "phones", Code: ports.InvalidOrderCode,
+					Message: fmt.Sprintf("the phone %d is repeated", phone.ID)}
+			}
+			kept[phone.ID] = true
+			before = phoneSnapshot(old)
+			action = domain.VersionUpdated
+			if old.SortOrder == phone.SortOrder && len(diffSnapshots(before, phoneSnapshot(phone))) == 0 {
+				*phone = *old
+				continue
+			}
+			phone.CreatedAt = old.CreatedAt
+		}
+		if err := repos.Phones.Save(phone); err != nil {
+			return err
+		}
+		if _, err := recordVersion(repos.Versions, domain.PhoneEntity, phone.ID, personID, action, before, phoneSnapshot(phone), actor); err != nil {
+			return err
+		}
+	}
+
+	for i := range current {
+		phone := &current[i]
+		if kept[phone.ID] {
+			continue
+		}
+		if err := repos.Phones.Delete(phone.ID); err != nil {
+			return err
+		}
+		if _, err := recordVersion(repos.Versions, domain.PhoneEntity, phone.ID, personID, domain.VersionDeleted, phoneSnapshot(phone), nil, actor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,30 +2,57 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/riada2/internal/core/domain"
 	"github.com/riada2/internal/core/ports"
-	"gorm.io/gorm"
 )
 
+// maxSearchResults es el límite por defecto de la búsqueda de personas.
+const maxSearchResults = 300
+
 type personServiceImpl struct {
-	personRepo ports.PersonRepository
+	personRepo      ports.PersonRepository
+	uow             ports.UnitOfWork
+	customFieldRepo ports.CustomFieldRepository
+	catalog         ports.UbigeoCatalog
+	geocoder        ports.Geocoder
+	countryCode     string
+	settings        ports.SettingsService
+	policy          ports.Policy
+	audit           ports.AuditService
+}
+
+func NewPersonService(personRepo ports.PersonRepository, uow ports.UnitOfWork, customFieldRepo ports.CustomFieldRepository, catalog ports.UbigeoCatalog, geocoder ports.Geocoder, countryCode string, settings ports.SettingsService, policy ports.Policy, audit ports.AuditService) ports.PersonService {
+	return &personServiceImpl{personRepo, uow, customFieldRepo, catalog, geocoder, countryCode, settings, policy, audit}
 }
 
-func NewPersonService(personRepo ports.PersonRepository) ports.PersonService {
-	return &personServiceImpl{personRepo}
+// validateCustomFields valida y normaliza los campos personalizados de la persona
+// según las definiciones vigentes.
+func (s *personServiceImpl) validateCustomFields(person *domain.Person) error {
+	definitions, err := s.customFieldRepo.List()
+	if err != nil {
+		return err
+	}
+	person.CustomFields, err = validateCustomFieldValues(definitions, person.CustomFields)
+	return err
 }
 
 // checkDocumentUniqueness valida que la combinación de TypeDoc y DocNumber sea única.
-// NOTA: Esto asume que un nuevo método `FindByDocument` existe en la interfaz `ports.PersonRepository`.
 func (s *personServiceImpl) checkDocumentUniqueness(person *domain.Person) error {
+	return checkDocumentUniqueness(s.personRepo, person)
+}
+
+// checkDocumentUniqueness es compartida con el historial, que también guarda personas al revertir.
+func checkDocumentUniqueness(personRepo ports.PersonRepository, person *domain.Person) error {
 	if person.TypeDoc == nil || person.DocNumber == nil || *person.DocNumber == "" {
 		return nil // No hay documento para verificar, se omite la validación.
 	}
 
-	existing, err := s.personRepo.FindByDocument(*person.TypeDoc, *person.DocNumber)
+	existing, err := personRepo.FindByDocument(*person.TypeDoc, *person.DocNumber)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, ports.ErrNotFound) {
 			return nil // El documento no existe, lo cual es correcto.
 		}
 		return err // Otro error de base de datos.
@@ -40,34 +67,59 @@ func (s *personServiceImpl) checkDocumentUniqueness(person *domain.Person) error
 	return nil
 }
 
-func (s *personServiceImpl) CreateOrUpdatePersonForUser(person *domain.Person) (*domain.Person, error) {
+// savePerson guarda la persona. La comprobación previa del documento no basta si dos
+// peticiones lo registran a la vez; entonces la clave única de la base de datos lo
+// rechaza y se informa igual que un documento repetido.
+func savePerson(personRepo ports.PersonRepository, person *domain.Person) error {
+	err := personRepo.Save(person)
+	if errors.Is(err, ports.ErrConflict) {
+		return ports.ErrPersonDocumentExists
+	}
+	return err
+}
+
+func (s *personServiceImpl) CreateOrUpdatePersonForUser(person *domain.Person, actor domain.Actor) (_ *domain.Person, err error) {
 	if person.UserID == nil {
 		return nil, errors.New("UserID is required to create or update a person for a user")
 	}
 
 	// Si el ID de la persona es 0, es una creación.
 	if person.ID == 0 {
-		if err := s.checkDocumentUniqueness(person); err != nil {
-			return nil, err
-		}
-		err := s.personRepo.Save(person)
-		return person, err
+		return s.create(person, actor)
 	}
+	return s.update(person, actor)
+}
+
+func (s *personServiceImpl) UpdatePerson(person *domain.Person, actor domain.Actor) (*domain.Person, error) {
+	return s.update(person, actor)
+}
+
+// update reemplaza los datos de un registro existente. Si la petición trae direcciones
+// o teléfonos, las listas completas reemplazan a las guardadas; si no (nil), no cambian.
+// El usuario vinculado nunca cambia. Todo se guarda en una sola transacción.
+func (s *personServiceImpl) update(person *domain.Person, actor domain.Actor) (_ *domain.Person, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditPersonUpdate, string(domain.PersonEntity), person.ID, changes, err))
+	}()
 
 	// Si se proporciona un ID, es una operación de actualización.
 	existingPerson, err := s.personRepo.FindByID(person.ID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, ports.ErrNotFound) {
 			return nil, ports.ErrPersonNotFound
 		}
 		return nil, err
 	}
 
-	// Comprobación de seguridad:
-	if existingPerson.UserID == nil || *existingPerson.UserID != *person.UserID {
-		return nil, errors.New("authorization failed: you can only update your own person record")
+	// Comprobación de seguridad: la política decide sobre el registro guardado, no sobre el recibido.
+	if err := s.policy.Authorize(actor, ports.ActionUpdate, ports.PolicyResource{Type: domain.PersonEntity, Person: existingPerson}); err != nil {
+		return nil, err
 	}
 
+	before := personSnapshot(existingPerson)
+	currentAddresses, currentPhones := existingPerson.Addresses, existingPerson.Phones
+
 	// Actualizamos los campos del registro existente en memoria.
 	existingPerson.Name = person.Name
 	existingPerson.MiddleName = person.MiddleName
@@ -78,33 +130,283 @@ func (s *personServiceImpl) CreateOrUpdatePersonForUser(person *domain.Person) (
 	existingPerson.TypeDoc = person.TypeDoc
 	existingPerson.Email = person.Email
 	existingPerson.Photo = person.Photo
+	existingPerson.CustomFields = person.CustomFields
+	syncAddresses, syncPhones := person.Addresses != nil, person.Phones != nil
+	if syncAddresses {
+		existingPerson.Addresses = person.Addresses
+	}
+	if syncPhones {
+		existingPerson.Phones = person.Phones
+	}
+
+	if err := s.validateCustomFields(existingPerson); err != nil {
+		return nil, err
+	}
+	settings, err := currentSettings(s.settings)
+	if err != nil {
+		return nil, err
+	}
+	if syncAddresses {
+		if err := s.normalizePersonAddresses(existingPerson); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkContactLimits(settings, existingPerson, len(currentAddresses), len(currentPhones)); err != nil {
+		return nil, err
+	}
+	if syncPhones {
+		if err := normalizePersonPhones(existingPerson, s.countryCode, settings.RequirePrimaryPhone); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkRequiredPersonFields(settings, existingPerson); err != nil {
+		return nil, err
+	}
 
 	// Validar la unicidad del documento DESPUÉS de actualizar los campos y ANTES de guardar.
 	if err := s.checkDocumentUniqueness(existingPerson); err != nil {
 		return nil, err
 	}
 
-	// Guardamos la entidad actualizada.
-	err = s.personRepo.Save(existingPerson)
-	return existingPerson, err
+	after := personSnapshot(existingPerson)
+	changes = diffSnapshots(before, after)
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		// Los contactos se guardan por separado para poder actualizarlos y eliminarlos.
+		addresses, phones := existingPerson.Addresses, existingPerson.Phones
+		existingPerson.Addresses, existingPerson.Phones = nil, nil
+		err := savePerson(repos.Persons, existingPerson)
+		existingPerson.Addresses, existingPerson.Phones = addresses, phones
+		if err != nil {
+			return err
+		}
+		if _, err := recordVersion(repos.Versions, domain.PersonEntity, existingPerson.ID, existingPerson.ID, domain.VersionUpdated, before, after, actor); err != nil {
+			return err
+		}
+		if syncAddresses {
+			if err := replaceAddresses(repos, existingPerson.ID, currentAddresses, existingPerson.Addresses, actor); err != nil {
+				return err
+			}
+		}
+		if syncPhones {
+			if err := replacePhones(repos, existingPerson.ID, currentPhones, existingPerson.Phones, actor); err != nil {
+				return err
+			}
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditPersonUpdate, string(domain.PersonEntity), existingPerson.ID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existingPerson, nil
 }
 
-func (s *personServiceImpl) CreatePerson(person *domain.Person) (*domain.Person, error) {
+func (s *personServiceImpl) CreatePerson(person *domain.Person, actor domain.Actor) (*domain.Person, error) {
+	return s.create(person, actor)
+}
+
+func (s *personServiceImpl) create(person *domain.Person, actor domain.Actor) (_ *domain.Person, err error) {
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditPersonCreate, string(domain.PersonEntity), person.ID, diffSnapshots(nil, personSnapshot(person)), err))
+	}()
+
+	if err := s.policy.Authorize(actor, ports.ActionCreate, ports.PolicyResource{Type: domain.PersonEntity, Person: person}); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateCustomFields(person); err != nil {
+		return nil, err
+	}
+	// Los contactos de una persona nueva siempre son nuevos, aunque la petición traiga IDs.
+	for i := range person.Addresses {
+		person.Addresses[i].ID = 0
+	}
+	for i := range person.Phones {
+		person.Phones[i].ID = 0
+	}
+	if err := s.normalizePersonAddresses(person); err != nil {
+		return nil, err
+	}
+	settings, err := currentSettings(s.settings)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkContactLimits(settings, person, 0, 0); err != nil {
+		return nil, err
+	}
+	if err := normalizePersonPhones(person, s.countryCode, settings.RequirePrimaryPhone); err != nil {
+		return nil, err
+	}
+	if err := checkRequiredPersonFields(settings, person); err != nil {
+		return nil, err
+	}
 	if err := s.checkDocumentUniqueness(person); err != nil {
 		return nil, err
 	}
-	err := s.personRepo.Save(person)
-	return person, err
+
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		// GORM guarda las direcciones y teléfonos anidados junto con la persona.
+		if err := savePerson(repos.Persons, person); err != nil {
+			return err
+		}
+		if _, err := recordVersion(repos.Versions, domain.PersonEntity, person.ID, person.ID, domain.VersionCreated, nil, personSnapshot(person), actor); err != nil {
+			return err
+		}
+		for i := range person.Addresses {
+			address := &person.Addresses[i]
+			if _, err := recordVersion(repos.Versions, domain.AddressEntity, address.ID, person.ID, domain.VersionCreated, nil, addressSnapshot(address), actor); err != nil {
+				return err
+			}
+		}
+		for i := range person.Phones {
+			phone := &person.Phones[i]
+			if _, err := recordVersion(repos.Versions, domain.PhoneEntity, phone.ID, person.ID, domain.VersionCreated, nil, phoneSnapshot(phone), actor); err != nil {
+				return err
+			}
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditPersonCreate, string(domain.PersonEntity), person.ID, diffSnapshots(nil, personSnapshot(person)), nil))
+	})
+	if err != nil {
+		// Si la transacción falló, la persona no llegó a guardarse.
+		person.ID = 0
+		return nil, err
+	}
+	return person, nil
 }
 
-func (s *personServiceImpl) DeletePerson(id uint) error {
-	return s.personRepo.Delete(id)
+func (s *personServiceImpl) DeletePerson(id uint, actor domain.Actor) (err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditPersonDelete, string(domain.PersonEntity), id, changes, err))
+	}()
+
+	person, err := s.personRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return ports.ErrPersonNotFound
+		}
+		return err
+	}
+	if err := s.policy.Authorize(actor, ports.ActionDelete, ports.PolicyResource{Type: domain.PersonEntity, Person: person}); err != nil {
+		return err
+	}
+
+	before := personSnapshot(person)
+	changes = diffSnapshots(before, nil)
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Persons.Delete(id); err != nil {
+			return err
+		}
+		if _, err := recordVersion(repos.Versions, domain.PersonEntity, id, id, domain.VersionDeleted, before, nil, actor); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditPersonDelete, string(domain.PersonEntity), id, changes, nil))
+	})
 }
 
-func (s *personServiceImpl) GetPersonByID(id uint) (*domain.Person, error) {
-	return s.personRepo.FindByID(id)
+func (s *personServiceImpl) GetPerson(id uint, actor domain.Actor) (*domain.Person, error) {
+	return findAuthorizedPerson(s.personRepo, s.policy, id, domain.PersonEntity, ports.ActionRead, actor)
 }
 
-func (s *personServiceImpl) SearchPersons(searchTerm string) ([]domain.Person, error) {
-	return s.personRepo.Search(searchTerm)
+func (s *personServiceImpl) SearchPersons(filter ports.PersonFilter) ([]domain.Person, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = maxSearchResults
+	}
+	if err := s.checkCustomFieldFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.personRepo.Search(normalizePersonFilter(filter, s.countryCode))
+}
+
+func (s *personServiceImpl) ExportPersons(filter ports.PersonFilter) ([]domain.Person, error) {
+	filter.Limit = 0
+	if err := s.checkCustomFieldFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.personRepo.Search(normalizePersonFilter(filter, s.countryCode))
+}
+
+// checkCustomFieldFilter rechaza filtros sobre campos personalizados que no existen
+// y códigos de ubigeo que no son de departamento, provincia o distrito.
+func (s *personServiceImpl) checkCustomFieldFilter(filter ports.PersonFilter) error {
+	if ubigeo := strings.TrimSpace(filter.Ubigeo); ubigeo != "" && (len(ubigeo)%2 != 0 || len(ubigeo) > 6 || !isDigits(ubigeo)) {
+		return invalidAddress("ubigeo filter must have 2, 4 or 6 digits")
+	}
+	for key := range filter.CustomFields {
+		if _, err := s.customFieldRepo.FindByKey(key); err != nil {
+			if errors.Is(err, ports.ErrNotFound) {
+				return fmt.Errorf("%w: unknown field %q", ports.ErrInvalidCustomField, key)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizePersonFilter limpia el término y normaliza las etiquetas igual que al guardarlas.
+// Si el término parece un teléfono, también se busca entre los teléfonos en formato E.164.
+func normalizePersonFilter(filter ports.PersonFilter, countryCode string) ports.PersonFilter {
+	filter.Term = strings.TrimSpace(filter.Term)
+	filter.Phone = phoneSearchDigits(filter.Term, countryCode)
+	filter.Ubigeo = strings.TrimSpace(filter.Ubigeo)
+	filter.Tags = normalizeTagNames(filter.Tags)
+	return filter
+}
+
+// checkContactLimits aplica los límites configurados a los contactos anidados de la persona.
+// Como en los servicios de direcciones y teléfonos, el límite solo impide agregar: una
+// persona que ya lo supera puede seguir editando sus contactos mientras no sume más.
+func checkContactLimits(settings domain.Settings, person *domain.Person, currentAddresses, currentPhones int) error {
+	if n := len(person.Addresses); n > currentAddresses && !domain.AllowsMore(settings.MaxAddresses, int64(n-1)) {
+		return &ports.ValidationError{Err: ports.ErrContactLimitReached, Field: "addresses", Code: ports.AddressLimitCode,
+			Message: fmt.Sprintf("a person cannot have more than %d addresses", settings.MaxAddresses)}
+	}
+	if n := len(person.Phones); n > currentPhones && !domain.AllowsMore(settings.MaxPhones, int64(n-1)) {
+		return &ports.ValidationError{Err: ports.ErrContactLimitReached, Field: "phones", Code: ports.PhoneLimitCode,
+			Message: fmt.Sprintf("a person cannot have more than %d phones", settings.MaxPhones)}
+	}
+	return nil
+}
+
+// normalizePersonAddresses valida y geocodifica las direcciones anidadas de la persona.
+// Su orden es el de la lista.
+func (s *personServiceImpl) normalizePersonAddresses(person *domain.Person) error {
+	for i := range person.Addresses {
+		if err := normalizeAddressFields(s.catalog, &person.Addresses[i]); err != nil {
+			return err
+		}
+		geocodeAddress(s.geocoder, &person.Addresses[i])
+		person.Addresses[i].SortOrder = i
+	}
+	return nil
+}
+
+// normalizePersonPhones normaliza los teléfonos anidados de una persona, rechaza los
+// repetidos y deja como mucho un teléfono principal. Si requirePrimary, siempre queda
+// uno: el marcado o, si no se marcó ninguno, el primero.
+func normalizePersonPhones(person *domain.Person, countryCode string, requirePrimary bool) error {
+	seen := make(map[string]bool)
+	primary := -1
+	for i := range person.Phones {
+		phone := &person.Phones[i]
+		if err := normalizePhone(phone, countryCode); err != nil {
+			return err
+		}
+		if seen[phone.Phone] {
+			return &ports.ValidationError{Err: ports.ErrPhoneExists, Field: "phones", Code: ports.PhoneDuplicateCode,
+				Message: fmt.Sprintf("the phone %s is repeated", phone.Phone)}
+		}
+		seen[phone.Phone] = true
+		if phone.IsPrimary && primary < 0 {
+			primary = i
+		}
+		phone.IsPrimary = false
+		phone.SortOrder = i
+	}
+	if primary < 0 && requirePrimary && len(person.Phones) > 0 {
+		primary = 0
+	}
+	if primary >= 0 {
+		person.Phones[primary].IsPrimary = true
+	}
+	return nil
 }
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/geocode"
+	"github.com/riada2/internal/policy"
+	"github.com/riada2/internal/repository/memory"
+	"github.com/riada2/internal/ubigeo"
+)
+
+var (
+	admin    = domain.Actor{UserID: 1, Role: domain.AdminRole}
+	owner    = domain.Actor{UserID: 10, Role: domain.UserRole}
+	delegate = domain.Actor{UserID: 20, Role: domain.UserRole}
+	stranger = domain.Actor{UserID: 30, Role: domain.UserRole}
+)
+
+// addPerson guarda la persona directamente en el repositorio, sin las validaciones ni
+// la auditoría del servicio de personas.
+func addPerson(t *testing.T, personRepo ports.PersonRepository, person *domain.Person) *domain.Person {
+	t.Helper()
+	if err := personRepo.Save(person); err != nil {
+		t.Fatalf("Save person: %v", err)
+	}
+	return person
+}
+
+// newSettings crea el servicio de configuración sobre el almacén en memoria.
+func newSettings(store *memory.Store) ports.SettingsService {
+	return NewSettingsService(memory.NewSettingRepository(store), memory.NewUnitOfWork(store), newAuditService(store))
+}
+
+// newPersonService crea el servicio de personas con el catálogo de ubigeo incluido.
+func newPersonService(t *testing.T, store *memory.Store, settings ports.SettingsService, accessPolicy ports.Policy) ports.PersonService {
+	t.Helper()
+	catalog, err := ubigeo.Load("")
+	if err != nil {
+		t.Fatalf("ubigeo.Load: %v", err)
+	}
+	return NewPersonService(memory.NewPersonRepository(store), memory.NewUnitOfWork(store), memory.NewCustomFieldRepository(store), catalog,
+		geocode.NewLookupGeocoder(nil), "51", settings, accessPolicy, newAuditService(store))
+}
+
+// personFixture arma los servicios de personas y teléfonos sobre los repositorios en memoria.
+type personFixture struct {
+	persons     ports.PersonService
+	phones      ports.PhoneService
+	settings    ports.SettingsService
+	personRepo  ports.PersonRepository
+	delegations ports.DelegationRepository
+}
+
+func newPersonFixture(t *testing.T) *personFixture {
+	t.Helper()
+	store := memory.NewStore()
+	personRepo := memory.NewPersonRepository(store)
+	delegationRepo := memory.NewDelegationRepository(store)
+	settings := newSettings(store)
+	accessPolicy := policy.New(delegationRepo)
+	return &personFixture{
+		persons: newPersonService(t, store, settings, accessPolicy),
+		phones: NewPhoneService(memory.NewPhoneRepository(store), personRepo, memory.NewUnitOfWork(store), "51",
+			settings, accessPolicy, newAuditService(store)),
+		settings:    settings,
+		personRepo:  personRepo,
+		delegations: delegationRepo,
+	}
+}
+
+func (f *personFixture) create(t *testing.T, person *domain.Person) *domain.Person {
+	t.Helper()
+	created, err := f.persons.CreatePerson(person, admin)
+	if err != nil {
+		t.Fatalf("CreatePerson: %v", err)
+	}
+	return created
+}
+
+func (f *personFixture) updateSettings(t *testing.T, values map[string]string) {
+	t.Helper()
+	if _, err := f.settings.UpdateSettings(values, admin); err != nil {
+		t.Fatalf("UpdateSettings: %v", err)
+	}
+}
+
+func withDocument(name, number string) *domain.Person {
+	dni := domain.DNI
+	return &domain.Person{Name: name, TypeDoc: &dni, DocNumber: &number}
+}
+
+// blindDocuments simula la carrera entre dos peticiones: la comprobación previa no ve
+// el documento que la otra petición acaba de registrar.
+type blindDocuments struct {
+	ports.PersonRepository
+}
+
+func (blindDocuments) FindByDocument(domain.DocType, string) (*domain.Person, error) {
+	return nil, ports.ErrNotFound
+}
+
+func TestDocumentUniqueness(t *testing.T) {
+	f := newPersonFixture(t)
+	ana := f.create(t, withDocument("Ana", "12345678"))
+
+	t.Run("create with a taken document", func(t *testing.T) {
+		_, err := f.persons.CreatePerson(withDocument("Otra", "12345678"), admin)
+		if !errors.Is(err, ports.ErrPersonDocumentExists) {
+			t.Fatalf("expected ErrPersonDocumentExists, got %v", err)
+		}
+	})
+
+	t.Run("update to a taken document", func(t *testing.T) {
+		luis := f.create(t, withDocument("Luis", "87654321"))
+		changed := withDocument("Luis", "12345678")
+		changed.ID = luis.ID
+		if _, err := f.persons.UpdatePerson(changed, admin); !errors.Is(err, ports.ErrPersonDocumentExists) {
+			t.Fatalf("expected ErrPersonDocumentExists, got %v", err)
+		}
+	})
+
+	t.Run("update keeping its own document", func(t *testing.T) {
+		same := withDocument("Ana María", "12345678")
+		same.ID = ana.ID
+		if _, err := f.persons.UpdatePerson(same, admin); err != nil {
+			t.Fatalf("UpdatePerson: %v", err)
+		}
+	})
+
+	t.Run("persons without document", func(t *testing.T) {
+		f.create(t, withDocument("Sin documento", ""))
+		f.create(t, withDocument("Tampoco", ""))
+	})
+
+	t.Run("the unique key catches what the check misses", func(t *testing.T) {
+		service := f.persons.(*personServiceImpl)
+		service.personRepo = blindDocuments{service.personRepo}
+		person := withDocument("Carrera", "12345678")
+		person.Phones = []domain.Phone{{Phone: "987654321"}}
+		if _, err := f.persons.CreatePerson(person, admin); !errors.Is(err, ports.ErrPersonDocumentExists) {
+			t.Fatalf("expected ErrPersonDocumentExists, got %v", err)
+		}
+		if person.ID != 0 {
+			t.Fatalf("rejected person kept ID %d", person.ID)
+		}
+		found, err := f.personRepo.Search(ports.PersonFilter{Term: "carrera"})
+		if err != nil || len(found) != 0 {
+			t.Fatalf("the rejected person was saved: %v %v", found, err)
+		}
+	})
+}
+
+func TestContactLimits(t *testing.T) {
+	f := newPersonFixture(t)
+	f.updateSettings(t, map[string]string{domain.SettingMaxPhones: "2", domain.SettingMaxAddresses: "1"})
+
+	t.Run("create over the phone limit", func(t *testing.T) {
+		person := &domain.Person{Name: "Ana", Phones: []domain.Phone{{Phone: "987654321"}, {Phone: "987654322"}, {Phone: "987654323"}}}
+		_, err := f.persons.CreatePerson(person, admin)
+		if !errors.Is(err, ports.ErrContactLimitReached) {
+			t.Fatalf("expected ErrContactLimitReached, got %v", err)
+		}
+	})
+
+	t.Run("create over the address limit", func(t *testing.T) {
+		person := &domain.Person{Name: "Ana", Addresses: []domain.Address{{Street: "Av. Arequipa 100"}, {Street: "Jr. Lampa 200"}}}
+		_, err := f.persons.CreatePerson(person, admin)
+		if !errors.Is(err, ports.ErrContactLimitReached) {
+			t.Fatalf("expected ErrContactLimitReached, got %v", err)
+		}
+	})
+
+	t.Run("add a phone over the limit", func(t *testing.T) {
+		person := f.create(t, &domain.Person{Name: "Luis", Phones: []domain.Phone{{Phone: "987654321"}, {Phone: "987654322"}}})
+		_, err := f.phones.CreateOrUpdatePhone(&domain.Phone{PersonID: person.ID, Phone: "987654323"}, admin)
+		if !errors.Is(err, ports.ErrContactLimitReached) {
+			t.Fatalf("expected ErrContactLimitReached, got %v", err)
+		}
+	})
+
+	t.Run("a person over a lowered limit can still shrink", func(t *testing.T) {
+		person := f.create(t, &domain.Person{Name: "Rosa", Phones: []domain.Phone{{Phone: "987654321"}, {Phone: "987654322"}}})
+		f.updateSettings(t, map[string]string{domain.SettingMaxPhones: "1"})
+		t.Cleanup(func() { f.updateSettings(t, map[string]string{domain.SettingMaxPhones: "2"}) })
+
+		update := &domain.Person{ID: person.ID, Name: "Rosa", Phones: person.Phones[:1]}
+		updated, err := f.persons.UpdatePerson(update, admin)
+		if err != nil {
+			t.Fatalf("UpdatePerson: %v", err)
+		}
+		if len(updated.Phones) != 1 {
+			t.Fatalf("expected 1 phone, got %d", len(updated.Phones))
+		}
+	})
+}
+
+func TestPartialCatalogAcceptsUnknownDistricts(t *testing.T) {
+	f := newPersonFixture(t)
+
+	// El catálogo incluido solo tiene la capital de Apurímac, no Andahuaylas.
+	person := f.create(t, &domain.Person{Name: "Ana", Addresses: []domain.Address{
+		{Street: "Jr. Ramos 120", Ubigeo: "030201", District: "Andahuaylas"},
+		{Street: "Av. Principal 5", District: "Pichari", Department: "Cusco"},
+	}})
+	if got := person.Addresses[0]; got.Ubigeo != "030201" || got.District != "Andahuaylas" {
+		t.Errorf("the unverified ubigeo was not kept as sent: %+v", got)
+	}
+	if got := person.Addresses[1]; got.Ubigeo != "" || got.District != "Pichari" {
+		t.Errorf("the unverified district was not kept as sent: %+v", got)
+	}
+
+	for _, address := range []domain.Address{
+		{Street: "Av. Lima 1", Ubigeo: "990101"},
+		{Street: "Av. Lima 1", Ubigeo: "150113", District: "Miraflores"},
+	} {
+		_, err := f.persons.CreatePerson(&domain.Person{Name: "Luis", Addresses: []domain.Address{address}}, admin)
+		if !errors.Is(err, ports.ErrInvalidAddress) {
+			t.Errorf("ubigeo %s with district %q: expected ErrInvalidAddress, got %v", address.Ubigeo, address.District, err)
+		}
+	}
+}
+
+func TestSearchByUbigeoPrefix(t *testing.T) {
+	f := newPersonFixture(t)
+	person := f.create(t, &domain.Person{Name: "Ana", Addresses: []domain.Address{{Street: "Av. Brasil 1234", Ubigeo: "150113"}}})
+
+	for _, prefix := range []string{"15", "1501", "150113", " 1501 "} {
+		persons, err := f.persons.SearchPersons(ports.PersonFilter{Ubigeo: prefix})
+		if err != nil || len(persons) != 1 || persons[0].ID != person.ID {
+			t.Errorf("SearchPersons(ubigeo %q) = %d persons, %v, want Ana", prefix, len(persons), err)
+		}
+	}
+	for _, prefix := range []string{"1", "150", "1501130", "15%", "1_", "ab"} {
+		if _, err := f.persons.SearchPersons(ports.PersonFilter{Ubigeo: prefix}); !errors.Is(err, ports.ErrInvalidAddress) {
+			t.Errorf("SearchPersons(ubigeo %q): expected ErrInvalidAddress, got %v", prefix, err)
+		}
+	}
+}
+
+func TestOwnership(t *testing.T) {
+	f := newPersonFixture(t)
+	person := f.create(t, &domain.Person{Name: "Ana", UserID: &owner.UserID})
+	if err := f.delegations.Save(&domain.Delegation{UserID: delegate.UserID, PersonID: person.ID, Status: domain.DelegationActive}); err != nil {
+		t.Fatalf("Save delegation: %v", err)
+	}
+
+	update := func(actor domain.Actor) error {
+		_, err := f.persons.UpdatePerson(&domain.Person{ID: person.ID, Name: "Ana María", UserID: &owner.UserID}, actor)
+		return err
+	}
+	addPhone := func(actor domain.Actor, number string) error {
+		_, err := f.phones.CreateOrUpdatePhone(&domain.Phone{PersonID: person.ID, Phone: number}, actor)
+		return err
+	}
+
+	tests := []struct {
+		name    string
+		run     func() error
+		allowed bool
+	}{
+		{"owner updates own person", func() error { return update(owner) }, true},
+		{"delegate updates the person", func() error { return update(delegate) }, true},
+		{"stranger cannot update the person", func() error { return update(stranger) }, false},
+		{"owner adds a phone", func() error { return addPhone(owner, "987654321") }, true},
+		{"delegate adds a phone", func() error { return addPhone(delegate, "987654322") }, true},
+		{"stranger cannot add a phone", func() error { return addPhone(stranger, "987654323") }, false},
+		{"stranger cannot delete the person", func() error { return f.persons.DeletePerson(person.ID, stranger) }, false},
+		{"owner cannot delete own person", func() error { return f.persons.DeletePerson(person.ID, owner) }, false},
+		{"stranger cannot create a person for the owner", func() error {
+			_, err := f.persons.CreatePerson(&domain.Person{Name: "Otra", UserID: &owner.UserID}, stranger)
+			return err
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			switch {
+			case tt.allowed && err != nil:
+				t.Fatalf("expected success, got %v", err)
+			case !tt.allowed && !errors.Is(err, ports.ErrForbidden):
+				t.Fatalf("expected ErrForbidden, got %v", err)
+			}
+		})
+	}
+
+	if err := f.persons.DeletePerson(person.ID, admin); err != nil {
+		t.Fatalf("admin DeletePerson: %v", err)
+	}
+}
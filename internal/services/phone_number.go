@@ -0,0 +1,139 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// peruCallingCode es el código de país de Perú, el único con reglas de numeración propias.
+const peruCallingCode = "51"
+
+// phoneSeparators son los caracteres de formato que se ignoran al normalizar un teléfono.
+const phoneSeparators = " -.()/\t"
+
+// minPhoneSearchDigits es el mínimo de dígitos para que un término de búsqueda
+// también se compare con los teléfonos.
+const minPhoneSearchDigits = 6
+
+// normalizePhoneNumber convierte un teléfono escrito en cualquier formato a E.164.
+// Los números con "+" o "00" ya traen el código de país; el resto se considera nacional:
+// se quita el prefijo de larga distancia "0" y se antepone defaultCountryCode.
+// En Perú los celulares tienen 9 dígitos y empiezan con 9, y los fijos 8 dígitos
+// contando el código de área (por ejemplo "01 4567890" o "044 123456").
+func normalizePhoneNumber(raw, defaultCountryCode string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", invalidPhone("phone", ports.PhoneRequiredCode, "phone is required")
+	}
+
+	international := false
+	switch {
+	case strings.HasPrefix(raw, "+"):
+		international, raw = true, raw[1:]
+	case strings.HasPrefix(raw, "00"):
+		international, raw = true, raw[2:]
+	}
+
+	var digits strings.Builder
+	for _, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case strings.ContainsRune(phoneSeparators, r):
+		default:
+			return "", invalidPhone("phone", ports.PhoneInvalidCharactersCode, fmt.Sprintf("phone contains invalid character %q", r))
+		}
+	}
+
+	number := digits.String()
+	if !international {
+		national := strings.TrimLeft(number, "0")
+		// "51999888777" sin "+" ya trae el código de país.
+		if rest, ok := strings.CutPrefix(number, defaultCountryCode); ok && !validNationalNumber(defaultCountryCode, national) && validNationalNumber(defaultCountryCode, rest) {
+			national = rest
+		}
+		number = defaultCountryCode + national
+	}
+
+	if len(number) < 8 || len(number) > 15 {
+		return "", invalidPhone("phone", ports.PhoneInvalidLengthCode, "phone must have between 8 and 15 digits including the country code")
+	}
+	if national, ok := strings.CutPrefix(number, peruCallingCode); ok && !validNationalNumber(peruCallingCode, national) {
+		return "", invalidPhone("phone", ports.PhoneInvalidLengthCode, "peruvian phones have 9 digits starting with 9 for mobiles or 8 digits including the area code for landlines")
+	}
+	return "+" + number, nil
+}
+
+// validNationalNumber aplica las reglas de numeración del país; solo Perú tiene reglas propias.
+func validNationalNumber(countryCode, national string) bool {
+	if countryCode != peruCallingCode {
+		return national != ""
+	}
+	return isPeruvianMobile(national) || (len(national) == 8 && national[0] >= '1' && national[0] <= '8')
+}
+
+func isPeruvianMobile(national string) bool {
+	return len(national) == 9 && national[0] == '9'
+}
+
+// normalizePhone normaliza el número y el tipo del teléfono. Sin tipo, se asume celular,
+// salvo los fijos peruanos, que se marcan como de casa.
+func normalizePhone(phone *domain.Phone, defaultCountryCode string) error {
+	number, err := normalizePhoneNumber(phone.Phone, defaultCountryCode)
+	if err != nil {
+		return err
+	}
+	phone.Phone = number
+
+	national, peruvian := strings.CutPrefix(strings.TrimPrefix(number, "+"), peruCallingCode)
+	phone.Type = domain.PhoneType(strings.ToLower(strings.TrimSpace(string(phone.Type))))
+	if phone.Type == "" {
+		phone.Type = domain.MobilePhone
+		if peruvian && !isPeruvianMobile(national) {
+			phone.Type = domain.HomePhone
+		}
+	}
+	if !phone.Type.IsValid() {
+		return invalidPhone("type", ports.PhoneInvalidTypeCode, fmt.Sprintf("invalid phone type %q, use mobile, home, work or whatsapp", phone.Type))
+	}
+	if phone.Type.IsMobile() && peruvian && !isPeruvianMobile(national) {
+		return invalidPhone("phone", ports.PhoneNotMobileCode, fmt.Sprintf("%s is a landline and cannot be of type %s", number, phone.Type))
+	}
+	return nil
+}
+
+// phoneSearchDigits devuelve los dígitos con los que se busca el término entre los teléfonos,
+// o "" si el término no parece un teléfono. Si el término es un número completo se usa su
+// forma E.164, para que "999 888 777" encuentre "+51999888777".
+func phoneSearchDigits(term, defaultCountryCode string) string {
+	digits := 0
+	for _, r := range term {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' || strings.ContainsRune(phoneSeparators, r):
+		default:
+			return ""
+		}
+	}
+	if digits < minPhoneSearchDigits {
+		return ""
+	}
+	if number, err := normalizePhoneNumber(term, defaultCountryCode); err == nil {
+		return strings.TrimPrefix(number, "+")
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, term)
+}
+
+// invalidPhone construye un error de validación de ErrInvalidPhone.
+func invalidPhone(field, code, message string) error {
+	return &ports.ValidationError{Err: ports.ErrInvalidPhone, Field: field, Code: code, Message: message}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		countryCode string
+		want        string
+		wantCode    string
+	}{
+		{name: "local mobile", raw: "987654321", countryCode: "51", want: "+51987654321"},
+		{name: "local mobile with spaces", raw: "  987 654 321 ", countryCode: "51", want: "+51987654321"},
+		{name: "local mobile with separators", raw: "987-654.321", countryCode: "51", want: "+51987654321"},
+		{name: "lima landline with area code", raw: "(01) 456-7890", countryCode: "51", want: "+5114567890"},
+		{name: "province landline with area code", raw: "044 123456", countryCode: "51", want: "+5144123456"},
+		{name: "with plus", raw: "+51 987 654 321", countryCode: "51", want: "+51987654321"},
+		{name: "with 00", raw: "0051987654321", countryCode: "51", want: "+51987654321"},
+		{name: "country code without plus", raw: "51987654321", countryCode: "51", want: "+51987654321"},
+		{name: "foreign with plus", raw: "+1 (415) 555-2671", countryCode: "51", want: "+14155552671"},
+		{name: "another default country", raw: "9 1234 5678", countryCode: "56", want: "+56912345678"},
+		{name: "empty", raw: "", countryCode: "51", wantCode: ports.PhoneRequiredCode},
+		{name: "only spaces", raw: "   ", countryCode: "51", wantCode: ports.PhoneRequiredCode},
+		{name: "letters", raw: "987-654-32a", countryCode: "51", wantCode: ports.PhoneInvalidCharactersCode},
+		{name: "extension", raw: "+51 987654321 ext 2", countryCode: "51", wantCode: ports.PhoneInvalidCharactersCode},
+		{name: "plus in the middle", raw: "98+7654321", countryCode: "51", wantCode: ports.PhoneInvalidCharactersCode},
+		{name: "too short", raw: "12345", countryCode: "51", wantCode: ports.PhoneInvalidLengthCode},
+		{name: "too short with plus", raw: "+1234567", countryCode: "51", wantCode: ports.PhoneInvalidLengthCode},
+		{name: "too long with plus", raw: "+1234567890123456", countryCode: "51", wantCode: ports.PhoneInvalidLengthCode},
+		{name: "peruvian mobile with 8 digits", raw: "98765432", countryCode: "51", wantCode: ports.PhoneInvalidLengthCode},
+		{name: "peruvian mobile with 10 digits", raw: "9876543210", countryCode: "51", wantCode: ports.PhoneInvalidLengthCode},
+		{name: "peruvian number with plus", raw: "+51 12345", countryCode: "51", wantCode: ports.PhoneInvalidLengthCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizePhoneNumber(tt.raw, tt.countryCode)
+			if tt.wantCode == "" {
+				if err != nil || got != tt.want {
+					t.Fatalf("normalizePhoneNumber(%q) = %q, %v, want %q", tt.raw, got, err, tt.want)
+				}
+				return
+			}
+			var validation *ports.ValidationError
+			if !errors.As(err, &validation) || !errors.Is(err, ports.ErrInvalidPhone) || validation.Code != tt.wantCode {
+				t.Fatalf("normalizePhoneNumber(%q) = %q, %v, want code %s", tt.raw, got, err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestNormalizePhoneType(t *testing.T) {
+	tests := []struct {
+		phone    domain.Phone
+		want     domain.PhoneType
+		wantCode string
+	}{
+		{phone: domain.Phone{Phone: "987654321"}, want: domain.MobilePhone},
+		{phone: domain.Phone{Phone: "01 4567890"}, want: domain.HomePhone},
+		{phone: domain.Phone{Phone: "01 4567890", Type: " Work "}, want: domain.WorkPhone},
+		{phone: domain.Phone{Phone: "987654321", Type: "WhatsApp"}, want: domain.WhatsAppPhone},
+		{phone: domain.Phone{Phone: "01 4567890", Type: "whatsapp"}, wantCode: ports.PhoneNotMobileCode},
+		{phone: domain.Phone{Phone: "987654321", Type: "fax"}, wantCode: ports.PhoneInvalidTypeCode},
+	}
+	for _, tt := range tests {
+		phone := tt.phone
+		err := normalizePhone(&phone, "51")
+		if tt.wantCode == "" {
+			if err != nil || phone.Type != tt.want {
+				t.Errorf("normalizePhone(%+v) = %s, %v, want %s", tt.phone, phone.Type, err, tt.want)
+			}
+			continue
+		}
+		var validation *ports.ValidationError
+		if !errors.As(err, &validation) || validation.Code != tt.wantCode {
+			t.Errorf("normalizePhone(%+v) = %v, want code %s", tt.phone, err, tt.wantCode)
+		}
+	}
+}
+
+func TestPhoneSearchDigits(t *testing.T) {
+	for term, want := range map[string]string{
+		"999 888 777":  "51999888777",
+		"+51999888777": "51999888777",
+		"888 777":      "888777",
+		"88777":        "",
+		"Ana 999888":   "",
+	} {
+		if got := phoneSearchDigits(term, "51"); got != want {
+			t.Errorf("phoneSearchDigits(%q) = %q, want %q", term, got, want)
+		}
+	}
+}
@@ -2,87 +2,358 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
 
 	"github.com/riada2/internal/core/domain"
 	"github.com/riada2/internal/core/ports"
-	"gorm.io/gorm"
 )
 
 type phoneServiceImpl struct {
 	phoneRepo  ports.PhoneRepository
 	personRepo ports.PersonRepository
+	uow        ports.UnitOfWork
+	// countryCode es el código de país de los teléfonos escritos en formato nacional.
+	countryCode string
+	settings    ports.SettingsService
+	policy      ports.Policy
+	audit       ports.AuditService
 }
 
-func NewPhoneService(phoneRepo ports.PhoneRepository, personRepo ports.PersonRepository) ports.PhoneService {
-	return &phoneServiceImpl{phoneRepo, personRepo}
+func NewPhoneService(phoneRepo ports.PhoneRepository, personRepo ports.PersonRepository, uow ports.UnitOfWork, countryCode string, settings ports.SettingsService, policy ports.Policy, audit ports.AuditService) ports.PhoneService {
+	return &phoneServiceImpl{phoneRepo, personRepo, uow, countryCode, settings, policy, audit}
 }
 
-func (s *phoneServiceImpl) CreateOrUpdatePhone(phone *domain.Phone) (*domain.Phone, error) {
+func (s *phoneServiceImpl) CreateOrUpdatePhone(phone *domain.Phone, actor domain.Actor) (_ *domain.Phone, err error) {
+	auditAction := domain.AuditPhoneCreate
+	if phone.ID != 0 {
+		auditAction = domain.AuditPhoneUpdate
+	}
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, auditAction, string(domain.PhoneEntity), phone.ID, changes, err))
+	}()
 
-	// Validar que la persona (PersonID) a la que se asocia el teléfono realmente exista.
-	_, err := s.personRepo.FindByID(phone.PersonID)
+	// Validar que la persona (PersonID) a la que se asocia el teléfono exista y que el actor pueda gestionarla.
+	policyAction := ports.ActionCreate
+	if phone.ID != 0 {
+		policyAction = ports.ActionUpdate
+	}
+	person, err := findAuthorizedPerson(s.personRepo, s.policy, phone.PersonID, domain.PhoneEntity, policyAction, actor)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// Usamos un error específico para que el handler pueda interpretarlo.
-			return nil, ports.ErrPersonNotFound
-		}
-		return nil, err // Otro error de base deatos.
+		return nil, err
+	}
+
+	if err := normalizePhone(phone, s.countryCode); err != nil {
+		return nil, err
+	}
+	settings, err := currentSettings(s.settings)
+	if err != nil {
+		return nil, err
 	}
 
 	// If it's an update, check ownership
+	var before map[string]*string
+	action := domain.VersionCreated
 	if phone.ID != 0 {
 		existingPhone, err := s.phoneRepo.FindByID(phone.ID)
 		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("phone not found")
+			if errors.Is(err, ports.ErrNotFound) {
+				return nil, ports.ErrPhoneNotFound
 			}
 			return nil, err
 		}
-		// Check if the phone belongs to the correct person
+		// El teléfono no puede pasar a otra persona.
 		if existingPhone.PersonID != phone.PersonID {
-			return nil, errors.New("authorization failed: you can only update your own phones")
+			return nil, ports.ErrPhoneNotFound
+		}
+		before = phoneSnapshot(existingPhone)
+		action = domain.VersionUpdated
+		// El orden solo cambia al reordenar.
+		phone.SortOrder = existingPhone.SortOrder
+		// El principal solo cambia marcando otro teléfono, para que la persona nunca se quede sin uno.
+		if existingPhone.IsPrimary && settings.RequirePrimaryPhone {
+			phone.IsPrimary = true
 		}
 	} else {
-		// Es un teléfono nuevo, verificar el límite. Una persona no puede tener más de 2 números.
-		// Se asume que el repositorio provee un método para contar los teléfonos de una persona.
+		// Es un teléfono nuevo, verificar el límite configurado.
 		count, err := s.phoneRepo.CountByPersonID(phone.PersonID)
 		if err != nil {
 			return nil, err
 		}
-		if count >= 2 {
-			return nil, errors.New("una persona no puede tener más de 2 números de teléfono")
+		if !domain.AllowsMore(settings.MaxPhones, count) {
+			return nil, &ports.ValidationError{Err: ports.ErrContactLimitReached, Field: "phone", Code: ports.PhoneLimitCode,
+				Message: fmt.Sprintf("a person cannot have more than %d phones", settings.MaxPhones)}
 		}
+		// Los teléfonos nuevos van al final.
+		phone.SortOrder = nextSortOrder(person.Phones, func(p domain.Phone) int { return p.SortOrder })
 	}
 
-	// Associate the phone with the person and save
-
-	if err := s.phoneRepo.Save(phone); err != nil {
+	others, err := s.otherPhones(phone)
+	if err != nil {
 		return nil, err
 	}
+	for _, other := range others {
+		if other.Phone == phone.Phone {
+			return nil, &ports.ValidationError{Err: ports.ErrPhoneExists, Field: "phone", Code: ports.PhoneDuplicateCode,
+				Message: fmt.Sprintf("the person already has the phone %s", phone.Phone)}
+		}
+	}
+	// Si se exige un principal, el primer teléfono de la persona lo es siempre.
+	if len(others) == 0 && settings.RequirePrimaryPhone {
+		phone.IsPrimary = true
+	}
 
+	// El teléfono, su versión, el cambio de principal y la auditoría se guardan juntos.
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Phones.Save(phone); err != nil {
+			return err
+		}
+		if _, err := recordVersion(repos.Versions, domain.PhoneEntity, phone.ID, phone.PersonID, action, before, phoneSnapshot(phone), actor); err != nil {
+			return err
+		}
+		if phone.IsPrimary {
+			for i := range others {
+				if others[i].IsPrimary {
+					if err := setPrimaryPhone(repos, &others[i], false, actor); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		changes = diffSnapshots(before, phoneSnapshot(phone))
+		return s.audit.RecordIn(repos, newAuditEntry(actor, auditAction, string(domain.PhoneEntity), phone.ID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
 	return phone, nil
 }
 
-func (s *phoneServiceImpl) DeletePhoneForUser(phoneID uint, userID uint) error {
-	// Find the person associated with the user
-	person, err := s.personRepo.FindByUserID(userID)
+// otherPhones devuelve los demás teléfonos de la persona del teléfono.
+func (s *phoneServiceImpl) otherPhones(phone *domain.Phone) ([]domain.Phone, error) {
+	phones, err := s.phoneRepo.FindByPersonID(phone.PersonID)
 	if err != nil {
-		return errors.New("no person profile found for this user")
+		return nil, err
 	}
+	others := phones[:0]
+	for _, other := range phones {
+		if other.ID != phone.ID {
+			others = append(others, other)
+		}
+	}
+	return others, nil
+}
+
+// setPrimaryPhone marca o desmarca el teléfono como principal y registra la versión.
+func setPrimaryPhone(repos ports.Repositories, phone *domain.Phone, primary bool, actor domain.Actor) error {
+	before := phoneSnapshot(phone)
+	phone.IsPrimary = primary
+	if err := repos.Phones.Save(phone); err != nil {
+		return err
+	}
+	_, err := recordVersion(repos.Versions, domain.PhoneEntity, phone.ID, phone.PersonID, domain.VersionUpdated, before, phoneSnapshot(phone), actor)
+	return err
+}
+
+func (s *phoneServiceImpl) DeletePhoneForUser(phoneID uint, actor domain.Actor) (err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditPhoneDelete, string(domain.PhoneEntity), phoneID, changes, err))
+	}()
 
 	// Find the phone to be deleted
 	phone, err := s.phoneRepo.FindByID(phoneID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("phone not found")
+		if errors.Is(err, ports.ErrNotFound) {
+			return ports.ErrPhoneNotFound
 		}
 		return err
 	}
 
-	// Check if the phone belongs to the correct person
-	if phone.PersonID != person.ID {
-		return errors.New("authorization failed: you can only delete your own phones")
+	// La política decide quién puede eliminar los teléfonos de la persona.
+	person, err := findAuthorizedPerson(s.personRepo, s.policy, phone.PersonID, domain.PhoneEntity, ports.ActionDelete, actor)
+	if err != nil {
+		return err
+	}
+
+	settings, err := currentSettings(s.settings)
+	if err != nil {
+		return err
+	}
+	if settings.Requires(domain.PersonFieldPhone) && len(person.Phones) <= 1 && (person.Household == nil || len(person.Household.Phones) == 0) {
+		return &ports.ValidationError{Err: ports.ErrInvalidPerson, Field: domain.PersonFieldPhone, Code: ports.FieldRequiredCode,
+			Message: "the phone is required and this is the last one of the person"}
+	}
+
+	before := phoneSnapshot(phone)
+	changes = diffSnapshots(before, nil)
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Phones.Delete(phoneID); err != nil {
+			return err
+		}
+		if _, err := recordVersion(repos.Versions, domain.PhoneEntity, phone.ID, phone.PersonID, domain.VersionDeleted, before, nil, actor); err != nil {
+			return err
+		}
+
+		// Si se eliminó el principal y se exige uno, pasa a serlo el primero que quede según el orden.
+		if phone.IsPrimary && settings.RequirePrimaryPhone {
+			remaining, err := repos.Phones.FindByPersonID(phone.PersonID)
+			if err != nil {
+				return err
+			}
+			if len(remaining) > 0 {
+				if err := setPrimaryPhone(repos, &remaining[0], true, actor); err != nil {
+					return err
+				}
+			}
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditPhoneDelete, string(domain.PhoneEntity), phoneID, changes, nil))
+	})
+}
+
+func (s *phoneServiceImpl) ListPhones(personID uint, actor domain.Actor) ([]domain.Phone, error) {
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, domain.PhoneEntity, ports.ActionRead, actor); err != nil {
+		return nil, err
+	}
+	return s.phoneRepo.FindByPersonID(personID)
+}
+
+func (s *phoneServiceImpl) GetPhone(personID, phoneID uint, actor domain.Actor) (*domain.Phone, error) {
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, domain.PhoneEntity, ports.ActionRead, actor); err != nil {
+		return nil, err
+	}
+	phone, err := s.phoneRepo.FindByID(phoneID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrPhoneNotFound
+		}
+		return nil, err
+	}
+	if phone.PersonID != personID {
+		return nil, ports.ErrPhoneNotFound
 	}
+	return phone, nil
+}
 
-	return s.phoneRepo.Delete(phoneID)
+func (s *phoneServiceImpl) ReorderPhones(personID uint, ids []uint, actor domain.Actor) (_ []domain.Phone, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditPhoneReorder, string(domain.PersonEntity), personID, changes, err))
+	}()
+
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, domain.PhoneEntity, ports.ActionUpdate, actor); err != nil {
+		return nil, err
+	}
+	phones, err := s.phoneRepo.FindByPersonID(personID)
+	if err != nil {
+		return nil, err
+	}
+	current := make([]uint, len(phones))
+	for i := range phones {
+		current[i] = phones[i].ID
+	}
+	if !sameIDs(current, ids) {
+		return nil, &ports.ValidationError{Err: ports.ErrInvalidPhone, Field: "ids", Code: ports.InvalidOrderCode,
+			Message: "the order must list every phone of the person exactly once"}
+	}
+
+	changes = map[string]domain.FieldChange{"order": {Old: textValue(joinIDs(current)), New: textValue(joinIDs(ids))}}
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Phones.Reorder(ids); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditPhoneReorder, string(domain.PersonEntity), personID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.phoneRepo.FindByPersonID(personID)
+}
+
+func (s *phoneServiceImpl) NormalizePhones(dryRun bool, actor domain.Actor) (report *domain.PhoneMigrationReport, err error) {
+	defer func() {
+		if dryRun {
+			return // La simulación no modifica datos, no se audita.
+		}
+		var changes map[string]domain.FieldChange
+		if report != nil {
+			changes = map[string]domain.FieldChange{
+				"normalized": {New: textValue(strconv.Itoa(report.Normalized))},
+				"invalid":    {New: textValue(strconv.Itoa(report.Invalid))},
+				"duplicates": {New: textValue(strconv.Itoa(report.Duplicates))},
+			}
+		}
+		recordSummary(s.audit, newAuditEntry(actor, domain.AuditPhoneNormalize, string(domain.PhoneEntity), 0, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+
+	settings, err := currentSettings(s.settings)
+	if err != nil {
+		return nil, err
+	}
+	phones, err := s.phoneRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Teléfonos agrupados por persona, en el orden del repositorio (del más antiguo al más nuevo).
+	byPerson := make(map[uint][]*domain.Phone)
+	var personIDs []uint
+	for i := range phones {
+		phone := &phones[i]
+		if _, ok := byPerson[phone.PersonID]; !ok {
+			personIDs = append(personIDs, phone.PersonID)
+		}
+		byPerson[phone.PersonID] = append(byPerson[phone.PersonID], phone)
+	}
+
+	report = &domain.PhoneMigrationReport{DryRun: dryRun}
+	for _, personID := range personIDs {
+		group := byPerson[personID]
+		hasPrimary := false
+		for _, phone := range group {
+			hasPrimary = hasPrimary || phone.IsPrimary
+		}
+
+		seen := make(map[string]bool)
+		for _, phone := range group {
+			report.Scanned++
+			normalized := *phone
+			if err := normalizePhone(&normalized, s.countryCode); err != nil {
+				report.Invalid++
+				continue
+			}
+			if seen[normalized.Phone] {
+				report.Duplicates++
+				continue
+			}
+			seen[normalized.Phone] = true
+			if !hasPrimary && settings.RequirePrimaryPhone {
+				normalized.IsPrimary, hasPrimary = true, true
+			}
+			if normalized == *phone {
+				continue
+			}
+
+			report.Normalized++
+			if dryRun {
+				continue
+			}
+			before := phoneSnapshot(phone)
+			_, err := versionedWrite(s.uow, func(repos ports.Repositories) (*domain.EntityVersion, error) {
+				if err := repos.Phones.Save(&normalized); err != nil {
+					return nil, err
+				}
+				return recordVersion(repos.Versions, domain.PhoneEntity, normalized.ID, normalized.PersonID, domain.VersionUpdated, before, phoneSnapshot(&normalized), actor)
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return report, nil
 }
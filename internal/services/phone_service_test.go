@@ -0,0 +1,25 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/policy"
+	"github.com/riada2/internal/repository/memory"
+)
+
+func TestPhoneIsNotSavedWithoutItsVersion(t *testing.T) {
+	store := memory.NewStore()
+	personRepo := memory.NewPersonRepository(store)
+	person := addPerson(t, personRepo, &domain.Person{Name: "Ana", LastName: "Quispe"})
+	phoneRepo := memory.NewPhoneRepository(store)
+	phones := NewPhoneService(phoneRepo, personRepo, failingVersionsUnitOfWork{memory.NewUnitOfWork(store)}, "51",
+		newSettings(store), policy.New(memory.NewDelegationRepository(store)), newAuditService(store))
+
+	if _, err := phones.CreateOrUpdatePhone(&domain.Phone{PersonID: person.ID, Phone: "987654321"}, admin); err == nil {
+		t.Fatal("CreateOrUpdatePhone should fail")
+	}
+	if found, err := phoneRepo.FindByPersonID(person.ID); err != nil || len(found) != 0 {
+		t.Fatalf("a failed version should not leave the phone, got %+v, %v", found, err)
+	}
+}
@@ -0,0 +1,387 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+const (
+	maxParents         = 2
+	defaultFamilyDepth = 3
+	maxFamilyDepth     = 10
+)
+
+type relationshipServiceImpl struct {
+	relationshipRepo ports.RelationshipRepository
+	personRepo       ports.PersonRepository
+	uow              ports.UnitOfWork
+	policy           ports.Policy
+	audit            ports.AuditService
+}
+
+func NewRelationshipService(relationshipRepo ports.RelationshipRepository, personRepo ports.PersonRepository, uow ports.UnitOfWork, policy ports.Policy, audit ports.AuditService) ports.RelationshipService {
+	return &relationshipServiceImpl{relationshipRepo, personRepo, uow, policy, audit}
+}
+
+// relative es una relación vista desde una persona concreta: OtherID es su <Type>.
+type relative struct {
+	OtherID uint
+	Type    domain.RelationshipType
+}
+
+func (s *relationshipServiceImpl) AddRelationship(relationship *domain.Relationship, reciprocal bool, actor domain.Actor) (_ *domain.Relationship, err error) {
+	changes := map[string]domain.FieldChange{
+		"personId":        {New: textValue(strconv.FormatUint(uint64(relationship.PersonID), 10))},
+		"relatedPersonId": {New: textValue(strconv.FormatUint(uint64(relationship.RelatedPersonID), 10))},
+		"type":            {New: textValue(string(relationship.Type))},
+	}
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditRelationshipCreate, domain.AuditRelationshipEntity, relationship.ID, changes, err))
+	}()
+
+	if !relationship.Type.IsValid() {
+		return nil, fmt.Errorf("%w: unknown type %q", ports.ErrInvalidRelationship, relationship.Type)
+	}
+	if relationship.PersonID == relationship.RelatedPersonID {
+		return nil, fmt.Errorf("%w: a person cannot be related to themselves", ports.ErrInvalidRelationship)
+	}
+
+	// La relación se declara sobre las dos personas (y la inversa se guarda en la otra):
+	// el actor debe poder gestionar ambas, para no vincular a un desconocido.
+	for _, id := range []uint{relationship.PersonID, relationship.RelatedPersonID} {
+		if _, err := findAuthorizedPerson(s.personRepo, s.policy, id, ports.RelationshipResource, ports.ActionCreate, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.validate(relationship); err != nil {
+		return nil, err
+	}
+
+	// La relación y su inversa se guardan juntas: si falla la inversa no queda solo una.
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := saveRelationship(repos.Relationships, relationship); err != nil {
+			return err
+		}
+		if reciprocal {
+			err := saveRelationship(repos.Relationships, &domain.Relationship{
+				PersonID:        relationship.RelatedPersonID,
+				RelatedPersonID: relationship.PersonID,
+				Type:            relationship.Type.Inverse(),
+				Since:           relationship.Since,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditRelationshipCreate, domain.AuditRelationshipEntity, relationship.ID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return relationship, nil
+}
+
+// saveRelationship guarda la relación; si se registró a la vez por otra petición, la
+// clave única la rechaza como repetida.
+func saveRelationship(relationshipRepo ports.RelationshipRepository, relationship *domain.Relationship) error {
+	err := relationshipRepo.Save(relationship)
+	if errors.Is(err, ports.ErrConflict) {
+		return ports.ErrRelationshipExists
+	}
+	return err
+}
+
+// validate aplica las reglas del grafo familiar antes de guardar una relación nueva.
+func (s *relationshipServiceImpl) validate(relationship *domain.Relationship) error {
+	relatives, err := s.relativesOf(relationship.PersonID)
+	if err != nil {
+		return err
+	}
+	for _, rel := range relatives {
+		if rel.OtherID == relationship.RelatedPersonID && rel.Type == relationship.Type {
+			return ports.ErrRelationshipExists
+		}
+	}
+
+	switch relationship.Type {
+	case domain.SpouseRelationship:
+		// Una persona puede tener como máximo un cónyuge.
+		for _, id := range []uint{relationship.PersonID, relationship.RelatedPersonID} {
+			spouses, err := s.relativesOfType(id, domain.SpouseRelationship)
+			if err != nil {
+				return err
+			}
+			if len(spouses) > 0 {
+				return fmt.Errorf("%w: person %d already has a spouse", ports.ErrInvalidRelationship, id)
+			}
+		}
+
+	case domain.ParentRelationship, domain.ChildRelationship:
+		parentID, childID := relationship.RelatedPersonID, relationship.PersonID
+		if relationship.Type == domain.ChildRelationship {
+			parentID, childID = relationship.PersonID, relationship.RelatedPersonID
+		}
+
+		parents, err := s.relativesOfType(childID, domain.ParentRelationship)
+		if err != nil {
+			return err
+		}
+		if len(parents) >= maxParents {
+			return fmt.Errorf("%w: person %d already has %d parents", ports.ErrInvalidRelationship, childID, maxParents)
+		}
+
+		// El padre no puede ser descendiente del hijo: eso cerraría un ciclo.
+		isDescendant, err := s.isDescendant(childID, parentID)
+		if err != nil {
+			return err
+		}
+		if isDescendant {
+			return fmt.Errorf("%w: the relationship would create a cycle in the family tree", ports.ErrInvalidRelationship)
+		}
+	}
+
+	return nil
+}
+
+// isDescendant indica si candidateID es descendiente de ancestorID.
+func (s *relationshipServiceImpl) isDescendant(ancestorID, candidateID uint) (bool, error) {
+	visited := map[uint]bool{ancestorID: true}
+	queue := []uint{ancestorID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		children, err := s.relativesOfType(current, domain.ChildRelationship)
+		if err != nil {
+			return false, err
+		}
+		for _, childID := range children {
+			if childID == candidateID {
+				return true, nil
+			}
+			if !visited[childID] {
+				visited[childID] = true
+				queue = append(queue, childID)
+			}
+		}
+	}
+	return false, nil
+}
+
+func (s *relationshipServiceImpl) RemoveRelationship(personID, relationshipID uint, actor domain.Actor) (err error) {
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditRelationshipDelete, domain.AuditRelationshipEntity, relationshipID, nil, err))
+	}()
+
+	relationship, err := s.relationshipRepo.FindByID(relationshipID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return ports.ErrRelationshipNotFound
+		}
+		return err
+	}
+	if relationship.PersonID != personID && relationship.RelatedPersonID != personID {
+		return ports.ErrRelationshipNotFound
+	}
+
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, ports.RelationshipResource, ports.ActionDelete, actor); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Relationships.Delete(relationship.ID); err != nil {
+			return err
+		}
+
+		// Si existe la relación inversa, también se elimina.
+		inverse, err := repos.Relationships.Find(relationship.RelatedPersonID, relationship.PersonID, relationship.Type.Inverse())
+		switch {
+		case err == nil:
+			if err := repos.Relationships.Delete(inverse.ID); err != nil {
+				return err
+			}
+		case !errors.Is(err, ports.ErrNotFound):
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditRelationshipDelete, domain.AuditRelationshipEntity, relationshipID, nil, nil))
+	})
+}
+
+func (s *relationshipServiceImpl) GetRelationships(personID uint, actor domain.Actor) ([]domain.Relationship, error) {
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, ports.RelationshipResource, ports.ActionRead, actor); err != nil {
+		return nil, err
+	}
+	return s.relationshipRepo.FindInvolving(personID)
+}
+
+func (s *relationshipServiceImpl) GetHousehold(personID uint, actor domain.Actor) ([]domain.HouseholdMember, error) {
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, ports.RelationshipResource, ports.ActionRead, actor); err != nil {
+		return nil, err
+	}
+
+	relatives, err := s.relativesOf(personID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]domain.HouseholdMember, 0, len(relatives))
+	for _, rel := range relatives {
+		person, err := s.findPerson(rel.OtherID)
+		if err != nil {
+			if errors.Is(err, ports.ErrPersonNotFound) {
+				continue // La persona relacionada fue eliminada.
+			}
+			return nil, err
+		}
+		members = append(members, domain.HouseholdMember{Person: *person, Relation: rel.Type})
+	}
+	return members, nil
+}
+
+func (s *relationshipServiceImpl) GetFamilyTree(personID uint, depth int, actor domain.Actor) (*domain.FamilyTreeNode, error) {
+	if _, err := findAuthorizedPerson(s.personRepo, s.policy, personID, ports.RelationshipResource, ports.ActionRead, actor); err != nil {
+		return nil, err
+	}
+	if depth <= 0 {
+		depth = defaultFamilyDepth
+	}
+	if depth > maxFamilyDepth {
+		depth = maxFamilyDepth
+	}
+
+	root, err := s.treeNode(personID)
+	if err != nil {
+		return nil, err
+	}
+
+	visited := map[uint]bool{personID: true}
+	if root.Parents, err = s.branch(personID, domain.ParentRelationship, depth, visited); err != nil {
+		return nil, err
+	}
+	if root.Children, err = s.branch(personID, domain.ChildRelationship, depth, visited); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// branch arma recursivamente una rama del árbol en una sola dirección:
+// ancestros (parent) o descendientes (child).
+func (s *relationshipServiceImpl) branch(personID uint, direction domain.RelationshipType, depth int, visited map[uint]bool) ([]domain.FamilyTreeNode, error) {
+	if depth == 0 {
+		return nil, nil
+	}
+
+	ids, err := s.relativesOfType(personID, direction)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []domain.FamilyTreeNode
+	for _, id := range ids {
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		node, err := s.treeNode(id)
+		if err != nil {
+			if errors.Is(err, ports.ErrPersonNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		next, err := s.branch(id, direction, depth-1, visited)
+		if err != nil {
+			return nil, err
+		}
+		if direction == domain.ParentRelationship {
+			node.Parents = next
+		} else {
+			node.Children = next
+		}
+		nodes = append(nodes, *node)
+	}
+	return nodes, nil
+}
+
+func (s *relationshipServiceImpl) treeNode(personID uint) (*domain.FamilyTreeNode, error) {
+	person, err := s.findPerson(personID)
+	if err != nil {
+		return nil, err
+	}
+	node := &domain.FamilyTreeNode{Person: *person}
+
+	spouses, err := s.relativesOfType(personID, domain.SpouseRelationship)
+	if err != nil {
+		return nil, err
+	}
+	if len(spouses) > 0 {
+		spouse, err := s.findPerson(spouses[0])
+		if err != nil && !errors.Is(err, ports.ErrPersonNotFound) {
+			return nil, err
+		}
+		node.Spouse = spouse
+	}
+	return node, nil
+}
+
+// relativesOf devuelve todas las relaciones de la persona vistas desde ella,
+// sin duplicados aunque la relación esté guardada en ambos sentidos.
+func (s *relationshipServiceImpl) relativesOf(personID uint) ([]relative, error) {
+	relationships, err := s.relationshipRepo.FindInvolving(personID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[relative]bool)
+	var relatives []relative
+	for _, r := range relationships {
+		rel := relative{OtherID: r.RelatedPersonID, Type: r.Type}
+		if r.PersonID != personID {
+			rel = relative{OtherID: r.PersonID, Type: r.Type.Inverse()}
+		}
+		if !seen[rel] {
+			seen[rel] = true
+			relatives = append(relatives, rel)
+		}
+	}
+
+	sort.Slice(relatives, func(i, j int) bool {
+		if relatives[i].Type != relatives[j].Type {
+			return relatives[i].Type < relatives[j].Type
+		}
+		return relatives[i].OtherID < relatives[j].OtherID
+	})
+	return relatives, nil
+}
+
+func (s *relationshipServiceImpl) relativesOfType(personID uint, relType domain.RelationshipType) ([]uint, error) {
+	relatives, err := s.relativesOf(personID)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint
+	for _, rel := range relatives {
+		if rel.Type == relType {
+			ids = append(ids, rel.OtherID)
+		}
+	}
+	return ids, nil
+}
+
+func (s *relationshipServiceImpl) findPerson(id uint) (*domain.Person, error) {
+	person, err := s.personRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrPersonNotFound
+		}
+		return nil, err
+	}
+	return person, nil
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/policy"
+	"github.com/riada2/internal/repository/memory"
+)
+
+// failingInverse simula un error al guardar la relación inversa, a mitad de la transacción.
+type failingInverse struct {
+	ports.RelationshipRepository
+	personID uint
+}
+
+func (r failingInverse) Save(relationship *domain.Relationship) error {
+	if relationship.PersonID == r.personID {
+		return errors.New("connection lost")
+	}
+	return r.RelationshipRepository.Save(relationship)
+}
+
+type failingInverseUnitOfWork struct {
+	ports.UnitOfWork
+	personID uint
+}
+
+func (u failingInverseUnitOfWork) Do(fn func(repos ports.Repositories) error) error {
+	return u.UnitOfWork.Do(func(repos ports.Repositories) error {
+		repos.Relationships = failingInverse{repos.Relationships, u.personID}
+		return fn(repos)
+	})
+}
+
+// relationshipFixture arma el servicio de relaciones sobre los repositorios en memoria.
+type relationshipFixture struct {
+	relationships    ports.RelationshipService
+	store            *memory.Store
+	relationshipRepo ports.RelationshipRepository
+	personRepo       ports.PersonRepository
+	delegations      ports.DelegationRepository
+	policy           ports.Policy
+}
+
+func newRelationshipFixture() *relationshipFixture {
+	store := memory.NewStore()
+	relationshipRepo := memory.NewRelationshipRepository(store)
+	personRepo := memory.NewPersonRepository(store)
+	delegationRepo := memory.NewDelegationRepository(store)
+	accessPolicy := policy.New(delegationRepo)
+	return &relationshipFixture{
+		relationships:    NewRelationshipService(relationshipRepo, personRepo, memory.NewUnitOfWork(store), accessPolicy, newAuditService(store)),
+		store:            store,
+		relationshipRepo: relationshipRepo,
+		personRepo:       personRepo,
+		delegations:      delegationRepo,
+		policy:           accessPolicy,
+	}
+}
+
+func TestReciprocalRelationships(t *testing.T) {
+	f := newRelationshipFixture()
+	parent := addPerson(t, f.personRepo, &domain.Person{Name: "Rosa", LastName: "Quispe"})
+	child := addPerson(t, f.personRepo, &domain.Person{Name: "Ana", LastName: "Quispe"})
+	relationshipRepo := f.relationshipRepo
+
+	t.Run("a failed inverse rolls back the relationship", func(t *testing.T) {
+		relationships := NewRelationshipService(relationshipRepo, f.personRepo,
+			failingInverseUnitOfWork{memory.NewUnitOfWork(f.store), parent.ID}, f.policy, newAuditService(f.store))
+		relationship := &domain.Relationship{PersonID: child.ID, RelatedPersonID: parent.ID, Type: domain.ParentRelationship}
+		if _, err := relationships.AddRelationship(relationship, true, admin); err == nil {
+			t.Fatal("AddRelationship should fail")
+		}
+		if found, err := relationshipRepo.FindInvolving(child.ID); err != nil || len(found) != 0 {
+			t.Fatalf("a failed inverse should not leave the relationship, got %+v, %v", found, err)
+		}
+	})
+
+	t.Run("removing a relationship removes its inverse", func(t *testing.T) {
+		relationship := &domain.Relationship{PersonID: child.ID, RelatedPersonID: parent.ID, Type: domain.ParentRelationship}
+		if _, err := f.relationships.AddRelationship(relationship, true, admin); err != nil {
+			t.Fatalf("AddRelationship: %v", err)
+		}
+		if found, err := relationshipRepo.FindInvolving(child.ID); err != nil || len(found) != 2 {
+			t.Fatalf("expected the relationship and its inverse, got %+v, %v", found, err)
+		}
+		if err := f.relationships.RemoveRelationship(child.ID, relationship.ID, admin); err != nil {
+			t.Fatalf("RemoveRelationship: %v", err)
+		}
+		if found, err := relationshipRepo.FindInvolving(child.ID); err != nil || len(found) != 0 {
+			t.Fatalf("expected no relationships, got %+v, %v", found, err)
+		}
+	})
+}
+
+func TestDelegateManagesRelationships(t *testing.T) {
+	f := newRelationshipFixture()
+	child := addPerson(t, f.personRepo, &domain.Person{Name: "Ana", UserID: &owner.UserID})
+	parent := addPerson(t, f.personRepo, &domain.Person{Name: "Rosa"})
+	if err := f.delegations.Save(&domain.Delegation{UserID: delegate.UserID, PersonID: child.ID, Status: domain.DelegationActive}); err != nil {
+		t.Fatalf("Save delegation: %v", err)
+	}
+
+	relationship := &domain.Relationship{PersonID: child.ID, RelatedPersonID: parent.ID, Type: domain.ParentRelationship}
+	if _, err := f.relationships.AddRelationship(relationship, true, stranger); !errors.Is(err, ports.ErrForbidden) {
+		t.Fatalf("stranger: expected ErrForbidden, got %v", err)
+	}
+	// El delegado no gestiona a la otra persona: no puede declararla madre de Ana.
+	if _, err := f.relationships.AddRelationship(relationship, false, delegate); !errors.Is(err, ports.ErrForbidden) {
+		t.Fatalf("delegate of one person: expected ErrForbidden, got %v", err)
+	}
+
+	if err := f.delegations.Save(&domain.Delegation{UserID: delegate.UserID, PersonID: parent.ID, Status: domain.DelegationActive}); err != nil {
+		t.Fatalf("Save delegation: %v", err)
+	}
+	if _, err := f.relationships.AddRelationship(relationship, true, delegate); err != nil {
+		t.Fatalf("delegate: AddRelationship: %v", err)
+	}
+	if err := f.relationships.RemoveRelationship(child.ID, relationship.ID, delegate); err != nil {
+		t.Fatalf("delegate: RemoveRelationship: %v", err)
+	}
+}
+
+func TestFamilyGraphIsReadByAuthorizedActors(t *testing.T) {
+	f := newRelationshipFixture()
+	child := addPerson(t, f.personRepo, &domain.Person{Name: "Ana", UserID: &owner.UserID})
+	parent := addPerson(t, f.personRepo, &domain.Person{Name: "Rosa"})
+	relationship := &domain.Relationship{PersonID: child.ID, RelatedPersonID: parent.ID, Type: domain.ParentRelationship}
+	if _, err := f.relationships.AddRelationship(relationship, true, admin); err != nil {
+		t.Fatalf("AddRelationship: %v", err)
+	}
+
+	reads := map[string]func(domain.Actor) error{
+		"relationships": func(actor domain.Actor) error {
+			_, err := f.relationships.GetRelationships(child.ID, actor)
+			return err
+		},
+		"household": func(actor domain.Actor) error {
+			_, err := f.relationships.GetHousehold(child.ID, actor)
+			return err
+		},
+		"family tree": func(actor domain.Actor) error {
+			_, err := f.relationships.GetFamilyTree(child.ID, 0, actor)
+			return err
+		},
+	}
+	for name, read := range reads {
+		if err := read(owner); err != nil {
+			t.Errorf("%s: the owner should read it, got %v", name, err)
+		}
+		if err := read(admin); err != nil {
+			t.Errorf("%s: an admin should read it, got %v", name, err)
+		}
+		if err := read(stranger); !errors.Is(err, ports.ErrForbidden) {
+			t.Errorf("%s: stranger expected ErrForbidden, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// Listas con las que se arman las personas de demostración.
+var (
+	seedFemaleNames = []string{
+		"María", "Rosa", "Carmen", "Ana", "Luz", "Juana", "Elena", "Lucía", "Sofía", "Valeria",
+		"Camila", "Milagros", "Yolanda", "Gladys", "Flor", "Julia", "Teresa", "Patricia", "Diana", "Fiorella",
+		"Nélida", "Isabel", "Ximena", "Graciela", "Marisol",
+	}
+	seedMaleNames = []string{
+		"José", "Juan", "Luis", "Carlos", "Jorge", "Miguel", "Pedro", "Víctor", "César", "Manuel",
+		"Jesús", "Ángel", "Diego", "Sebastián", "Mateo", "Alonso", "Rubén", "Walter", "Hugo", "Edwin",
+		"Percy", "Raúl", "Óscar", "Fernando", "Renzo",
+	}
+	seedSurnames = []string{
+		"Quispe", "Mamani", "Flores", "Huamán", "Rojas", "Sánchez", "García", "Chávez", "Torres", "Ramírez",
+		"Mendoza", "Vargas", "Castillo", "Espinoza", "Ramos", "Díaz", "Gutiérrez", "Condori", "Cruz", "Vásquez",
+		"Silva", "Pérez", "Gonzales", "Rodríguez", "López", "Huanca", "Ticona", "Paredes", "Cárdenas", "Salazar",
+		"Córdova", "Ccori", "Apaza", "Villanueva", "Zapata",
+	}
+	seedStreetTypes = []string{"Av.", "Jr.", "Calle", "Psje."}
+	seedStreetNames = []string{
+		"Los Olivos", "San Martín", "Grau", "Bolognesi", "Túpac Amaru", "Arequipa", "Los Pinos", "Las Flores",
+		"Tacna", "Huáscar", "Santa Rosa", "Alfonso Ugarte", "José Olaya", "Libertad", "Los Incas", "Manco Cápac",
+	}
+	seedReferences = []string{
+		"Frente al parque", "Cerca al mercado", "Al costado de la iglesia", "A media cuadra del colegio",
+		"Altura del paradero", "Portón verde",
+	}
+)
+
+const (
+	// Los DNI de demostración son de 8 dígitos entre 10000000 y 79999999. Se obtienen
+	// recorriendo ese rango con un paso coprimo con su tamaño, así que no se repiten
+	// entre personas de una misma semilla.
+	seedDNIBase  = 10_000_000
+	seedDNIRange = 70_000_000
+	seedDNIStep  = 48_271
+	// limaProvince es Lima Metropolitana, donde vive la mitad de las personas generadas.
+	limaProvince = "1501"
+)
+
+// seedBirthdayStart es la fecha de nacimiento más antigua: las personas nacen entre
+// 1935 y 2019. El rango es fijo para que la misma semilla dé las mismas fechas en
+// cualquier momento.
+var seedBirthdayStart = time.Date(1935, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+const seedBirthdayDays = 85 * 365
+
+// seedGenerator genera las personas de demostración de una semilla. Cada persona
+// depende solo de la semilla y de su posición, así que la persona i es la misma en
+// cualquier carga con esa semilla, sea cual sea el volumen. Las direcciones se reparten
+// entre los distritos del catálogo configurado: con el catálogo incluido, que es
+// parcial, solo entre las capitales, Lima Metropolitana y el Callao.
+type seedGenerator struct {
+	seed      uint64
+	districts []string // códigos de ubigeo de todos los distritos, ordenados
+	lima      []string // los de Lima Metropolitana
+}
+
+// seededPerson es una persona generada y el nombre de su cuenta de usuario.
+type seededPerson struct {
+	person   domain.Person
+	username string
+}
+
+func newSeedGenerator(seed int64, catalog ports.UbigeoCatalog) (*seedGenerator, error) {
+	g := &seedGenerator{seed: uint64(seed)}
+	for _, department := range catalog.Departments() {
+		provinces, err := catalog.Provinces(department.Code)
+		if err != nil {
+			return nil, err
+		}
+		for _, province := range provinces {
+			districts, err := catalog.Districts(province.Code)
+			if err != nil {
+				return nil, err
+			}
+			for _, district := range districts {
+				g.districts = append(g.districts, district.Code)
+				if province.Code == limaProvince {
+					g.lima = append(g.lima, district.Code)
+				}
+			}
+		}
+	}
+	if len(g.districts) == 0 {
+		return nil, fmt.Errorf("%w: the ubigeo catalog has no districts", ports.ErrInvalidSeedOptions)
+	}
+	sort.Strings(g.districts)
+	sort.Strings(g.lima)
+	return g, nil
+}
+
+// person genera la persona de la posición index.
+func (g *seedGenerator) person(index int) seededPerson {
+	rng := rand.New(rand.NewSource(int64(splitMix(g.seed ^ splitMix(uint64(index))))))
+	pick := func(values []string) string { return values[rng.Intn(len(values))] }
+
+	sex, names := domain.Female, seedFemaleNames
+	if rng.Intn(2) == 0 {
+		sex, names = domain.Male, seedMaleNames
+	}
+	person := domain.Person{Name: pick(names), Sex: sex}
+	if rng.Intn(10) < 4 {
+		if middleName := pick(names); middleName != person.Name {
+			person.MiddleName = middleName
+		}
+	}
+	// Apellido paterno y materno, como en los documentos peruanos.
+	paternal := pick(seedSurnames)
+	person.LastName = paternal + " " + pick(seedSurnames)
+
+	dni := fmt.Sprintf("%08d", seedDNIBase+(uint64(index)*seedDNIStep+splitMix(g.seed)%seedDNIRange)%seedDNIRange)
+	docType := domain.DNI
+	person.TypeDoc, person.DocNumber = &docType, &dni
+
+	birthday := seedBirthdayStart.AddDate(0, 0, rng.Intn(seedBirthdayDays))
+	person.Birthday = &birthday
+
+	username := usernamePart(person.Name) + "." + usernamePart(paternal) + dni[len(dni)-4:]
+	if rng.Intn(10) < 6 {
+		email := username + "@example.com"
+		person.Email = &email
+	}
+
+	// Una dirección, en Lima Metropolitana la mitad de las veces.
+	ubigeo := pick(g.districts)
+	if len(g.lima) > 0 && rng.Intn(2) == 0 {
+		ubigeo = pick(g.lima)
+	}
+	address := domain.Address{
+		Street:  pick(seedStreetTypes) + " " + pick(seedStreetNames),
+		Number:  strconv.Itoa(100 + rng.Intn(3900)),
+		Ubigeo:  ubigeo,
+		Country: "PE",
+	}
+	if rng.Intn(10) < 3 {
+		address.Reference = pick(seedReferences)
+	}
+	person.Addresses = []domain.Address{address}
+
+	// Un celular principal y, a veces, un teléfono fijo (en Lima) o un WhatsApp aparte.
+	person.Phones = []domain.Phone{{Phone: mobileNumber(rng), Type: domain.MobilePhone, IsPrimary: true}}
+	switch n := rng.Intn(10); {
+	case n < 2 && strings.HasPrefix(ubigeo, limaProvince):
+		person.Phones = append(person.Phones, domain.Phone{Phone: fmt.Sprintf("+51 1 %d%02d %04d", 2+rng.Intn(6), rng.Intn(100), rng.Intn(10000)), Type: domain.HomePhone})
+	case n < 3:
+		person.Phones = append(person.Phones, domain.Phone{Phone: mobileNumber(rng), Type: domain.WhatsAppPhone})
+	}
+
+	return seededPerson{person: person, username: username}
+}
+
+// mobileNumber genera un celular peruano: 9 dígitos que empiezan con 9.
+func mobileNumber(rng *rand.Rand) string {
+	return fmt.Sprintf("+51 9%02d %03d %03d", rng.Intn(100), rng.Intn(1000), rng.Intn(1000))
+}
+
+// usernamePart es un nombre en minúsculas, sin tildes ni espacios.
+func usernamePart(name string) string {
+	return strings.ReplaceAll(domain.PlaceKey(name), " ", "")
+}
+
+// splitMix mezcla los bits de x (SplitMix64) para derivar semillas independientes.
+func splitMix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
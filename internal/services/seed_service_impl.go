@@ -0,0 +1,103 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// maxSeedErrors es la cantidad de errores que se detallan en el informe de la carga.
+const maxSeedErrors = 10
+
+type seedServiceImpl struct {
+	personService ports.PersonService
+	userService   ports.UserService
+	personRepo    ports.PersonRepository
+	catalog       ports.UbigeoCatalog
+}
+
+// NewSeedService crea el servicio de datos de demostración. Las personas y las cuentas se
+// crean con los servicios de personas y usuarios, con sus mismas validaciones y auditoría.
+func NewSeedService(personService ports.PersonService, userService ports.UserService, personRepo ports.PersonRepository, catalog ports.UbigeoCatalog) ports.SeedService {
+	return &seedServiceImpl{personService, userService, personRepo, catalog}
+}
+
+func (s *seedServiceImpl) Seed(options ports.SeedOptions, actor domain.Actor) (*domain.SeedReport, error) {
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	switch {
+	case options.Persons <= 0 || options.Persons > ports.MaxSeedPersons:
+		return nil, fmt.Errorf("%w: the number of persons must be between 1 and %d", ports.ErrInvalidSeedOptions, ports.MaxSeedPersons)
+	case options.Accounts < 0 || options.Accounts > options.Persons:
+		return nil, fmt.Errorf("%w: the number of accounts must be between 0 and the number of persons", ports.ErrInvalidSeedOptions)
+	case options.Accounts > 0 && options.Password == "":
+		return nil, fmt.Errorf("%w: a password is required for the accounts", ports.ErrInvalidSeedOptions)
+	}
+
+	generator, err := newSeedGenerator(options.Seed, s.catalog)
+	if err != nil {
+		return nil, err
+	}
+	report := &domain.SeedReport{Seed: options.Seed, Persons: options.Persons}
+	fail := func(seeded *seededPerson, err error) {
+		report.Failed++
+		if len(report.Errors) < maxSeedErrors {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s (DNI %s): %v", seeded.person.FullName(), *seeded.person.DocNumber, err))
+		}
+	}
+
+	for i := 0; i < options.Persons; i++ {
+		seeded := generator.person(i)
+
+		// El DNI identifica a la persona generada: si ya existe, es de una carga anterior.
+		_, err := s.personRepo.FindByDocument(domain.DNI, *seeded.person.DocNumber)
+		if err == nil {
+			report.Existing++
+			continue
+		}
+		if !errors.Is(err, ports.ErrNotFound) {
+			return report, err
+		}
+
+		if i < options.Accounts {
+			userID, created, err := s.account(seeded.username, options.Password, actor)
+			if err != nil {
+				fail(&seeded, err)
+				continue
+			}
+			if created {
+				report.Accounts++
+			}
+			seeded.person.UserID = userID
+		}
+
+		if _, err := s.personService.CreatePerson(&seeded.person, actor); err != nil {
+			fail(&seeded, err)
+			continue
+		}
+		report.Created++
+	}
+	return report, nil
+}
+
+// account devuelve la cuenta de username para vincularla a la persona, y la crea si no
+// existe. Una cuenta que ya existe y tiene su propia persona (un nombre repetido, o una
+// cuenta real con ese nombre) no se vincula: la persona queda sin cuenta.
+func (s *seedServiceImpl) account(username, password string, actor domain.Actor) (_ *uint, created bool, err error) {
+	existing, err := s.userService.GetUserByUsername(username)
+	if err == nil {
+		if _, err := s.personRepo.FindByUserID(existing.ID); !errors.Is(err, ports.ErrNotFound) {
+			return nil, false, err
+		}
+		return &existing.ID, false, nil
+	}
+
+	user, err := s.userService.CreateUser(username, password, domain.UserRole, actor)
+	if err != nil {
+		return nil, false, err
+	}
+	return &user.ID, true, nil
+}
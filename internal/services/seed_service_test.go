@@ -0,0 +1,155 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+	"github.com/riada2/internal/policy"
+	"github.com/riada2/internal/repository/memory"
+	"github.com/riada2/internal/ubigeo"
+)
+
+// seedFixture arma el servicio de carga de datos de prueba sobre los repositorios en memoria.
+type seedFixture struct {
+	seeds      ports.SeedService
+	users      ports.UserService
+	personRepo ports.PersonRepository
+}
+
+func newSeedFixture(t *testing.T) *seedFixture {
+	t.Helper()
+	catalog, err := ubigeo.Load("")
+	if err != nil {
+		t.Fatalf("ubigeo.Load: %v", err)
+	}
+	store := memory.NewStore()
+	personRepo := memory.NewPersonRepository(store)
+	persons := newPersonService(t, store, newSettings(store), policy.New(memory.NewDelegationRepository(store)))
+	users := NewUserService(memory.NewUserRepository(store), memory.NewUnitOfWork(store), "secret", time.Hour, newAuditService(store))
+	return &seedFixture{
+		seeds:      NewSeedService(persons, users, personRepo, catalog),
+		users:      users,
+		personRepo: personRepo,
+	}
+}
+
+// seededPeople devuelve las personas guardadas por una carga, ordenadas por ID.
+func seededPeople(t *testing.T, f *seedFixture) []domain.Person {
+	t.Helper()
+	people, err := f.personRepo.Search(ports.PersonFilter{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	return people
+}
+
+func TestSeedIsReproducible(t *testing.T) {
+	options := ports.SeedOptions{Seed: 7, Persons: 40}
+	load := func() []domain.Person {
+		f := newSeedFixture(t)
+		report, err := f.seeds.Seed(options, admin)
+		if err != nil {
+			t.Fatalf("Seed: %v", err)
+		}
+		if report.Created != options.Persons || report.Failed != 0 {
+			t.Fatalf("every person should be created, got %+v", report)
+		}
+		return seededPeople(t, f)
+	}
+
+	first, second := load(), load()
+	dni := regexp.MustCompile(`^[1-7][0-9]{7}$`)
+	mobile := regexp.MustCompile(`^\+519[0-9]{8}$`)
+	for i := range first {
+		a, b := first[i], second[i]
+		if a.FullName() != b.FullName() || *a.DocNumber != *b.DocNumber || !a.Birthday.Equal(*b.Birthday) ||
+			a.Phones[0].Phone != b.Phones[0].Phone || a.Addresses[0].Ubigeo != b.Addresses[0].Ubigeo {
+			t.Fatalf("the same seed generated different persons: %s and %s", a.FullName(), b.FullName())
+		}
+		if !dni.MatchString(*a.DocNumber) {
+			t.Errorf("%s has an invalid DNI %q", a.FullName(), *a.DocNumber)
+		}
+		if !mobile.MatchString(a.Phones[0].Phone) || !a.Phones[0].IsPrimary {
+			t.Errorf("%s should have a primary Peruvian mobile, got %+v", a.FullName(), a.Phones[0])
+		}
+		if a.Addresses[0].District == "" {
+			t.Errorf("the address of %s should be completed from the ubigeo catalog", a.FullName())
+		}
+	}
+
+	other := newSeedFixture(t)
+	if _, err := other.seeds.Seed(ports.SeedOptions{Seed: 8, Persons: 40}, admin); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	if people := seededPeople(t, other); *people[0].DocNumber == *first[0].DocNumber {
+		t.Fatal("another seed should generate other persons")
+	}
+}
+
+func TestSeedRerunOnlyAddsMissingPersons(t *testing.T) {
+	f := newSeedFixture(t)
+	if _, err := f.seeds.Seed(ports.SeedOptions{Seed: 1, Persons: 10, Accounts: 3, Password: "demo"}, admin); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	report, err := f.seeds.Seed(ports.SeedOptions{Seed: 1, Persons: 15, Accounts: 3, Password: "demo"}, admin)
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	if report.Existing != 10 || report.Created != 5 || report.Accounts != 0 {
+		t.Fatalf("a larger rerun should only add the 5 new persons, got %+v", report)
+	}
+	people := seededPeople(t, f)
+	if len(people) != 15 {
+		t.Fatalf("got %d persons, want 15", len(people))
+	}
+
+	users, err := f.users.GetAllUsers()
+	if err != nil {
+		t.Fatalf("GetAllUsers: %v", err)
+	}
+	accounts := make(map[uint]domain.Role, len(users))
+	for _, user := range users {
+		accounts[user.ID] = user.Role
+	}
+	linked := 0
+	for _, person := range people {
+		if person.UserID == nil {
+			continue
+		}
+		linked++
+		if accounts[*person.UserID] != domain.UserRole {
+			t.Errorf("%s is linked to a missing account %d", person.FullName(), *person.UserID)
+		}
+	}
+	if linked != 3 {
+		t.Fatalf("%d persons have an account, want 3", linked)
+	}
+}
+
+func TestSeedOptions(t *testing.T) {
+	f := newSeedFixture(t)
+	tests := []struct {
+		name    string
+		options ports.SeedOptions
+		actor   domain.Actor
+		want    error
+	}{
+		{"not admin", ports.SeedOptions{Persons: 1}, owner, ports.ErrForbidden},
+		{"no persons", ports.SeedOptions{}, admin, ports.ErrInvalidSeedOptions},
+		{"too many persons", ports.SeedOptions{Persons: ports.MaxSeedPersons + 1}, admin, ports.ErrInvalidSeedOptions},
+		{"more accounts than persons", ports.SeedOptions{Persons: 1, Accounts: 2, Password: "demo"}, admin, ports.ErrInvalidSeedOptions},
+		{"accounts without password", ports.SeedOptions{Persons: 1, Accounts: 1}, admin, ports.ErrInvalidSeedOptions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := f.seeds.Seed(tt.options, tt.actor); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
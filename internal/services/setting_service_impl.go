@@ -0,0 +1,290 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// settingsCacheTTL es el tiempo que se reutiliza la configuración leída. Los cambios
+// hechos en esta instancia se ven de inmediato; los de otras instancias, tras este plazo.
+const settingsCacheTTL = time.Minute
+
+// settingDefinition describe una clave de configuración: su valor por defecto y cómo se
+// interpreta. apply devuelve un mensaje de error si el valor no es válido.
+type settingDefinition struct {
+	defaultValue string
+	description  string
+	apply        func(settings *domain.Settings, value string) error
+}
+
+// Los límites por defecto conservan los que antes estaban fijos en el código.
+var settingDefinitions = map[string]settingDefinition{
+	domain.SettingMaxAddresses: {
+		defaultValue: "2",
+		description:  "Maximum number of own addresses per person; 0 means no limit.",
+		apply: func(settings *domain.Settings, value string) (err error) {
+			settings.MaxAddresses, err = parseLimitSetting(value)
+			return err
+		},
+	},
+	domain.SettingMaxPhones: {
+		defaultValue: "2",
+		description:  "Maximum number of own phones per person; 0 means no limit.",
+		apply: func(settings *domain.Settings, value string) (err error) {
+			settings.MaxPhones, err = parseLimitSetting(value)
+			return err
+		},
+	},
+	domain.SettingRequirePrimaryPhone: {
+		defaultValue: "true",
+		description:  "Whether every person with phones must have exactly one primary phone.",
+		apply: func(settings *domain.Settings, value string) (err error) {
+			settings.RequirePrimaryPhone, err = strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("must be true or false")
+			}
+			return nil
+		},
+	},
+	domain.SettingRequiredPersonFields: {
+		defaultValue: "",
+		description:  "Comma-separated person fields that are required: " + strings.Join(domain.PersonFields, ", ") + ".",
+		apply: func(settings *domain.Settings, value string) error {
+			settings.RequiredPersonFields = nil
+			for _, field := range splitSettingList(value) {
+				if !slices.Contains(domain.PersonFields, field) {
+					return fmt.Errorf("unknown person field %q", field)
+				}
+				if !slices.Contains(settings.RequiredPersonFields, field) {
+					settings.RequiredPersonFields = append(settings.RequiredPersonFields, field)
+				}
+			}
+			return nil
+		},
+	},
+}
+
+type settingsServiceImpl struct {
+	settingRepo ports.SettingRepository
+	uow         ports.UnitOfWork
+	audit       ports.AuditService
+
+	mu       sync.Mutex
+	cached   *domain.Settings
+	loadedAt time.Time
+}
+
+func NewSettingsService(settingRepo ports.SettingRepository, uow ports.UnitOfWork, audit ports.AuditService) ports.SettingsService {
+	return &settingsServiceImpl{settingRepo: settingRepo, uow: uow, audit: audit}
+}
+
+func (s *settingsServiceImpl) Current() (domain.Settings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached != nil && time.Since(s.loadedAt) < settingsCacheTTL {
+		return *s.cached, nil
+	}
+	stored, err := s.settingRepo.List()
+	if err != nil {
+		return domain.Settings{}, err
+	}
+	settings := buildSettings(stored)
+	s.cached, s.loadedAt = &settings, time.Now()
+	return settings, nil
+}
+
+func (s *settingsServiceImpl) ListSettings(actor domain.Actor) ([]domain.SettingInfo, error) {
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+	stored, err := s.settingRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	return settingInfos(stored), nil
+}
+
+func (s *settingsServiceImpl) UpdateSettings(values map[string]string, actor domain.Actor) (_ []domain.SettingInfo, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditSettingsUpdate, domain.AuditSettingEntity, 0, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+
+	stored, err := s.settingRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]string, len(stored))
+	for _, setting := range stored {
+		current[setting.Key] = setting.Value
+	}
+
+	// Se validan todas las claves antes de guardar ninguna.
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var updated []domain.Setting
+	changes = make(map[string]domain.FieldChange)
+	now := time.Now()
+	for _, key := range keys {
+		definition, ok := settingDefinitions[key]
+		if !ok {
+			return nil, &ports.ValidationError{Err: ports.ErrInvalidSetting, Field: key, Code: ports.SettingUnknownCode,
+				Message: fmt.Sprintf("unknown setting %q", key)}
+		}
+		value := strings.TrimSpace(values[key])
+		var parsed domain.Settings
+		if err := definition.apply(&parsed, value); err != nil {
+			return nil, &ports.ValidationError{Err: ports.ErrInvalidSetting, Field: key, Code: ports.SettingInvalidCode,
+				Message: fmt.Sprintf("%s: %v", key, err)}
+		}
+		old, ok := current[key]
+		if !ok {
+			old = definition.defaultValue
+		}
+		if old == value {
+			continue
+		}
+		userID := actor.UserID
+		updated = append(updated, domain.Setting{Key: key, Value: value, UpdatedBy: &userID, UpdatedAt: now})
+		changes[key] = domain.FieldChange{Old: textValue(old), New: textValue(value)}
+	}
+
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if len(updated) > 0 {
+			if err := repos.Settings.SaveAll(updated); err != nil {
+				return err
+			}
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditSettingsUpdate, domain.AuditSettingEntity, 0, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(updated) > 0 {
+		s.mu.Lock()
+		s.cached = nil
+		s.mu.Unlock()
+	}
+
+	stored, err = s.settingRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	return settingInfos(stored), nil
+}
+
+// buildSettings interpreta los valores guardados sobre los valores por defecto. Un valor
+// guardado que ya no es válido (por ejemplo, escrito a mano en la base) se ignora.
+func buildSettings(stored []domain.Setting) domain.Settings {
+	var settings domain.Settings
+	for _, definition := range settingDefinitions {
+		_ = definition.apply(&settings, definition.defaultValue)
+	}
+	for _, setting := range stored {
+		if definition, ok := settingDefinitions[setting.Key]; ok {
+			if err := definition.apply(&settings, setting.Value); err != nil {
+				_ = definition.apply(&settings, definition.defaultValue)
+			}
+		}
+	}
+	return settings
+}
+
+// settingInfos devuelve todas las claves conocidas ordenadas, con su valor vigente.
+func settingInfos(stored []domain.Setting) []domain.SettingInfo {
+	byKey := make(map[string]domain.Setting, len(stored))
+	for _, setting := range stored {
+		byKey[setting.Key] = setting
+	}
+
+	infos := make([]domain.SettingInfo, 0, len(settingDefinitions))
+	for key, definition := range settingDefinitions {
+		info := domain.SettingInfo{Key: key, Value: definition.defaultValue, DefaultValue: definition.defaultValue, Description: definition.description}
+		if setting, ok := byKey[key]; ok {
+			updatedAt := setting.UpdatedAt
+			info.Value, info.UpdatedBy, info.UpdatedAt = setting.Value, setting.UpdatedBy, &updatedAt
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Key < infos[j].Key })
+	return infos
+}
+
+func parseLimitSetting(value string) (int, error) {
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("must be a non-negative integer")
+	}
+	return limit, nil
+}
+
+// splitSettingList separa una lista separada por comas, descartando los elementos vacíos.
+func splitSettingList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// currentSettings lee la configuración vigente; sin servicio de configuración usa los valores por defecto.
+func currentSettings(settings ports.SettingsService) (domain.Settings, error) {
+	if settings == nil {
+		return buildSettings(nil), nil
+	}
+	return settings.Current()
+}
+
+// checkRequiredPersonFields valida los campos que la configuración declara obligatorios.
+// Las direcciones y teléfonos del hogar cuentan como propios.
+func checkRequiredPersonFields(settings domain.Settings, person *domain.Person) error {
+	missing := func(field string) error {
+		return &ports.ValidationError{Err: ports.ErrInvalidPerson, Field: field, Code: ports.FieldRequiredCode,
+			Message: fmt.Sprintf("%s is required", field)}
+	}
+	for _, field := range settings.RequiredPersonFields {
+		var empty bool
+		switch field {
+		case domain.PersonFieldMiddleName:
+			empty = strings.TrimSpace(person.MiddleName) == ""
+		case domain.PersonFieldLastName:
+			empty = strings.TrimSpace(person.LastName) == ""
+		case domain.PersonFieldSex:
+			empty = person.Sex == ""
+		case domain.PersonFieldBirthday:
+			empty = person.Birthday == nil
+		case domain.PersonFieldDocument:
+			empty = person.TypeDoc == nil || person.DocNumber == nil || strings.TrimSpace(*person.DocNumber) == ""
+		case domain.PersonFieldEmail:
+			empty = person.Email == nil || strings.TrimSpace(*person.Email) == ""
+		case domain.PersonFieldPhoto:
+			empty = person.Photo == nil || *person.Photo == ""
+		case domain.PersonFieldAddress:
+			empty = len(person.Addresses) == 0 && (person.Household == nil || len(person.Household.Addresses) == 0)
+		case domain.PersonFieldPhone:
+			empty = len(person.Phones) == 0 && (person.Household == nil || len(person.Household.Phones) == 0)
+		}
+		if empty {
+			return missing(field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+const maxTagLength = 50
+
+type tagServiceImpl struct {
+	tagRepo    ports.TagRepository
+	personRepo ports.PersonRepository
+	uow        ports.UnitOfWork
+	audit      ports.AuditService
+}
+
+func NewTagService(tagRepo ports.TagRepository, personRepo ports.PersonRepository, uow ports.UnitOfWork, audit ports.AuditService) ports.TagService {
+	return &tagServiceImpl{tagRepo, personRepo, uow, audit}
+}
+
+func (s *tagServiceImpl) ListTags() ([]domain.Tag, error) {
+	return s.tagRepo.List()
+}
+
+func (s *tagServiceImpl) SetPersonTags(personID uint, names []string, actor domain.Actor) (tags []domain.Tag, err error) {
+	var changes map[string]domain.FieldChange
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditPersonTags, string(domain.PersonEntity), personID, changes, err))
+	}()
+
+	if !actor.IsAdmin() {
+		return nil, ports.ErrForbidden
+	}
+
+	names = normalizeTagNames(names)
+	for _, name := range names {
+		if utf8.RuneCountInString(name) > maxTagLength {
+			return nil, fmt.Errorf("%w: %q is longer than %d characters", ports.ErrInvalidTag, name, maxTagLength)
+		}
+	}
+
+	person, err := s.personRepo.FindByID(personID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrPersonNotFound
+		}
+		return nil, err
+	}
+
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		created, err := repos.Tags.FindOrCreate(names)
+		if err != nil {
+			return err
+		}
+		if err := repos.Tags.ReplacePersonTags(personID, created); err != nil {
+			return err
+		}
+		changes = diffSnapshots(
+			map[string]*string{"tags": textValue(joinTagNames(person.Tags))},
+			map[string]*string{"tags": textValue(joinTagNames(created))},
+		)
+		tags = created
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditPersonTags, string(domain.PersonEntity), personID, changes, nil))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// normalizeTagNames normaliza los nombres, descarta los vacíos y elimina duplicados.
+func normalizeTagNames(names []string) []string {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, name := range names {
+		name = domain.NormalizeTagName(name)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func joinTagNames(tags []domain.Tag) string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
@@ -9,18 +9,23 @@ import (
 	"github.com/riada2/internal/core/ports"
 
 	"golang.org/x/crypto/bcrypt"
-	"gorm.io/gorm"
 )
 
 type userServiceImpl struct {
 	userRepo  ports.UserRepository
+	uow       ports.UnitOfWork
 	jwtSecret string
+	tokenTTL  time.Duration // vigencia de los tokens de sesión
+	audit     ports.AuditService
 }
 
-func NewUserService(repo ports.UserRepository, jwtSecret string) ports.UserService {
+func NewUserService(repo ports.UserRepository, uow ports.UnitOfWork, jwtSecret string, tokenTTL time.Duration, audit ports.AuditService) ports.UserService {
 	return &userServiceImpl{
 		userRepo:  repo,
+		uow:       uow,
 		jwtSecret: jwtSecret,
+		tokenTTL:  tokenTTL,
+		audit:     audit,
 	}
 }
 
@@ -35,9 +40,25 @@ func toUserResponse(user *domain.User) domain.UserResponse {
 	}
 }
 
-func (s *userServiceImpl) Register(username, password string) (*domain.User, error) {
+func (s *userServiceImpl) Register(username, password string, actor domain.Actor) (*domain.User, error) {
+	return s.CreateUser(username, password, domain.UserRole, actor)
+}
+
+func (s *userServiceImpl) CreateUser(username, password string, role domain.Role, actor domain.Actor) (user *domain.User, err error) {
+	changes := map[string]domain.FieldChange{"username": {New: textValue(username)}, "role": {New: textValue(string(role))}}
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditUserRegister, domain.AuditUserEntity, 0, changes, err))
+	}()
+
+	if username == "" || password == "" {
+		return nil, errors.New("username and password are required")
+	}
+	if !role.IsValid() {
+		return nil, errors.New("invalid role, use admin or user")
+	}
+
 	// Verificar si el usuario ya existe
-	if _, err := s.userRepo.FindByUsername(username); !errors.Is(err, gorm.ErrRecordNotFound) {
+	if _, err := s.userRepo.FindByUsername(username); !errors.Is(err, ports.ErrNotFound) {
 		return nil, errors.New("username already exists")
 	}
 
@@ -46,20 +67,38 @@ func (s *userServiceImpl) Register(username, password string) (*domain.User, err
 		return nil, err
 	}
 
-	user := &domain.User{
+	user = &domain.User{
 		Username:     username,
 		PasswordHash: string(hashedPassword),
-		Role:         domain.UserRole, // Por defecto, rol 'user'
+		Role:         role,
 	}
 
-	if err := s.userRepo.Save(user); err != nil {
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Users.Save(user); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditUserRegister, domain.AuditUserEntity, user.ID, changes, nil))
+	})
+	if err != nil {
+		if errors.Is(err, ports.ErrConflict) {
+			return nil, errors.New("username already exists")
+		}
 		return nil, err
 	}
-
 	return user, nil
 }
 
-func (s *userServiceImpl) Login(username, password string) (string, *domain.Role, error) {
+func (s *userServiceImpl) Login(username, password string, actor domain.Actor) (_ string, _ *domain.Role, err error) {
+	// El actor de un login solo trae IP y RequestID; el usuario se conoce al validar las credenciales.
+	// Un login no modifica datos: si la auditoría no está disponible, Record lo deja en el
+	// log y el login sigue, para que una caída de la auditoría no impida entrar.
+	var userID uint
+	defer func() {
+		actor.UserID = userID
+		changes := map[string]domain.FieldChange{"username": {New: textValue(username)}}
+		s.audit.Record(newAuditEntry(actor, domain.AuditUserLogin, domain.AuditUserEntity, userID, changes, err))
+	}()
+
 	user, err := s.userRepo.FindByUsername(username)
 	if err != nil {
 		return "", nil, errors.New("invalid credentials")
@@ -68,12 +107,14 @@ func (s *userServiceImpl) Login(username, password string) (string, *domain.Role
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
 		return "", nil, errors.New("invalid credentials")
 	}
+	userID = user.ID
+	actor.Role = user.Role
 
 	// Crear token JWT
 	claims := jwt.MapClaims{
 		"sub":  user.ID,
 		"role": user.Role,
-		"exp":  time.Now().Add(time.Hour * 72).Unix(),
+		"exp":  time.Now().Add(s.tokenTTL).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -98,7 +139,14 @@ func (s *userServiceImpl) GetAllUsers() ([]domain.UserResponse, error) {
 	return userResponses, nil
 }
 
-func (s *userServiceImpl) UpdateUser(id uint, username *string, role *domain.Role) (*domain.UserResponse, error) {
+func (s *userServiceImpl) UpdateUser(id uint, username *string, role *domain.Role, actor domain.Actor) (_ *domain.UserResponse, err error) {
+	// Los cambios de rol se auditan con una acción propia por ser sensibles para la seguridad.
+	auditAction := domain.AuditUserUpdate
+	changes := make(map[string]domain.FieldChange)
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, auditAction, domain.AuditUserEntity, id, changes, err))
+	}()
+
 	user, err := s.userRepo.FindByID(id)
 	if err != nil {
 		return nil, errors.New("user not found")
@@ -108,17 +156,75 @@ func (s *userServiceImpl) UpdateUser(id uint, username *string, role *domain.Rol
 		if existingUser, err := s.userRepo.FindByUsername(*username); err == nil && existingUser.ID != id {
 			return nil, errors.New("username already taken")
 		}
+		if *username != user.Username {
+			changes["username"] = domain.FieldChange{Old: textValue(user.Username), New: textValue(*username)}
+		}
 		user.Username = *username
 	}
 
 	if role != nil {
+		if !role.IsValid() {
+			return nil, errors.New("invalid role, use admin or user")
+		}
+		if *role != user.Role {
+			changes["role"] = domain.FieldChange{Old: textValue(string(user.Role)), New: textValue(string(*role))}
+			auditAction = domain.AuditUserRoleChange
+		}
 		user.Role = *role
 	}
 
-	if err := s.userRepo.Save(user); err != nil {
+	err = s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Users.Save(user); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, auditAction, domain.AuditUserEntity, id, changes, nil))
+	})
+	if err != nil {
+		if errors.Is(err, ports.ErrConflict) {
+			return nil, errors.New("username already taken")
+		}
 		return nil, err
 	}
 
 	response := toUserResponse(user)
 	return &response, nil
 }
+
+func (s *userServiceImpl) GetUserByUsername(username string) (*domain.UserResponse, error) {
+	user, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	response := toUserResponse(user)
+	return &response, nil
+}
+
+func (s *userServiceImpl) SetPassword(id uint, password string, actor domain.Actor) (err error) {
+	// La auditoría registra el cambio, nunca la contraseña ni su hash.
+	defer func() {
+		recordFailure(s.audit, newAuditEntry(actor, domain.AuditUserPasswordChange, domain.AuditUserEntity, id, nil, err))
+	}()
+
+	if password == "" {
+		return errors.New("password is required")
+	}
+	user, err := s.userRepo.FindByID(id)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = string(hashedPassword)
+	return s.uow.Do(func(repos ports.Repositories) error {
+		if err := repos.Users.Save(user); err != nil {
+			return err
+		}
+		return s.audit.RecordIn(repos, newAuditEntry(actor, domain.AuditUserPasswordChange, domain.AuditUserEntity, id, nil, nil))
+	})
+}
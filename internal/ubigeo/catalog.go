@@ -0,0 +1,171 @@
+// Package ubigeo carga el catálogo de ubicaciones geográficas del INEI (departamento,
+// provincia y distrito) desde un archivo CSV.
+package ubigeo
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+// bundledCatalog es el catálogo incluido en el binario: todos los departamentos con
+// su capital y el detalle de Lima Metropolitana y el Callao. Es parcial: las direcciones
+// de los demás distritos se aceptan sin verificar. Para el catálogo completo se indica
+// un archivo con el mismo formato en UBIGEO_CATALOG_PATH.
+//
+//go:embed ubigeo.csv
+var bundledCatalog string
+
+type catalog struct {
+	byCode       map[string]domain.Ubigeo
+	byDistrict   map[string][]domain.Ubigeo
+	departments  []domain.UbigeoArea
+	provinces    map[string][]domain.UbigeoArea
+	districts    map[string][]domain.UbigeoArea
+	departmentOf map[string]string
+	complete     bool
+}
+
+// Load lee el catálogo del archivo CSV en path, o el catálogo incluido si path está vacío.
+// El archivo tiene una cabecera y las columnas ubigeo, departamento, provincia y distrito,
+// con una fila por distrito, y se considera completo.
+func Load(path string) (ports.UbigeoCatalog, error) {
+	if path == "" {
+		return parse(strings.NewReader(bundledCatalog), false)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return parse(file, true)
+}
+
+func parse(r io.Reader, complete bool) (*catalog, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid ubigeo catalog: %w", err)
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("invalid ubigeo catalog: no districts")
+	}
+
+	c := &catalog{
+		byCode:       make(map[string]domain.Ubigeo),
+		byDistrict:   make(map[string][]domain.Ubigeo),
+		provinces:    make(map[string][]domain.UbigeoArea),
+		districts:    make(map[string][]domain.UbigeoArea),
+		departmentOf: make(map[string]string),
+		complete:     complete,
+	}
+	provinceNames := make(map[string]string)
+
+	for i, row := range rows[1:] {
+		line := i + 2
+		u := domain.Ubigeo{
+			Code:       strings.TrimSpace(row[0]),
+			Department: strings.TrimSpace(row[1]),
+			Province:   strings.TrimSpace(row[2]),
+			District:   strings.TrimSpace(row[3]),
+		}
+		if !isCode(u.Code, 6) || u.Department == "" || u.Province == "" || u.District == "" {
+			return nil, fmt.Errorf("invalid ubigeo catalog: line %d is not a valid district", line)
+		}
+		if _, ok := c.byCode[u.Code]; ok {
+			return nil, fmt.Errorf("invalid ubigeo catalog: line %d repeats code %s", line, u.Code)
+		}
+
+		departmentCode, provinceCode := u.Code[:2], u.Code[:4]
+		if name, ok := c.departmentOf[departmentCode]; !ok {
+			c.departmentOf[departmentCode] = u.Department
+			c.departments = append(c.departments, domain.UbigeoArea{Code: departmentCode, Name: u.Department})
+		} else if name != u.Department {
+			return nil, fmt.Errorf("invalid ubigeo catalog: line %d names department %s %q and %q", line, departmentCode, name, u.Department)
+		}
+		if name, ok := provinceNames[provinceCode]; !ok {
+			provinceNames[provinceCode] = u.Province
+			c.provinces[departmentCode] = append(c.provinces[departmentCode], domain.UbigeoArea{Code: provinceCode, Name: u.Province})
+		} else if name != u.Province {
+			return nil, fmt.Errorf("invalid ubigeo catalog: line %d names province %s %q and %q", line, provinceCode, name, u.Province)
+		}
+
+		c.byCode[u.Code] = u
+		key := domain.PlaceKey(u.District)
+		c.byDistrict[key] = append(c.byDistrict[key], u)
+		c.districts[provinceCode] = append(c.districts[provinceCode], domain.UbigeoArea{Code: u.Code, Name: u.District})
+	}
+
+	sortAreas(c.departments)
+	for _, areas := range c.provinces {
+		sortAreas(areas)
+	}
+	for _, areas := range c.districts {
+		sortAreas(areas)
+	}
+	return c, nil
+}
+
+func (c *catalog) Departments() []domain.UbigeoArea {
+	return c.departments
+}
+
+func (c *catalog) Provinces(departmentCode string) ([]domain.UbigeoArea, error) {
+	provinces, ok := c.provinces[departmentCode]
+	if !ok {
+		return nil, ports.ErrUbigeoNotFound
+	}
+	return provinces, nil
+}
+
+func (c *catalog) Districts(provinceCode string) ([]domain.UbigeoArea, error) {
+	districts, ok := c.districts[provinceCode]
+	if !ok {
+		return nil, ports.ErrUbigeoNotFound
+	}
+	return districts, nil
+}
+
+func (c *catalog) Find(code string) (*domain.Ubigeo, error) {
+	u, ok := c.byCode[code]
+	if !ok {
+		return nil, ports.ErrUbigeoNotFound
+	}
+	return &u, nil
+}
+
+func (c *catalog) FindByDistrict(name string) []domain.Ubigeo {
+	return c.byDistrict[domain.PlaceKey(name)]
+}
+
+func (c *catalog) Complete() bool {
+	return c.complete
+}
+
+// sortAreas ordena por nombre sin tener en cuenta las tildes.
+func sortAreas(areas []domain.UbigeoArea) {
+	sort.Slice(areas, func(i, j int) bool {
+		return domain.PlaceKey(areas[i].Name) < domain.PlaceKey(areas[j].Name)
+	})
+}
+
+func isCode(code string, length int) bool {
+	if len(code) != length {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
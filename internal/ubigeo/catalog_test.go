@@ -0,0 +1,149 @@
+package ubigeo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/riada2/internal/core/domain"
+	"github.com/riada2/internal/core/ports"
+)
+
+func loadBundled(t *testing.T) ports.UbigeoCatalog {
+	t.Helper()
+	catalog, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return catalog
+}
+
+func TestFind(t *testing.T) {
+	catalog := loadBundled(t)
+
+	tests := []struct {
+		code string
+		want *domain.Ubigeo
+	}{
+		{code: "150113", want: &domain.Ubigeo{Code: "150113", Department: "Lima", Province: "Lima", District: "Jesús María"}},
+		{code: "070101", want: &domain.Ubigeo{Code: "070101", Department: "Callao", Province: "Callao", District: "Callao"}},
+		{code: "150199"},
+		{code: "990101"},
+		// Un prefijo de departamento o provincia no es un distrito.
+		{code: "15"},
+		{code: "1501"},
+		{code: ""},
+	}
+	for _, tt := range tests {
+		got, err := catalog.Find(tt.code)
+		if tt.want == nil {
+			if !errors.Is(err, ports.ErrUbigeoNotFound) {
+				t.Errorf("Find(%q) = %+v, %v, want ErrUbigeoNotFound", tt.code, got, err)
+			}
+			continue
+		}
+		if err != nil || *got != *tt.want {
+			t.Errorf("Find(%q) = %+v, %v, want %+v", tt.code, got, err, tt.want)
+		}
+	}
+}
+
+func TestProvincesAndDistricts(t *testing.T) {
+	catalog := loadBundled(t)
+
+	provinces, err := catalog.Provinces("15")
+	if err != nil || !hasArea(provinces, "1501", "Lima") {
+		t.Fatalf("Provinces(15) = %+v, %v, want Lima", provinces, err)
+	}
+	districts, err := catalog.Districts("1501")
+	if err != nil || !hasArea(districts, "150113", "Jesús María") {
+		t.Fatalf("Districts(1501) = %+v, %v, want Jesús María", districts, err)
+	}
+	// Los nombres se ordenan sin tener en cuenta las tildes: Ancón va antes que Ate.
+	if districts[0].Name != "Ancón" {
+		t.Errorf("first district of Lima = %s, want Ancón", districts[0].Name)
+	}
+
+	for _, code := range []string{"99", "150", "150113"} {
+		if _, err := catalog.Provinces(code); !errors.Is(err, ports.ErrUbigeoNotFound) {
+			t.Errorf("Provinces(%q): expected ErrUbigeoNotFound, got %v", code, err)
+		}
+	}
+	for _, code := range []string{"9901", "15", "150113"} {
+		if _, err := catalog.Districts(code); !errors.Is(err, ports.ErrUbigeoNotFound) {
+			t.Errorf("Districts(%q): expected ErrUbigeoNotFound, got %v", code, err)
+		}
+	}
+	if departments := catalog.Departments(); !hasArea(departments, "02", "Áncash") || !hasArea(departments, "15", "Lima") {
+		t.Errorf("Departments = %+v, want Áncash and Lima", departments)
+	}
+}
+
+func TestFindByDistrict(t *testing.T) {
+	catalog := loadBundled(t)
+
+	for _, name := range []string{"Jesús María", "jesus maria", "  JESÚS   MARÍA "} {
+		found := catalog.FindByDistrict(name)
+		if len(found) != 1 || found[0].Code != "150113" {
+			t.Errorf("FindByDistrict(%q) = %+v, want 150113", name, found)
+		}
+	}
+	if found := catalog.FindByDistrict("Atlantis"); len(found) != 0 {
+		t.Errorf("FindByDistrict(Atlantis) = %+v, want none", found)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	if loadBundled(t).Complete() {
+		t.Error("the bundled catalog should be partial")
+	}
+
+	path := filepath.Join(t.TempDir(), "ubigeo.csv")
+	content := "ubigeo,departamento,provincia,distrito\n150101,Lima,Lima,Lima\n150113,Lima,Lima,Jesús María\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	catalog, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !catalog.Complete() {
+		t.Error("a catalog loaded from a file should be complete")
+	}
+	if _, err := catalog.Find("040101"); !errors.Is(err, ports.ErrUbigeoNotFound) {
+		t.Errorf("Find(040101) in the file catalog: expected ErrUbigeoNotFound, got %v", err)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("Load of a missing file should fail")
+	}
+}
+
+func TestParseRejectsInvalidCatalogs(t *testing.T) {
+	header := "ubigeo,departamento,provincia,distrito\n"
+	for name, content := range map[string]string{
+		"no districts":          header,
+		"short code":            header + "15011,Lima,Lima,Lima\n",
+		"non-numeric code":      header + "15O101,Lima,Lima,Lima\n",
+		"missing district":      header + "150101,Lima,Lima,\n",
+		"repeated code":         header + "150101,Lima,Lima,Lima\n150101,Lima,Lima,Cercado\n",
+		"two department names":  header + "150101,Lima,Lima,Lima\n150201,Lima Provincias,Barranca,Barranca\n",
+		"two province names":    header + "150101,Lima,Lima,Lima\n150102,Lima,Lima Metropolitana,Ancón\n",
+		"wrong number of cells": header + "150101,Lima,Lima\n",
+	} {
+		if _, err := parse(strings.NewReader(content), true); err == nil {
+			t.Errorf("%s: parse should fail", name)
+		}
+	}
+}
+
+func hasArea(areas []domain.UbigeoArea, code, name string) bool {
+	for _, area := range areas {
+		if area.Code == code && area.Name == name {
+			return true
+		}
+	}
+	return false
+}